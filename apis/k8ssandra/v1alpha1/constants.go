@@ -9,6 +9,22 @@ const (
 	// changes to the ConfigMap to be properly detected and applied.
 	PerNodeConfigHashAnnotation = "k8ssandra.io/per-node-config-hash"
 
+	// SecretsHashAnnotation is the annotation used to store a hash of the contents of the
+	// encryption stores secrets mounted in the Cassandra pods into the PodTemplateSpec of the
+	// CassandraDatacenter resource. This way, changing a keystore or truststore triggers a rolling
+	// restart of the datacenter.
+	SecretsHashAnnotation = "k8ssandra.io/secrets-hash"
+
+	// LogShippingConfigHashAnnotation is the annotation used to store a hash of the configuration
+	// of the log shipping sidecar into the PodTemplateSpec of the CassandraDatacenter resource, so
+	// that changing the configuration triggers a rolling restart of the datacenter.
+	LogShippingConfigHashAnnotation = "k8ssandra.io/log-shipping-config-hash"
+
+	// AuditLoggingConfigHashAnnotation is the annotation used to store a hash of the files of the
+	// audit logging configuration into the PodTemplateSpec of the CassandraDatacenter resource, so
+	// that changing the files triggers a rolling restart of the datacenter.
+	AuditLoggingConfigHashAnnotation = "k8ssandra.io/audit-logging-config-hash"
+
 	// InitialSystemReplicationAnnotation provides the initial replication of system keyspaces
 	// (system_auth, system_distributed, system_traces) encoded as JSON. This annotation
 	// is set on a K8ssandraCluster when it is first created. The value does not change
@@ -36,6 +52,51 @@ const (
 
 	RebuildLabel = "k8ssandra.io/rebuild"
 
+	// ReplaceNodeAnnotation tells the operator to replace a dead Cassandra node. The value must be the name of the pod
+	// of the node. The operator creates a replacenode CassandraTask in the datacenter of the pod, and removes the
+	// annotation once the task has finished.
+	ReplaceNodeAnnotation = "k8ssandra.io/replace-node"
+
+	// ReleaseDcAnnotation tells the operator to release a DC instead of decommissioning it when the DC is removed from
+	// the K8ssandraCluster spec. The value must be the name of the DC. The CassandraDatacenter is left running, the
+	// operator labels and annotations are removed from it, and the keyspace replication is not modified.
+	ReleaseDcAnnotation = "k8ssandra.io/release-dc"
+
+	// DecommissionDcAnnotation confirms the decommission of a DC removed from the K8ssandraCluster spec when
+	// RequireDecommissionConfirmation is set. The value must be the name of the DC. The operator removes the annotation
+	// once the DC is decommissioned.
+	DecommissionDcAnnotation = "k8ssandra.io/decommission-dc"
+
+	// OperatorInstanceAnnotation records on a K8ssandraCluster the id of the operator instance that manages it, when
+	// the operator instances are given an id. The other instances do not reconcile the K8ssandraCluster. Changing or
+	// removing the annotation hands the cluster over to another instance.
+	OperatorInstanceAnnotation = "k8ssandra.io/operator-instance"
+
+	// OperatorInstanceRenewTimeAnnotation records on a K8ssandraCluster when the operator instance named by its
+	// OperatorInstanceAnnotation last renewed its claim, in RFC 3339 format. It is managed by the operator.
+	OperatorInstanceRenewTimeAnnotation = "k8ssandra.io/operator-instance-renew-time"
+
+	// MigrationVersionAnnotation records on a K8ssandraCluster the version of the post-upgrade migration that the
+	// operator last applied to its CassandraDatacenters. It is managed by the operator.
+	MigrationVersionAnnotation = "k8ssandra.io/migration-version"
+
+	// ManagementApiRequestTimeoutAnnotation is set on a CassandraDatacenter when the management API request timeout
+	// of its K8ssandraCluster datacenter is overridden. The value is a duration, e.g. "2m".
+	ManagementApiRequestTimeoutAnnotation = "k8ssandra.io/management-api-request-timeout"
+
+	// ReadinessPolicyAnnotation is set on a CassandraDatacenter when the readiness policy of its K8ssandraCluster
+	// datacenter is not the default one, so that the components deployed on top of the datacenter wait for it the same
+	// way. The value is the policy in JSON, e.g. {"mode":"Quorum"}.
+	ReadinessPolicyAnnotation = "k8ssandra.io/readiness-policy"
+
+	// ContextsInMaintenanceAnnotation puts Kubernetes contexts in maintenance in addition to the ContextsInMaintenance
+	// of the K8ssandraCluster spec. The value is a comma-separated list of context names.
+	ContextsInMaintenanceAnnotation = "k8ssandra.io/contexts-in-maintenance"
+
+	// CassandraEnvScriptAnnotation holds the custom cassandra-env.sh fragment of a datacenter on its Cassandra pods,
+	// which mount it as a file through the downward API.
+	CassandraEnvScriptAnnotation = "k8ssandra.io/cassandra-env-script"
+
 	NameLabel      = "app.kubernetes.io/name"
 	NameLabelValue = "k8ssandra-operator"
 
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	medusaapi "github.com/k8ssandra/k8ssandra-operator/apis/medusa/v1alpha1"
 	reaperapi "github.com/k8ssandra/k8ssandra-operator/apis/reaper/v1alpha1"
@@ -26,6 +28,7 @@ import (
 	"github.com/k8ssandra/k8ssandra-operator/pkg/images"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/meta"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -76,6 +79,44 @@ type K8ssandraClusterSpec struct {
 	// +kubebuilder:validation:Enum=internal;external
 	// +kubebuilder:default=internal
 	SecretsProvider string `json:"secretsProvider,omitempty"`
+
+	// StatusHistory, when set, makes the operator record the last reconcile transitions in the K8ssandraCluster
+	// status, for post-mortem debugging without relying on log retention.
+	// +optional
+	StatusHistory *StatusHistory `json:"statusHistory,omitempty"`
+
+	// ReconcileTimeout bounds the time spent in a single reconcile of the K8ssandraCluster. Once it has elapsed, the
+	// reconcile stops before moving on to the next datacenter or to the components reconciled after the
+	// datacenters, records its progress in the status, sets the ReconcileTimedOut condition and requeues. At least one
+	// datacenter is reconciled each time, and the next reconcile resumes with the datacenter the previous one stopped
+	// before. Reconciles are unbounded when it is not set.
+	// +optional
+	ReconcileTimeout *metav1.Duration `json:"reconcileTimeout,omitempty"`
+
+	// ContextsInMaintenance lists the Kubernetes contexts whose cluster is under maintenance. The operator does not
+	// modify the datacenters deployed in these contexts, keeps using the seeds they had before the maintenance, and
+	// does not go past the reconcile of the datacenters until the maintenance ends. Contexts can also be put in
+	// maintenance with the k8ssandra.io/contexts-in-maintenance annotation.
+	// +optional
+	ContextsInMaintenance []string `json:"contextsInMaintenance,omitempty"`
+}
+
+const DefaultStatusHistoryLimit = 10
+
+type StatusHistory struct {
+	// Limit is the maximum number of transitions kept in the status. The oldest transitions are discarded first.
+	// Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Limit int32 `json:"limit,omitempty"`
+}
+
+func (in *StatusHistory) GetLimit() int {
+	if in == nil || in.Limit <= 0 {
+		return DefaultStatusHistoryLimit
+	}
+	return int(in.Limit)
 }
 
 // IsAuthEnabled returns true if auth is not specified by the user (auth by default)
@@ -105,6 +146,144 @@ type K8ssandraClusterStatus struct {
 
 	// +kubebuilder:default=None
 	Error string `json:"error,omitempty"`
+
+	// Seeds are the seed nodes found during the last reconcile. They are used in place of fresh seeds when
+	// SeedResolutionFailurePolicy is UseCachedSeeds and the seeds of a datacenter cannot be listed.
+	// +optional
+	Seeds []SeedStatus `json:"seeds,omitempty"`
+
+	// SeedsRefreshTime is the last time the seeds were re-resolved by listing the seed pods of the datacenters. It is
+	// only recorded when SeedRefreshInterval is set.
+	// +optional
+	SeedsRefreshTime *metav1.Time `json:"seedsRefreshTime,omitempty"`
+
+	// History is the list of the last reconcile transitions, oldest first. It is only recorded when StatusHistory is
+	// set.
+	// +optional
+	History []StatusTransition `json:"history,omitempty"`
+
+	// Connection describes how applications connect to the cluster. The endpoints of each datacenter are reported in
+	// the status of the datacenter.
+	// +optional
+	Connection *ClusterConnectionStatus `json:"connection,omitempty"`
+
+	// CrdVersions are the versions of the CassandraDatacenter CRD installed in the k8s contexts hosting the
+	// datacenters. They are only recorded when the datacenters span more than one k8s context.
+	// +optional
+	CrdVersions []CrdVersionStatus `json:"crdVersions,omitempty"`
+
+	// Inventory lists the objects managed by the operator for the cluster in all the k8s contexts, as found by their
+	// management labels during the last reconcile.
+	// +optional
+	Inventory *InventoryStatus `json:"inventory,omitempty"`
+
+	// ResumeDatacenter is the datacenter the last reconcile stopped before because ReconcileTimeout elapsed. The next
+	// reconcile starts with it, and reconciles the datacenters that come before it afterwards. It is cleared once all
+	// the datacenters are reconciled.
+	// +optional
+	ResumeDatacenter string `json:"resumeDatacenter,omitempty"`
+}
+
+// MaxInventoryObjects is the largest number of objects listed in the inventory of a K8ssandraCluster.
+const MaxInventoryObjects = 100
+
+// InventoryStatus lists the objects managed by the operator for a K8ssandraCluster.
+type InventoryStatus struct {
+	// Objects are the managed objects, sorted by k8s context, kind, namespace and name. At most MaxInventoryObjects
+	// objects are listed.
+	// +optional
+	Objects []ManagedObject `json:"objects,omitempty"`
+
+	// Total is the number of managed objects. It is larger than the number of listed objects when the inventory is
+	// truncated.
+	Total int `json:"total"`
+}
+
+// ManagedObject identifies an object managed by the operator.
+type ManagedObject struct {
+	// K8sContext is the k8s context of the object, empty for the control plane.
+	// +optional
+	K8sContext string `json:"k8sContext,omitempty"`
+
+	Kind string `json:"kind"`
+
+	Namespace string `json:"namespace"`
+
+	Name string `json:"name"`
+}
+
+// CrdVersionStatus is the version of the CassandraDatacenter CRD installed in a k8s context.
+type CrdVersionStatus struct {
+	// K8sContext is the k8s context, empty for the control plane.
+	// +optional
+	K8sContext string `json:"k8sContext,omitempty"`
+
+	// Version is the version of cass-operator the CRD comes from, as recorded in its app.kubernetes.io/version label.
+	// It is empty when the version is unknown, because the CRD does not have the label or can not be read.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// ClusterConnectionStatus describes how applications connect to the cluster.
+type ClusterConnectionStatus struct {
+	// SuperuserSecretRef is the secret, in the namespace of the K8ssandraCluster, holding the username and password of
+	// the superuser.
+	SuperuserSecretRef corev1.LocalObjectReference `json:"superuserSecretRef"`
+
+	// ClusterService is the name of the Service selecting the Cassandra nodes of all the datacenters, in each namespace
+	// where datacenters are deployed. It is only reported when the cluster CQL Service is enabled.
+	// +optional
+	ClusterService string `json:"clusterService,omitempty"`
+}
+
+// DatacenterConnectionStatus describes the endpoint applications use to connect to the nodes of a datacenter.
+type DatacenterConnectionStatus struct {
+	// K8sContext is the Kubernetes context of the datacenter. It is empty for the local context.
+	// +optional
+	K8sContext string `json:"k8sContext,omitempty"`
+
+	// Namespace is the namespace of the Service.
+	Namespace string `json:"namespace"`
+
+	// Service is the name of the Service selecting the Cassandra nodes of the datacenter.
+	Service string `json:"service"`
+
+	// Port is the CQL port of the Service.
+	Port int32 `json:"port"`
+
+	// PodDomain is the domain of the Cassandra pods of the datacenter, when PodHostname is set. Each pod resolves to
+	// <pod-name>.<pod-domain>.
+	// +optional
+	PodDomain string `json:"podDomain,omitempty"`
+}
+
+// StatusTransition records a change of a condition, or of the reconcile error.
+type StatusTransition struct {
+	// Time is the time at which the transition was observed.
+	Time metav1.Time `json:"time"`
+
+	// Type is the type of the condition that changed, or "Error" for a change of the reconcile error.
+	Type string `json:"type"`
+
+	// Status is the new status of the condition. For the reconcile error, it is "True" when an error occurred and
+	// "False" when the reconcile succeeded again.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Reason is the message of the condition, or the reconcile error.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// SeedStatus describes a seed node.
+type SeedStatus struct {
+	// Datacenter is the name of the CassandraDatacenter the seed belongs to.
+	Datacenter string `json:"datacenter"`
+
+	// Name is the name of the seed pod.
+	Name string `json:"name"`
+
+	// Address is the IP address of the seed pod.
+	Address string `json:"address"`
 }
 
 type K8ssandraClusterConditionType string
@@ -118,6 +297,125 @@ const (
 	// does not change.
 	CassandraInitialized = "CassandraInitialized"
 
+	// DatacenterMissing is set to true when a CassandraDatacenter that was previously created by the operator cannot
+	// be found anymore, and the DeletedDatacenterPolicy is Report. It is set back to false once all datacenters exist.
+	DatacenterMissing K8ssandraClusterConditionType = "DatacenterMissing"
+
+	// CanaryUpgradeInProgress is set to true while the server version or image change of at least one datacenter is
+	// only rolled out to canary nodes. It is set back to false once no datacenter is in canary upgrade mode.
+	CanaryUpgradeInProgress K8ssandraClusterConditionType = "CanaryUpgradeInProgress"
+
+	// DiskUsageHigh is set to true when the data volume usage of at least one Cassandra node exceeds the threshold
+	// configured in DiskUsageMonitoring.
+	DiskUsageHigh K8ssandraClusterConditionType = "DiskUsageHigh"
+
+	// CompactionBacklogHigh is set to true when the number of pending compactions of at least one Cassandra node
+	// exceeds the threshold configured in CompactionBacklogMonitoring.
+	CompactionBacklogHigh K8ssandraClusterConditionType = "CompactionBacklogHigh"
+
+	// DroppedMutationsHigh is set to true when the number of mutations dropped by the nodes of at least one
+	// datacenter since the previous check exceeds the threshold configured in DroppedMutationsMonitoring.
+	DroppedMutationsHigh K8ssandraClusterConditionType = "DroppedMutationsHigh"
+
+	// ManagementApiLatencyHigh is set to true when the round-trip time of the management API requests sent by the
+	// operator to at least one datacenter exceeds the threshold configured in ManagementApiLatencyMonitoring.
+	ManagementApiLatencyHigh K8ssandraClusterConditionType = "ManagementApiLatencyHigh"
+
+	// SeedTopologyInconsistent is set to true when the seeds configured in the ready datacenters do not include a seed
+	// of each of them, or do not connect all of them together, which can lead to a split-brain cluster. It is also set
+	// when the seeds contain the same address more than once, or when a ready datacenter does not contribute the number
+	// of seeds cass-operator labels among its ready pods.
+	SeedTopologyInconsistent K8ssandraClusterConditionType = "SeedTopologyInconsistent"
+
+	// TokenOwnershipImbalanced is set to true when the token ownership imbalance of at least one datacenter exceeds
+	// the threshold configured in TokenBalanceMonitoring.
+	TokenOwnershipImbalanced K8ssandraClusterConditionType = "TokenOwnershipImbalanced"
+
+	// DatacenterSpecRejected is set to true when the API server or cass-operator's validating webhook rejects the
+	// CassandraDatacenter spec derived from the K8ssandraCluster. Its message contains the rejection reason. It is set
+	// back to false once the datacenter is accepted.
+	DatacenterSpecRejected K8ssandraClusterConditionType = "DatacenterSpecRejected"
+
+	// SuperuserSecretMalformed is set to true when the superuser secret lacks a non-empty username or password, in
+	// which case the datacenters are not reconciled. Its message names the missing keys. It is set back to false once
+	// the secret is fixed.
+	SuperuserSecretMalformed K8ssandraClusterConditionType = "SuperuserSecretMalformed"
+
+	// DecommissionConfirmationRequired is set to true when a datacenter was removed from the spec but is not
+	// decommissioned because RequireDecommissionConfirmation is set and the DecommissionDcAnnotation does not name it.
+	// Its message names the datacenter. It is set back to false once the decommission proceeds or the datacenter is
+	// added back.
+	DecommissionConfirmationRequired K8ssandraClusterConditionType = "DecommissionConfirmationRequired"
+
+	// ClusterNameChanged is set to true when the cluster name of the K8ssandraCluster differs from the one of an
+	// existing CassandraDatacenter, in which case the datacenter is not updated. Its message names the datacenter. It
+	// is set back to false once the cluster name is reverted.
+	ClusterNameChanged K8ssandraClusterConditionType = "ClusterNameChanged"
+
+	// ManagedByInstance is set to true by the operator instance that manages the K8ssandraCluster when the operator
+	// instances are given an id. Its message names the managing instance.
+	ManagedByInstance K8ssandraClusterConditionType = "ManagedByInstance"
+
+	// GossipStateStuck is set to true when at least one node has been JOINING or LEAVING for longer than the threshold
+	// configured in GossipMonitoring. Its message names the stuck nodes.
+	GossipStateStuck K8ssandraClusterConditionType = "GossipStateStuck"
+
+	// RemoteCertificateExpired is set to true when the client certificate used to access the k8s context of a
+	// datacenter has expired, or when a remote request failed because of an expired certificate. Its message names the
+	// contexts and the expiry times. The kubeconfig of the context must be renewed. It is set back to false once no
+	// certificate is expired.
+	RemoteCertificateExpired K8ssandraClusterConditionType = "RemoteCertificateExpired"
+
+	// ReconcileTimedOut is set to true when the last reconcile stopped early because ReconcileTimeout elapsed. Its
+	// message names the step the reconcile stopped before. It is set back to false once a reconcile completes.
+	ReconcileTimedOut K8ssandraClusterConditionType = "ReconcileTimedOut"
+
+	// ContextsInMaintenance is set to true when the datacenters of some Kubernetes contexts are not reconciled because
+	// their contexts are in maintenance. Its message lists the contexts. It is set back to false once the maintenance
+	// ends.
+	ContextsInMaintenance K8ssandraClusterConditionType = "ContextsInMaintenance"
+
+	// CassOperatorVersionSkew is set to true when a datacenter uses CassandraDatacenter fields that the cass-operator
+	// deployed in its context is too old to support. Such datacenters are not created or updated, since cass-operator
+	// would silently drop those fields. Its message names the datacenter, the fields and the versions they require. It
+	// is set back to false once cass-operator is upgraded or the fields are removed. It is set to unknown when the
+	// version of cass-operator cannot be determined, its message then naming the datacenter and the reason.
+	CassOperatorVersionSkew K8ssandraClusterConditionType = "CassOperatorVersionSkew"
+
+	// ManagementApiUnreachable is set to true when the steps that depend on the management API were deferred because
+	// it could not be reached, which only happens with the Defer ManagementApiUnreachablePolicy. Its message lists the
+	// datacenters whose steps were deferred. It is set back to false once the deferred steps have been performed.
+	ManagementApiUnreachable K8ssandraClusterConditionType = "ManagementApiUnreachable"
+
+	// ScalingDeferred is set to true when the size change of a datacenter is deferred because
+	// MaxConcurrentScalingDatacenters other datacenters are already scaling. Its message lists the deferred datacenter
+	// and the datacenters being scaled. It is set back to false once all the datacenters are reconciled.
+	ScalingDeferred K8ssandraClusterConditionType = "ScalingDeferred"
+
+	// NodesDown is set to true when more nodes than allowed by the NodeHealthGate are down in a datacenter that is
+	// ready. While it is true, changes to the seeds are not pushed to the datacenters that already have seeds. Its
+	// message lists the down nodes of these datacenters. It is set back to false once enough nodes are up.
+	NodesDown K8ssandraClusterConditionType = "NodesDown"
+
+	// CrdVersionsDiverged is set to true when the versions of the CassandraDatacenter CRD installed in the k8s contexts
+	// hosting the datacenters are further apart than the versions tested together, which can make cass-operator handle
+	// the same fields differently from one context to the other. It is set to unknown when the versions of some
+	// contexts are unknown and the others are within the tested range. Its message lists the versions of the contexts.
+	// It is set back to false once the versions are known and within the tested range.
+	CrdVersionsDiverged K8ssandraClusterConditionType = "CrdVersionsDiverged"
+
+	// AuthSettingsDiverged is set to true when the datacenters do not all use the same authenticator, authorizer and
+	// role manager. This is expected while auth is changed one datacenter at a time, but the requests coordinated by a
+	// datacenter can be rejected by the others in the meantime. Its message lists the differences. It is set back to
+	// false once the settings are identical.
+	AuthSettingsDiverged K8ssandraClusterConditionType = "AuthSettingsDiverged"
+
+	// MultipleNodesPerWorkerAllowed is set to true when soft pod anti-affinity is enabled in some datacenters, either in
+	// their own options or in the cluster-wide ones. Several Cassandra nodes can then run on the same worker, which is not
+	// suitable for production. Its message lists the datacenters. It is set back to false once soft pod anti-affinity
+	// is disabled.
+	MultipleNodesPerWorkerAllowed K8ssandraClusterConditionType = "MultipleNodesPerWorkerAllowed"
+
 	DecommNone                DecommissionProgress = ""
 	DecommUpdatingReplication DecommissionProgress = "UpdatingReplication"
 	DecommDeleting            DecommissionProgress = "Decommissioning"
@@ -130,6 +428,10 @@ type K8ssandraClusterCondition struct {
 	// LastTransitionTime is the last time the condition transited from one status to another.
 	// +optional
 	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Message is a human-readable message with details about the condition.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // K8ssandraStatus defines the observed of a k8ssandra instance
@@ -138,6 +440,168 @@ type K8ssandraStatus struct {
 	Cassandra            *cassdcapi.CassandraDatacenterStatus `json:"cassandra,omitempty"`
 	Stargate             *stargateapi.StargateStatus          `json:"stargate,omitempty"`
 	Reaper               *reaperapi.ReaperStatus              `json:"reaper,omitempty"`
+
+	// DiskUsage is the highest data volume usage among the nodes of the datacenter. It is only reported when
+	// DiskUsageMonitoring is set.
+	// +optional
+	DiskUsage *DiskUsageStatus `json:"diskUsage,omitempty"`
+
+	// CompactionBacklog is the highest number of pending compactions among the nodes of the datacenter. It is only
+	// reported when CompactionBacklogMonitoring is set.
+	// +optional
+	CompactionBacklog *CompactionBacklogStatus `json:"compactionBacklog,omitempty"`
+
+	// DroppedMutations is the number of mutations dropped by the nodes of the datacenter. It is only reported when
+	// DroppedMutationsMonitoring is set.
+	// +optional
+	DroppedMutations *DroppedMutationsStatus `json:"droppedMutations,omitempty"`
+
+	// Latency is the round-trip time of the management API requests sent by the operator to the nodes of the
+	// datacenter. It is only reported when ManagementApiLatencyMonitoring is set.
+	// +optional
+	Latency *DatacenterLatencyStatus `json:"latency,omitempty"`
+
+	// TokenOwnership is the token ownership imbalance of the datacenter. It is only reported when
+	// TokenBalanceMonitoring is set.
+	// +optional
+	TokenOwnership *TokenOwnershipStatus `json:"tokenOwnership,omitempty"`
+
+	// Gossip summarizes the gossip state of the nodes of the datacenter. It is only reported when GossipMonitoring is
+	// set.
+	// +optional
+	Gossip *GossipStatus `json:"gossip,omitempty"`
+
+	// EffectiveConfig summarizes the Cassandra configuration applied to the CassandraDatacenter, after the cluster-wide
+	// and per-DC settings and the settings derived by the operator have been merged.
+	// +optional
+	EffectiveConfig *EffectiveConfigStatus `json:"effectiveConfig,omitempty"`
+
+	// Connection is the endpoint applications use to connect to the nodes of the datacenter.
+	// +optional
+	Connection *DatacenterConnectionStatus `json:"connection,omitempty"`
+}
+
+type EffectiveConfigStatus struct {
+	// Hash is a hash of the configuration applied to the CassandraDatacenter. It changes whenever any setting changes,
+	// except for the passwords, which are redacted before hashing.
+	Hash string `json:"hash"`
+
+	// Settings maps each setting of the configuration, in the form <file>.<setting> (for example
+	// cassandra-yaml.num_tokens), to its value. Nested values are rendered as JSON. The values of the settings whose
+	// name contains "password" are redacted, and the values longer than 256 bytes are truncated.
+	// +optional
+	Settings map[string]string `json:"settings,omitempty"`
+
+	// OmittedSettings is the number of settings left out of Settings, which reports at most 200 of them, in
+	// alphabetical order.
+	// +optional
+	OmittedSettings int32 `json:"omittedSettings,omitempty"`
+}
+
+type DiskUsageStatus struct {
+	// UsedPercent is the percentage of the data volume capacity used by the node with the highest usage.
+	UsedPercent int32 `json:"usedPercent"`
+
+	// Node is the address of the node with the highest usage.
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// LastCheckTime is the last time the disk usage was checked.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+type CompactionBacklogStatus struct {
+	// PendingCompactions is the number of pending compactions of the node with the highest backlog.
+	PendingCompactions int32 `json:"pendingCompactions"`
+
+	// Node is the name of the pod running the node with the highest backlog.
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// TotalPendingCompactions is the number of pending compactions across all the nodes of the datacenter.
+	// +optional
+	TotalPendingCompactions int32 `json:"totalPendingCompactions,omitempty"`
+
+	// LastCheckTime is the last time the compaction backlog was checked.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+type DroppedMutationsStatus struct {
+	// Total is the number of mutations dropped by the nodes of the datacenter since they started.
+	Total int64 `json:"total"`
+
+	// Recent is the number of mutations dropped by the nodes of the datacenter since the previous check. The first
+	// check since the operator started only records a baseline, and reports 0. The mutations dropped by a node that
+	// restarted since the previous check are counted from its restart.
+	Recent int64 `json:"recent"`
+
+	// Node is the name of the pod running the node that dropped the most mutations since it started.
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// LastCheckTime is the last time the dropped mutations were checked.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+type TokenOwnershipStatus struct {
+	// ImbalancePercent is how much more of the token ring than an even share the node with the highest ownership
+	// owns, in percent. For example, in a datacenter of 4 nodes, a node owning 30% of the ring has an imbalance of 20%.
+	ImbalancePercent int32 `json:"imbalancePercent"`
+
+	// Node is the name of the pod running the node with the highest ownership.
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// LastCheckTime is the last time the token ownership was checked.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+type GossipStatus struct {
+	// NormalNodes is the number of nodes of the datacenter in the NORMAL gossip state.
+	NormalNodes int32 `json:"normalNodes"`
+
+	// Nodes are the nodes of the datacenter that are not in the NORMAL gossip state, ordered by address. At most
+	// MaxReportedGossipNodes nodes are listed, stuck nodes first. All the nodes are checked, including the ones that
+	// are not listed.
+	// +optional
+	Nodes []NodeGossipState `json:"nodes,omitempty"`
+
+	// LastCheckTime is the last time the gossip state was polled.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+type NodeGossipState struct {
+	// Node is the address of the node.
+	Node string `json:"node"`
+
+	// Status is the gossip status of the node, for example JOINING, LEAVING, LEFT or MOVING. Nodes bootstrapping or
+	// replacing another node are reported as JOINING, and nodes without a gossip status yet as UNKNOWN.
+	Status string `json:"status"`
+
+	// Alive is whether the node is seen alive by the coordinator the state was polled from.
+	Alive bool `json:"alive"`
+
+	// Since is the first time the node was observed in its current status.
+	// +optional
+	Since *metav1.Time `json:"since,omitempty"`
+
+	// Stuck is set when the node has been JOINING or LEAVING for longer than the stuck threshold.
+	// +optional
+	Stuck bool `json:"stuck,omitempty"`
+}
+
+type DatacenterLatencyStatus struct {
+	// RoundTripTime is the lowest round-trip time of a management API request to the nodes of the datacenter.
+	RoundTripTime metav1.Duration `json:"roundTripTime"`
+
+	// LastCheckTime is the last time the latency was measured.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -264,151 +728,621 @@ type CassandraClusterTemplate struct {
 	// +kubebuilder:validation:Enum=cassandra;dse
 	// +kubebuilder:default=cassandra
 	ServerType ServerDistribution `json:"serverType,omitempty"`
-}
-
-type CassandraDatacenterTemplate struct {
-	Meta EmbeddedObjectMeta `json:"metadata,omitempty"`
-
-	K8sContext string `json:"k8sContext,omitempty"`
-
-	DatacenterOptions `json:",inline"`
-
-	// Size is the number Cassandra pods to deploy in this datacenter.
-	// This number does not include Stargate instances.
-	// +kubebuilder:validation:Minimum=1
-	Size int32 `json:"size"`
 
-	// Stopped means that the datacenter will be stopped. Use this for maintenance or for cost saving. A stopped
-	// CassandraDatacenter will have no running server pods, like using "stop" with  traditional System V init scripts.
-	// Other Kubernetes resources will be left intact, and volumes will re-attach when the CassandraDatacenter
-	// workload is resumed.
+	// DeletedDatacenterPolicy controls what happens when a CassandraDatacenter previously created by the operator
+	// is found to be missing, for example because it was deleted out of band. With "Recreate" (the default), the
+	// datacenter is recreated from scratch. With "Report", the datacenter is not recreated; instead the
+	// DatacenterMissing condition is set on the K8ssandraCluster until the datacenter is restored or removed from the
+	// spec.
 	// +optional
-	// +kubebuilder:default=false
-	Stopped bool `json:"stopped,omitempty"`
-
-	// Stargate defines the desired deployment characteristics for Stargate in this datacenter. Leave nil to skip
-	// deploying Stargate in this datacenter.
+	// +kubebuilder:validation:Enum=Recreate;Report
+	// +kubebuilder:default=Recreate
+	DeletedDatacenterPolicy DeletedDatacenterPolicy `json:"deletedDatacenterPolicy,omitempty"`
+
+	// SeedRefreshInterval is the minimum time between two resolutions of the seeds, which lists the seed pods of
+	// every datacenter. In between, the seeds recorded in the K8ssandraCluster status are used. The seeds are always
+	// re-resolved while a datacenter that is not stopped has no recorded seeds. When unset, the seeds are re-resolved
+	// on every reconcile.
 	// +optional
-	Stargate *stargateapi.StargateDatacenterTemplate `json:"stargate,omitempty"`
+	SeedRefreshInterval *metav1.Duration `json:"seedRefreshInterval,omitempty"`
 
-	// PerNodeConfigMapRef is a reference to a ConfigMap that contains per-node configuration for
-	// this DC. The ConfigMap is expected to have entries in the following form:
-	// <pod-name>_<file-name>.yaml, where <pod-name> is the name of the pod and <file-name> is the
-	// name of a configuration file (typically, cassandra.yaml). The value of the entry is expected
-	// to be a YAML fragment that contains the per-node configuration for each pod. When the pod is
-	// started, the per-node ConfigMap is mounted and the contents of each entry corresponding to
-	// the pod are merged into their respective configuration files.
+	// SeedResolutionFailurePolicy controls what happens when seed pods cannot be listed in one of the datacenters
+	// because of a transient error, such as a timeout or an unreachable Kubernetes API server. With "Fail" (the
+	// default), the reconcile fails and is retried. With "UseCachedSeeds", the seeds last observed for that datacenter,
+	// as recorded in the K8ssandraCluster status, are used instead so that the other datacenters can still be
+	// reconciled.
 	// +optional
-	PerNodeConfigMapRef corev1.LocalObjectReference `json:"perNodeConfigMapRef,omitempty"`
-}
-
-// DatacenterOptions are configuration settings that are can be set at the Cluster level and overridden for a single DC
-type DatacenterOptions struct {
-	// ServerVersion is the Cassandra or DSE version. The following versions are supported:
-	// - Cassandra: 3.11.X and 4.0.X
-	// - DSE: 6.8.X
-	// +kubebuilder:validation:Pattern=(6\.8\.\d+)|(3\.11\.\d+)|(4\.\d+\.\d+)
-	ServerVersion string `json:"serverVersion,omitempty"`
-
-	// ServerImage is the image for the cassandra container. Note that this should be a
-	// management-api image. If left empty the operator will choose a default image based
-	// on ServerVersion.
+	// +kubebuilder:validation:Enum=Fail;UseCachedSeeds
+	// +kubebuilder:default=Fail
+	SeedResolutionFailurePolicy SeedResolutionFailurePolicy `json:"seedResolutionFailurePolicy,omitempty"`
+
+	// EmptySeedsPolicy controls what happens when no seed pods are found in a datacenter that is ready and not
+	// stopped, which happens transiently while its pods are restarted during a rollout. With "Propagate" (the
+	// default), the empty seeds are propagated right away. With "Requeue", the previously known seeds of that
+	// datacenter, recorded in the status, are propagated instead of removing them from the other datacenters, and the
+	// seeds are resolved again shortly. The other changes to the datacenters are still applied meanwhile. Stopped
+	// datacenters and datacenters that are not ready yet are never considered transiently empty.
 	// +optional
-	ServerImage string `json:"serverImage,omitempty"`
-
-	// CassandraConfig contains configuration settings that are applied to cassandra.yaml, dse.yaml
-	// and the various jvm*.options files.
+	// +kubebuilder:validation:Enum=Propagate;Requeue
+	// +kubebuilder:default=Propagate
+	EmptySeedsPolicy EmptySeedsPolicy `json:"emptySeedsPolicy,omitempty"`
+
+	// ConcurrentOperationPolicy controls what happens when a CassandraDatacenter needs to be updated while
+	// cass-operator is performing an operation on it, such as scaling, a rolling restart or a node replacement. With
+	// "Apply" (the default), the update is applied right away. With "Defer", the update is postponed until
+	// cass-operator has completed the operation. Note that with "Defer", an update fixing an operation that cannot
+	// complete, for example because of an invalid configuration, is never applied.
 	// +optional
-	CassandraConfig *CassandraConfig `json:"config,omitempty"`
-
-	// StorageConfig is the persistent storage requirements for each Cassandra pod. This
-	// includes everything under /var/lib/cassandra, namely the commit log and data
-	// directories.
+	// +kubebuilder:validation:Enum=Apply;Defer
+	// +kubebuilder:default=Apply
+	ConcurrentOperationPolicy ConcurrentOperationPolicy `json:"concurrentOperationPolicy,omitempty"`
+
+	// ManagementApiUnreachablePolicy controls what happens when the management API cannot be reached on any node of
+	// a ready datacenter. With "Fail" (the default), the reconcile fails and is retried. With "Defer", the steps that
+	// depend on the management API, such as the replication of keyspaces and the Stargate and Reaper schemas, are
+	// deferred and reported in the ManagementApiUnreachable condition, while the reconcile of the datacenters,
+	// Stargate and Reaper carries on. Deferred steps are retried until the management API is reachable again.
 	// +optional
-	StorageConfig *cassdcapi.StorageConfig `json:"storageConfig,omitempty"`
-
-	// Networking enables host networking and configures a NodePort ports.
+	// +kubebuilder:validation:Enum=Fail;Defer
+	// +kubebuilder:default=Fail
+	ManagementApiUnreachablePolicy ManagementApiUnreachablePolicy `json:"managementApiUnreachablePolicy,omitempty"`
+
+	// MaxConcurrentScalingDatacenters is the maximum number of datacenters that cass-operator may be scaling at the
+	// same time. The update of a datacenter whose size changes is deferred while that many other datacenters are
+	// scaling, and reported in the ScalingDeferred condition. A datacenter is scaling when cass-operator reports it, or
+	// when the number of its ready nodes differs from its size. Datacenters are updated one after the other, so this
+	// mostly matters when a ReadinessPolicy lets the reconcile move on while a datacenter is still scaling. Unlimited
+	// when unset.
 	// +optional
-	Networking *NetworkingConfig `json:"networking,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentScalingDatacenters *int32 `json:"maxConcurrentScalingDatacenters,omitempty"`
 
-	// Resources is the cpu and memory resources for the cassandra container.
+	// SeedPropagationQuorum is the number of datacenters that must be ready before changes to the seeds are pushed
+	// to the datacenters that already have seeds from the other datacenters. Until then, their seeds are left
+	// unchanged, which reduces churn during a staged multi-datacenter bring-up; once the quorum is reached, the
+	// seeds of all the datacenters are updated. Datacenters without seeds, such as new datacenters, always receive the
+	// current seeds so that they join the existing cluster. When unset, seeds are propagated right away.
 	// +optional
-	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	SeedPropagationQuorum *int32 `json:"seedPropagationQuorum,omitempty"`
 
-	// Racks is a list of named racks. Note that racks are used to create node affinity. //
+	// DiskUsageMonitoring, when set, makes the operator periodically check how much of the data volume of each
+	// Cassandra node is used, report the highest usage of each datacenter in the K8ssandraCluster status, and set the
+	// DiskUsageHigh condition when it exceeds a threshold.
 	// +optional
-	Racks []cassdcapi.Rack `json:"racks,omitempty"`
-
-	// Deprecated: JMX security is now based on CQL roles. We don't need an init container to configure JMX
-	// authentication anymore. The value of this field will be ignored.
-	JmxInitContainerImage *images.Image `json:"jmxInitContainerImage,omitempty"`
+	DiskUsageMonitoring *DiskUsageMonitoring `json:"diskUsageMonitoring,omitempty"`
 
-	// SoftPodAntiAffinity sets whether multiple Cassandra instances can be scheduled on the same node.
-	// This should normally be false to ensure cluster resilience but may be set true for test/dev scenarios to minimise
-	// the number of nodes required.
-	SoftPodAntiAffinity *bool `json:"softPodAntiAffinity,omitempty"`
+	// CompactionBacklogMonitoring, when set, makes the operator periodically poll the pending compactions of each
+	// Cassandra node, report the highest backlog of each datacenter in the K8ssandraCluster status, and set the
+	// CompactionBacklogHigh condition when it exceeds a threshold.
+	// +optional
+	CompactionBacklogMonitoring *CompactionBacklogMonitoring `json:"compactionBacklogMonitoring,omitempty"`
 
-	// Tolerations applied to every Cassandra pod.
+	// DroppedMutationsMonitoring, when set, makes the operator periodically poll the number of mutations dropped by
+	// each Cassandra node, report the total of each datacenter in the K8ssandraCluster status, and set the
+	// DroppedMutationsHigh condition when the mutations dropped since the previous check exceed a threshold.
 	// +optional
-	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	DroppedMutationsMonitoring *DroppedMutationsMonitoring `json:"droppedMutationsMonitoring,omitempty"`
 
-	// MgmtAPIHeap defines the amount of memory devoted to the management
-	// api heap.
+	// ManagementApiLatencyMonitoring, when set, makes the operator periodically measure the round-trip time of its
+	// management API requests to the nodes of each datacenter, report it in the K8ssandraCluster status, and set the
+	// ManagementApiLatencyHigh condition when it exceeds a threshold.
 	// +optional
-	MgmtAPIHeap *resource.Quantity `json:"mgmtAPIHeap,omitempty"`
+	ManagementApiLatencyMonitoring *ManagementApiLatencyMonitoring `json:"managementApiLatencyMonitoring,omitempty"`
 
-	// Telemetry defines the desired state for telemetry resources in this datacenter.
-	// If telemetry configurations are defined, telemetry resources will be deployed to integrate with
-	// a user-provided monitoring solution (at present, only support for Prometheus is available).
+	// TokenBalanceMonitoring, when set, makes the operator periodically check how evenly the token ring is owned by
+	// the nodes of each datacenter, report the imbalance of each datacenter in the K8ssandraCluster status, and set the
+	// TokenOwnershipImbalanced condition when it exceeds a threshold. Uneven ownership causes hotspots.
 	// +optional
-	Telemetry *telemetryapi.TelemetrySpec `json:"telemetry,omitempty"`
+	TokenBalanceMonitoring *TokenBalanceMonitoring `json:"tokenBalanceMonitoring,omitempty"`
 
-	// CDC defines the desired state for CDC integrations. It can be used to feed mutation events from Cassandra into an Apache Pulsar cluster,
-	// from where they can be expored to external systems.
+	// GossipMonitoring, when set, makes the operator periodically poll the gossip state of the Cassandra nodes, report
+	// the nodes of each datacenter that are not in the NORMAL state in the K8ssandraCluster status, and set the
+	// GossipStateStuck condition when a node stays JOINING or LEAVING for too long.
 	// +optional
-	CDC *cassdcapi.CDCConfiguration `json:"cdc,omitempty"`
+	GossipMonitoring *GossipMonitoring `json:"gossipMonitoring,omitempty"`
 
-	// Containers defines containers to be deployed in each Cassandra pod.
-	// K8ssandra-operator and cass-operator will create their own containers, which can be referenced here to override specific settings,
-	// such as mounts or resources request/limits for example.
-	// Example:
-	//  containers:
-	//  - name: server-system-logger
-	//  - name: custom-container
-	//    image: busybox
-	//  - name: cassandra
+	// ClusterCqlService, when enabled, makes the operator create a headless Service selecting the Cassandra pods of
+	// all the datacenters of the cluster, in each namespace hosting a datacenter. Clients can use it as a stable
+	// endpoint to reach any datacenter.
 	// +optional
-	Containers []corev1.Container `json:"containers,omitempty"`
+	ClusterCqlService *ClusterCqlService `json:"clusterCqlService,omitempty"`
 
-	// InitContainers defines init-containers to be deployed in each Cassandra pod.
-	// K8ssandra-operator and cass-operator will create their own init-containers, which can be referenced here to control ordering or override specific settings
-	// such as mounts or resources request/limits for example.
-	// Only the name of the k8ssandra-operator/cass-operator init-containers is required, not their full definition which will be created by the operators.
-	// Example:
-	//  initContainers:
-	//  - name: server-config-init
-	//  - name: custom-init-container
-	//    image: busybox
-	//  - name: medusa-restore
-	// Otherwise init-containers referenced here will be injected in first position
+	// RequireDecommissionConfirmation, when true, makes the operator wait for the K8ssandraCluster to be annotated with
+	// k8ssandra.io/decommission-dc set to the name of a datacenter removed from the spec before decommissioning it. Until
+	// then, the DecommissionConfirmationRequired condition is set. This protects against data loss caused by a
+	// datacenter accidentally removed or renamed in the spec.
 	// +optional
-	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	RequireDecommissionConfirmation bool `json:"requireDecommissionConfirmation,omitempty"`
 
-	// Volumes defines additional volumes to be added to each Cassandra pod.
-	// If the volume uses a PersistentVolumeClaim, the PVC will be managed by the statefulset.
+	// LogShipping deploys a log shipping sidecar, such as Fluent Bit, in the Cassandra pods of every datacenter. The
+	// sidecar reads the Cassandra logs from the server-logs volume. Its configuration is taken from a ConfigMap in the
+	// namespace of the K8ssandraCluster, which the operator replicates to the k8s context and namespace of each
+	// datacenter. Changing the configuration triggers a rolling restart of the datacenters.
 	// +optional
-	ExtraVolumes *K8ssandraVolumes `json:"extraVolumes,omitempty"`
+	LogShipping *LogShipping `json:"logShipping,omitempty"`
 
+	// NodeHealthGate, when set, makes the operator check that the nodes of the ready datacenters are up before pushing
+	// changes to the seeds, since a datacenter can be ready while some of its nodes are down. When more nodes than
+	// allowed are down in a ready datacenter, the seeds of the datacenters that already have seeds are left unchanged,
+	// as with SeedPropagationQuorum, and the NodesDown condition is set.
 	// +optional
-	DseWorkloads *cassdcapi.DseWorkloads `json:"dseWorkloads,omitempty"`
+	NodeHealthGate *NodeHealthGate `json:"nodeHealthGate,omitempty"`
 
-	// PodSecurityContext defines the security context for the Cassandra pods.
+	// JvmOptions are the default JVM options, such as the heap and GC settings, of every datacenter. They have the
+	// lowest precedence: each option set in config.jvmOptions, at the cluster or at the datacenter level, overrides
+	// the default, while the options it leaves unset are taken from here.
 	// +optional
-	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	JvmOptions *JvmOptions `json:"jvmOptions,omitempty"`
+}
 
-	// ManagementApiAuth defines the authentication settings for the management API in the Cassandra pods.
+type ClusterCqlService struct {
+	// Enabled turns on the creation of the cluster-wide CQL Service.
 	// +optional
-	ManagementApiAuth *cassdcapi.ManagementApiAuthConfig `json:"managementApiAuth,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// IsEnabled returns true if the cluster-wide CQL Service is enabled.
+func (in *ClusterCqlService) IsEnabled() bool {
+	return in != nil && in.Enabled != nil && *in.Enabled
+}
+
+type NodeHealthGate struct {
+	// MaxDownNodes is the number of nodes of a ready datacenter that can be down without holding the seed
+	// propagation. Nodes are down when gossip reports them as not alive while in the NORMAL state, the nodes that left
+	// the cluster or were removed are not counted. Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxDownNodes *int32 `json:"maxDownNodes,omitempty"`
+}
+
+func (in *NodeHealthGate) GetMaxDownNodes() int {
+	if in == nil || in.MaxDownNodes == nil || *in.MaxDownNodes < 0 {
+		return 0
+	}
+	return int(*in.MaxDownNodes)
+}
+
+type LogShipping struct {
+	// Image is the image of the log shipping sidecar.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// ConfigMapRef is the ConfigMap holding the configuration files of the log shipping sidecar, in the namespace of
+	// the K8ssandraCluster.
+	ConfigMapRef corev1.LocalObjectReference `json:"configMapRef"`
+
+	// ConfigMountPath is the directory where the configuration files are mounted in the sidecar.
+	// +optional
+	// +kubebuilder:default="/fluent-bit/etc"
+	ConfigMountPath string `json:"configMountPath,omitempty"`
+
+	// Resources is the cpu and memory resources of the log shipping sidecar.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+type DeletedDatacenterPolicy string
+
+const (
+	DeletedDatacenterPolicyRecreate = DeletedDatacenterPolicy("Recreate")
+	DeletedDatacenterPolicyReport   = DeletedDatacenterPolicy("Report")
+)
+
+type SeedResolutionFailurePolicy string
+
+const (
+	SeedResolutionFailurePolicyFail           = SeedResolutionFailurePolicy("Fail")
+	SeedResolutionFailurePolicyUseCachedSeeds = SeedResolutionFailurePolicy("UseCachedSeeds")
+)
+
+type EmptySeedsPolicy string
+
+const (
+	EmptySeedsPolicyPropagate = EmptySeedsPolicy("Propagate")
+	EmptySeedsPolicyRequeue   = EmptySeedsPolicy("Requeue")
+)
+
+type ConcurrentOperationPolicy string
+
+const (
+	ConcurrentOperationPolicyApply = ConcurrentOperationPolicy("Apply")
+	ConcurrentOperationPolicyDefer = ConcurrentOperationPolicy("Defer")
+)
+
+type ManagementApiUnreachablePolicy string
+
+const (
+	ManagementApiUnreachablePolicyFail  = ManagementApiUnreachablePolicy("Fail")
+	ManagementApiUnreachablePolicyDefer = ManagementApiUnreachablePolicy("Defer")
+)
+
+// MonitoringPolling is how often a monitoring check polls the Cassandra nodes.
+type MonitoringPolling struct {
+	// PollInterval is the interval between two checks. Defaults to 1 minute for the gossip state, 1 hour for the token
+	// ownership, and 5 minutes for the other checks.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+func (in *MonitoringPolling) getPollInterval(defaultInterval time.Duration) time.Duration {
+	if in.PollInterval == nil || in.PollInterval.Duration <= 0 {
+		return defaultInterval
+	}
+	return in.PollInterval.Duration
+}
+
+const (
+	DefaultDiskUsageThresholdPercent = 80
+	DefaultDiskUsagePollInterval     = 5 * time.Minute
+)
+
+type DiskUsageMonitoring struct {
+	// ThresholdPercent is the data volume usage, in percent of its capacity, above which the DiskUsageHigh condition
+	// is set. Defaults to 80.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	ThresholdPercent int32 `json:"thresholdPercent,omitempty"`
+
+	MonitoringPolling `json:",inline"`
+}
+
+func (in *DiskUsageMonitoring) GetThresholdPercent() int32 {
+	if in == nil || in.ThresholdPercent == 0 {
+		return DefaultDiskUsageThresholdPercent
+	}
+	return in.ThresholdPercent
+}
+
+func (in *DiskUsageMonitoring) GetPollInterval() time.Duration {
+	if in == nil {
+		return DefaultDiskUsagePollInterval
+	}
+	return in.getPollInterval(DefaultDiskUsagePollInterval)
+}
+
+const (
+	DefaultCompactionBacklogThreshold    = 100
+	DefaultCompactionBacklogPollInterval = 5 * time.Minute
+)
+
+type CompactionBacklogMonitoring struct {
+	// Threshold is the number of pending compactions of a node above which the CompactionBacklogHigh condition is
+	// set. Defaults to 100.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Threshold int32 `json:"threshold,omitempty"`
+
+	MonitoringPolling `json:",inline"`
+}
+
+func (in *CompactionBacklogMonitoring) GetThreshold() int32 {
+	if in == nil || in.Threshold == 0 {
+		return DefaultCompactionBacklogThreshold
+	}
+	return in.Threshold
+}
+
+func (in *CompactionBacklogMonitoring) GetPollInterval() time.Duration {
+	if in == nil {
+		return DefaultCompactionBacklogPollInterval
+	}
+	return in.getPollInterval(DefaultCompactionBacklogPollInterval)
+}
+
+const (
+	DefaultDroppedMutationsThreshold    = 0
+	DefaultDroppedMutationsPollInterval = 5 * time.Minute
+)
+
+type DroppedMutationsMonitoring struct {
+	// Threshold is the number of mutations dropped by the nodes of a datacenter between two checks above which the
+	// DroppedMutationsHigh condition is set. Defaults to 0, i.e. any dropped mutation sets the condition.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Threshold int64 `json:"threshold,omitempty"`
+
+	MonitoringPolling `json:",inline"`
+}
+
+func (in *DroppedMutationsMonitoring) GetThreshold() int64 {
+	if in == nil {
+		return DefaultDroppedMutationsThreshold
+	}
+	return in.Threshold
+}
+
+func (in *DroppedMutationsMonitoring) GetPollInterval() time.Duration {
+	if in == nil {
+		return DefaultDroppedMutationsPollInterval
+	}
+	return in.getPollInterval(DefaultDroppedMutationsPollInterval)
+}
+
+const (
+	DefaultManagementApiLatencyThreshold    = 100 * time.Millisecond
+	DefaultManagementApiLatencyPollInterval = 5 * time.Minute
+)
+
+// ManagementApiLatencyMonitoring configures the measurement of the latency between the operator and the datacenters.
+// The latency to a datacenter is the lowest round-trip time of a management API request sent by the operator to its
+// nodes, over an already established connection. This is not the latency between the nodes of two datacenters: it
+// only approximates it for the datacenters of other Kubernetes clusters, when the operator runs in the same Kubernetes
+// cluster as one of the datacenters.
+type ManagementApiLatencyMonitoring struct {
+	// Threshold is the round-trip time above which the ManagementApiLatencyHigh condition is set. Defaults to 100ms.
+	// +optional
+	Threshold *metav1.Duration `json:"threshold,omitempty"`
+
+	MonitoringPolling `json:",inline"`
+}
+
+func (in *ManagementApiLatencyMonitoring) GetThreshold() time.Duration {
+	if in == nil || in.Threshold == nil || in.Threshold.Duration <= 0 {
+		return DefaultManagementApiLatencyThreshold
+	}
+	return in.Threshold.Duration
+}
+
+func (in *ManagementApiLatencyMonitoring) GetPollInterval() time.Duration {
+	if in == nil {
+		return DefaultManagementApiLatencyPollInterval
+	}
+	return in.getPollInterval(DefaultManagementApiLatencyPollInterval)
+}
+
+const (
+	DefaultTokenImbalanceThresholdPercent = 20
+	DefaultTokenBalancePollInterval       = time.Hour
+)
+
+type TokenBalanceMonitoring struct {
+	// ThresholdPercent is the imbalance, in percent, above which the TokenOwnershipImbalanced condition is set. The
+	// imbalance of a datacenter is how much more of the token ring than an even share its most loaded node owns.
+	// Defaults to 20.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ThresholdPercent int32 `json:"thresholdPercent,omitempty"`
+
+	MonitoringPolling `json:",inline"`
+}
+
+func (in *TokenBalanceMonitoring) GetThresholdPercent() int32 {
+	if in == nil || in.ThresholdPercent == 0 {
+		return DefaultTokenImbalanceThresholdPercent
+	}
+	return in.ThresholdPercent
+}
+
+func (in *TokenBalanceMonitoring) GetPollInterval() time.Duration {
+	if in == nil {
+		return DefaultTokenBalancePollInterval
+	}
+	return in.getPollInterval(DefaultTokenBalancePollInterval)
+}
+
+const (
+	DefaultGossipStuckThreshold = 30 * time.Minute
+	DefaultGossipPollInterval   = time.Minute
+
+	// MaxReportedGossipNodes is the maximum number of nodes listed in the gossip status of a datacenter.
+	MaxReportedGossipNodes = 10
+)
+
+type GossipMonitoring struct {
+	// StuckThreshold is how long a node may stay JOINING or LEAVING before it is flagged as stuck and the
+	// GossipStateStuck condition is set. Defaults to 30 minutes.
+	// +optional
+	StuckThreshold *metav1.Duration `json:"stuckThreshold,omitempty"`
+
+	MonitoringPolling `json:",inline"`
+}
+
+func (in *GossipMonitoring) GetStuckThreshold() time.Duration {
+	if in == nil || in.StuckThreshold == nil || in.StuckThreshold.Duration <= 0 {
+		return DefaultGossipStuckThreshold
+	}
+	return in.StuckThreshold.Duration
+}
+
+func (in *GossipMonitoring) GetPollInterval() time.Duration {
+	if in == nil {
+		return DefaultGossipPollInterval
+	}
+	return in.getPollInterval(DefaultGossipPollInterval)
+}
+
+type CassandraDatacenterTemplate struct {
+	Meta EmbeddedObjectMeta `json:"metadata,omitempty"`
+
+	K8sContext string `json:"k8sContext,omitempty"`
+
+	DatacenterOptions `json:",inline"`
+
+	// Size is the number Cassandra pods to deploy in this datacenter.
+	// This number does not include Stargate instances.
+	// +kubebuilder:validation:Minimum=1
+	Size int32 `json:"size"`
+
+	// SystemReplicationFactor overrides the replication factor of the system keyspaces (system_auth,
+	// system_distributed and system_traces, plus the DSE-specific ones) in this datacenter. If not set, the
+	// replication factor is the smallest of 3 and Size. It cannot be greater than Size.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	SystemReplicationFactor *int32 `json:"systemReplicationFactor,omitempty"`
+
+	// Stopped means that the datacenter will be stopped. Use this for maintenance or for cost saving. A stopped
+	// CassandraDatacenter will have no running server pods, like using "stop" with  traditional System V init scripts.
+	// Other Kubernetes resources will be left intact, and volumes will re-attach when the CassandraDatacenter
+	// workload is resumed.
+	// +optional
+	// +kubebuilder:default=false
+	Stopped bool `json:"stopped,omitempty"`
+
+	// Stargate defines the desired deployment characteristics for Stargate in this datacenter. Leave nil to skip
+	// deploying Stargate in this datacenter.
+	// +optional
+	Stargate *stargateapi.StargateDatacenterTemplate `json:"stargate,omitempty"`
+
+	// PerNodeConfigMapRef is a reference to a ConfigMap that contains per-node configuration for
+	// this DC. The ConfigMap is expected to have entries in the following form:
+	// <pod-name>_<file-name>.yaml, where <pod-name> is the name of the pod and <file-name> is the
+	// name of a configuration file (typically, cassandra.yaml). The value of the entry is expected
+	// to be a YAML fragment that contains the per-node configuration for each pod. When the pod is
+	// started, the per-node ConfigMap is mounted and the contents of each entry corresponding to
+	// the pod are merged into their respective configuration files.
+	// +optional
+	PerNodeConfigMapRef corev1.LocalObjectReference `json:"perNodeConfigMapRef,omitempty"`
+
+	// SeedExcludedRacks lists racks of this datacenter whose nodes are never advertised as seeds to the other
+	// datacenters, for example racks running on spot instances. If all the seeds of the datacenter are in excluded
+	// racks, the datacenter does not provide any seed to the other datacenters. This does not change the seeds used
+	// within the datacenter, which are selected by cass-operator.
+	// +optional
+	SeedExcludedRacks []string `json:"seedExcludedRacks,omitempty"`
+
+	// SeedServiceRef references a Service, in the namespace and context of this datacenter, whose addresses are added
+	// to the seeds of this datacenter, alongside the seeds of the other datacenters and the cluster's AdditionalSeeds.
+	// The addresses of the Service's Endpoints are used; for an ExternalName Service, its external name is resolved
+	// through DNS instead. This allows a stable seed source that does not depend on pod IPs.
+	// +optional
+	SeedServiceRef *corev1.LocalObjectReference `json:"seedServiceRef,omitempty"`
+}
+
+// CassDcName returns the Cassandra datacenter name override if it exists,
+// otherwise the cassdc object name.
+func (in *CassandraDatacenterTemplate) CassDcName() string {
+	if in.DatacenterName != "" {
+		return in.DatacenterName
+	}
+	return in.Meta.Name
+}
+
+// DatacenterOptions are configuration settings that are can be set at the Cluster level and overridden for a single DC
+type DatacenterOptions struct {
+	// ServerVersion is the Cassandra or DSE version. The following versions are supported:
+	// - Cassandra: 3.11.X and 4.0.X
+	// - DSE: 6.8.X
+	// +kubebuilder:validation:Pattern=(6\.8\.\d+)|(3\.11\.\d+)|(4\.\d+\.\d+)
+	ServerVersion string `json:"serverVersion,omitempty"`
+
+	// ServerImage is the image for the cassandra container. Note that this should be a
+	// management-api image. If left empty the operator will choose a default image based
+	// on ServerVersion.
+	// +optional
+	ServerImage string `json:"serverImage,omitempty"`
+
+	// ConfigBuilderImage is the image for the server-config-builder init container, which renders the configuration
+	// files of the nodes. Use it to pull the image from a private registry, e.g. in air-gapped environments. If left
+	// empty, cass-operator chooses a default image.
+	// +optional
+	ConfigBuilderImage string `json:"configBuilderImage,omitempty"`
+
+	// CassandraConfig contains configuration settings that are applied to cassandra.yaml, dse.yaml
+	// and the various jvm*.options files.
+	// +optional
+	CassandraConfig *CassandraConfig `json:"config,omitempty"`
+
+	// StorageConfig is the persistent storage requirements for each Cassandra pod. This
+	// includes everything under /var/lib/cassandra, namely the commit log and data
+	// directories.
+	// +optional
+	StorageConfig *cassdcapi.StorageConfig `json:"storageConfig,omitempty"`
+
+	// Networking enables host networking and configures a NodePort ports.
+	// +optional
+	Networking *NetworkingConfig `json:"networking,omitempty"`
+
+	// Resources is the cpu and memory resources for the cassandra container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Racks is a list of named racks. Note that racks are used to create node affinity. //
+	// +optional
+	Racks []cassdcapi.Rack `json:"racks,omitempty"`
+
+	// Deprecated: JMX security is now based on CQL roles. We don't need an init container to configure JMX
+	// authentication anymore. The value of this field will be ignored.
+	JmxInitContainerImage *images.Image `json:"jmxInitContainerImage,omitempty"`
+
+	// SoftPodAntiAffinity sets whether multiple Cassandra instances can be scheduled on the same node. It maps to
+	// cass-operator's allowMultipleNodesPerWorker and defaults to false, in which case at most one Cassandra pod can be
+	// scheduled on each worker node. When true, the scheduler tries to spread Cassandra pods across worker nodes, but
+	// schedules several of them on the same node if it has to.
+	// This should normally be false to ensure cluster resilience but may be set true for test/dev scenarios to minimise
+	// the number of nodes required. It is not suitable for production, since losing a single worker can then take down
+	// several replicas at once.
+	SoftPodAntiAffinity *bool `json:"softPodAntiAffinity,omitempty"`
+
+	// Tolerations applied to every Cassandra pod.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// MgmtAPIHeap defines the amount of memory devoted to the management
+	// api heap.
+	// +optional
+	MgmtAPIHeap *resource.Quantity `json:"mgmtAPIHeap,omitempty"`
+
+	// Telemetry defines the desired state for telemetry resources in this datacenter.
+	// If telemetry configurations are defined, telemetry resources will be deployed to integrate with
+	// a user-provided monitoring solution (at present, only support for Prometheus is available).
+	// +optional
+	Telemetry *telemetryapi.TelemetrySpec `json:"telemetry,omitempty"`
+
+	// CDC defines the desired state for CDC integrations. It can be used to feed mutation events from Cassandra into an Apache Pulsar cluster,
+	// from where they can be expored to external systems.
+	// +optional
+	CDC *cassdcapi.CDCConfiguration `json:"cdc,omitempty"`
+
+	// Containers defines containers to be deployed in each Cassandra pod.
+	// K8ssandra-operator and cass-operator will create their own containers, which can be referenced here to override specific settings,
+	// such as mounts or resources request/limits for example.
+	// Example:
+	//  containers:
+	//  - name: server-system-logger
+	//  - name: custom-container
+	//    image: busybox
+	//  - name: cassandra
+	// +optional
+	Containers []corev1.Container `json:"containers,omitempty"`
+
+	// InitContainers defines init-containers to be deployed in each Cassandra pod.
+	// K8ssandra-operator and cass-operator will create their own init-containers, which can be referenced here to control ordering or override specific settings
+	// such as mounts or resources request/limits for example.
+	// Only the name of the k8ssandra-operator/cass-operator init-containers is required, not their full definition which will be created by the operators.
+	// Example:
+	//  initContainers:
+	//  - name: server-config-init
+	//  - name: custom-init-container
+	//    image: busybox
+	//  - name: medusa-restore
+	// Otherwise init-containers referenced here will be injected in first position
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// Volumes defines additional volumes to be added to each Cassandra pod.
+	// If the volume uses a PersistentVolumeClaim, the PVC will be managed by the statefulset.
+	// +optional
+	ExtraVolumes *K8ssandraVolumes `json:"extraVolumes,omitempty"`
+
+	// +optional
+	DseWorkloads *cassdcapi.DseWorkloads `json:"dseWorkloads,omitempty"`
+
+	// PodSecurityContext defines the security context for the Cassandra pods.
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// FsGroup is the group that owns the volumes of the Cassandra pods, for storage backends that require a specific
+	// group for Cassandra to write its data. It overrides PodSecurityContext.FSGroup. When PodSecurityContext is not
+	// set, the other settings of the default security context applied by cass-operator are kept.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	FsGroup *int64 `json:"fsGroup,omitempty"`
+
+	// ManagementApiAuth defines the authentication settings for the management API in the Cassandra pods.
+	// +optional
+	ManagementApiAuth *cassdcapi.ManagementApiAuthConfig `json:"managementApiAuth,omitempty"`
 
 	// The image to use in each Cassandra pod for the (short-lived) init container that merges global and perNodeConfig
 	// configuration. This is only useful when PerNodeConfigMapRef is set.
@@ -426,6 +1360,614 @@ type DatacenterOptions struct {
 	// Use cautiously.
 	// +optional
 	DatacenterName string `json:"datacenterName,omitempty"`
+
+	// CanaryUpgrade, when enabled, makes changes of the server version or image roll out to the first rack of the
+	// datacenter only, using cass-operator's canary upgrade mode. This allows validating a new version on a few nodes
+	// before upgrading the whole datacenter. Once the canary nodes are validated, disable it to complete the rollout.
+	// +optional
+	CanaryUpgrade *CanaryUpgradeConfig `json:"canaryUpgrade,omitempty"`
+
+	// StartupTimeouts configures how long Cassandra nodes are given to start and bootstrap before their probes can
+	// fail. Large datacenters may need longer timeouts so that nodes are not restarted in the middle of bootstrapping.
+	// Probes explicitly defined for the cassandra container in Containers take precedence over these settings.
+	// +optional
+	StartupTimeouts *StartupTimeouts `json:"startupTimeouts,omitempty"`
+
+	// HintsTuning configures hinted handoff, which can be tuned per datacenter depending on the characteristics of
+	// the network links between datacenters. It sets hinted_handoff_throttle_in_kb, max_hints_delivery_threads and
+	// max_hint_window_in_ms, which CassandraConfig must then leave unset.
+	// +optional
+	HintsTuning *HintsTuning `json:"hintsTuning,omitempty"`
+
+	// ExtraEnvVars are environment variables added to the cassandra container, for example JVM_EXTRA_OPTS. Variables
+	// managed by k8ssandra-operator or cass-operator, such as MANAGEMENT_API_HEAP_SIZE or USE_MGMT_API, cannot be set.
+	// +optional
+	ExtraEnvVars []corev1.EnvVar `json:"extraEnvVars,omitempty"`
+
+	// PvcRetentionPolicy controls what happens to the data of the nodes removed when the datacenter is scaled down.
+	// cass-operator always deletes the PVCs of decommissioned nodes. With "Delete", the default, their
+	// PersistentVolumes are handled according to the reclaim policy of their StorageClass. With "Retain", the reclaim
+	// policy of these PersistentVolumes is switched to Retain before the scale down is applied, so that the data
+	// outlives the PVCs. This requires the operator to be allowed to patch PersistentVolumes.
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	PvcRetentionPolicy PvcRetentionPolicy `json:"pvcRetentionPolicy,omitempty"`
+
+	// Snitch configures the snitch of the datacenter, which can differ between datacenters, for example in multi-cloud
+	// deployments. When set, it owns endpoint_snitch and dynamic_snitch, which CassandraConfig must then leave unset.
+	// When unset, cass-operator's default, GossipingPropertyFileSnitch, is used.
+	// +optional
+	Snitch *SnitchConfig `json:"snitch,omitempty"`
+
+	// DiskFailurePolicies configures how the nodes of the datacenter react to disk and commit log failures. It sets
+	// disk_failure_policy, commit_failure_policy and min_free_space_per_drive_in_mb, which CassandraConfig must then
+	// leave unset.
+	// +optional
+	DiskFailurePolicies *DiskFailurePolicies `json:"diskFailurePolicies,omitempty"`
+
+	// RackDcProperties sets the properties of cassandra-rackdc.properties, which is read by
+	// GossipingPropertyFileSnitch and by the cloud snitches. When unset, the file only contains the dc and rack
+	// written by cass-operator.
+	// +optional
+	RackDcProperties *RackDcProperties `json:"rackDcProperties,omitempty"`
+
+	// AddressStrategy selects the address that the nodes broadcast to the other nodes. "PodIP" broadcasts the pod IP,
+	// which requires pod IPs to be routable from the other datacenters. "HostIP" broadcasts the IP of the worker node,
+	// and "NodeName" the name of the worker node, which must be resolvable from the other datacenters; both require
+	// host networking or NodePort networking. When unset, cass-operator broadcasts the host IP with NodePort
+	// networking and the pod IP otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=PodIP;HostIP;NodeName
+	AddressStrategy AddressStrategy `json:"addressStrategy,omitempty"`
+
+	// ClientAddressStrategy selects the address that the nodes advertise to the clients as broadcast_rpc_address.
+	// "PodIP" advertises the pod IP, and can not be used with NodePort networking. "HostIP" advertises the IP of the
+	// worker node, which is its external IP on clusters whose worker nodes have public IPs; it requires host networking
+	// or NodePort networking. "Service" advertises ClientAddress, for example the address of a LoadBalancer Service in
+	// front of the datacenter. When unset, the nodes advertise their broadcast address.
+	// +optional
+	// +kubebuilder:validation:Enum=PodIP;HostIP;Service
+	ClientAddressStrategy ClientAddressStrategy `json:"clientAddressStrategy,omitempty"`
+
+	// ClientAddress is the IP address or host name advertised to the clients with the Service client address
+	// strategy.
+	// +optional
+	ClientAddress string `json:"clientAddress,omitempty"`
+
+	// ManagementApiTimeouts overrides the timeouts of the management API requests that the operator sends to the nodes
+	// of the datacenter.
+	// +optional
+	ManagementApiTimeouts *ManagementApiTimeouts `json:"managementApiTimeouts,omitempty"`
+
+	// NetworkPolicy restricts the traffic of the Cassandra pods of the datacenter with a NetworkPolicy managed by the
+	// operator. When unset, no NetworkPolicy is created.
+	// +optional
+	NetworkPolicy *NetworkPolicyConfig `json:"networkPolicy,omitempty"`
+
+	// DnsConfig sets the DNS parameters of the Cassandra pods, in addition to the ones generated from their DNS policy.
+	// It can be used to resolve the names of the pods of other k8s clusters. At most 3 nameservers, which must be IP
+	// addresses, and 32 search domains are allowed.
+	// +optional
+	DnsConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// PodHostname configures the DNS names of the Cassandra pods, so that each pod has a predictable FQDN that can be
+	// used as a seed, including from other k8s clusters. The pods of a StatefulSet always use their name as hostname,
+	// and the headless Service governing the StatefulSet, the all-pods Service of the datacenter, as subdomain. The
+	// FQDN of each pod is therefore <pod-name>.<cluster>-<dc>-all-pods-service.<namespace>.svc.<cluster-domain>, and
+	// the part following the pod name is reported in the connection status of the datacenter.
+	// +optional
+	PodHostname *PodHostname `json:"podHostname,omitempty"`
+
+	// ReadinessPolicy defines when the operator considers the datacenter ready to proceed with the reconciliation of
+	// the next datacenters, and with the schema operations. It also applies to the seed propagation quorum, to the
+	// seed topology check, and to the Stargate, Reaper and Medusa restore controllers, which wait for the datacenter.
+	// By default, all the nodes must be ready.
+	// +optional
+	ReadinessPolicy *ReadinessPolicy `json:"readinessPolicy,omitempty"`
+
+	// MaterializedViews configures the materialized views of the datacenter. It sets enable_materialized_views, named
+	// materialized_views_enabled since Cassandra 4.1, and the concurrency of the view writes and builds. CassandraConfig
+	// must not set these settings, under either name.
+	// +optional
+	MaterializedViews *MaterializedViewsTuning `json:"materializedViews,omitempty"`
+
+	// StartupProbe adds a startup probe to the cassandra container, which delays the liveness and readiness probes
+	// until the node has started. Unlike StartupTimeouts, it lets nodes that start quickly be probed early, while
+	// giving slow nodes up to MaxStartupTime to start. A startup probe explicitly defined for the cassandra container
+	// in Containers takes precedence. By default, no startup probe is set, as with cass-operator.
+	// +optional
+	StartupProbe *StartupProbe `json:"startupProbe,omitempty"`
+
+	// DedicatedNodes schedules the Cassandra pods on worker nodes dedicated to Cassandra with a taint. The toleration
+	// matching the taint is added to Tolerations, and the pods can optionally be restricted to the dedicated nodes
+	// with a node affinity.
+	// +optional
+	DedicatedNodes *DedicatedNodes `json:"dedicatedNodes,omitempty"`
+
+	// MemtableAndCacheTuning configures the memtables and caches of the datacenter, which can be tuned per datacenter
+	// depending on whether its workload is write-heavy or read-heavy. It sets memtable_allocation_type, the memtable
+	// space and flush writers, and the sizes of the file, key, row and counter caches, which CassandraConfig must then
+	// leave unset.
+	// +optional
+	MemtableAndCacheTuning *MemtableAndCacheTuning `json:"memtableAndCacheTuning,omitempty"`
+
+	// CassandraEnvScript is a shell fragment sourced at the end of cassandra-env.sh, for example to attach a Java agent
+	// to the Cassandra process. It is sourced before Cassandra starts, so it must neither exit nor replace the process,
+	// and it can not use command substitution. It is mounted in the Cassandra pods from one of their annotations, and
+	// installed by an init container that runs with the PerNodeConfigInitContainerImage.
+	// +optional
+	CassandraEnvScript string `json:"cassandraEnvScript,omitempty"`
+
+	// RequestTimeouts configures how long the coordinators of the datacenter wait for the replicas to answer read,
+	// write and range requests, which can be tuned per datacenter, for example for latency-sensitive or cross-region
+	// datacenters. CassandraConfig must not set these timeouts as well, whether under their names in Cassandra 4.1
+	// or under the older names ending in _in_ms.
+	// +optional
+	RequestTimeouts *RequestTimeouts `json:"requestTimeouts,omitempty"`
+
+	// StreamingEncryption configures the encryption of the streams of the datacenter, such as the bootstrap, rebuild
+	// and repair streams. Streams go through the internode connections, so they are encrypted according to the
+	// internode encryption configured in server_encryption_options; these settings are validated against it. They set
+	// the optional and enable_legacy_ssl_storage_port options of server_encryption_options, whose other options are
+	// still set in CassandraConfig.
+	// +optional
+	StreamingEncryption *StreamingEncryption `json:"streamingEncryption,omitempty"`
+
+	// AuditLogging configures the audit logging of the datacenter, which records the requests received by the nodes.
+	// It sets the enabled, logger, included_categories and excluded_categories options of audit_logging_options; the
+	// other audit logging options, such as audit_logs_dir or archive_command, can still be set in CassandraConfig.
+	// Audit logging requires Cassandra 4.0 or later.
+	// +optional
+	AuditLogging *AuditLogging `json:"auditLogging,omitempty"`
+
+	// ChangeDataCapture configures the change data capture (CDC) of the datacenter, which keeps the commit log segments
+	// holding mutations of tables with cdc enabled for consumers such as streaming pipelines. It sets cdc_enabled, the
+	// space allotted to the CDC logs and cdc_raw_directory, which CassandraConfig must then leave unset. It is not to
+	// be confused with the cdc field of the datacenter, which configures the agent feeding the mutations into Apache
+	// Pulsar.
+	// +optional
+	ChangeDataCapture *ChangeDataCapture `json:"changeDataCapture,omitempty"`
+}
+
+type PodHostname struct {
+	// ClusterDomain is the DNS domain of the k8s cluster of the datacenter.
+	// +optional
+	// +kubebuilder:default=cluster.local
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// SetHostnameAsFQDN sets the hostname of the Cassandra pods to their FQDN instead of their name. The FQDN of the
+	// pods can then not exceed 64 characters.
+	// +optional
+	SetHostnameAsFQDN bool `json:"setHostnameAsFQDN,omitempty"`
+}
+
+type AddressStrategy string
+
+const (
+	AddressStrategyPodIP    = AddressStrategy("PodIP")
+	AddressStrategyHostIP   = AddressStrategy("HostIP")
+	AddressStrategyNodeName = AddressStrategy("NodeName")
+)
+
+type ClientAddressStrategy string
+
+const (
+	ClientAddressStrategyPodIP   = ClientAddressStrategy("PodIP")
+	ClientAddressStrategyHostIP  = ClientAddressStrategy("HostIP")
+	ClientAddressStrategyService = ClientAddressStrategy("Service")
+)
+
+type PvcRetentionPolicy string
+
+const (
+	PvcRetentionPolicyDelete = PvcRetentionPolicy("Delete")
+	PvcRetentionPolicyRetain = PvcRetentionPolicy("Retain")
+)
+
+type CanaryUpgradeConfig struct {
+	// Enabled turns on canary upgrades for server version or image changes.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// NodeCount is the number of nodes of the first rack to upgrade. If it is 0 or greater than the size of the rack,
+	// all the nodes of the first rack are upgraded.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	NodeCount int32 `json:"nodeCount,omitempty"`
+}
+
+// IsEnabled returns true if canary upgrades are enabled.
+func (in *CanaryUpgradeConfig) IsEnabled() bool {
+	return in != nil && in.Enabled != nil && *in.Enabled
+}
+
+const (
+	MinStartupTimeout = 10 * time.Second
+	MaxStartupTimeout = 24 * time.Hour
+)
+
+type ManagementApiTimeouts struct {
+	// RequestTimeout is how long the operator waits for the response of each management API request, such as a
+	// schema or keyspace replication change, before failing it. When unset, the timeouts of cass-operator's client
+	// apply, which range from 20 seconds to 2 minutes depending on the operation. Must be between 1 second and 1 hour.
+	// +optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+}
+
+const (
+	MinManagementApiTimeout = time.Second
+	MaxManagementApiTimeout = time.Hour
+)
+
+type ReadinessMode string
+
+const (
+	ReadinessModeAll       = ReadinessMode("All")
+	ReadinessModeQuorum    = ReadinessMode("Quorum")
+	ReadinessModeThreshold = ReadinessMode("Threshold")
+)
+
+type ReadinessPolicy struct {
+	// Mode is "All" (the default) to require cass-operator to report the datacenter ready, which happens when all the
+	// nodes are ready; "Quorum" to only require a majority of the nodes to be ready; or "Threshold" to require the
+	// percentage of ready nodes set in MinReadyPercent.
+	// +optional
+	// +kubebuilder:validation:Enum=All;Quorum;Threshold
+	Mode ReadinessMode `json:"mode,omitempty"`
+
+	// MinReadyPercent is the minimum percentage of nodes that must be ready with the Threshold mode. The number of
+	// nodes is rounded up.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MinReadyPercent *int32 `json:"minReadyPercent,omitempty"`
+}
+
+// NetworkPolicyConfig describes the traffic allowed to and from the Cassandra pods of a datacenter. Internode traffic
+// is always allowed between the Cassandra and Stargate pods of the cluster that run in the same k8s context, and with
+// the seeds of the datacenters deployed in other contexts. The Reaper pods of the cluster are always allowed to
+// connect to the CQL and JMX ports. The ports are the ones configured in cassandra.yaml and in the JVM options. Any
+// other ingress not matched by one of the rules below is denied.
+type NetworkPolicyConfig struct {
+	// AllowedClients are the peers allowed to connect to the CQL ports, in addition to Reaper. When empty, the CQL
+	// ports are open to all peers.
+	// +optional
+	AllowedClients []networkingv1.NetworkPolicyPeer `json:"allowedClients,omitempty"`
+
+	// ManagementClients are the peers allowed to connect to the management API, metrics and Medusa ports. They must
+	// include the k8ssandra-operator and cass-operator pods. When empty, these ports are open to all peers.
+	// +optional
+	ManagementClients []networkingv1.NetworkPolicyPeer `json:"managementClients,omitempty"`
+
+	// PeerCidrs are the CIDRs of the pod networks of the other k8s contexts of the cluster. Internode traffic is
+	// allowed from and to these CIDRs, so that the non-seed nodes of the other datacenters can reach this datacenter.
+	// +optional
+	PeerCidrs []string `json:"peerCidrs,omitempty"`
+
+	// RestrictEgress denies the egress traffic of the Cassandra pods, except for DNS, internode traffic and
+	// AdditionalEgress.
+	// +optional
+	RestrictEgress bool `json:"restrictEgress,omitempty"`
+
+	// AdditionalIngress are ingress rules added as-is to the NetworkPolicy.
+	// +optional
+	AdditionalIngress []networkingv1.NetworkPolicyIngressRule `json:"additionalIngress,omitempty"`
+
+	// AdditionalEgress are egress rules added as-is to the NetworkPolicy. They are ignored unless RestrictEgress is
+	// true.
+	// +optional
+	AdditionalEgress []networkingv1.NetworkPolicyEgressRule `json:"additionalEgress,omitempty"`
+}
+
+type StartupTimeouts struct {
+	// ReadinessDelay is how long to wait after the cassandra container has started before probing its readiness. It
+	// maps to the initial delay of the readiness probe. Must be between 10 seconds and 24 hours.
+	// +optional
+	ReadinessDelay *metav1.Duration `json:"readinessDelay,omitempty"`
+
+	// BootstrapTimeout is how long a node may take to start and bootstrap before failing liveness probes cause it to
+	// be restarted. It maps to the initial delay of the liveness probe. Must be between 10 seconds and 24 hours.
+	// +optional
+	BootstrapTimeout *metav1.Duration `json:"bootstrapTimeout,omitempty"`
+}
+
+type StartupProbe struct {
+	// MaxStartupTime is how long a node may take to start before failing startup probes cause it to be restarted. Must
+	// be between 10 seconds and 24 hours.
+	MaxStartupTime metav1.Duration `json:"maxStartupTime"`
+
+	// Period is the interval between two probes. Defaults to 10 seconds. Must be between 1 second and MaxStartupTime.
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+}
+
+type DedicatedNodes struct {
+	// TaintKey is the key of the taint of the dedicated worker nodes.
+	// +kubebuilder:validation:MinLength=1
+	TaintKey string `json:"taintKey"`
+
+	// TaintValue is the value of the taint of the dedicated worker nodes. When empty, the toleration matches any value
+	// of TaintKey.
+	// +optional
+	TaintValue string `json:"taintValue,omitempty"`
+
+	// TaintEffect is the effect of the taint of the dedicated worker nodes. When empty, the toleration matches all
+	// effects.
+	// +optional
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	TaintEffect corev1.TaintEffect `json:"taintEffect,omitempty"`
+
+	// NodeAffinity, when enabled, also restricts the Cassandra pods to the worker nodes labeled with TaintKey set to
+	// TaintValue, since tolerating the taint alone does not prevent the pods from being scheduled on other nodes.
+	// Requires TaintValue.
+	// +optional
+	NodeAffinity bool `json:"nodeAffinity,omitempty"`
+}
+
+type HintsTuning struct {
+	// HintedHandoffThrottleInKb is the maximum throughput, in KB per second, at which each delivery thread sends
+	// hints. It maps to hinted_handoff_throttle_in_kb in cassandra.yaml, or to hinted_handoff_throttle with Cassandra
+	// 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	HintedHandoffThrottleInKb *int32 `json:"hintedHandoffThrottleInKb,omitempty"`
+
+	// MaxHintsDeliveryThreads is the number of threads delivering hints. It maps to max_hints_delivery_threads in
+	// cassandra.yaml.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxHintsDeliveryThreads *int32 `json:"maxHintsDeliveryThreads,omitempty"`
+
+	// MaxHintWindowInMs is how long, in milliseconds, hints are generated for a node that is down. It maps to
+	// max_hint_window_in_ms in cassandra.yaml, or to max_hint_window with Cassandra 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxHintWindowInMs *int32 `json:"maxHintWindowInMs,omitempty"`
+}
+
+type MemtableAndCacheTuning struct {
+	// MemtableAllocationType is how memtables are allocated. It maps to memtable_allocation_type in cassandra.yaml.
+	// unslabbed_heap_buffers requires Cassandra 4.0 or later.
+	// +optional
+	// +kubebuilder:validation:Enum=unslabbed_heap_buffers;heap_buffers;offheap_buffers;offheap_objects
+	MemtableAllocationType string `json:"memtableAllocationType,omitempty"`
+
+	// MemtableHeapSpaceInMb is the total heap space, in MB, used by memtables. It maps to memtable_heap_space_in_mb in
+	// cassandra.yaml, or to memtable_heap_space with Cassandra 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MemtableHeapSpaceInMb *int32 `json:"memtableHeapSpaceInMb,omitempty"`
+
+	// MemtableOffheapSpaceInMb is the total off-heap space, in MB, used by memtables. It maps to
+	// memtable_offheap_space_in_mb in cassandra.yaml, or to memtable_offheap_space with Cassandra 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MemtableOffheapSpaceInMb *int32 `json:"memtableOffheapSpaceInMb,omitempty"`
+
+	// MemtableFlushWriters is the number of threads flushing memtables to disk. It maps to memtable_flush_writers in
+	// cassandra.yaml.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MemtableFlushWriters *int32 `json:"memtableFlushWriters,omitempty"`
+
+	// FileCacheSizeInMb is the size, in MB, of the chunk cache holding the uncompressed chunks of SSTables. It maps to
+	// file_cache_size_in_mb in cassandra.yaml, or to file_cache_size with Cassandra 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	FileCacheSizeInMb *int32 `json:"fileCacheSizeInMb,omitempty"`
+
+	// KeyCacheSizeInMb is the size, in MB, of the key cache. It maps to key_cache_size_in_mb in cassandra.yaml, or to
+	// key_cache_size with Cassandra 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	KeyCacheSizeInMb *int32 `json:"keyCacheSizeInMb,omitempty"`
+
+	// RowCacheSizeInMb is the size, in MB, of the row cache. 0 disables the row cache. It maps to row_cache_size_in_mb
+	// in cassandra.yaml, or to row_cache_size with Cassandra 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RowCacheSizeInMb *int32 `json:"rowCacheSizeInMb,omitempty"`
+
+	// CounterCacheSizeInMb is the size, in MB, of the counter cache. It maps to counter_cache_size_in_mb in
+	// cassandra.yaml, or to counter_cache_size with Cassandra 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	CounterCacheSizeInMb *int32 `json:"counterCacheSizeInMb,omitempty"`
+}
+
+type RequestTimeouts struct {
+	// ReadRequestTimeoutInMs is how long, in milliseconds, the coordinator waits for read operations to complete. It
+	// maps to read_request_timeout_in_ms in cassandra.yaml, or to read_request_timeout with Cassandra 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ReadRequestTimeoutInMs *int32 `json:"readRequestTimeoutInMs,omitempty"`
+
+	// WriteRequestTimeoutInMs is how long, in milliseconds, the coordinator waits for write operations to complete. It
+	// maps to write_request_timeout_in_ms in cassandra.yaml, or to write_request_timeout with Cassandra 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	WriteRequestTimeoutInMs *int32 `json:"writeRequestTimeoutInMs,omitempty"`
+
+	// RangeRequestTimeoutInMs is how long, in milliseconds, the coordinator waits for sequential or index scans to
+	// complete. It maps to range_request_timeout_in_ms in cassandra.yaml, or to range_request_timeout with Cassandra
+	// 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RangeRequestTimeoutInMs *int32 `json:"rangeRequestTimeoutInMs,omitempty"`
+}
+
+type StreamingEncryption struct {
+	// Required, when true, makes the operator reject a configuration in which some streams of the datacenter would
+	// not be encrypted: internode_encryption must then be set to "all" in server_encryption_options, since with "dc"
+	// or "rack" the streams within a datacenter or a rack, such as most bootstrap streams, are not encrypted.
+	// +optional
+	Required *bool `json:"required,omitempty"`
+
+	// Optional, when true, lets the nodes accept unencrypted streams and internode connections as well as encrypted
+	// ones, which is needed while internode encryption is being enabled on a running cluster. It maps to
+	// server_encryption_options.optional in cassandra.yaml, and requires Cassandra 4.0 or later.
+	// +optional
+	Optional *bool `json:"optional,omitempty"`
+
+	// LegacySslStoragePort, when true, keeps the nodes listening on the ssl_storage_port for the encrypted streams and
+	// internode connections of Cassandra 3.11 nodes, which is needed while upgrading an encrypted cluster from
+	// Cassandra 3.11. It maps to server_encryption_options.enable_legacy_ssl_storage_port in cassandra.yaml, and
+	// requires Cassandra 4.0 or later.
+	// +optional
+	LegacySslStoragePort *bool `json:"legacySslStoragePort,omitempty"`
+}
+
+// AuditLogCategory is a category of requests recorded by the Cassandra audit log.
+type AuditLogCategory string
+
+const (
+	AuditLogCategoryQuery   = AuditLogCategory("QUERY")
+	AuditLogCategoryDml     = AuditLogCategory("DML")
+	AuditLogCategoryDdl     = AuditLogCategory("DDL")
+	AuditLogCategoryDcl     = AuditLogCategory("DCL")
+	AuditLogCategoryOther   = AuditLogCategory("OTHER")
+	AuditLogCategoryAuth    = AuditLogCategory("AUTH")
+	AuditLogCategoryError   = AuditLogCategory("ERROR")
+	AuditLogCategoryPrepare = AuditLogCategory("PREPARE")
+)
+
+type AuditLogging struct {
+	// Enabled turns audit logging on. It maps to audit_logging_options.enabled in cassandra.yaml.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Logger is the audit logger: BinAuditLogger writes a binary log that can be read with auditlogviewer, and
+	// FileAuditLogger writes to the AUDIT logback appender. It maps to audit_logging_options.logger in cassandra.yaml,
+	// and defaults to BinAuditLogger.
+	// +optional
+	// +kubebuilder:validation:Enum=BinAuditLogger;FileAuditLogger
+	Logger string `json:"logger,omitempty"`
+
+	// IncludedCategories are the categories of requests that are recorded, all of them when empty. The categories are
+	// QUERY, DML, DDL, DCL, OTHER, AUTH, ERROR and PREPARE. It maps to audit_logging_options.included_categories in
+	// cassandra.yaml.
+	// +optional
+	IncludedCategories []AuditLogCategory `json:"includedCategories,omitempty"`
+
+	// ExcludedCategories are the categories of requests that are not recorded. It maps to
+	// audit_logging_options.excluded_categories in cassandra.yaml.
+	// +optional
+	ExcludedCategories []AuditLogCategory `json:"excludedCategories,omitempty"`
+
+	// ConfigMapRef is a ConfigMap, in the namespace of the K8ssandraCluster, holding files that the audit logging
+	// configuration refers to, such as the script of archive_command. The operator replicates it to the k8s context and
+	// namespace of the datacenter, and mounts its files in the cassandra container under /opt/audit-logging. Changing
+	// the files triggers a rolling restart of the datacenter.
+	// +optional
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+}
+
+type ChangeDataCapture struct {
+	// Enabled turns change data capture on. It maps to cdc_enabled in cassandra.yaml.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// TotalSpaceInMb is the space, in MB, that the CDC commit log segments can use before writes to tables with cdc
+	// enabled are rejected. It maps to cdc_total_space_in_mb in cassandra.yaml, or to cdc_total_space with Cassandra
+	// 4.1 and later.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TotalSpaceInMb *int32 `json:"totalSpaceInMb,omitempty"`
+
+	// RawDirectory is the absolute path of the directory where the CDC commit log segments are kept. It maps to
+	// cdc_raw_directory in cassandra.yaml, and defaults to the cdc_raw directory of the server data volume. It must be
+	// under /var/lib/cassandra, unless RawDirectoryStorage is set.
+	// +optional
+	RawDirectory string `json:"rawDirectory,omitempty"`
+
+	// RawDirectoryStorage, when set, is the spec of a PersistentVolumeClaim mounted at RawDirectory in the cassandra
+	// container, so that the CDC commit log segments do not use the space of the server data volume. It requires
+	// RawDirectory. It can only be set when the datacenter is created, since the volume becomes a volume claim
+	// template of its StatefulSets; neither it nor RawDirectory can be changed afterwards.
+	// +optional
+	RawDirectoryStorage *corev1.PersistentVolumeClaimSpec `json:"rawDirectoryStorage,omitempty"`
+}
+
+type MaterializedViewsTuning struct {
+	// Enabled allows the creation of materialized views, which are disabled by default since Cassandra 4.0. It maps to
+	// materialized_views_enabled in cassandra.yaml with Cassandra 4.1 and later, and to enable_materialized_views with
+	// older versions and DSE.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ConcurrentWrites is the number of threads applying the updates of base tables to their materialized views. It
+	// maps to concurrent_materialized_view_writes in cassandra.yaml.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ConcurrentWrites *int32 `json:"concurrentWrites,omitempty"`
+
+	// ConcurrentBuilders is the number of threads building materialized views from the existing data of their base
+	// tables. It maps to concurrent_materialized_view_builders in cassandra.yaml, and requires Cassandra 4.0 or later.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ConcurrentBuilders *int32 `json:"concurrentBuilders,omitempty"`
+}
+
+type SnitchConfig struct {
+	// EndpointSnitch is the snitch class, for example GossipingPropertyFileSnitch or Ec2MultiRegionSnitch. Classes
+	// of the org.apache.cassandra.locator package can be referred to by their short name. It maps to endpoint_snitch
+	// in cassandra.yaml. SimpleSnitch can only be used in single-datacenter clusters, and PropertyFileSnitch must be
+	// used by all the datacenters or none.
+	// +kubebuilder:validation:MinLength=1
+	EndpointSnitch string `json:"endpointSnitch"`
+
+	// DynamicSnitch controls whether the dynamic snitch, which routes reads away from slow replicas, wraps the
+	// endpoint snitch. It maps to dynamic_snitch in cassandra.yaml.
+	// +optional
+	DynamicSnitch *bool `json:"dynamicSnitch,omitempty"`
+}
+
+var (
+	DiskFailurePolicyValues   = []string{"die", "stop_paranoid", "stop", "best_effort", "ignore"}
+	CommitFailurePolicyValues = []string{"die", "stop", "stop_commit", "ignore"}
+)
+
+type DiskFailurePolicies struct {
+	// DiskFailurePolicy is the policy applied when a data disk fails. It maps to disk_failure_policy in
+	// cassandra.yaml.
+	// +optional
+	// +kubebuilder:validation:Enum=die;stop_paranoid;stop;best_effort;ignore
+	DiskFailurePolicy string `json:"diskFailurePolicy,omitempty"`
+
+	// CommitFailurePolicy is the policy applied when the commit log fails. It maps to commit_failure_policy in
+	// cassandra.yaml.
+	// +optional
+	// +kubebuilder:validation:Enum=die;stop;stop_commit;ignore
+	CommitFailurePolicy string `json:"commitFailurePolicy,omitempty"`
+
+	// MinFreeSpacePerDriveInMb is the free space, in MB, that compactions and flushes leave on each data drive. It
+	// maps to min_free_space_per_drive_in_mb in cassandra.yaml.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinFreeSpacePerDriveInMb *int32 `json:"minFreeSpacePerDriveInMb,omitempty"`
+}
+
+type RackDcProperties struct {
+	// Dc is the dc property. It must match the Cassandra name of the datacenter, which is its DatacenterName or,
+	// when that is unset, its name.
+	// +optional
+	Dc string `json:"dc,omitempty"`
+
+	// Rack is the rack property. Since the property is shared by all the nodes of the datacenter, it can only be set
+	// when the datacenter has a single rack, and must match the name of that rack, or "default" if the datacenter
+	// does not declare racks.
+	// +optional
+	Rack string `json:"rack,omitempty"`
+
+	// PreferLocal makes the nodes connect to the nodes of their own datacenter through their internal addresses. It
+	// maps to the prefer_local property.
+	// +optional
+	PreferLocal *bool `json:"preferLocal,omitempty"`
+
+	// DcSuffix is appended to the datacenter name reported by the cloud snitches, such as Ec2Snitch. It maps to the
+	// dc_suffix property.
+	// +optional
+	DcSuffix string `json:"dcSuffix,omitempty"`
 }
 
 // NetworkingConfig is a copy of cass-operator's NetworkingConfig struct. It is copied here to
@@ -479,6 +2021,16 @@ func (s *K8ssandraClusterStatus) GetConditionStatus(conditionType K8ssandraClust
 	return corev1.ConditionUnknown
 }
 
+// GetCondition returns the condition of the given type, if it exists.
+func (s *K8ssandraClusterStatus) GetCondition(conditionType K8ssandraClusterConditionType) (K8ssandraClusterCondition, bool) {
+	for _, condition := range s.Conditions {
+		if condition.Type == conditionType {
+			return condition, true
+		}
+	}
+	return K8ssandraClusterCondition{}, false
+}
+
 func (s *K8ssandraClusterStatus) SetCondition(condition K8ssandraClusterCondition) {
 	for i, c := range s.Conditions {
 		if c.Type == condition.Type {
@@ -489,6 +2041,27 @@ func (s *K8ssandraClusterStatus) SetCondition(condition K8ssandraClusterConditio
 	s.Conditions = append(s.Conditions, condition)
 }
 
+// SetConditionStatus sets the condition of the given type to status, with the given message. The condition is left
+// untouched when neither its status nor its message changes, and its LastTransitionTime is only updated when its status
+// changes.
+func (s *K8ssandraClusterStatus) SetConditionStatus(conditionType K8ssandraClusterConditionType, status corev1.ConditionStatus, message string) {
+	now := metav1.Now()
+	if condition, found := s.GetCondition(conditionType); found && condition.Status == status {
+		if condition.Message == message {
+			return
+		}
+		if condition.LastTransitionTime != nil {
+			now = *condition.LastTransitionTime
+		}
+	}
+	s.SetCondition(K8ssandraClusterCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: &now,
+		Message:            message,
+	})
+}
+
 func init() {
 	SchemeBuilder.Register(&K8ssandraCluster{}, &K8ssandraClusterList{})
 }
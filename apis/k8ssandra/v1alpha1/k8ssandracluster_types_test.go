@@ -2,10 +2,13 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	stargateapi "github.com/k8ssandra/k8ssandra-operator/apis/stargate/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
 
@@ -128,3 +131,26 @@ func TestNetworkingConfig_ToCassNetworkingConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestK8ssandraClusterStatus_SetConditionStatus(t *testing.T) {
+	past := metav1.NewTime(time.Now().Add(-time.Hour))
+	status := &K8ssandraClusterStatus{Conditions: []K8ssandraClusterCondition{{
+		Type:               ScalingDeferred,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: &past,
+		Message:            "dc1",
+	}}}
+
+	status.SetConditionStatus(ScalingDeferred, corev1.ConditionTrue, "dc2")
+	condition, _ := status.GetCondition(ScalingDeferred)
+	assert.Equal(t, "dc2", condition.Message)
+	assert.Equal(t, past, *condition.LastTransitionTime, "the transition time is kept while the status is unchanged")
+
+	status.SetConditionStatus(ScalingDeferred, corev1.ConditionFalse, "")
+	condition, _ = status.GetCondition(ScalingDeferred)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.True(t, condition.LastTransitionTime.After(past.Time))
+
+	status.SetConditionStatus(CassandraInitialized, corev1.ConditionTrue, "")
+	assert.Len(t, status.Conditions, 2)
+}
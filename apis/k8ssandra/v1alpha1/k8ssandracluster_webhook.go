@@ -18,9 +18,15 @@ package v1alpha1
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
+	"github.com/k8ssandra/cass-operator/pkg/oplabels"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/clientcache"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -34,6 +40,21 @@ var (
 	ErrNoStorageConfig = fmt.Errorf("storageConfig must be defined at cluster level or dc level")
 	ErrNoResourcesSet  = fmt.Errorf("softPodAntiAffinity requires Resources to be set")
 	ErrClusterName     = fmt.Errorf("cluster name can not be changed")
+	ErrSystemRF        = fmt.Errorf("systemReplicationFactor can not be greater than the datacenter size")
+	ErrFsGroup         = fmt.Errorf("fsGroup and podSecurityContext.fsGroup can not be set to different values")
+	ErrStartupTimeout  = fmt.Errorf("startupTimeouts must be between %v and %v", MinStartupTimeout, MaxStartupTimeout)
+	ErrMissingLabels   = fmt.Errorf("required labels are missing")
+	ErrMaxClusterSize  = fmt.Errorf("the cluster exceeds the maximum number of nodes")
+
+	ErrSeedPropagationQuorum = fmt.Errorf("seedPropagationQuorum can not be greater than the number of datacenters")
+	ErrManagedPodLabel       = fmt.Errorf("pod labels managed by cass-operator can not be set in metadata.pods")
+	ErrCdcRawDirectoryVolume = fmt.Errorf("changeDataCapture rawDirectoryStorage and its rawDirectory can not be changed in existing datacenters")
+
+	// requiredLabels are the label keys that K8ssandraClusters and the CassandraDatacenters derived from them must carry.
+	requiredLabels []string
+
+	// maxClusterSize is the maximum number of nodes of a K8ssandraCluster, across all its datacenters.
+	maxClusterSize int
 )
 
 // log is for logging in this package.
@@ -46,6 +67,18 @@ func (r *K8ssandraCluster) SetupWebhookWithManager(mgr ctrl.Manager, cCache *cli
 		Complete()
 }
 
+// SetRequiredLabels configures the label keys that the webhook requires on K8ssandraClusters and on the metadata of
+// each of their datacenters. Passing an empty list disables the check.
+func SetRequiredLabels(labels []string) {
+	requiredLabels = labels
+}
+
+// SetMaxClusterSize configures the maximum number of nodes that the webhook accepts for a K8ssandraCluster, across all
+// its datacenters. Passing zero disables the check.
+func SetMaxClusterSize(size int) {
+	maxClusterSize = size
+}
+
 var _ webhook.Defaulter = &K8ssandraCluster{}
 
 // Default implements webhook.Defaulter so a webhook will be registered for the type
@@ -62,11 +95,29 @@ var _ webhook.Validator = &K8ssandraCluster{}
 func (r *K8ssandraCluster) ValidateCreate() error {
 	webhookLog.Info("validate K8ssandraCluster create", "K8ssandraCluster", r.Name)
 
+	if err := r.validateRequiredLabels(nil); err != nil {
+		return err
+	}
+	if err := r.validatePodLabels(nil); err != nil {
+		return err
+	}
+	if err := ValidateClusterSize(r, 0, maxClusterSize); err != nil {
+		return err
+	}
 	return r.validateK8ssandraCluster()
 }
 
 func (r *K8ssandraCluster) validateK8ssandraCluster() error {
+	if err := ValidateSeedPropagationQuorum(r); err != nil {
+		return err
+	}
 	hasClusterStorageConfig := r.Spec.Cassandra.DatacenterOptions.StorageConfig != nil
+	if err := validateStartupTimeouts(r.Spec.Cassandra.DatacenterOptions.StartupTimeouts); err != nil {
+		return err
+	}
+	if err := validateFsGroup(r.Spec.Cassandra.DatacenterOptions); err != nil {
+		return err
+	}
 	// Verify given k8s-contexts are correct
 	for _, dc := range r.Spec.Cassandra.Datacenters {
 		_, err := clientCache.GetRemoteClient(dc.K8sContext)
@@ -80,13 +131,247 @@ func (r *K8ssandraCluster) validateK8ssandraCluster() error {
 			return ErrNoStorageConfig
 		}
 		// From cass-operator, if AllowMultipleWorkersPerNode is set, Resources must be defined or cass-operator will reject this Datacenter
-		if dc.DatacenterOptions.SoftPodAntiAffinity != nil && *dc.DatacenterOptions.SoftPodAntiAffinity {
-			if dc.DatacenterOptions.Resources == nil {
-				return ErrNoResourcesSet
+		if r.softPodAntiAffinity(dc) && dc.DatacenterOptions.Resources == nil && r.Spec.Cassandra.DatacenterOptions.Resources == nil {
+			return ErrNoResourcesSet
+		}
+		if err := validateStartupTimeouts(dc.DatacenterOptions.StartupTimeouts); err != nil {
+			return err
+		}
+		if err := validateFsGroup(dc.DatacenterOptions); err != nil {
+			return err
+		}
+		if dc.SystemReplicationFactor != nil && *dc.SystemReplicationFactor > dc.Size {
+			return ErrSystemRF
+		}
+	}
+
+	return nil
+}
+
+// softPodAntiAffinity returns true if soft pod anti-affinity is enabled in the datacenter dc, either in its own options
+// or in the cluster-wide ones.
+func (r *K8ssandraCluster) softPodAntiAffinity(dc CassandraDatacenterTemplate) bool {
+	if dc.DatacenterOptions.SoftPodAntiAffinity != nil {
+		return *dc.DatacenterOptions.SoftPodAntiAffinity
+	}
+	softPodAntiAffinity := r.Spec.Cassandra.DatacenterOptions.SoftPodAntiAffinity
+	return softPodAntiAffinity != nil && *softPodAntiAffinity
+}
+
+// validateRequiredLabels checks that the K8ssandraCluster, and every CassandraDatacenter derived from it, carry the
+// configured required labels. Datacenter labels are inherited from .spec.cassandra.metadata.labels and can be overridden
+// in each datacenter's metadata. On update, old is the previous version of the K8ssandraCluster, and only the objects
+// whose labels changed are checked, so that objects created before the labels were required can still be updated and
+// deleted.
+func (r *K8ssandraCluster) validateRequiredLabels(old *K8ssandraCluster) error {
+	if len(requiredLabels) == 0 || r.DeletionTimestamp != nil {
+		return nil
+	}
+	if old == nil || !reflect.DeepEqual(r.Labels, old.Labels) {
+		if missing := missingLabels(r.Labels); len(missing) > 0 {
+			return errors.Wrapf(ErrMissingLabels, "K8ssandraCluster %s is missing %v", r.Name, missing)
+		}
+	}
+	if r.Spec.Cassandra == nil {
+		return nil
+	}
+	oldDcLabels := make(map[string]map[string]string)
+	if old != nil && old.Spec.Cassandra != nil {
+		for _, dc := range old.Spec.Cassandra.Datacenters {
+			oldDcLabels[dc.Meta.Name] = old.datacenterLabels(dc)
+		}
+	}
+	for _, dc := range r.Spec.Cassandra.Datacenters {
+		dcLabels := r.datacenterLabels(dc)
+		if previous, found := oldDcLabels[dc.Meta.Name]; found && reflect.DeepEqual(dcLabels, previous) {
+			continue
+		}
+		if missing := missingLabels(dcLabels); len(missing) > 0 {
+			return errors.Wrapf(ErrMissingLabels, "datacenter %s is missing %v", dc.Meta.Name, missing)
+		}
+	}
+	return nil
+}
+
+// datacenterLabels returns the labels of the CassandraDatacenter derived from dc.
+func (r *K8ssandraCluster) datacenterLabels(dc CassandraDatacenterTemplate) map[string]string {
+	dcLabels := make(map[string]string)
+	for k, v := range r.Spec.Cassandra.Meta.Labels {
+		dcLabels[k] = v
+	}
+	for k, v := range dc.Meta.Metadata.Labels {
+		dcLabels[k] = v
+	}
+	return dcLabels
+}
+
+// managedPodLabels are the labels that cass-operator sets on the Cassandra pods, in addition to the ones prefixed with
+// cassandra.datastax.com/.
+var managedPodLabels = map[string]bool{
+	oplabels.ManagedByLabel: true,
+	oplabels.NameLabel:      true,
+	oplabels.InstanceLabel:  true,
+	oplabels.VersionLabel:   true,
+	oplabels.CreatedByLabel: true,
+}
+
+// validatePodLabels checks that the metadata.pods labels of the datacenters do not include labels managed by
+// cass-operator, which would overwrite them. On update, old is the previous version of the K8ssandraCluster, and only
+// the datacenters whose pod labels changed are checked, so that existing datacenters that already set them can still
+// be updated and deleted. The labels prefixed with cassandra.datastax.com/, which could make cass-operator misidentify
+// the pods, are also rejected when the datacenter is reconciled.
+func (r *K8ssandraCluster) validatePodLabels(old *K8ssandraCluster) error {
+	if r.DeletionTimestamp != nil || r.Spec.Cassandra == nil {
+		return nil
+	}
+	oldPodLabels := make(map[string]map[string]string)
+	if old != nil && old.Spec.Cassandra != nil {
+		for _, dc := range old.Spec.Cassandra.Datacenters {
+			oldPodLabels[dc.Meta.Name] = dc.Meta.Metadata.Pods.Labels
+		}
+	}
+	for _, dc := range r.Spec.Cassandra.Datacenters {
+		podLabels := dc.Meta.Metadata.Pods.Labels
+		if previous, found := oldPodLabels[dc.Meta.Name]; found && reflect.DeepEqual(podLabels, previous) {
+			continue
+		}
+		keys := make([]string, 0, len(podLabels))
+		for key := range podLabels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if strings.HasPrefix(key, "cassandra.datastax.com/") || managedPodLabels[key] {
+				return errors.Wrapf(ErrManagedPodLabel, "label %s of datacenter %s", key, dc.Meta.Name)
 			}
 		}
 	}
+	return nil
+}
+
+// validateCdcRawDirectoryVolume checks that the datacenters of old do not change the volume of their CDC raw directory.
+// The volume becomes a volume claim template of the StatefulSets of the datacenter, which can not be changed once they
+// are created.
+func (r *K8ssandraCluster) validateCdcRawDirectoryVolume(old *K8ssandraCluster) error {
+	if r.Spec.Cassandra == nil || old.Spec.Cassandra == nil {
+		return nil
+	}
+	oldDcs := make(map[string]CassandraDatacenterTemplate)
+	for _, dc := range old.Spec.Cassandra.Datacenters {
+		oldDcs[dc.Meta.Name] = dc
+	}
+	for _, dc := range r.Spec.Cassandra.Datacenters {
+		oldDc, found := oldDcs[dc.Meta.Name]
+		if !found {
+			continue
+		}
+		directory, storage := r.Spec.Cassandra.cdcRawDirectoryVolume(dc)
+		oldDirectory, oldStorage := old.Spec.Cassandra.cdcRawDirectoryVolume(oldDc)
+		if storage == nil && oldStorage == nil {
+			continue
+		}
+		if directory != oldDirectory || !reflect.DeepEqual(storage, oldStorage) {
+			return errors.Wrapf(ErrCdcRawDirectoryVolume, "datacenter %s", dc.Meta.Name)
+		}
+	}
+	return nil
+}
+
+// cdcRawDirectoryVolume returns the CDC raw directory of dc and the storage of its volume, as set in the datacenter or
+// else in the cluster.
+func (in *CassandraClusterTemplate) cdcRawDirectoryVolume(dc CassandraDatacenterTemplate) (string, *corev1.PersistentVolumeClaimSpec) {
+	var directory string
+	var storage *corev1.PersistentVolumeClaimSpec
+	if cdc := in.DatacenterOptions.ChangeDataCapture; cdc != nil {
+		directory, storage = cdc.RawDirectory, cdc.RawDirectoryStorage
+	}
+	if cdc := dc.DatacenterOptions.ChangeDataCapture; cdc != nil {
+		if cdc.RawDirectory != "" {
+			directory = cdc.RawDirectory
+		}
+		if cdc.RawDirectoryStorage != nil {
+			storage = cdc.RawDirectoryStorage
+		}
+	}
+	return directory, storage
+}
+
+// ValidateClusterSize checks that the sum of the sizes of the datacenters of kc does not exceed maxSize, and reports
+// the size of each datacenter otherwise. A cluster that previously had previousSize nodes is only rejected if it grows,
+// so that a cluster admitted before the maximum was lowered can still be updated and scaled down. Clusters being
+// deleted are not checked. Passing zero as maxSize disables the check.
+func ValidateClusterSize(kc *K8ssandraCluster, previousSize, maxSize int) error {
+	if maxSize <= 0 || kc.Spec.Cassandra == nil || kc.DeletionTimestamp != nil {
+		return nil
+	}
+	total := ClusterSize(kc)
+	if total <= maxSize || total <= previousSize {
+		return nil
+	}
+	sizes := make([]string, 0, len(kc.Spec.Cassandra.Datacenters))
+	for _, dc := range kc.Spec.Cassandra.Datacenters {
+		sizes = append(sizes, fmt.Sprintf("%s: %d", dc.Meta.Name, dc.Size))
+	}
+	return errors.Wrapf(ErrMaxClusterSize, "K8ssandraCluster %s has %d nodes, the maximum is %d (%s)",
+		kc.Name, total, maxSize, strings.Join(sizes, ", "))
+}
+
+// ClusterSize returns the sum of the sizes of the datacenters of kc.
+func ClusterSize(kc *K8ssandraCluster) int {
+	if kc.Spec.Cassandra == nil {
+		return 0
+	}
+	total := 0
+	for _, dc := range kc.Spec.Cassandra.Datacenters {
+		total += int(dc.Size)
+	}
+	return total
+}
+
+// ValidateSeedPropagationQuorum checks that the seedPropagationQuorum of kc, if set, can be reached by its datacenters.
+// Otherwise, changes to the seeds would never be propagated.
+func ValidateSeedPropagationQuorum(kc *K8ssandraCluster) error {
+	if kc.Spec.Cassandra == nil || kc.Spec.Cassandra.SeedPropagationQuorum == nil {
+		return nil
+	}
+	quorum := int(*kc.Spec.Cassandra.SeedPropagationQuorum)
+	if dcCount := len(kc.Spec.Cassandra.Datacenters); quorum > dcCount {
+		return errors.Wrapf(ErrSeedPropagationQuorum, "K8ssandraCluster %s has %d datacenters, the quorum is %d",
+			kc.Name, dcCount, quorum)
+	}
+	return nil
+}
+
+func missingLabels(labels map[string]string) []string {
+	var missing []string
+	for _, key := range requiredLabels {
+		if value, found := labels[key]; !found || value == "" {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
 
+func validateStartupTimeouts(timeouts *StartupTimeouts) error {
+	if timeouts == nil {
+		return nil
+	}
+	for _, timeout := range []*metav1.Duration{timeouts.ReadinessDelay, timeouts.BootstrapTimeout} {
+		if timeout != nil && (timeout.Duration < MinStartupTimeout || timeout.Duration > MaxStartupTimeout) {
+			return ErrStartupTimeout
+		}
+	}
+	return nil
+}
+
+func validateFsGroup(options DatacenterOptions) error {
+	if options.FsGroup == nil || options.PodSecurityContext == nil || options.PodSecurityContext.FSGroup == nil {
+		return nil
+	}
+	if *options.FsGroup != *options.PodSecurityContext.FSGroup {
+		return ErrFsGroup
+	}
 	return nil
 }
 
@@ -103,6 +388,19 @@ func (r *K8ssandraCluster) ValidateUpdate(old runtime.Object) error {
 		return fmt.Errorf("previous object could not be casted to K8ssandraCluster")
 	}
 
+	if err := r.validateRequiredLabels(oldCluster); err != nil {
+		return err
+	}
+	if err := r.validatePodLabels(oldCluster); err != nil {
+		return err
+	}
+	if err := ValidateClusterSize(r, ClusterSize(oldCluster), maxClusterSize); err != nil {
+		return err
+	}
+	if err := r.validateCdcRawDirectoryVolume(oldCluster); err != nil {
+		return err
+	}
+
 	// Verify Reaper keyspace is not changed
 	oldReaperSpec := oldCluster.Spec.Reaper
 	reaperSpec := r.Spec.Reaper
@@ -35,8 +35,10 @@ import (
 
 	//+kubebuilder:scaffold:imports
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -143,6 +145,15 @@ func TestWebhook(t *testing.T) {
 	t.Run("ReaperKeyspaceValidation", testReaperKeyspaceValidation)
 	t.Run("StorageConfigValidation", testStorageConfigValidation)
 	t.Run("NumTokensValidation", testNumTokens)
+	t.Run("StartupTimeoutsValidation", testStartupTimeoutsValidation)
+	t.Run("SystemReplicationFactorValidation", testSystemReplicationFactorValidation)
+	t.Run("FsGroupValidation", testFsGroupValidation)
+	t.Run("RequiredLabelsValidation", testRequiredLabelsValidation)
+	t.Run("MaxClusterSizeValidation", testMaxClusterSizeValidation)
+	t.Run("SeedPropagationQuorumValidation", testSeedPropagationQuorumValidation)
+	t.Run("PodLabelsValidation", testPodLabelsValidation)
+	t.Run("CdcRawDirectoryVolumeValidation", testCdcRawDirectoryVolumeValidation)
+	t.Run("SoftPodAntiAffinityValidation", testSoftPodAntiAffinityValidation)
 }
 
 func testContextValidation(t *testing.T) {
@@ -376,3 +387,256 @@ func createMinimalClusterObj(name, namespace string) *K8ssandraCluster {
 		},
 	}
 }
+
+func testStartupTimeoutsValidation(t *testing.T) {
+	required := require.New(t)
+	createNamespace(required, "startup-timeouts-namespace")
+	cluster := createMinimalClusterObj("startup-timeouts-test", "startup-timeouts-namespace")
+
+	cluster.Spec.Cassandra.DatacenterOptions.StartupTimeouts = &StartupTimeouts{
+		BootstrapTimeout: &metav1.Duration{Duration: time.Second},
+	}
+	err := k8sClient.Create(ctx, cluster)
+	required.Error(err)
+
+	cluster.Spec.Cassandra.DatacenterOptions.StartupTimeouts.BootstrapTimeout.Duration = 2 * time.Hour
+	err = k8sClient.Create(ctx, cluster)
+	required.NoError(err)
+
+	cluster.Spec.Cassandra.Datacenters[0].DatacenterOptions.StartupTimeouts = &StartupTimeouts{
+		ReadinessDelay: &metav1.Duration{Duration: 48 * time.Hour},
+	}
+	err = k8sClient.Update(ctx, cluster)
+	required.Error(err)
+}
+
+func testSystemReplicationFactorValidation(t *testing.T) {
+	required := require.New(t)
+	createNamespace(required, "system-rf-namespace")
+	cluster := createMinimalClusterObj("system-rf-test", "system-rf-namespace")
+
+	systemRF := cluster.Spec.Cassandra.Datacenters[0].Size + 1
+	cluster.Spec.Cassandra.Datacenters[0].SystemReplicationFactor = &systemRF
+	err := k8sClient.Create(ctx, cluster)
+	required.Error(err)
+
+	systemRF = cluster.Spec.Cassandra.Datacenters[0].Size
+	err = k8sClient.Create(ctx, cluster)
+	required.NoError(err)
+}
+
+func testFsGroupValidation(t *testing.T) {
+	required := require.New(t)
+	createNamespace(required, "fs-group-namespace")
+	cluster := createMinimalClusterObj("fs-group-test", "fs-group-namespace")
+
+	cluster.Spec.Cassandra.Datacenters[0].FsGroup = pointer.Int64(2000)
+	cluster.Spec.Cassandra.Datacenters[0].PodSecurityContext = &corev1.PodSecurityContext{FSGroup: pointer.Int64(1000)}
+	err := k8sClient.Create(ctx, cluster)
+	required.Error(err)
+
+	cluster.Spec.Cassandra.Datacenters[0].PodSecurityContext.FSGroup = pointer.Int64(2000)
+	err = k8sClient.Create(ctx, cluster)
+	required.NoError(err)
+}
+
+func testSoftPodAntiAffinityValidation(t *testing.T) {
+	required := require.New(t)
+	createNamespace(required, "soft-anti-affinity-namespace")
+	cluster := createMinimalClusterObj("soft-anti-affinity-test", "soft-anti-affinity-namespace")
+
+	// enabled cluster-wide, and inherited by the datacenter
+	cluster.Spec.Cassandra.DatacenterOptions.SoftPodAntiAffinity = pointer.Bool(true)
+	err := k8sClient.Create(ctx, cluster)
+	required.Error(err)
+
+	cluster.Spec.Cassandra.Datacenters[0].SoftPodAntiAffinity = pointer.Bool(false)
+	err = k8sClient.Create(ctx, cluster)
+	required.NoError(err)
+
+	cluster.Spec.Cassandra.Datacenters[0].SoftPodAntiAffinity = nil
+	err = k8sClient.Update(ctx, cluster)
+	required.Error(err)
+
+	cluster.Spec.Cassandra.DatacenterOptions.Resources = &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}
+	err = k8sClient.Update(ctx, cluster)
+	required.NoError(err)
+}
+
+func testRequiredLabelsValidation(t *testing.T) {
+	required := require.New(t)
+	createNamespace(required, "required-labels-namespace")
+	SetRequiredLabels([]string{"team", "cost-center"})
+	defer SetRequiredLabels(nil)
+
+	cluster := createMinimalClusterObj("required-labels-test", "required-labels-namespace")
+	cluster.Spec.Cassandra.Datacenters[0].Meta.Name = "dc1"
+	err := k8sClient.Create(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), ErrMissingLabels.Error())
+
+	// The datacenter does not inherit the labels of the K8ssandraCluster
+	cluster.Labels = map[string]string{"team": "storage", "cost-center": "1234"}
+	err = k8sClient.Create(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), "datacenter dc1")
+
+	cluster.Spec.Cassandra.Meta.Labels = map[string]string{"team": "storage"}
+	cluster.Spec.Cassandra.Datacenters[0].Meta.Metadata.Labels = map[string]string{"cost-center": "1234"}
+	err = k8sClient.Create(ctx, cluster)
+	required.NoError(err)
+
+	cluster.Spec.Cassandra.Meta.Labels = nil
+	err = k8sClient.Update(ctx, cluster)
+	required.Error(err)
+
+	// Objects created before the labels were required can still be updated, as long as their labels do not change
+	SetRequiredLabels([]string{"team", "cost-center", "owner"})
+	cluster.Spec.Cassandra.Meta.Labels = map[string]string{"team": "storage"}
+	cluster.Spec.Cassandra.Datacenters[0].Size = 3
+	err = k8sClient.Update(ctx, cluster)
+	required.NoError(err)
+
+	cluster.Labels["team"] = "analytics"
+	err = k8sClient.Update(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), "K8ssandraCluster required-labels-test is missing [owner]")
+
+	// Objects being deleted are never checked
+	now := metav1.Now()
+	deleting := cluster.DeepCopy()
+	deleting.DeletionTimestamp = &now
+	deleting.Labels = nil
+	required.NoError(deleting.validateRequiredLabels(cluster))
+}
+
+func testMaxClusterSizeValidation(t *testing.T) {
+	required := require.New(t)
+	createNamespace(required, "max-cluster-size-namespace")
+	SetMaxClusterSize(4)
+	defer SetMaxClusterSize(0)
+
+	cluster := createMinimalClusterObj("max-cluster-size-test", "max-cluster-size-namespace")
+	cluster.Spec.Cassandra.Datacenters[0].Meta.Name = "dc1"
+	cluster.Spec.Cassandra.Datacenters[0].Size = 3
+	cluster.Spec.Cassandra.Datacenters = append(cluster.Spec.Cassandra.Datacenters, CassandraDatacenterTemplate{
+		Meta:       EmbeddedObjectMeta{Name: "dc2"},
+		K8sContext: "envtest",
+		Size:       2,
+	})
+	err := k8sClient.Create(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), ErrMaxClusterSize.Error())
+	required.Contains(err.Error(), "has 5 nodes, the maximum is 4 (dc1: 3, dc2: 2)")
+
+	cluster.Spec.Cassandra.Datacenters[1].Size = 1
+	err = k8sClient.Create(ctx, cluster)
+	required.NoError(err)
+
+	cluster.Spec.Cassandra.Datacenters[0].Size = 4
+	err = k8sClient.Update(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), ErrMaxClusterSize.Error())
+
+	// A cluster admitted before the maximum was lowered can still be updated, as long as it does not grow
+	SetMaxClusterSize(3)
+	cluster.Spec.Cassandra.Datacenters[0].Size = 3
+	cluster.Spec.Cassandra.Datacenters[1].Size = 1
+	err = k8sClient.Update(ctx, cluster)
+	required.NoError(err)
+
+	cluster.Spec.Cassandra.Datacenters[1].Size = 2
+	err = k8sClient.Update(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), ErrMaxClusterSize.Error())
+}
+
+func testSeedPropagationQuorumValidation(t *testing.T) {
+	required := require.New(t)
+	createNamespace(required, "seed-quorum-namespace")
+	cluster := createMinimalClusterObj("seed-quorum-test", "seed-quorum-namespace")
+
+	cluster.Spec.Cassandra.SeedPropagationQuorum = pointer.Int32(2)
+	err := k8sClient.Create(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), ErrSeedPropagationQuorum.Error())
+	required.Contains(err.Error(), "has 1 datacenters, the quorum is 2")
+
+	cluster.Spec.Cassandra.SeedPropagationQuorum = pointer.Int32(1)
+	err = k8sClient.Create(ctx, cluster)
+	required.NoError(err)
+}
+
+func testPodLabelsValidation(t *testing.T) {
+	required := require.New(t)
+	createNamespace(required, "pod-labels-namespace")
+	cluster := createMinimalClusterObj("pod-labels-test", "pod-labels-namespace")
+	cluster.Spec.Cassandra.Datacenters[0].Meta.Name = "dc1"
+
+	cluster.Spec.Cassandra.Datacenters[0].Meta.Metadata.Pods.Labels = map[string]string{"mesh": "enabled", "app.kubernetes.io/managed-by": "me"}
+	err := k8sClient.Create(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), ErrManagedPodLabel.Error())
+	required.Contains(err.Error(), "label app.kubernetes.io/managed-by of datacenter dc1")
+
+	cluster.Spec.Cassandra.Datacenters[0].Meta.Metadata.Pods.Labels = map[string]string{"mesh": "enabled"}
+	err = k8sClient.Create(ctx, cluster)
+	required.NoError(err)
+
+	cluster.Spec.Cassandra.Datacenters[0].Meta.Metadata.Pods.Labels["cassandra.datastax.com/seed-node"] = "true"
+	err = k8sClient.Update(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), ErrManagedPodLabel.Error())
+
+	// Datacenters that already set a managed label can still be updated, as long as their pod labels do not change
+	old := cluster.DeepCopy()
+	old.Spec.Cassandra.Datacenters[0].Meta.Metadata.Pods.Labels = map[string]string{"app.kubernetes.io/managed-by": "me"}
+	updated := old.DeepCopy()
+	updated.Spec.Cassandra.Datacenters[0].Size = 3
+	required.NoError(updated.validatePodLabels(old))
+}
+
+func testCdcRawDirectoryVolumeValidation(t *testing.T) {
+	required := require.New(t)
+	createNamespace(required, "cdc-raw-namespace")
+	cluster := createMinimalClusterObj("cdc-raw-test", "cdc-raw-namespace")
+	cluster.Spec.Cassandra.Datacenters[0].Meta.Name = "dc1"
+	cluster.Spec.Cassandra.DatacenterOptions.ChangeDataCapture = &ChangeDataCapture{Enabled: pointer.Bool(true)}
+	required.NoError(k8sClient.Create(ctx, cluster))
+
+	storage := &corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+		},
+	}
+	cluster.Spec.Cassandra.DatacenterOptions.ChangeDataCapture.RawDirectory = "/var/lib/cdc-raw"
+	cluster.Spec.Cassandra.DatacenterOptions.ChangeDataCapture.RawDirectoryStorage = storage
+	err := k8sClient.Update(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), ErrCdcRawDirectoryVolume.Error())
+
+	// New datacenters can mount the volume
+	cluster.Spec.Cassandra.DatacenterOptions.ChangeDataCapture.RawDirectory = ""
+	cluster.Spec.Cassandra.DatacenterOptions.ChangeDataCapture.RawDirectoryStorage = nil
+	cluster.Spec.Cassandra.Datacenters = append(cluster.Spec.Cassandra.Datacenters, CassandraDatacenterTemplate{
+		Meta:       EmbeddedObjectMeta{Name: "dc2"},
+		K8sContext: "envtest",
+		Size:       1,
+		DatacenterOptions: DatacenterOptions{
+			ChangeDataCapture: &ChangeDataCapture{RawDirectory: "/var/lib/cdc-raw", RawDirectoryStorage: storage},
+		},
+	})
+	required.NoError(k8sClient.Update(ctx, cluster))
+
+	// Other CDC settings can still be changed
+	cluster.Spec.Cassandra.DatacenterOptions.ChangeDataCapture.TotalSpaceInMb = pointer.Int32(8192)
+	required.NoError(k8sClient.Update(ctx, cluster))
+
+	cluster.Spec.Cassandra.Datacenters[1].DatacenterOptions.ChangeDataCapture.RawDirectory = "/var/lib/cdc"
+	err = k8sClient.Update(ctx, cluster)
+	required.Error(err)
+	required.Contains(err.Error(), "datacenter dc2")
+}
@@ -30,6 +30,8 @@ import (
 	"github.com/k8ssandra/k8ssandra-operator/pkg/encryption"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/images"
 	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -113,6 +115,61 @@ func (in *AuditLogOptions) DeepCopy() *AuditLogOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogging) DeepCopyInto(out *AuditLogging) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IncludedCategories != nil {
+		in, out := &in.IncludedCategories, &out.IncludedCategories
+		*out = make([]AuditLogCategory, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedCategories != nil {
+		in, out := &in.ExcludedCategories, &out.ExcludedCategories
+		*out = make([]AuditLogCategory, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogging.
+func (in *AuditLogging) DeepCopy() *AuditLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryUpgradeConfig) DeepCopyInto(out *CanaryUpgradeConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryUpgradeConfig.
+func (in *CanaryUpgradeConfig) DeepCopy() *CanaryUpgradeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryUpgradeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CassandraClusterTemplate) DeepCopyInto(out *CassandraClusterTemplate) {
 	*out = *in
@@ -141,6 +198,71 @@ func (in *CassandraClusterTemplate) DeepCopyInto(out *CassandraClusterTemplate)
 		*out = new(encryption.Stores)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SeedRefreshInterval != nil {
+		in, out := &in.SeedRefreshInterval, &out.SeedRefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxConcurrentScalingDatacenters != nil {
+		in, out := &in.MaxConcurrentScalingDatacenters, &out.MaxConcurrentScalingDatacenters
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SeedPropagationQuorum != nil {
+		in, out := &in.SeedPropagationQuorum, &out.SeedPropagationQuorum
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DiskUsageMonitoring != nil {
+		in, out := &in.DiskUsageMonitoring, &out.DiskUsageMonitoring
+		*out = new(DiskUsageMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CompactionBacklogMonitoring != nil {
+		in, out := &in.CompactionBacklogMonitoring, &out.CompactionBacklogMonitoring
+		*out = new(CompactionBacklogMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DroppedMutationsMonitoring != nil {
+		in, out := &in.DroppedMutationsMonitoring, &out.DroppedMutationsMonitoring
+		*out = new(DroppedMutationsMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagementApiLatencyMonitoring != nil {
+		in, out := &in.ManagementApiLatencyMonitoring, &out.ManagementApiLatencyMonitoring
+		*out = new(ManagementApiLatencyMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenBalanceMonitoring != nil {
+		in, out := &in.TokenBalanceMonitoring, &out.TokenBalanceMonitoring
+		*out = new(TokenBalanceMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GossipMonitoring != nil {
+		in, out := &in.GossipMonitoring, &out.GossipMonitoring
+		*out = new(GossipMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterCqlService != nil {
+		in, out := &in.ClusterCqlService, &out.ClusterCqlService
+		*out = new(ClusterCqlService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogShipping != nil {
+		in, out := &in.LogShipping, &out.LogShipping
+		*out = new(LogShipping)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeHealthGate != nil {
+		in, out := &in.NodeHealthGate, &out.NodeHealthGate
+		*out = new(NodeHealthGate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JvmOptions != nil {
+		in, out := &in.JvmOptions, &out.JvmOptions
+		*out = new(JvmOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraClusterTemplate.
@@ -176,12 +298,27 @@ func (in *CassandraDatacenterTemplate) DeepCopyInto(out *CassandraDatacenterTemp
 	*out = *in
 	in.Meta.DeepCopyInto(&out.Meta)
 	in.DatacenterOptions.DeepCopyInto(&out.DatacenterOptions)
+	if in.SystemReplicationFactor != nil {
+		in, out := &in.SystemReplicationFactor, &out.SystemReplicationFactor
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Stargate != nil {
 		in, out := &in.Stargate, &out.Stargate
 		*out = new(stargatev1alpha1.StargateDatacenterTemplate)
 		(*in).DeepCopyInto(*out)
 	}
 	out.PerNodeConfigMapRef = in.PerNodeConfigMapRef
+	if in.SeedExcludedRacks != nil {
+		in, out := &in.SeedExcludedRacks, &out.SeedExcludedRacks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SeedServiceRef != nil {
+		in, out := &in.SeedServiceRef, &out.SeedServiceRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraDatacenterTemplate.
@@ -194,6 +331,156 @@ func (in *CassandraDatacenterTemplate) DeepCopy() *CassandraDatacenterTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChangeDataCapture) DeepCopyInto(out *ChangeDataCapture) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TotalSpaceInMb != nil {
+		in, out := &in.TotalSpaceInMb, &out.TotalSpaceInMb
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RawDirectoryStorage != nil {
+		in, out := &in.RawDirectoryStorage, &out.RawDirectoryStorage
+		*out = new(v1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeDataCapture.
+func (in *ChangeDataCapture) DeepCopy() *ChangeDataCapture {
+	if in == nil {
+		return nil
+	}
+	out := new(ChangeDataCapture)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConnectionStatus) DeepCopyInto(out *ClusterConnectionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConnectionStatus.
+func (in *ClusterConnectionStatus) DeepCopy() *ClusterConnectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCqlService) DeepCopyInto(out *ClusterCqlService) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCqlService.
+func (in *ClusterCqlService) DeepCopy() *ClusterCqlService {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCqlService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompactionBacklogMonitoring) DeepCopyInto(out *CompactionBacklogMonitoring) {
+	*out = *in
+	in.MonitoringPolling.DeepCopyInto(&out.MonitoringPolling)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompactionBacklogMonitoring.
+func (in *CompactionBacklogMonitoring) DeepCopy() *CompactionBacklogMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(CompactionBacklogMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompactionBacklogStatus) DeepCopyInto(out *CompactionBacklogStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompactionBacklogStatus.
+func (in *CompactionBacklogStatus) DeepCopy() *CompactionBacklogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CompactionBacklogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrdVersionStatus) DeepCopyInto(out *CrdVersionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrdVersionStatus.
+func (in *CrdVersionStatus) DeepCopy() *CrdVersionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CrdVersionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatacenterConnectionStatus) DeepCopyInto(out *DatacenterConnectionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterConnectionStatus.
+func (in *DatacenterConnectionStatus) DeepCopy() *DatacenterConnectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatacenterConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatacenterLatencyStatus) DeepCopyInto(out *DatacenterLatencyStatus) {
+	*out = *in
+	out.RoundTripTime = in.RoundTripTime
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterLatencyStatus.
+func (in *DatacenterLatencyStatus) DeepCopy() *DatacenterLatencyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatacenterLatencyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatacenterOptions) DeepCopyInto(out *DatacenterOptions) {
 	*out = *in
@@ -285,11 +572,118 @@ func (in *DatacenterOptions) DeepCopyInto(out *DatacenterOptions) {
 		*out = new(v1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FsGroup != nil {
+		in, out := &in.FsGroup, &out.FsGroup
+		*out = new(int64)
+		**out = **in
+	}
 	if in.ManagementApiAuth != nil {
 		in, out := &in.ManagementApiAuth, &out.ManagementApiAuth
 		*out = new(v1beta1.ManagementApiAuthConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CanaryUpgrade != nil {
+		in, out := &in.CanaryUpgrade, &out.CanaryUpgrade
+		*out = new(CanaryUpgradeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupTimeouts != nil {
+		in, out := &in.StartupTimeouts, &out.StartupTimeouts
+		*out = new(StartupTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HintsTuning != nil {
+		in, out := &in.HintsTuning, &out.HintsTuning
+		*out = new(HintsTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraEnvVars != nil {
+		in, out := &in.ExtraEnvVars, &out.ExtraEnvVars
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Snitch != nil {
+		in, out := &in.Snitch, &out.Snitch
+		*out = new(SnitchConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskFailurePolicies != nil {
+		in, out := &in.DiskFailurePolicies, &out.DiskFailurePolicies
+		*out = new(DiskFailurePolicies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RackDcProperties != nil {
+		in, out := &in.RackDcProperties, &out.RackDcProperties
+		*out = new(RackDcProperties)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagementApiTimeouts != nil {
+		in, out := &in.ManagementApiTimeouts, &out.ManagementApiTimeouts
+		*out = new(ManagementApiTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DnsConfig != nil {
+		in, out := &in.DnsConfig, &out.DnsConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodHostname != nil {
+		in, out := &in.PodHostname, &out.PodHostname
+		*out = new(PodHostname)
+		**out = **in
+	}
+	if in.ReadinessPolicy != nil {
+		in, out := &in.ReadinessPolicy, &out.ReadinessPolicy
+		*out = new(ReadinessPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaterializedViews != nil {
+		in, out := &in.MaterializedViews, &out.MaterializedViews
+		*out = new(MaterializedViewsTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(StartupProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DedicatedNodes != nil {
+		in, out := &in.DedicatedNodes, &out.DedicatedNodes
+		*out = new(DedicatedNodes)
+		**out = **in
+	}
+	if in.MemtableAndCacheTuning != nil {
+		in, out := &in.MemtableAndCacheTuning, &out.MemtableAndCacheTuning
+		*out = new(MemtableAndCacheTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestTimeouts != nil {
+		in, out := &in.RequestTimeouts, &out.RequestTimeouts
+		*out = new(RequestTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StreamingEncryption != nil {
+		in, out := &in.StreamingEncryption, &out.StreamingEncryption
+		*out = new(StreamingEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuditLogging != nil {
+		in, out := &in.AuditLogging, &out.AuditLogging
+		*out = new(AuditLogging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChangeDataCapture != nil {
+		in, out := &in.ChangeDataCapture, &out.ChangeDataCapture
+		*out = new(ChangeDataCapture)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterOptions.
@@ -303,57 +697,184 @@ func (in *DatacenterOptions) DeepCopy() *DatacenterOptions {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmbeddedObjectMeta) DeepCopyInto(out *EmbeddedObjectMeta) {
+func (in *DedicatedNodes) DeepCopyInto(out *DedicatedNodes) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedObjectMeta.
-func (in *EmbeddedObjectMeta) DeepCopy() *EmbeddedObjectMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedNodes.
+func (in *DedicatedNodes) DeepCopy() *DedicatedNodes {
 	if in == nil {
 		return nil
 	}
-	out := new(EmbeddedObjectMeta)
+	out := new(DedicatedNodes)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FullQueryLoggerOptions) DeepCopyInto(out *FullQueryLoggerOptions) {
+func (in *DiskFailurePolicies) DeepCopyInto(out *DiskFailurePolicies) {
 	*out = *in
-	if in.ArchiveCommand != nil {
-		in, out := &in.ArchiveCommand, &out.ArchiveCommand
-		*out = new(string)
-		**out = **in
-	}
-	if in.RollCycle != nil {
-		in, out := &in.RollCycle, &out.RollCycle
-		*out = new(string)
-		**out = **in
-	}
-	if in.Block != nil {
-		in, out := &in.Block, &out.Block
-		*out = new(bool)
-		**out = **in
-	}
-	if in.MaxQueueWeight != nil {
-		in, out := &in.MaxQueueWeight, &out.MaxQueueWeight
-		*out = new(int)
-		**out = **in
-	}
-	if in.MaxLogSize != nil {
-		in, out := &in.MaxLogSize, &out.MaxLogSize
-		*out = new(int)
-		**out = **in
-	}
-	if in.MaxArchiveRetries != nil {
-		in, out := &in.MaxArchiveRetries, &out.MaxArchiveRetries
-		*out = new(int)
-		**out = **in
-	}
-	if in.LogDir != nil {
-		in, out := &in.LogDir, &out.LogDir
-		*out = new(string)
+	if in.MinFreeSpacePerDriveInMb != nil {
+		in, out := &in.MinFreeSpacePerDriveInMb, &out.MinFreeSpacePerDriveInMb
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskFailurePolicies.
+func (in *DiskFailurePolicies) DeepCopy() *DiskFailurePolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskFailurePolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskUsageMonitoring) DeepCopyInto(out *DiskUsageMonitoring) {
+	*out = *in
+	in.MonitoringPolling.DeepCopyInto(&out.MonitoringPolling)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskUsageMonitoring.
+func (in *DiskUsageMonitoring) DeepCopy() *DiskUsageMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskUsageMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskUsageStatus) DeepCopyInto(out *DiskUsageStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskUsageStatus.
+func (in *DiskUsageStatus) DeepCopy() *DiskUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DroppedMutationsMonitoring) DeepCopyInto(out *DroppedMutationsMonitoring) {
+	*out = *in
+	in.MonitoringPolling.DeepCopyInto(&out.MonitoringPolling)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DroppedMutationsMonitoring.
+func (in *DroppedMutationsMonitoring) DeepCopy() *DroppedMutationsMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(DroppedMutationsMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DroppedMutationsStatus) DeepCopyInto(out *DroppedMutationsStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DroppedMutationsStatus.
+func (in *DroppedMutationsStatus) DeepCopy() *DroppedMutationsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DroppedMutationsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveConfigStatus) DeepCopyInto(out *EffectiveConfigStatus) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveConfigStatus.
+func (in *EffectiveConfigStatus) DeepCopy() *EffectiveConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedObjectMeta) DeepCopyInto(out *EmbeddedObjectMeta) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedObjectMeta.
+func (in *EmbeddedObjectMeta) DeepCopy() *EmbeddedObjectMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedObjectMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FullQueryLoggerOptions) DeepCopyInto(out *FullQueryLoggerOptions) {
+	*out = *in
+	if in.ArchiveCommand != nil {
+		in, out := &in.ArchiveCommand, &out.ArchiveCommand
+		*out = new(string)
+		**out = **in
+	}
+	if in.RollCycle != nil {
+		in, out := &in.RollCycle, &out.RollCycle
+		*out = new(string)
+		**out = **in
+	}
+	if in.Block != nil {
+		in, out := &in.Block, &out.Block
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxQueueWeight != nil {
+		in, out := &in.MaxQueueWeight, &out.MaxQueueWeight
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxLogSize != nil {
+		in, out := &in.MaxLogSize, &out.MaxLogSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxArchiveRetries != nil {
+		in, out := &in.MaxArchiveRetries, &out.MaxArchiveRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.LogDir != nil {
+		in, out := &in.LogDir, &out.LogDir
+		*out = new(string)
 		**out = **in
 	}
 }
@@ -368,6 +889,102 @@ func (in *FullQueryLoggerOptions) DeepCopy() *FullQueryLoggerOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GossipMonitoring) DeepCopyInto(out *GossipMonitoring) {
+	*out = *in
+	if in.StuckThreshold != nil {
+		in, out := &in.StuckThreshold, &out.StuckThreshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	in.MonitoringPolling.DeepCopyInto(&out.MonitoringPolling)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GossipMonitoring.
+func (in *GossipMonitoring) DeepCopy() *GossipMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(GossipMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GossipStatus) DeepCopyInto(out *GossipStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeGossipState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GossipStatus.
+func (in *GossipStatus) DeepCopy() *GossipStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GossipStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HintsTuning) DeepCopyInto(out *HintsTuning) {
+	if in.HintedHandoffThrottleInKb != nil {
+		in, out := &in.HintedHandoffThrottleInKb, &out.HintedHandoffThrottleInKb
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxHintsDeliveryThreads != nil {
+		in, out := &in.MaxHintsDeliveryThreads, &out.MaxHintsDeliveryThreads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxHintWindowInMs != nil {
+		in, out := &in.MaxHintWindowInMs, &out.MaxHintWindowInMs
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HintsTuning.
+func (in *HintsTuning) DeepCopy() *HintsTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(HintsTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryStatus) DeepCopyInto(out *InventoryStatus) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]ManagedObject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryStatus.
+func (in *InventoryStatus) DeepCopy() *InventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JvmOptions) DeepCopyInto(out *JvmOptions) {
 	*out = *in
@@ -869,6 +1486,21 @@ func (in *K8ssandraClusterSpec) DeepCopyInto(out *K8ssandraClusterSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.StatusHistory != nil {
+		in, out := &in.StatusHistory, &out.StatusHistory
+		*out = new(StatusHistory)
+		**out = **in
+	}
+	if in.ReconcileTimeout != nil {
+		in, out := &in.ReconcileTimeout, &out.ReconcileTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ContextsInMaintenance != nil {
+		in, out := &in.ContextsInMaintenance, &out.ContextsInMaintenance
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8ssandraClusterSpec.
@@ -898,6 +1530,37 @@ func (in *K8ssandraClusterStatus) DeepCopyInto(out *K8ssandraClusterStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Seeds != nil {
+		in, out := &in.Seeds, &out.Seeds
+		*out = make([]SeedStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SeedsRefreshTime != nil {
+		in, out := &in.SeedsRefreshTime, &out.SeedsRefreshTime
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]StatusTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(ClusterConnectionStatus)
+		**out = **in
+	}
+	if in.CrdVersions != nil {
+		in, out := &in.CrdVersions, &out.CrdVersions
+		*out = make([]CrdVersionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = new(InventoryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8ssandraClusterStatus.
@@ -928,6 +1591,46 @@ func (in *K8ssandraStatus) DeepCopyInto(out *K8ssandraStatus) {
 		*out = new(reaperv1alpha1.ReaperStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DiskUsage != nil {
+		in, out := &in.DiskUsage, &out.DiskUsage
+		*out = new(DiskUsageStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CompactionBacklog != nil {
+		in, out := &in.CompactionBacklog, &out.CompactionBacklog
+		*out = new(CompactionBacklogStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DroppedMutations != nil {
+		in, out := &in.DroppedMutations, &out.DroppedMutations
+		*out = new(DroppedMutationsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(DatacenterLatencyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenOwnership != nil {
+		in, out := &in.TokenOwnership, &out.TokenOwnership
+		*out = new(TokenOwnershipStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gossip != nil {
+		in, out := &in.Gossip, &out.Gossip
+		*out = new(GossipStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EffectiveConfig != nil {
+		in, out := &in.EffectiveConfig, &out.EffectiveConfig
+		*out = new(EffectiveConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(DatacenterConnectionStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8ssandraStatus.
@@ -970,60 +1673,378 @@ func (in *K8ssandraVolumes) DeepCopy() *K8ssandraVolumes {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkingConfig) DeepCopyInto(out *NetworkingConfig) {
+func (in *LogShipping) DeepCopyInto(out *LogShipping) {
 	*out = *in
-	if in.NodePort != nil {
-		in, out := &in.NodePort, &out.NodePort
-		*out = new(v1beta1.NodePortConfig)
-		**out = **in
-	}
-	if in.HostNetwork != nil {
-		in, out := &in.HostNetwork, &out.HostNetwork
-		*out = new(bool)
-		**out = **in
+	out.ConfigMapRef = in.ConfigMapRef
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingConfig.
-func (in *NetworkingConfig) DeepCopy() *NetworkingConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogShipping.
+func (in *LogShipping) DeepCopy() *LogShipping {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkingConfig)
+	out := new(LogShipping)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ParameterizedClass) DeepCopyInto(out *ParameterizedClass) {
+func (in *ManagedObject) DeepCopyInto(out *ManagedObject) {
 	*out = *in
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = new(map[string]string)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make(map[string]string, len(*in))
-			for key, val := range *in {
-				(*out)[key] = val
-			}
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterizedClass.
-func (in *ParameterizedClass) DeepCopy() *ParameterizedClass {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedObject.
+func (in *ManagedObject) DeepCopy() *ManagedObject {
 	if in == nil {
 		return nil
 	}
-	out := new(ParameterizedClass)
+	out := new(ManagedObject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicaFilteringProtectionOptions) DeepCopyInto(out *ReplicaFilteringProtectionOptions) {
-	*out = *in
-	if in.CachedRowsWarnThreshold != nil {
+func (in *ManagementApiLatencyMonitoring) DeepCopyInto(out *ManagementApiLatencyMonitoring) {
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	in.MonitoringPolling.DeepCopyInto(&out.MonitoringPolling)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementApiLatencyMonitoring.
+func (in *ManagementApiLatencyMonitoring) DeepCopy() *ManagementApiLatencyMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementApiLatencyMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementApiTimeouts) DeepCopyInto(out *ManagementApiTimeouts) {
+	*out = *in
+	if in.RequestTimeout != nil {
+		in, out := &in.RequestTimeout, &out.RequestTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementApiTimeouts.
+func (in *ManagementApiTimeouts) DeepCopy() *ManagementApiTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementApiTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaterializedViewsTuning) DeepCopyInto(out *MaterializedViewsTuning) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConcurrentWrites != nil {
+		in, out := &in.ConcurrentWrites, &out.ConcurrentWrites
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentBuilders != nil {
+		in, out := &in.ConcurrentBuilders, &out.ConcurrentBuilders
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaterializedViewsTuning.
+func (in *MaterializedViewsTuning) DeepCopy() *MaterializedViewsTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(MaterializedViewsTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemtableAndCacheTuning) DeepCopyInto(out *MemtableAndCacheTuning) {
+	*out = *in
+	if in.MemtableHeapSpaceInMb != nil {
+		in, out := &in.MemtableHeapSpaceInMb, &out.MemtableHeapSpaceInMb
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemtableOffheapSpaceInMb != nil {
+		in, out := &in.MemtableOffheapSpaceInMb, &out.MemtableOffheapSpaceInMb
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemtableFlushWriters != nil {
+		in, out := &in.MemtableFlushWriters, &out.MemtableFlushWriters
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FileCacheSizeInMb != nil {
+		in, out := &in.FileCacheSizeInMb, &out.FileCacheSizeInMb
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeyCacheSizeInMb != nil {
+		in, out := &in.KeyCacheSizeInMb, &out.KeyCacheSizeInMb
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RowCacheSizeInMb != nil {
+		in, out := &in.RowCacheSizeInMb, &out.RowCacheSizeInMb
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CounterCacheSizeInMb != nil {
+		in, out := &in.CounterCacheSizeInMb, &out.CounterCacheSizeInMb
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemtableAndCacheTuning.
+func (in *MemtableAndCacheTuning) DeepCopy() *MemtableAndCacheTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(MemtableAndCacheTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringPolling) DeepCopyInto(out *MonitoringPolling) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringPolling.
+func (in *MonitoringPolling) DeepCopy() *MonitoringPolling {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringPolling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyConfig) DeepCopyInto(out *NetworkPolicyConfig) {
+	*out = *in
+	if in.AllowedClients != nil {
+		in, out := &in.AllowedClients, &out.AllowedClients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManagementClients != nil {
+		in, out := &in.ManagementClients, &out.ManagementClients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PeerCidrs != nil {
+		in, out := &in.PeerCidrs, &out.PeerCidrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalIngress != nil {
+		in, out := &in.AdditionalIngress, &out.AdditionalIngress
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalEgress != nil {
+		in, out := &in.AdditionalEgress, &out.AdditionalEgress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyConfig.
+func (in *NetworkPolicyConfig) DeepCopy() *NetworkPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkingConfig) DeepCopyInto(out *NetworkingConfig) {
+	*out = *in
+	if in.NodePort != nil {
+		in, out := &in.NodePort, &out.NodePort
+		*out = new(v1beta1.NodePortConfig)
+		**out = **in
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingConfig.
+func (in *NetworkingConfig) DeepCopy() *NetworkingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGossipState) DeepCopyInto(out *NodeGossipState) {
+	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGossipState.
+func (in *NodeGossipState) DeepCopy() *NodeGossipState {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGossipState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthGate) DeepCopyInto(out *NodeHealthGate) {
+	*out = *in
+	if in.MaxDownNodes != nil {
+		in, out := &in.MaxDownNodes, &out.MaxDownNodes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthGate.
+func (in *NodeHealthGate) DeepCopy() *NodeHealthGate {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterizedClass) DeepCopyInto(out *ParameterizedClass) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(map[string]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]string, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterizedClass.
+func (in *ParameterizedClass) DeepCopy() *ParameterizedClass {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterizedClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodHostname) DeepCopyInto(out *PodHostname) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodHostname.
+func (in *PodHostname) DeepCopy() *PodHostname {
+	if in == nil {
+		return nil
+	}
+	out := new(PodHostname)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RackDcProperties) DeepCopyInto(out *RackDcProperties) {
+	*out = *in
+	if in.PreferLocal != nil {
+		in, out := &in.PreferLocal, &out.PreferLocal
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RackDcProperties.
+func (in *RackDcProperties) DeepCopy() *RackDcProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(RackDcProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessPolicy) DeepCopyInto(out *ReadinessPolicy) {
+	*out = *in
+	if in.MinReadyPercent != nil {
+		in, out := &in.MinReadyPercent, &out.MinReadyPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessPolicy.
+func (in *ReadinessPolicy) DeepCopy() *ReadinessPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaFilteringProtectionOptions) DeepCopyInto(out *ReplicaFilteringProtectionOptions) {
+	*out = *in
+	if in.CachedRowsWarnThreshold != nil {
 		in, out := &in.CachedRowsWarnThreshold, &out.CachedRowsWarnThreshold
 		*out = new(int)
 		**out = **in
@@ -1081,6 +2102,178 @@ func (in *RequestSchedulerOptions) DeepCopy() *RequestSchedulerOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestTimeouts) DeepCopyInto(out *RequestTimeouts) {
+	*out = *in
+	if in.ReadRequestTimeoutInMs != nil {
+		in, out := &in.ReadRequestTimeoutInMs, &out.ReadRequestTimeoutInMs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WriteRequestTimeoutInMs != nil {
+		in, out := &in.WriteRequestTimeoutInMs, &out.WriteRequestTimeoutInMs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RangeRequestTimeoutInMs != nil {
+		in, out := &in.RangeRequestTimeoutInMs, &out.RangeRequestTimeoutInMs
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestTimeouts.
+func (in *RequestTimeouts) DeepCopy() *RequestTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedStatus) DeepCopyInto(out *SeedStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedStatus.
+func (in *SeedStatus) DeepCopy() *SeedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnitchConfig) DeepCopyInto(out *SnitchConfig) {
+	*out = *in
+	if in.DynamicSnitch != nil {
+		in, out := &in.DynamicSnitch, &out.DynamicSnitch
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnitchConfig.
+func (in *SnitchConfig) DeepCopy() *SnitchConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SnitchConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartupProbe) DeepCopyInto(out *StartupProbe) {
+	*out = *in
+	out.MaxStartupTime = in.MaxStartupTime
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartupProbe.
+func (in *StartupProbe) DeepCopy() *StartupProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(StartupProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartupTimeouts) DeepCopyInto(out *StartupTimeouts) {
+	*out = *in
+	if in.ReadinessDelay != nil {
+		in, out := &in.ReadinessDelay, &out.ReadinessDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BootstrapTimeout != nil {
+		in, out := &in.BootstrapTimeout, &out.BootstrapTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartupTimeouts.
+func (in *StartupTimeouts) DeepCopy() *StartupTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(StartupTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusHistory) DeepCopyInto(out *StatusHistory) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusHistory.
+func (in *StatusHistory) DeepCopy() *StatusHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusTransition) DeepCopyInto(out *StatusTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusTransition.
+func (in *StatusTransition) DeepCopy() *StatusTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamingEncryption) DeepCopyInto(out *StreamingEncryption) {
+	*out = *in
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LegacySslStoragePort != nil {
+		in, out := &in.LegacySslStoragePort, &out.LegacySslStoragePort
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamingEncryption.
+func (in *StreamingEncryption) DeepCopy() *StreamingEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamingEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetGroups) DeepCopyInto(out *SubnetGroups) {
 	*out = *in
@@ -1101,6 +2294,41 @@ func (in *SubnetGroups) DeepCopy() *SubnetGroups {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenBalanceMonitoring) DeepCopyInto(out *TokenBalanceMonitoring) {
+	*out = *in
+	in.MonitoringPolling.DeepCopyInto(&out.MonitoringPolling)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenBalanceMonitoring.
+func (in *TokenBalanceMonitoring) DeepCopy() *TokenBalanceMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenBalanceMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenOwnershipStatus) DeepCopyInto(out *TokenOwnershipStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenOwnershipStatus.
+func (in *TokenOwnershipStatus) DeepCopy() *TokenOwnershipStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenOwnershipStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrackWarnings) DeepCopyInto(out *TrackWarnings) {
 	*out = *in
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	telemetryapi "github.com/k8ssandra/k8ssandra-operator/apis/telemetry/v1alpha1"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/encryption"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/images"
@@ -139,6 +141,24 @@ type ReaperTemplate struct {
 	// labels and annotations for Reaper resources
 	// +optional
 	ResourceMeta *meta.ResourceMeta `json:"metadata,omitempty"`
+
+	// RepairStatusPollInterval is the interval at which the operator queries Reaper for the repair runs of the
+	// cluster, and reports a per-keyspace summary of them in the Reaper status. The default is 5 minutes. Set it to 0
+	// to disable repair status reporting.
+	// +optional
+	RepairStatusPollInterval *metav1.Duration `json:"repairStatusPollInterval,omitempty"`
+}
+
+// DefaultRepairStatusPollInterval is the interval at which repair status is polled when RepairStatusPollInterval is
+// not set.
+const DefaultRepairStatusPollInterval = 5 * time.Minute
+
+// GetRepairStatusPollInterval returns the effective repair status poll interval; zero means polling is disabled.
+func (in ReaperTemplate) GetRepairStatusPollInterval() time.Duration {
+	if in.RepairStatusPollInterval == nil {
+		return DefaultRepairStatusPollInterval
+	}
+	return in.RepairStatusPollInterval.Duration
 }
 
 // UseExternalSecrets defines whether the user has specified if credentials and
@@ -307,6 +327,27 @@ type ReaperStatus struct {
 
 	// +optional
 	Conditions []ReaperCondition `json:"conditions,omitempty"`
+
+	// Repairs summarizes the repair runs known to Reaper for the cluster, per keyspace.
+	// +optional
+	Repairs []KeyspaceRepairStatus `json:"repairs,omitempty"`
+
+	// LastRepairStatusCheck is the last time the repair status was successfully fetched from Reaper.
+	// +optional
+	LastRepairStatusCheck *metav1.Time `json:"lastRepairStatusCheck,omitempty"`
+}
+
+// KeyspaceRepairStatus summarizes the repair runs of a single keyspace.
+type KeyspaceRepairStatus struct {
+	Keyspace string `json:"keyspace"`
+
+	// LastRepairTime is the end time of the most recent repair run that completed successfully for this keyspace.
+	// +optional
+	LastRepairTime *metav1.Time `json:"lastRepairTime,omitempty"`
+
+	// PercentRepaired is the percentage of segments repaired by the most recent repair run for this keyspace.
+	// +optional
+	PercentRepaired int `json:"percentRepaired,omitempty"`
 }
 
 func (in *ReaperStatus) GetConditionStatus(conditionType ReaperConditionType) corev1.ConditionStatus {
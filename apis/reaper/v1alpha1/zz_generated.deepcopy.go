@@ -27,6 +27,7 @@ import (
 	"github.com/k8ssandra/k8ssandra-operator/pkg/images"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/meta"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -70,6 +71,25 @@ func (in *CassandraDatacenterRef) DeepCopy() *CassandraDatacenterRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceRepairStatus) DeepCopyInto(out *KeyspaceRepairStatus) {
+	*out = *in
+	if in.LastRepairTime != nil {
+		in, out := &in.LastRepairTime, &out.LastRepairTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceRepairStatus.
+func (in *KeyspaceRepairStatus) DeepCopy() *KeyspaceRepairStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceRepairStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Reaper) DeepCopyInto(out *Reaper) {
 	*out = *in
@@ -196,6 +216,17 @@ func (in *ReaperStatus) DeepCopyInto(out *ReaperStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Repairs != nil {
+		in, out := &in.Repairs, &out.Repairs
+		*out = make([]KeyspaceRepairStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastRepairStatusCheck != nil {
+		in, out := &in.LastRepairStatusCheck, &out.LastRepairStatusCheck
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReaperStatus.
@@ -287,6 +318,11 @@ func (in *ReaperTemplate) DeepCopyInto(out *ReaperTemplate) {
 		*out = new(meta.ResourceMeta)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RepairStatusPollInterval != nil {
+		in, out := &in.RepairStatusPollInterval, &out.RepairStatusPollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReaperTemplate.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -30,6 +31,9 @@ import (
 const (
 	ClientConfigHashAnnotation = k8ssandraapi.ResourceHashAnnotation
 	KubeSecretHashAnnotation   = "k8ssandra.io/secret-hash"
+
+	// minRotationCheckDelay is the delay before checking a kubeconfig secret being rotated again for the first time.
+	minRotationCheckDelay = time.Second
 )
 
 type ClientConfigReconciler struct {
@@ -37,8 +41,17 @@ type ClientConfigReconciler struct {
 	ClientCache  *clientcache.ClientCache
 	shutdownFunc context.CancelFunc
 
+	// SecretRotationGracePeriod is how long a modified kubeconfig secret that is not usable yet, for example because it
+	// does not contain the context of the ClientConfig, is waited for before restarting the operator anyway. The
+	// operator restarts as soon as the secret is modified when it is zero.
+	SecretRotationGracePeriod time.Duration
+
 	// filterMutex  sync.RWMutex
 	secretFilter map[types.NamespacedName]types.NamespacedName
+
+	// rotationsInProgress are the times at which the ClientConfigs whose secret is not usable yet were found to be
+	// modified.
+	rotationsInProgress map[types.NamespacedName]time.Time
 }
 
 func (r *ClientConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -66,7 +79,11 @@ func (r *ClientConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	cCfgHash, secretHash, err := calculateHashes(ctx, r.ClientCache.GetLocalClient(), clientConfig)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			// ClientConfig was deleted, shutdown to refresh correct list
+			// Secret was deleted, it may be recreated shortly if it is being rotated
+			if requeueAfter, waiting := r.waitForRotation(req.NamespacedName, &clientConfig); waiting {
+				logger.Info(fmt.Sprintf("Secret of ClientConfig %v is being rotated, waiting for it to be recreated", req))
+				return ctrl.Result{RequeueAfter: requeueAfter}, nil
+			}
 			logger.Info(fmt.Sprintf("Secret %v was deleted, shutting down the operator", req))
 			r.shutdownFunc()
 			return ctrl.Result{}, nil
@@ -78,14 +95,54 @@ func (r *ClientConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if clientConfig.Annotations[ClientConfigHashAnnotation] != cCfgHash ||
 		clientConfig.Annotations[KubeSecretHashAnnotation] != secretHash {
 		// Hashes do not match, something was modified, shutdown to refresh
+		if requeueAfter, waiting := r.waitForRotation(req.NamespacedName, &clientConfig); waiting {
+			logger.Info(fmt.Sprintf("Secret of ClientConfig %v is being rotated, waiting for it to be usable", req))
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
 		logger.Info(fmt.Sprintf("ClientConfig %v or secret has been modified, shutting down the operator", req))
 		r.shutdownFunc()
 		return ctrl.Result{}, nil
 	}
 
+	// The secret was restored before becoming usable
+	delete(r.rotationsInProgress, req.NamespacedName)
 	return ctrl.Result{}, nil
 }
 
+// waitForRotation returns true if the modified kubeconfig secret of clientConfig cannot be used to connect to its
+// context yet, and the grace period since the modification was first detected has not elapsed, along with the delay
+// before checking the secret again. The delay doubles with each check.
+func (r *ClientConfigReconciler) waitForRotation(key types.NamespacedName, clientConfig *configapi.ClientConfig) (time.Duration, bool) {
+	if r.SecretRotationGracePeriod <= 0 {
+		return 0, false
+	}
+	if _, err := r.ClientCache.GetRestConfig(clientConfig); err == nil {
+		delete(r.rotationsInProgress, key)
+		return 0, false
+	}
+	if r.rotationsInProgress == nil {
+		r.rotationsInProgress = make(map[types.NamespacedName]time.Time)
+	}
+	detectedAt, found := r.rotationsInProgress[key]
+	if !found {
+		detectedAt = time.Now()
+		r.rotationsInProgress[key] = detectedAt
+	}
+	elapsed := time.Since(detectedAt)
+	if elapsed >= r.SecretRotationGracePeriod {
+		delete(r.rotationsInProgress, key)
+		return 0, false
+	}
+	requeueAfter := minRotationCheckDelay
+	if elapsed > requeueAfter {
+		requeueAfter = elapsed
+	}
+	if remaining := r.SecretRotationGracePeriod - elapsed; requeueAfter > remaining {
+		requeueAfter = remaining
+	}
+	return requeueAfter, true
+}
+
 // SetupWithManager will only set this controller to listen in control plane cluster
 func (r *ClientConfigReconciler) SetupWithManager(mgr ctrl.Manager, cancelFunc context.CancelFunc) error {
 	r.shutdownFunc = cancelFunc
@@ -143,6 +200,12 @@ func (r *ClientConfigReconciler) InitClientConfigs(ctx context.Context, mgr ctrl
 			return nil, err
 		}
 
+		// The secret was modified since the previous start, the new credentials might not be accepted everywhere yet
+		if previousHash, found := cCfg.Annotations[KubeSecretHashAnnotation]; found && previousHash != secretHash {
+			logger.Info("Kubeconfig secret was rotated", "context", cCfg.GetContextName())
+			r.ClientCache.SetSecretRotated(cCfg.GetContextName(), time.Now())
+		}
+
 		metav1.SetMetaDataAnnotation(&cCfg.ObjectMeta, ClientConfigHashAnnotation, cCfgHash)
 		metav1.SetMetaDataAnnotation(&cCfg.ObjectMeta, KubeSecretHashAnnotation, secretHash)
 
@@ -159,6 +222,13 @@ func (r *ClientConfigReconciler) InitClientConfigs(ctx context.Context, mgr ctrl
 			return nil, err
 		}
 
+		// The manager already watches its own cluster, reuse its client rather than opening a second connection
+		if r.ClientCache.IsLocalCluster(cfg) {
+			logger.V(1).Info("ClientConfig targets the local cluster, reusing the manager client", "context", cCfg.GetContextName())
+			r.ClientCache.AddLocalClient(cCfg.GetContextName())
+			continue
+		}
+
 		// Add cluster to the manager
 		var c cluster.Cluster
 		if strings.Contains(watchNamespace, ",") {
@@ -178,6 +248,9 @@ func (r *ClientConfigReconciler) InitClientConfigs(ctx context.Context, mgr ctrl
 		}
 
 		r.ClientCache.AddClient(cCfg.GetContextName(), c.GetClient())
+		if expiry, found := clientcache.ClientCertificateExpiry(cfg); found {
+			r.ClientCache.SetCertificateExpiry(cCfg.GetContextName(), expiry)
+		}
 
 		err = mgr.Add(c)
 		if err != nil {
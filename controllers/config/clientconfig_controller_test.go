@@ -84,6 +84,7 @@ func TestClientConfigReconciler(t *testing.T) {
 	t.Run("SecretModification", testEnv.ControllerTest(ctx, testSecretModification))
 	t.Run("ClientConfigDeletion", testEnv.ControllerTest(ctx, testConfigDeletion))
 	t.Run("SecretDeletion", testEnv.ControllerTest(ctx, testSecretDeletion))
+	t.Run("SecretRotation", testEnv.ControllerTest(ctx, testSecretRotation))
 }
 
 func insertKubeConfigSecret(ctx context.Context, localClient client.Client, namespace string) (*corev1.Secret, error) {
@@ -250,3 +251,42 @@ func testSecretDeletion(t *testing.T, ctx context.Context, f *framework.Framewor
 		return cancelCalls > currentCount
 	}, timeout, interval)
 }
+
+func testSecretRotation(t *testing.T, ctx context.Context, f *framework.Framework, namespace string) {
+	assert := assert.New(t)
+	reconciler.SecretRotationGracePeriod = 10 * time.Second
+	defer func() { reconciler.SecretRotationGracePeriod = 0 }()
+
+	t.Log("Insert clientConfig and secrets, load them to reconciler")
+	secret, err := insertKubeConfigSecret(ctx, f.Client, namespace)
+	assert.NoError(err)
+
+	_, err = insertClientConfig(ctx, f.Client, namespace, "envtest", secret.Name)
+	assert.NoError(err)
+
+	_, err = reconciler.InitClientConfigs(ctx, usedMgr, namespace)
+	assert.NoError(err)
+
+	// Store currentCount of cancelFunc
+	currentCount := cancelCalls
+
+	t.Log("Remove the kubeconfig from the secret, as if it was being rotated")
+	secretKey := types.NamespacedName{Namespace: namespace, Name: secret.Name}
+	secretCurrent := &corev1.Secret{}
+	assert.NoError(f.Client.Get(ctx, secretKey, secretCurrent))
+	secretCurrent.Data = map[string][]byte{"rotating": []byte("true")}
+	assert.NoError(f.Client.Update(ctx, secretCurrent))
+
+	assert.Never(func() bool {
+		return cancelCalls > currentCount
+	}, 3*time.Second, interval, "the operator must not restart while the secret is not usable")
+
+	t.Log("Complete the rotation and wait for shutdown call")
+	assert.NoError(f.Client.Get(ctx, secretKey, secretCurrent))
+	secretCurrent.Data = map[string][]byte{"kubeconfig": kubeConfig, "rotated": []byte("true")}
+	assert.NoError(f.Client.Update(ctx, secretCurrent))
+
+	assert.Eventually(func() bool {
+		return cancelCalls > currentCount
+	}, timeout, interval)
+}
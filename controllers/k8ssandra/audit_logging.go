@@ -0,0 +1,59 @@
+package k8ssandra
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/reconciliation"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileAuditLogging replicates the ConfigMap holding the files of the audit logging configuration of the DC to its
+// k8s context and namespace, and mounts the files in the cassandra container. The source ConfigMap is labeled as
+// watched by the K8ssandraCluster so that changes to its contents are replicated, and roll out to the pods. When the
+// DC does not refer to audit logging files, the replicated ConfigMap is deleted.
+func (r *K8ssandraClusterReconciler) reconcileAuditLogging(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcConfig *cassandra.DatacenterConfig,
+	remoteClient client.Client,
+	dcLogger logr.Logger,
+) result.ReconcileResult {
+	configMapKey := client.ObjectKey{
+		Namespace: utils.FirstNonEmptyString(dcConfig.Meta.Namespace, kc.Namespace),
+		Name:      cassandra.AuditLoggingConfigMapName(kc.SanitizedName(), dcConfig.Meta.Name),
+	}
+
+	auditLogging := dcConfig.AuditLogging
+	if auditLogging == nil || auditLogging.ConfigMapRef == nil {
+		if err := deleteConfigMapIfExists(ctx, remoteClient, configMapKey, dcLogger); err != nil {
+			return result.Error(err)
+		}
+		return result.Continue()
+	}
+
+	source, err := r.getWatchedConfigMap(ctx, kc, auditLogging.ConfigMapRef.Name, dcLogger)
+	if err != nil {
+		return result.Error(err)
+	}
+
+	desiredConfigMap := cassandra.NewAuditLoggingConfigMap(kc, dcConfig, source)
+	labels.SetWatchedByK8ssandraCluster(desiredConfigMap, utils.GetKey(kc))
+	recRes := reconciliation.ReconcileObject(ctx, remoteClient, r.DefaultDelay, *desiredConfigMap)
+	switch {
+	case recRes.IsError():
+		return recRes
+	case recRes.IsRequeue():
+		return recRes
+	}
+
+	configHash := utils.DeepHashString([]interface{}{source.Data, source.BinaryData})
+	cassandra.AddAuditLoggingFiles(dcConfig, configMapKey.Name, configHash)
+	dcLogger.Info("Audit logging ConfigMap successfully reconciled")
+	return result.Continue()
+}
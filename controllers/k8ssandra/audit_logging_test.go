@@ -0,0 +1,81 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileAuditLogging(t *testing.T) {
+	ctx := context.Background()
+	logger := testr.New(t)
+	kc := &api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	source := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "audit-files"},
+		Data:       map[string]string{"archive.sh": "#!/bin/sh\ngzip \"$1\"\n"},
+	}
+	controlPlaneClient, err := test.NewFakeClient(source)
+	require.NoError(t, err)
+	remoteClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(controlPlaneClient)
+	configMapKey := client.ObjectKey{Namespace: "dc1-ns", Name: "test-dc1-audit-logging"}
+	reconcileDc := func(auditLogging *api.AuditLogging) *cassandra.DatacenterConfig {
+		dcConfig := &cassandra.DatacenterConfig{
+			Meta:         api.EmbeddedObjectMeta{Name: "dc1", Namespace: "dc1-ns"},
+			AuditLogging: auditLogging,
+		}
+		// the first pass creates or updates the replicated ConfigMap and requeues
+		for i := 0; i < 2; i++ {
+			if !r.reconcileAuditLogging(ctx, kc, dcConfig, remoteClient, logger).Completed() {
+				break
+			}
+		}
+		return dcConfig
+	}
+
+	dcConfig := reconcileDc(&api.AuditLogging{ConfigMapRef: &corev1.LocalObjectReference{Name: "audit-files"}})
+
+	require.NoError(t, controlPlaneClient.Get(ctx, client.ObjectKeyFromObject(source), source))
+	assert.True(t, labels.IsWatchedByK8ssandraCluster(source, utils.GetKey(kc)))
+	replicated := &corev1.ConfigMap{}
+	require.NoError(t, remoteClient.Get(ctx, configMapKey, replicated))
+	assert.Equal(t, source.Data, replicated.Data)
+
+	volumeIdx, found := cassandra.FindVolume(&dcConfig.PodTemplateSpec, "audit-logging-files")
+	require.True(t, found)
+	assert.Equal(t, configMapKey.Name, dcConfig.PodTemplateSpec.Spec.Volumes[volumeIdx].ConfigMap.Name)
+	idx, found := cassandra.FindContainer(&dcConfig.PodTemplateSpec, "cassandra")
+	require.True(t, found)
+	assert.Contains(t, dcConfig.PodTemplateSpec.Spec.Containers[idx].VolumeMounts,
+		corev1.VolumeMount{Name: "audit-logging-files", MountPath: cassandra.AuditLoggingFilesMountPath})
+	hash := dcConfig.PodTemplateSpec.Annotations[api.AuditLoggingConfigHashAnnotation]
+	assert.NotEmpty(t, hash)
+
+	// a change of the files is replicated, and rolls out to the pods
+	source.Data["archive.sh"] = "#!/bin/sh\nxz \"$1\"\n"
+	require.NoError(t, controlPlaneClient.Update(ctx, source))
+	dcConfig = reconcileDc(&api.AuditLogging{ConfigMapRef: &corev1.LocalObjectReference{Name: "audit-files"}})
+	require.NoError(t, remoteClient.Get(ctx, configMapKey, replicated))
+	assert.Equal(t, source.Data, replicated.Data)
+	assert.NotEqual(t, hash, dcConfig.PodTemplateSpec.Annotations[api.AuditLoggingConfigHashAnnotation])
+
+	// removing the reference deletes the replicated files
+	dcConfig = reconcileDc(&api.AuditLogging{Enabled: pointer.Bool(true)})
+	assert.True(t, apierrors.IsNotFound(remoteClient.Get(ctx, configMapKey, &corev1.ConfigMap{})))
+	_, found = cassandra.FindVolume(&dcConfig.PodTemplateSpec, "audit-logging-files")
+	assert.False(t, found)
+}
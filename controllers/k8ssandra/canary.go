@@ -0,0 +1,65 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// applyCanaryUpgrade turns on cass-operator's canary upgrade mode in desiredDc when canary upgrades are enabled and
+// the server version or image of the existing CassandraDatacenter is being changed. Canary mode is kept until canary
+// upgrades are disabled, at which point the change is rolled out to the remaining nodes. It returns true if desiredDc
+// is in canary upgrade mode.
+func (r *K8ssandraClusterReconciler) applyCanaryUpgrade(
+	ctx context.Context,
+	dcConfig *cassandra.DatacenterConfig,
+	desiredDc *cassdcapi.CassandraDatacenter,
+	remoteClient client.Client,
+	logger logr.Logger,
+) (bool, error) {
+	if !dcConfig.CanaryUpgrade.IsEnabled() {
+		return false, nil
+	}
+
+	actualDc := &cassdcapi.CassandraDatacenter{}
+	if err := remoteClient.Get(ctx, client.ObjectKeyFromObject(desiredDc), actualDc); err != nil {
+		if errors.IsNotFound(err) {
+			// New datacenters are deployed as a whole.
+			return false, nil
+		}
+		return false, err
+	}
+
+	if !actualDc.Spec.CanaryUpgrade && !serverChanged(actualDc, desiredDc) {
+		return false, nil
+	}
+
+	logger.Info("Applying canary upgrade", "ServerVersion", desiredDc.Spec.ServerVersion, "ServerImage", desiredDc.Spec.ServerImage)
+	desiredDc.Spec.CanaryUpgrade = true
+	desiredDc.Spec.CanaryUpgradeCount = dcConfig.CanaryUpgrade.NodeCount
+	return true, nil
+}
+
+// serverChanged returns true if the server version or image of desiredDc differs from the one of actualDc.
+func serverChanged(actualDc, desiredDc *cassdcapi.CassandraDatacenter) bool {
+	return actualDc.Spec.ServerVersion != desiredDc.Spec.ServerVersion || actualDc.Spec.ServerImage != desiredDc.Spec.ServerImage
+}
+
+// setCanaryUpgradeCondition sets the CanaryUpgradeInProgress condition to true if canaryDcs is not empty, and to false
+// if it is empty and the condition was true.
+func setCanaryUpgradeCondition(kc *api.K8ssandraCluster, canaryDcs []string) {
+	if len(canaryDcs) > 0 {
+		message := fmt.Sprintf("Canary upgrade in progress in datacenters: %s", strings.Join(canaryDcs, ", "))
+		kc.Status.SetConditionStatus(api.CanaryUpgradeInProgress, corev1.ConditionTrue, message)
+	} else if kc.Status.GetConditionStatus(api.CanaryUpgradeInProgress) == corev1.ConditionTrue {
+		kc.Status.SetConditionStatus(api.CanaryUpgradeInProgress, corev1.ConditionFalse, "")
+	}
+}
@@ -0,0 +1,101 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestApplyCanaryUpgrade(t *testing.T) {
+	newDc := func(version string, canary bool) *cassdcapi.CassandraDatacenter {
+		return &cassdcapi.CassandraDatacenter{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"},
+			Spec:       cassdcapi.CassandraDatacenterSpec{ServerVersion: version, CanaryUpgrade: canary},
+		}
+	}
+	enabled := &cassandra.DatacenterConfig{CanaryUpgrade: &api.CanaryUpgradeConfig{Enabled: pointer.Bool(true), NodeCount: 1}}
+
+	tests := []struct {
+		name         string
+		dcConfig     *cassandra.DatacenterConfig
+		actualDc     *cassdcapi.CassandraDatacenter
+		desiredDc    *cassdcapi.CassandraDatacenter
+		expectCanary bool
+	}{
+		{
+			name:         "version change with canary upgrades enabled",
+			dcConfig:     enabled,
+			actualDc:     newDc("4.0.6", false),
+			desiredDc:    newDc("4.0.7", false),
+			expectCanary: true,
+		},
+		{
+			name:         "canary upgrade already applied",
+			dcConfig:     enabled,
+			actualDc:     newDc("4.0.7", true),
+			desiredDc:    newDc("4.0.7", false),
+			expectCanary: true,
+		},
+		{
+			name:      "no version change",
+			dcConfig:  enabled,
+			actualDc:  newDc("4.0.7", false),
+			desiredDc: newDc("4.0.7", false),
+		},
+		{
+			name:      "new datacenter",
+			dcConfig:  enabled,
+			desiredDc: newDc("4.0.7", false),
+		},
+		{
+			name:      "canary upgrades disabled",
+			dcConfig:  &cassandra.DatacenterConfig{CanaryUpgrade: &api.CanaryUpgradeConfig{Enabled: pointer.Bool(false)}},
+			actualDc:  newDc("4.0.7", true),
+			desiredDc: newDc("4.0.7", false),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, err := test.NewFakeClient()
+			require.NoError(t, err)
+			if tt.actualDc != nil {
+				require.NoError(t, fakeClient.Create(context.Background(), tt.actualDc))
+			}
+			r := newTracingTestReconciler(fakeClient)
+
+			inCanary, err := r.applyCanaryUpgrade(context.Background(), tt.dcConfig, tt.desiredDc, fakeClient, testr.New(t))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectCanary, inCanary)
+			assert.Equal(t, tt.expectCanary, tt.desiredDc.Spec.CanaryUpgrade)
+			if tt.expectCanary {
+				assert.Equal(t, int32(1), tt.desiredDc.Spec.CanaryUpgradeCount)
+			}
+		})
+	}
+}
+
+func TestSetCanaryUpgradeCondition(t *testing.T) {
+	kc := &api.K8ssandraCluster{}
+
+	setCanaryUpgradeCondition(kc, []string{})
+	assert.Equal(t, corev1.ConditionUnknown, kc.Status.GetConditionStatus(api.CanaryUpgradeInProgress))
+
+	setCanaryUpgradeCondition(kc, []string{"dc1", "dc2"})
+	condition, found := kc.Status.GetCondition(api.CanaryUpgradeInProgress)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "dc1, dc2")
+
+	setCanaryUpgradeCondition(kc, []string{})
+	assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.CanaryUpgradeInProgress))
+}
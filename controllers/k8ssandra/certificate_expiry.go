@@ -0,0 +1,64 @@
+package k8ssandra
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	kerrors "github.com/k8ssandra/k8ssandra-operator/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// checkCertificateExpiry sets the RemoteCertificateExpired condition when the client certificate of a k8s context used
+// by the datacenters of kc has expired, and returns true if err, the error of the reconcile, was caused by an expired
+// certificate. An unauthorized error is attributed to an expired certificate only when one of these client
+// certificates has expired, since the API server rejects expired client certificates as invalid credentials.
+func (r *K8ssandraClusterReconciler) checkCertificateExpiry(kc *api.K8ssandraCluster, err error) bool {
+	expired := r.expiredCertificates(kc)
+	certificateError := kerrors.IsCertificateExpired(err)
+	if certificateError && len(expired) == 0 {
+		expired = append(expired, fmt.Sprintf("a remote request failed: %v", err))
+	}
+	setRemoteCertificateExpiredCondition(kc, expired)
+	return certificateError || (len(expired) > 0 && apierrors.IsUnauthorized(err))
+}
+
+// expiredCertificates describes the expired client certificates of the k8s contexts used by the datacenters of kc.
+func (r *K8ssandraClusterReconciler) expiredCertificates(kc *api.K8ssandraCluster) []string {
+	if kc.Spec.Cassandra == nil || r.ClientCache == nil {
+		return nil
+	}
+	contexts := make(map[string]bool)
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if dcTemplate.K8sContext != "" {
+			contexts[dcTemplate.K8sContext] = true
+		}
+	}
+	now := time.Now()
+	var expired []string
+	for k8sContext := range contexts {
+		if expiry, found := r.ClientCache.GetCertificateExpiry(k8sContext); found && !now.Before(expiry) {
+			expired = append(expired, fmt.Sprintf("the client certificate of context %s expired at %s", k8sContext, expiry.UTC().Format(time.RFC3339)))
+		}
+	}
+	sort.Strings(expired)
+	return expired
+}
+
+// setRemoteCertificateExpiredCondition sets the RemoteCertificateExpired condition to true if expired is not empty,
+// and back to false otherwise.
+func setRemoteCertificateExpiredCondition(kc *api.K8ssandraCluster, expired []string) {
+	status := corev1.ConditionFalse
+	message := ""
+	if len(expired) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("Certificate expired: %s. Renew the kubeconfig of the affected contexts.", strings.Join(expired, "; "))
+	}
+	if _, found := kc.Status.GetCondition(api.RemoteCertificateExpired); !found && status == corev1.ConditionFalse {
+		return
+	}
+	kc.Status.SetConditionStatus(api.RemoteCertificateExpired, status, message)
+}
@@ -0,0 +1,127 @@
+package k8ssandra
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckCertificateExpiry(t *testing.T) {
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "east"},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, K8sContext: "west"},
+					},
+				},
+			},
+		}
+	}
+	unauthorized := apierrors.NewUnauthorized("Unauthorized")
+	expiredCertificate := fmt.Errorf("failed to get CassandraDatacenter: %w", x509.CertificateInvalidError{Reason: x509.Expired})
+
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	r.ClientCache.SetCertificateExpiry("east", time.Now().Add(24*time.Hour))
+
+	t.Run("valid certificates", func(t *testing.T) {
+		kc := newKc()
+		assert.False(t, r.checkCertificateExpiry(kc, nil))
+		assert.False(t, r.checkCertificateExpiry(kc, unauthorized), "unauthorized errors are not attributed to valid certificates")
+		_, found := kc.Status.GetCondition(api.RemoteCertificateExpired)
+		assert.False(t, found)
+	})
+
+	t.Run("expired client certificate", func(t *testing.T) {
+		kc := newKc()
+		expiry := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+		r.ClientCache.SetCertificateExpiry("west", expiry)
+		defer r.ClientCache.SetCertificateExpiry("west", time.Now().Add(24*time.Hour))
+
+		assert.True(t, r.checkCertificateExpiry(kc, unauthorized))
+		condition, found := kc.Status.GetCondition(api.RemoteCertificateExpired)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Contains(t, condition.Message, "the client certificate of context west expired at 2023-01-02T03:04:05Z")
+		assert.NotContains(t, condition.Message, "east")
+
+		assert.False(t, r.checkCertificateExpiry(kc, errors.New("connection refused")), "other errors are not attributed to the certificate")
+		assert.Equal(t, corev1.ConditionTrue, kc.Status.GetConditionStatus(api.RemoteCertificateExpired))
+
+		// the condition is cleared once the certificate is renewed
+		r.ClientCache.SetCertificateExpiry("west", time.Now().Add(24*time.Hour))
+		assert.False(t, r.checkCertificateExpiry(kc, nil))
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.RemoteCertificateExpired))
+	})
+
+	t.Run("expired server certificate", func(t *testing.T) {
+		kc := newKc()
+		assert.True(t, r.checkCertificateExpiry(kc, expiredCertificate))
+		condition, found := kc.Status.GetCondition(api.RemoteCertificateExpired)
+		require.True(t, found)
+		assert.Contains(t, condition.Message, "certificate has expired or is not yet valid")
+	})
+}
+
+// secretErrorClient fails the reads of secrets with err.
+type secretErrorClient struct {
+	client.Client
+	err error
+}
+
+func (c *secretErrorClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, ok := obj.(*corev1.Secret); ok {
+		return c.err
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func TestReconcileBacksOffOnExpiredCertificate(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "test",
+			Finalizers: []string{k8ssandraClusterFinalizer},
+		},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				SuperuserSecretRef: corev1.LocalObjectReference{Name: "test-superuser"},
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "east"},
+				},
+			},
+		},
+	}
+	fakeClient, err := test.NewFakeClient(kc)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(&secretErrorClient{Client: fakeClient, err: apierrors.NewUnauthorized("Unauthorized")})
+	r.Recorder = record.NewFakeRecorder(10)
+	r.ClientCache.SetCertificateExpiry("east", time.Now().Add(-time.Hour))
+
+	res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(kc)})
+	require.NoError(t, err, "the error is not returned, to avoid the short retries of the controller")
+	assert.Equal(t, ctrl.Result{RequeueAfter: r.LongDelay}, res)
+
+	actual := &api.K8ssandraCluster{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(kc), actual))
+	assert.Equal(t, corev1.ConditionTrue, actual.Status.GetConditionStatus(api.RemoteCertificateExpired))
+	assert.Contains(t, actual.Status.Error, "Unauthorized")
+}
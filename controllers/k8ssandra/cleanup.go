@@ -99,6 +99,10 @@ func (r *K8ssandraClusterReconciler) checkDeletion(ctx context.Context, kc *api.
 		}
 	}
 
+	if r.deleteOrphanedDatacenters(ctx, kc, logger) {
+		hasErrors = true
+	}
+
 	if hasErrors {
 		return result.RequeueSoon(r.DefaultDelay)
 	}
@@ -109,10 +113,93 @@ func (r *K8ssandraClusterReconciler) checkDeletion(ctx context.Context, kc *api.
 		logger.Error(err, "Failed to remove finalizer")
 		return result.Error(err)
 	}
+	r.unreachableSeedAddresses.forget(utils.GetKey(kc))
+	r.seenClaims.forget(utils.GetKey(kc))
+	r.gossipNodes.forget(utils.GetKey(kc))
+	r.droppedMutations.forget(utils.GetKey(kc))
 
 	return result.Done()
 }
 
+// deleteOrphanedDatacenters deletes the CassandraDatacenters created for kc in all the k8s contexts known to the
+// operator, including the ones no longer listed in its spec, for example because their removal did not complete or
+// because their k8s context was removed from the spec. Owner references cannot be used for this, since they do not work
+// across clusters. It returns true if some of them could not be listed or deleted in the local cluster or in a k8s
+// context referenced by kc. The failures in the other contexts, which may be unreachable or decommissioned, are only
+// logged, so that they do not hold the deletion of kc.
+func (r *K8ssandraClusterReconciler) deleteOrphanedDatacenters(ctx context.Context, kc *api.K8ssandraCluster, logger logr.Logger) (hasErrors bool) {
+	selector := k8ssandralabels.PartOfLabels(utils.GetKey(kc))
+	options := &client.ListOptions{LabelSelector: labels.SelectorFromSet(selector)}
+	referenced := referencedK8sContexts(kc)
+	if deleteDatacenters(ctx, r.ClientCache.GetLocalClient(), options, logger) {
+		hasErrors = true
+	}
+	for k8sContext, remoteClient := range r.ClientCache.GetRemoteClients() {
+		if r.ClientCache.IsLocalContext(k8sContext) {
+			continue
+		}
+		contextLogger := logger.WithValues("Context", k8sContext)
+		if deleteDatacenters(ctx, remoteClient, options, contextLogger) {
+			if referenced[k8sContext] {
+				hasErrors = true
+			} else {
+				contextLogger.Info("Ignoring the failure in a k8s context not referenced by the K8ssandraCluster")
+			}
+		}
+	}
+	return hasErrors
+}
+
+// deleteDatacenters deletes the CassandraDatacenters matching options with remoteClient. It returns true if some of them
+// could not be listed or deleted.
+func deleteDatacenters(ctx context.Context, remoteClient client.Client, options *client.ListOptions, logger logr.Logger) (hasErrors bool) {
+	dcList := &cassdcapi.CassandraDatacenterList{}
+	if err := remoteClient.List(ctx, dcList, options); err != nil {
+		logger.Error(err, "Failed to list CassandraDatacenters for deletion")
+		return true
+	}
+	for i := range dcList.Items {
+		dc := &dcList.Items[i]
+		if dc.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if err := remoteClient.Delete(ctx, dc); err != nil {
+			if !errors.IsNotFound(err) {
+				logger.Error(err, "Failed to delete CassandraDatacenter", "CassandraDatacenter", utils.GetKey(dc))
+				hasErrors = true
+			}
+			continue
+		}
+		logger.Info("Deleted CassandraDatacenter", "CassandraDatacenter", utils.GetKey(dc))
+	}
+	return hasErrors
+}
+
+// referencedK8sContexts returns the k8s contexts that kc references, in the datacenters of its spec, or in the
+// connections, inventory and CRD versions of its status.
+func referencedK8sContexts(kc *api.K8ssandraCluster) map[string]bool {
+	referenced := make(map[string]bool)
+	if kc.Spec.Cassandra != nil {
+		for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+			referenced[dcTemplate.K8sContext] = true
+		}
+	}
+	for _, dcStatus := range kc.Status.Datacenters {
+		if dcStatus.Connection != nil {
+			referenced[dcStatus.Connection.K8sContext] = true
+		}
+	}
+	if kc.Status.Inventory != nil {
+		for _, object := range kc.Status.Inventory.Objects {
+			referenced[object.K8sContext] = true
+		}
+	}
+	for _, crdVersion := range kc.Status.CrdVersions {
+		referenced[crdVersion.K8sContext] = true
+	}
+	return referenced
+}
+
 // checkFinalizer ensures that the K8ssandraCluster has a finalizer.
 func (r *K8ssandraClusterReconciler) checkFinalizer(ctx context.Context, kc *api.K8ssandraCluster, logger logr.Logger) result.ReconcileResult {
 	if controllerutil.ContainsFinalizer(kc, k8ssandraClusterFinalizer) {
@@ -132,13 +219,28 @@ func (r *K8ssandraClusterReconciler) checkFinalizer(ctx context.Context, kc *api
 func (r *K8ssandraClusterReconciler) checkDcDeletion(ctx context.Context, kc *api.K8ssandraCluster, logger logr.Logger) result.ReconcileResult {
 	dcName := k8ssandra.GetDatacenterForDecommission(kc)
 	if dcName == "" {
-		return result.Continue()
+		clearDecommissionConfirmationCondition(kc)
+		if recResult := r.removeDcAnnotation(ctx, kc, api.ReleaseDcAnnotation); recResult.Completed() {
+			return recResult
+		}
+		return r.removeDcAnnotation(ctx, kc, api.DecommissionDcAnnotation)
 	}
 
 	status := kc.Status.Datacenters[dcName]
 
+	if status.DecommissionProgress == api.DecommNone && annotations.HasAnnotationWithValue(kc, api.ReleaseDcAnnotation, dcName) {
+		logger.Info("Releasing DC instead of decommissioning it", "DC", dcName)
+		return r.releaseDc(ctx, kc, dcName, logger)
+	}
+
 	switch kc.Status.Datacenters[dcName].DecommissionProgress {
 	case api.DecommNone:
+		if !decommissionConfirmed(kc, dcName) {
+			logger.Info("Waiting for confirmation to decommission DC", "DC", dcName, "Annotation", api.DecommissionDcAnnotation)
+			setDecommissionConfirmationCondition(kc, dcName)
+			return result.Continue()
+		}
+		clearDecommissionConfirmationCondition(kc)
 		logger.Info("Preparing to updating replication for DC decommission", "DC", dcName)
 		status.DecommissionProgress = api.DecommUpdatingReplication
 		kc.Status.Datacenters[dcName] = status
@@ -155,30 +257,15 @@ func (r *K8ssandraClusterReconciler) checkDcDeletion(ctx context.Context, kc *ap
 func (r *K8ssandraClusterReconciler) deleteDc(ctx context.Context, kc *api.K8ssandraCluster, dcName string, logger logr.Logger) result.ReconcileResult {
 	kcKey := utils.GetKey(kc)
 
-	stargate, remoteClient, err := r.findStargateForDeletion(ctx, kcKey, dcName, nil)
+	remoteClient, err := r.deleteDcComponents(ctx, kcKey, dcName, logger)
 	if err != nil {
 		return result.Error(err)
 	}
 
-	if stargate != nil {
-		if err = remoteClient.Delete(ctx, stargate); err != nil && !errors.IsNotFound(err) {
-			return result.Error(fmt.Errorf("failed to delete Stargate for dc (%s): %v", dcName, err))
-		}
-		logger.Info("Deleted Stargate", "Stargate", utils.GetKey(stargate))
-	}
-
-	reaper, remoteClient, err := r.findReaperForDeletion(ctx, kcKey, dcName, remoteClient)
-	if err != nil {
+	if err := r.deleteNetworkPolicies(ctx, kcKey, dcName, logger); err != nil {
 		return result.Error(err)
 	}
 
-	if reaper != nil {
-		if err = remoteClient.Delete(ctx, reaper); err != nil && !errors.IsNotFound(err) {
-			return result.Error(fmt.Errorf("failed to delete Reaper for dc (%s): %v", dcName, err))
-		}
-		logger.Info("Deleted Reaper", "Reaper", utils.GetKey(reaper))
-	}
-
 	dc, remoteClient, err := r.findDcForDeletion(ctx, kcKey, dcName, remoteClient)
 	if err != nil {
 		return result.Error(err)
@@ -212,6 +299,36 @@ func (r *K8ssandraClusterReconciler) deleteDc(ctx context.Context, kc *api.K8ssa
 	return result.Continue()
 }
 
+// deleteDcComponents deletes the Stargate and Reaper deployed for the DC. It returns the client of the cluster in
+// which they were found, or nil if there were none.
+func (r *K8ssandraClusterReconciler) deleteDcComponents(ctx context.Context, kcKey client.ObjectKey, dcName string, logger logr.Logger) (client.Client, error) {
+	stargate, remoteClient, err := r.findStargateForDeletion(ctx, kcKey, dcName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if stargate != nil {
+		if err = remoteClient.Delete(ctx, stargate); err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete Stargate for dc (%s): %v", dcName, err)
+		}
+		logger.Info("Deleted Stargate", "Stargate", utils.GetKey(stargate))
+	}
+
+	reaper, remoteClient, err := r.findReaperForDeletion(ctx, kcKey, dcName, remoteClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if reaper != nil {
+		if err = remoteClient.Delete(ctx, reaper); err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete Reaper for dc (%s): %v", dcName, err)
+		}
+		logger.Info("Deleted Reaper", "Reaper", utils.GetKey(reaper))
+	}
+
+	return remoteClient, nil
+}
+
 func (r *K8ssandraClusterReconciler) findStargateForDeletion(
 	ctx context.Context,
 	kcKey client.ObjectKey,
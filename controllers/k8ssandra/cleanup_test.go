@@ -0,0 +1,148 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckDeletionDeletesAllManagedDatacenters(t *testing.T) {
+	ctx := context.Background()
+	now := metav1.Now()
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "test",
+			Finalizers:        []string{k8ssandraClusterFinalizer},
+			DeletionTimestamp: &now,
+		},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "east"},
+				},
+			},
+		},
+	}
+	newDc := func(name string, kcKey client.ObjectKey) *cassdcapi.CassandraDatacenter {
+		return &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    labels.PartOfLabels(kcKey),
+		}}
+	}
+	kcKey := client.ObjectKeyFromObject(kc)
+	otherKcKey := client.ObjectKey{Namespace: "default", Name: "other"}
+
+	controlPlaneClient, err := test.NewFakeClient(kc)
+	require.NoError(t, err)
+	eastClient, err := test.NewFakeClient(
+		newDc("dc1", kcKey),
+		// left over by a removal that did not complete
+		newDc("dc-removed", kcKey),
+	)
+	require.NoError(t, err)
+	westClient, err := test.NewFakeClient(
+		// in a k8s context that is no longer in the spec
+		newDc("dc2", kcKey),
+		newDc("dc3", otherKcKey),
+	)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(controlPlaneClient)
+	r.ClientCache.AddClient("east", eastClient)
+	r.ClientCache.AddClient("west", westClient)
+
+	recResult := r.checkDeletion(ctx, kc, testr.New(t))
+	require.False(t, recResult.IsError())
+	assert.False(t, recResult.IsRequeue())
+
+	for _, dcKey := range []client.ObjectKey{{Namespace: "default", Name: "dc1"}, {Namespace: "default", Name: "dc-removed"}} {
+		err := eastClient.Get(ctx, dcKey, &cassdcapi.CassandraDatacenter{})
+		assert.True(t, apierrors.IsNotFound(err), "%s must be deleted", dcKey.Name)
+	}
+	err = westClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "dc2"}, &cassdcapi.CassandraDatacenter{})
+	assert.True(t, apierrors.IsNotFound(err), "dc2 must be deleted")
+	assert.NoError(t, westClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "dc3"}, &cassdcapi.CassandraDatacenter{}),
+		"the datacenters of other clusters are kept")
+
+	err = controlPlaneClient.Get(ctx, kcKey, &api.K8ssandraCluster{})
+	assert.True(t, apierrors.IsNotFound(err), "the finalizer is removed once the datacenters are deleted")
+}
+
+// unreachableClient simulates a k8s context whose API server can not be reached.
+type unreachableClient struct {
+	client.Client
+}
+
+func (unreachableClient) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return fmt.Errorf("dial tcp 10.0.0.1:6443: connect: connection refused")
+}
+
+func TestCheckDeletionWithUnreachableContexts(t *testing.T) {
+	ctx := context.Background()
+	newKc := func() *api.K8ssandraCluster {
+		now := metav1.Now()
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              "test",
+				Finalizers:        []string{k8ssandraClusterFinalizer},
+				DeletionTimestamp: &now,
+			},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "east"},
+					},
+				},
+			},
+		}
+	}
+	newReconciler := func(t *testing.T, kc *api.K8ssandraCluster) (*K8ssandraClusterReconciler, client.Client) {
+		controlPlaneClient, err := test.NewFakeClient(kc)
+		require.NoError(t, err)
+		eastClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		westClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		r := newTracingTestReconciler(controlPlaneClient)
+		r.ClientCache.AddClient("east", eastClient)
+		r.ClientCache.AddClient("west", unreachableClient{westClient})
+		return r, controlPlaneClient
+	}
+
+	t.Run("context not referenced", func(t *testing.T) {
+		kc := newKc()
+		r, controlPlaneClient := newReconciler(t, kc)
+
+		recResult := r.checkDeletion(ctx, kc, testr.New(t))
+		require.False(t, recResult.IsError())
+		assert.False(t, recResult.IsRequeue())
+		err := controlPlaneClient.Get(ctx, client.ObjectKeyFromObject(kc), &api.K8ssandraCluster{})
+		assert.True(t, apierrors.IsNotFound(err), "the finalizer is removed despite the unreachable context")
+	})
+
+	t.Run("context referenced by the status", func(t *testing.T) {
+		kc := newKc()
+		kc.Status.Datacenters = map[string]api.K8ssandraStatus{
+			"dc2": {Connection: &api.DatacenterConnectionStatus{K8sContext: "west", Namespace: "default", Service: "test-dc2-service"}},
+		}
+		r, controlPlaneClient := newReconciler(t, kc)
+
+		recResult := r.checkDeletion(ctx, kc, testr.New(t))
+		assert.True(t, recResult.IsRequeue())
+		assert.NoError(t, controlPlaneClient.Get(ctx, client.ObjectKeyFromObject(kc), &api.K8ssandraCluster{}),
+			"the finalizer is kept until the datacenters of the referenced contexts are deleted")
+	})
+}
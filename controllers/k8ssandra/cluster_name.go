@@ -0,0 +1,41 @@
+package k8ssandra
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkClusterName verifies that the existing datacenter actualDc belongs to the cluster cassClusterName. Renaming the
+// cluster of an existing datacenter would make its nodes unable to join the ring, so the change is refused: an error is
+// returned, and the ClusterNameChanged condition is set until the cluster name is reverted. The webhook rejects such
+// changes as well, but it may not be deployed.
+func checkClusterName(kc *api.K8ssandraCluster, actualDc *cassdcapi.CassandraDatacenter, cassClusterName string) error {
+	if actualDc.Spec.ClusterName == cassClusterName {
+		clearClusterNameChangedCondition(kc, actualDc.Name)
+		return nil
+	}
+
+	message := fmt.Sprintf("%s has cluster name %s, but expected %s. Cluster name cannot be changed in an existing cluster",
+		clusterNameChangedMessagePrefix(actualDc.Name), actualDc.Spec.ClusterName, cassClusterName)
+	kc.Status.SetConditionStatus(api.ClusterNameChanged, corev1.ConditionTrue, message)
+	return errors.New(message)
+}
+
+func clusterNameChangedMessagePrefix(dcName string) string {
+	return fmt.Sprintf("CassandraDatacenter %s", dcName)
+}
+
+// clearClusterNameChangedCondition sets the ClusterNameChanged condition back to false if it was set because of the
+// datacenter dcName, whose cluster name matches again.
+func clearClusterNameChangedCondition(kc *api.K8ssandraCluster, dcName string) {
+	condition, found := kc.Status.GetCondition(api.ClusterNameChanged)
+	if !found || condition.Status != corev1.ConditionTrue || !strings.HasPrefix(condition.Message, clusterNameChangedMessagePrefix(dcName)+" ") {
+		return
+	}
+	kc.Status.SetConditionStatus(api.ClusterNameChanged, corev1.ConditionFalse, "")
+}
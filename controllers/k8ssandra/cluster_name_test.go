@@ -0,0 +1,49 @@
+package k8ssandra
+
+import (
+	"testing"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckClusterName(t *testing.T) {
+	kc := &api.K8ssandraCluster{}
+	dc1 := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Name: "dc1"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "cluster1"},
+	}
+	dc2 := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Name: "dc2"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "cluster1"},
+	}
+
+	require.NoError(t, checkClusterName(kc, dc1, "cluster1"))
+	_, found := kc.Status.GetCondition(api.ClusterNameChanged)
+	assert.False(t, found)
+
+	// renaming the cluster is refused
+	err := checkClusterName(kc, dc1, "renamed")
+	require.EqualError(t, err, "CassandraDatacenter dc1 has cluster name cluster1, but expected renamed. Cluster name cannot be changed in an existing cluster")
+	condition, found := kc.Status.GetCondition(api.ClusterNameChanged)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, err.Error(), condition.Message)
+	transitionTime := condition.LastTransitionTime
+
+	require.Error(t, checkClusterName(kc, dc1, "renamed"))
+	condition, _ = kc.Status.GetCondition(api.ClusterNameChanged)
+	assert.Equal(t, transitionTime, condition.LastTransitionTime, "the transition time is kept while the cluster name differs")
+
+	// another DC with a matching name does not clear the condition
+	require.NoError(t, checkClusterName(kc, dc2, "cluster1"))
+	assert.Equal(t, corev1.ConditionTrue, kc.Status.GetConditionStatus(api.ClusterNameChanged))
+
+	// reverting the cluster name clears it
+	require.NoError(t, checkClusterName(kc, dc1, "cluster1"))
+	assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.ClusterNameChanged))
+}
@@ -0,0 +1,88 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestCreateDatacenterConfigsMaxClusterSize(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, Size: 3},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, Size: 3},
+				},
+			},
+		},
+	}
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	r.MaxClusterSize = 5
+
+	_, err = r.createDatacenterConfigs(context.Background(), kc, logr.Discard(), cassandra.SystemReplication{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, api.ErrMaxClusterSize)
+	assert.Contains(t, err.Error(), "K8ssandraCluster test has 6 nodes, the maximum is 5 (dc1: 3, dc2: 3)")
+
+	assert.NoError(t, api.ValidateClusterSize(kc, 0, 6))
+	assert.NoError(t, api.ValidateClusterSize(kc, 0, 0))
+
+	// The maximum was lowered after the nodes were deployed
+	kc.Status.Datacenters = map[string]api.K8ssandraStatus{
+		"dc1": {Cassandra: &cassdcapi.CassandraDatacenterStatus{NodeStatuses: cassdcapi.CassandraStatusMap{
+			"dc1-rack1-sts-0": {}, "dc1-rack1-sts-1": {}, "dc1-rack1-sts-2": {},
+		}}},
+		"dc2": {Cassandra: &cassdcapi.CassandraDatacenterStatus{NodeStatuses: cassdcapi.CassandraStatusMap{
+			"dc2-rack1-sts-0": {}, "dc2-rack1-sts-1": {}, "dc2-rack1-sts-2": {},
+		}}},
+	}
+	_, err = r.createDatacenterConfigs(context.Background(), kc, logr.Discard(), cassandra.SystemReplication{})
+	assert.NotErrorIs(t, err, api.ErrMaxClusterSize, "a cluster that does not grow is still reconciled")
+
+	kc.Spec.Cassandra.Datacenters[1].Size = 4
+	_, err = r.createDatacenterConfigs(context.Background(), kc, logr.Discard(), cassandra.SystemReplication{})
+	assert.ErrorIs(t, err, api.ErrMaxClusterSize)
+
+	now := metav1.Now()
+	kc.DeletionTimestamp = &now
+	assert.NoError(t, api.ValidateClusterSize(kc, 6, 5), "a cluster being deleted is not checked")
+}
+
+func TestCreateDatacenterConfigsSeedPropagationQuorum(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				SeedPropagationQuorum: pointer.Int32(3),
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, Size: 3},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, Size: 3},
+				},
+			},
+		},
+	}
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	_, err = r.createDatacenterConfigs(context.Background(), kc, logr.Discard(), cassandra.SystemReplication{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, api.ErrSeedPropagationQuorum)
+	assert.Contains(t, err.Error(), "K8ssandraCluster test has 2 datacenters, the quorum is 3")
+
+	kc.Spec.Cassandra.SeedPropagationQuorum = pointer.Int32(2)
+	assert.NoError(t, api.ValidateSeedPropagationQuorum(kc))
+}
@@ -0,0 +1,39 @@
+package k8ssandra
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// clusterState holds what a reconciler keeps in memory about each K8ssandraCluster between reconciles. It is safe for
+// concurrent use, and its zero value is ready to use.
+type clusterState[T any] struct {
+	mu     sync.Mutex
+	values map[types.NamespacedName]T
+}
+
+// get returns the value recorded for the cluster kcKey, and false if there is none.
+func (s *clusterState[T]) get(kcKey types.NamespacedName) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, found := s.values[kcKey]
+	return value, found
+}
+
+// set records value for the cluster kcKey.
+func (s *clusterState[T]) set(kcKey types.NamespacedName, value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.values == nil {
+		s.values = make(map[types.NamespacedName]T)
+	}
+	s.values[kcKey] = value
+}
+
+// forget drops the value recorded for the cluster kcKey, once it is deleted.
+func (s *clusterState[T]) forget(kcKey types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, kcKey)
+}
@@ -0,0 +1,28 @@
+package k8ssandra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestClusterState(t *testing.T) {
+	var state clusterState[int]
+	kcKey := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	_, found := state.get(kcKey)
+	assert.False(t, found, "the zero value holds nothing")
+
+	state.set(kcKey, 1)
+	value, found := state.get(kcKey)
+	assert.True(t, found)
+	assert.Equal(t, 1, value)
+
+	_, found = state.get(types.NamespacedName{Namespace: "other", Name: "test"})
+	assert.False(t, found)
+
+	state.forget(kcKey)
+	_, found = state.get(kcKey)
+	assert.False(t, found)
+}
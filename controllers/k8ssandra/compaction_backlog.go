@@ -0,0 +1,100 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkCompactionBacklog reports the highest and total numbers of pending compactions of each DC in the status of kc,
+// and sets the CompactionBacklogHigh condition when the highest exceeds the configured threshold. Checks happen at most
+// once per poll interval. Failing to check the backlog of a DC is logged but does not fail the reconcile.
+func (r *K8ssandraClusterReconciler) checkCompactionBacklog(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcs []*cassdcapi.CassandraDatacenter,
+	logger logr.Logger,
+) result.ReconcileResult {
+	monitoring := kc.Spec.Cassandra.CompactionBacklogMonitoring
+	if monitoring == nil || !monitoringCheckDue(kc, dcs, monitoring.GetPollInterval(), compactionBacklogLastCheckTime) {
+		return result.Continue()
+	}
+
+	now := metav1.Now()
+	backlogs := make(map[string]*api.CompactionBacklogStatus)
+	err := r.pollDatacenters(ctx, kc, dcs, logger, "Failed to check compaction backlog",
+		func(dc *cassdcapi.CassandraDatacenter, mgmtApi cassandra.ManagementApiFacade) error {
+			pendingCompactions, err := mgmtApi.GetPendingCompactions()
+			if err != nil {
+				return err
+			}
+			backlog := maxCompactionBacklog(pendingCompactions)
+			if backlog == nil {
+				return nil
+			}
+			for _, pending := range pendingCompactions {
+				backlog.TotalPendingCompactions += pending
+			}
+			backlog.LastCheckTime = &now
+			backlogs[dc.Name] = backlog
+			if kdcStatus, found := kc.Status.Datacenters[dc.Name]; found {
+				kdcStatus.CompactionBacklog = backlog
+				kc.Status.Datacenters[dc.Name] = kdcStatus
+			}
+			return nil
+		})
+	if err != nil {
+		return result.Error(err)
+	}
+	setCompactionBacklogCondition(kc, backlogs, monitoring.GetThreshold())
+	return result.Continue()
+}
+
+// compactionBacklogLastCheckTime returns the time the compaction backlog of the DC was last checked, or nil if it never was.
+func compactionBacklogLastCheckTime(status api.K8ssandraStatus) *metav1.Time {
+	if status.CompactionBacklog == nil {
+		return nil
+	}
+	return status.CompactionBacklog.LastCheckTime
+}
+
+// maxCompactionBacklog returns the node with the highest number of pending compactions, or nil if there are no nodes.
+// Ties are broken by pod name so that the result is stable.
+func maxCompactionBacklog(pendingCompactions map[string]int32) *api.CompactionBacklogStatus {
+	var backlog *api.CompactionBacklogStatus
+	for pod, pending := range pendingCompactions {
+		if backlog == nil || pending > backlog.PendingCompactions || (pending == backlog.PendingCompactions && pod < backlog.Node) {
+			backlog = &api.CompactionBacklogStatus{PendingCompactions: pending, Node: pod}
+		}
+	}
+	return backlog
+}
+
+// setCompactionBacklogCondition sets the CompactionBacklogHigh condition to true if the backlog of at least one DC
+// exceeds threshold, and to false otherwise.
+func setCompactionBacklogCondition(kc *api.K8ssandraCluster, backlogs map[string]*api.CompactionBacklogStatus, threshold int32) {
+	highBacklogs := make([]string, 0)
+	for dcName, backlog := range backlogs {
+		if backlog.PendingCompactions > threshold {
+			highBacklogs = append(highBacklogs, fmt.Sprintf("%s (pod %s with %d pending compactions)", dcName, backlog.Node, backlog.PendingCompactions))
+		}
+	}
+	sort.Strings(highBacklogs)
+
+	status := corev1.ConditionFalse
+	message := ""
+	if len(highBacklogs) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("Compaction backlog exceeds %d in datacenters: %s", threshold, strings.Join(highBacklogs, ", "))
+	}
+	kc.Status.SetConditionStatus(api.CompactionBacklogHigh, status, message)
+}
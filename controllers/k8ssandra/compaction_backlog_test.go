@@ -0,0 +1,132 @@
+package k8ssandra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckCompactionBacklog(t *testing.T) {
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					CompactionBacklogMonitoring: &api.CompactionBacklogMonitoring{Threshold: 20},
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+					},
+				},
+			},
+			Status: api.K8ssandraClusterStatus{
+				Datacenters: map[string]api.K8ssandraStatus{"dc1": {}, "dc2": {}},
+			},
+		}
+	}
+	dcs := []*cassdcapi.CassandraDatacenter{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc2"}},
+	}
+
+	newReconciler := func(t *testing.T, mgmtApis map[string]*test.FakeManagementApiFacade) *K8ssandraClusterReconciler {
+		fakeClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		factory := &test.FakeManagementApiFactory{}
+		factory.SetT(t)
+		factory.SetAdapter(func(_ context.Context, dc *cassdcapi.CassandraDatacenter, _ client.Client, _ logr.Logger) (cassandra.ManagementApiFacade, error) {
+			return mgmtApis[dc.Name], nil
+		})
+		r.ManagementApi = factory
+		return r
+	}
+	newMgmtApi := func(pendingCompactions map[string]int32, err error) *test.FakeManagementApiFacade {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.GetPendingCompactions).Return(pendingCompactions, err)
+		return mgmtApi
+	}
+
+	t.Run("backlog above threshold", func(t *testing.T) {
+		mgmtApis := map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(map[string]int32{"dc1-rack1-sts-0": 3, "dc1-rack1-sts-1": 42}, nil),
+			"dc2": newMgmtApi(map[string]int32{"dc2-rack1-sts-0": 5}, nil),
+		}
+		kc := newKc()
+
+		recResult := newReconciler(t, mgmtApis).checkCompactionBacklog(context.Background(), kc, dcs, testr.New(t))
+		assert.False(t, recResult.Completed())
+
+		backlog := kc.Status.Datacenters["dc1"].CompactionBacklog
+		require.NotNil(t, backlog)
+		assert.Equal(t, int32(42), backlog.PendingCompactions)
+		assert.Equal(t, "dc1-rack1-sts-1", backlog.Node)
+		assert.Equal(t, int32(45), backlog.TotalPendingCompactions)
+		assert.NotNil(t, backlog.LastCheckTime)
+		assert.Equal(t, int32(5), kc.Status.Datacenters["dc2"].CompactionBacklog.PendingCompactions)
+		condition, found := kc.Status.GetCondition(api.CompactionBacklogHigh)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Contains(t, condition.Message, "dc1 (pod dc1-rack1-sts-1 with 42 pending compactions)")
+		assert.NotContains(t, condition.Message, "dc2")
+	})
+
+	t.Run("backlog below threshold", func(t *testing.T) {
+		mgmtApis := map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(map[string]int32{"dc1-rack1-sts-0": 3}, nil),
+			"dc2": newMgmtApi(map[string]int32{"dc2-rack1-sts-0": 5}, nil),
+		}
+		kc := newKc()
+
+		newReconciler(t, mgmtApis).checkCompactionBacklog(context.Background(), kc, dcs, testr.New(t))
+
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.CompactionBacklogHigh))
+	})
+
+	t.Run("management API failure in one dc", func(t *testing.T) {
+		mgmtApis := map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(nil, errors.New("connection refused")),
+			"dc2": newMgmtApi(map[string]int32{"dc2-rack1-sts-0": 25}, nil),
+		}
+		kc := newKc()
+
+		recResult := newReconciler(t, mgmtApis).checkCompactionBacklog(context.Background(), kc, dcs, testr.New(t))
+		assert.False(t, recResult.Completed())
+
+		assert.Nil(t, kc.Status.Datacenters["dc1"].CompactionBacklog)
+		assert.Equal(t, int32(25), kc.Status.Datacenters["dc2"].CompactionBacklog.PendingCompactions)
+		assert.Equal(t, corev1.ConditionTrue, kc.Status.GetConditionStatus(api.CompactionBacklogHigh))
+	})
+
+	t.Run("checked within poll interval", func(t *testing.T) {
+		mgmtApis := map[string]*test.FakeManagementApiFacade{
+			"dc1": test.NewFakeManagementApiFacade(),
+			"dc2": test.NewFakeManagementApiFacade(),
+		}
+		kc := newKc()
+		lastCheck := metav1.NewTime(time.Now().Add(-time.Minute))
+		for dcName := range kc.Status.Datacenters {
+			kc.Status.Datacenters[dcName] = api.K8ssandraStatus{CompactionBacklog: &api.CompactionBacklogStatus{LastCheckTime: &lastCheck}}
+		}
+
+		newReconciler(t, mgmtApis).checkCompactionBacklog(context.Background(), kc, dcs, testr.New(t))
+
+		for _, mgmtApi := range mgmtApis {
+			mgmtApi.AssertNotCalled(t, test.GetPendingCompactions)
+		}
+		assert.Equal(t, corev1.ConditionUnknown, kc.Status.GetConditionStatus(api.CompactionBacklogHigh))
+	})
+}
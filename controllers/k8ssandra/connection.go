@@ -0,0 +1,41 @@
+package k8ssandra
+
+import (
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+)
+
+// setClusterConnectionStatus reports the superuser secret and the cluster CQL Service of kc in its status, so that
+// applications can look up how to connect to the cluster.
+func setClusterConnectionStatus(kc *api.K8ssandraCluster) {
+	if kc.Spec.Cassandra.SuperuserSecretRef.Name == "" {
+		kc.Status.Connection = nil
+		return
+	}
+	connection := &api.ClusterConnectionStatus{SuperuserSecretRef: kc.Spec.Cassandra.SuperuserSecretRef}
+	if kc.Spec.Cassandra.ClusterCqlService.IsEnabled() {
+		connection.ClusterService = cassandra.ClusterCqlServiceKey(kc, kc.Namespace).Name
+	}
+	kc.Status.Connection = connection
+}
+
+// setDatacenterConnectionStatus reports the Service of dc, which cass-operator creates in the context of dcConfig, in
+// the status of its datacenter, along with the CQL port of its nodes and the domain of its pods if a pod hostname is
+// configured.
+func setDatacenterConnectionStatus(kc *api.K8ssandraCluster, dc *cassdcapi.CassandraDatacenter, dcConfig *cassandra.DatacenterConfig) {
+	dcStatus, found := kc.Status.Datacenters[dc.Name]
+	if !found {
+		return
+	}
+	dcStatus.Connection = &api.DatacenterConnectionStatus{
+		K8sContext: dcConfig.K8sContext,
+		Namespace:  dc.Namespace,
+		Service:    dc.GetDatacenterServiceName(),
+		Port:       int32(cassandra.NativeTransportPort(dcConfig)),
+	}
+	if dcConfig.PodHostname != nil {
+		dcStatus.Connection.PodDomain = cassandra.PodDomain(dc, dcConfig.PodHostname.ClusterDomain)
+	}
+	kc.Status.Datacenters[dc.Name] = dcStatus
+}
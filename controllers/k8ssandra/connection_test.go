@@ -0,0 +1,63 @@
+package k8ssandra
+
+import (
+	"testing"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestSetClusterConnectionStatus(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				SuperuserSecretRef: corev1.LocalObjectReference{Name: "test-superuser"},
+			},
+		},
+	}
+
+	setClusterConnectionStatus(kc)
+	assert.Equal(t, &api.ClusterConnectionStatus{SuperuserSecretRef: corev1.LocalObjectReference{Name: "test-superuser"}}, kc.Status.Connection)
+
+	kc.Spec.Cassandra.ClusterCqlService = &api.ClusterCqlService{Enabled: pointer.Bool(true)}
+	setClusterConnectionStatus(kc)
+	assert.Equal(t, "test-all-dcs-service", kc.Status.Connection.ClusterService)
+}
+
+func TestSetDatacenterConnectionStatus(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Status: api.K8ssandraClusterStatus{
+			Datacenters: map[string]api.K8ssandraStatus{"dc1": {}},
+		},
+	}
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "dc-namespace", Name: "dc1"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "Test Cluster"},
+	}
+
+	dcConfig := &cassandra.DatacenterConfig{K8sContext: "cluster-1"}
+
+	setDatacenterConnectionStatus(kc, dc, dcConfig)
+	assert.Equal(t, &api.DatacenterConnectionStatus{
+		K8sContext: "cluster-1",
+		Namespace:  "dc-namespace",
+		Service:    "testcluster-dc1-service",
+		Port:       9042,
+	}, kc.Status.Datacenters["dc1"].Connection)
+
+	dcConfig.PodHostname = &api.PodHostname{ClusterDomain: "east.example.com"}
+	setDatacenterConnectionStatus(kc, dc, dcConfig)
+	assert.Equal(t, "testcluster-dc1-all-pods-service.dc-namespace.svc.east.example.com", kc.Status.Datacenters["dc1"].Connection.PodDomain)
+
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"native_transport_port": 9052}
+	setDatacenterConnectionStatus(kc, dc, dcConfig)
+	assert.Equal(t, int32(9052), kc.Status.Datacenters["dc1"].Connection.Port, "the configured CQL port is reported")
+}
@@ -0,0 +1,102 @@
+package k8ssandra
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// reconcileClusterCqlService creates or updates the cluster-wide CQL Service in each k8s context and namespace where
+// a DC of kc is deployed, when it is enabled. When it is disabled, the Services previously created are deleted.
+func (r *K8ssandraClusterReconciler) reconcileClusterCqlService(ctx context.Context, kc *api.K8ssandraCluster, logger logr.Logger) result.ReconcileResult {
+	kcKey := utils.GetKey(kc)
+	for _, location := range datacenterLocations(kc) {
+		remoteClient, err := r.ClientCache.GetRemoteClient(location.k8sContext)
+		if err != nil {
+			logger.Error(err, "Failed to get remote client", "K8sContext", location.k8sContext)
+			return result.Error(err)
+		}
+
+		serviceKey := cassandra.ClusterCqlServiceKey(kc, location.namespace)
+		serviceLogger := logger.WithValues("Service", serviceKey, "K8sContext", location.k8sContext)
+
+		actualService := &corev1.Service{}
+		err = remoteClient.Get(ctx, serviceKey, actualService)
+		if err != nil && !apierrors.IsNotFound(err) {
+			serviceLogger.Error(err, "Failed to get cluster CQL Service")
+			return result.Error(err)
+		}
+		found := err == nil
+
+		if !kc.Spec.Cassandra.ClusterCqlService.IsEnabled() {
+			if found && labels.IsPartOf(actualService, kcKey) {
+				if err := remoteClient.Delete(ctx, actualService); err != nil && !apierrors.IsNotFound(err) {
+					serviceLogger.Error(err, "Failed to delete cluster CQL Service")
+					return result.Error(err)
+				}
+				serviceLogger.Info("Deleted cluster CQL Service")
+			}
+			continue
+		}
+
+		desiredService := cassandra.NewClusterCqlService(kc, location.namespace)
+		if !found {
+			// Note: cannot set controller reference on remote objects
+			if err := remoteClient.Create(ctx, desiredService); err != nil {
+				if apierrors.IsAlreadyExists(err) {
+					return result.RequeueSoon(r.DefaultDelay)
+				}
+				serviceLogger.Error(err, "Failed to create cluster CQL Service")
+				return result.Error(err)
+			}
+			serviceLogger.Info("Created cluster CQL Service")
+		} else if !annotations.CompareHashAnnotations(actualService, desiredService) {
+			resourceVersion := actualService.GetResourceVersion()
+			desiredService.DeepCopyInto(actualService)
+			actualService.SetResourceVersion(resourceVersion)
+			if err := remoteClient.Update(ctx, actualService); err != nil {
+				serviceLogger.Error(err, "Failed to update cluster CQL Service")
+				return result.Error(err)
+			}
+			serviceLogger.Info("Updated cluster CQL Service")
+		}
+	}
+	return result.Continue()
+}
+
+type datacenterLocation struct {
+	k8sContext string
+	namespace  string
+}
+
+// datacenterLocations returns the distinct k8s contexts and namespaces where the DCs of kc are deployed, sorted.
+func datacenterLocations(kc *api.K8ssandraCluster) []datacenterLocation {
+	seen := make(map[datacenterLocation]bool)
+	var locations []datacenterLocation
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		location := datacenterLocation{
+			k8sContext: dcTemplate.K8sContext,
+			namespace:  utils.FirstNonEmptyString(dcTemplate.Meta.Namespace, kc.Namespace),
+		}
+		if !seen[location] {
+			seen[location] = true
+			locations = append(locations, location)
+		}
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].k8sContext != locations[j].k8sContext {
+			return locations[i].k8sContext < locations[j].k8sContext
+		}
+		return locations[i].namespace < locations[j].namespace
+	})
+	return locations
+}
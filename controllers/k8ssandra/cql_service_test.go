@@ -0,0 +1,93 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+)
+
+func TestReconcileClusterCqlService(t *testing.T) {
+	ctx := context.Background()
+	logger := testr.New(t)
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				ClusterName:       "Test Cluster",
+				ClusterCqlService: &api.ClusterCqlService{Enabled: pointer.Bool(true)},
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+				},
+			},
+		},
+	}
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	recResult := r.reconcileClusterCqlService(ctx, kc, logger)
+	require.False(t, recResult.Completed())
+
+	service := &corev1.Service{}
+	require.NoError(t, fakeClient.Get(ctx, cassandra.ClusterCqlServiceKey(kc, "default"), service))
+	assert.Equal(t, corev1.ClusterIPNone, service.Spec.ClusterIP)
+	require.Len(t, service.Spec.Ports, 1)
+	assert.Equal(t, int32(9042), service.Spec.Ports[0].Port)
+
+	newPod := func(clusterName, dcName string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+			cassdcapi.ClusterLabel:    cassdcapi.CleanLabelValue(clusterName),
+			cassdcapi.DatacenterLabel: dcName,
+		}}}
+	}
+	selector := k8slabels.SelectorFromSet(service.Spec.Selector)
+	assert.True(t, selector.Matches(k8slabels.Set(newPod("Test Cluster", "dc1").Labels)))
+	assert.True(t, selector.Matches(k8slabels.Set(newPod("Test Cluster", "dc2").Labels)))
+	assert.False(t, selector.Matches(k8slabels.Set(newPod("Other Cluster", "dc1").Labels)))
+
+	// the selector follows the cluster name
+	kc.Spec.Cassandra.ClusterName = "Renamed Cluster"
+	require.False(t, r.reconcileClusterCqlService(ctx, kc, logger).Completed())
+	require.NoError(t, fakeClient.Get(ctx, cassandra.ClusterCqlServiceKey(kc, "default"), service))
+	assert.True(t, k8slabels.SelectorFromSet(service.Spec.Selector).Matches(k8slabels.Set(newPod("Renamed Cluster", "dc2").Labels)))
+
+	// disabling the service deletes it
+	kc.Spec.Cassandra.ClusterCqlService.Enabled = pointer.Bool(false)
+	require.False(t, r.reconcileClusterCqlService(ctx, kc, logger).Completed())
+	err = fakeClient.Get(ctx, cassandra.ClusterCqlServiceKey(kc, "default"), service)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestDatacenterLocations(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "east"},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, K8sContext: "east"},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc3", Namespace: "other"}, K8sContext: "east"},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc4"}, K8sContext: "west"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []datacenterLocation{
+		{k8sContext: "east", namespace: "default"},
+		{k8sContext: "east", namespace: "other"},
+		{k8sContext: "west", namespace: "default"},
+	}, datacenterLocations(kc))
+}
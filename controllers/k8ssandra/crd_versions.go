@@ -0,0 +1,146 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	cassandraDatacenterCrdName = "cassandradatacenters.cassandra.datastax.com"
+
+	// crdVersionLabel is the label recording the version of cass-operator that the CassandraDatacenter CRD comes from.
+	crdVersionLabel = "app.kubernetes.io/version"
+
+	// maxTestedCrdMinorVersionSkew is the largest difference between the minor versions of the CassandraDatacenter CRDs
+	// of two k8s contexts that is tested. CRDs of different major versions are never tested together.
+	maxTestedCrdMinorVersionSkew = 1
+)
+
+var crdGvk = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// checkCrdVersions records in the status of kc the version of the CassandraDatacenter CRD installed in each k8s context
+// hosting a datacenter, and sets the CrdVersionsDiverged condition when the versions are further apart than the tested
+// range. The check only runs when the datacenters span more than one k8s context. The version of a CRD that cannot be
+// read, for example because the operator is not allowed to read CRDs, or that does not have the version label, is
+// unknown: it is recorded empty in the status, and the condition is set to unknown unless the known versions diverge.
+func (r *K8ssandraClusterReconciler) checkCrdVersions(ctx context.Context, kc *api.K8ssandraCluster, logger logr.Logger) {
+	if !spansMultipleContexts(kc) {
+		kc.Status.CrdVersions = nil
+		setCrdVersionsDivergedCondition(kc, nil, false)
+		return
+	}
+
+	versions := make([]api.CrdVersionStatus, 0)
+	checked := make(map[string]bool)
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if checked[dcTemplate.K8sContext] {
+			continue
+		}
+		checked[dcTemplate.K8sContext] = true
+		version, err := r.cassandraDatacenterCrdVersion(ctx, dcTemplate.K8sContext)
+		if err != nil {
+			logger.Info("Failed to read the version of the CassandraDatacenter CRD",
+				"K8sContext", dcTemplate.K8sContext, "Error", err.Error())
+		}
+		versions = append(versions, api.CrdVersionStatus{K8sContext: dcTemplate.K8sContext, Version: version})
+	}
+	kc.Status.CrdVersions = versions
+
+	diverged := crdVersionsDiverged(versions)
+	if diverged {
+		logger.Info("The CassandraDatacenter CRD versions of the k8s contexts diverge beyond the tested range",
+			"Versions", formatCrdVersions(versions))
+	}
+	setCrdVersionsDivergedCondition(kc, versions, diverged)
+}
+
+// cassandraDatacenterCrdVersion returns the version recorded in the labels of the CassandraDatacenter CRD of the k8s
+// context, or an empty string if the CRD does not have the label. The CRD is read as unstructured, since its type is not
+// registered in the scheme of the operator.
+func (r *K8ssandraClusterReconciler) cassandraDatacenterCrdVersion(ctx context.Context, k8sContext string) (string, error) {
+	remoteClient, err := r.ClientCache.GetRemoteClient(k8sContext)
+	if err != nil {
+		return "", err
+	}
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGvk)
+	if err := remoteClient.Get(ctx, client.ObjectKey{Name: cassandraDatacenterCrdName}, crd); err != nil {
+		return "", err
+	}
+	return crd.GetLabels()[crdVersionLabel], nil
+}
+
+// crdVersionsDiverged returns true if some of the versions have different major versions, or minor versions more than
+// maxTestedCrdMinorVersionSkew apart. Versions that are missing or that are not valid semantic versions are ignored.
+func crdVersionsDiverged(versions []api.CrdVersionStatus) bool {
+	var lowest, highest *semver.Version
+	for _, status := range versions {
+		version, err := semver.NewVersion(status.Version)
+		if err != nil {
+			continue
+		}
+		if lowest == nil || version.LessThan(lowest) {
+			lowest = version
+		}
+		if highest == nil || version.GreaterThan(highest) {
+			highest = version
+		}
+	}
+	if lowest == nil {
+		return false
+	}
+	return lowest.Major() != highest.Major() || highest.Minor()-lowest.Minor() > maxTestedCrdMinorVersionSkew
+}
+
+// formatCrdVersions renders the versions as a list of <context>: <version> pairs.
+func formatCrdVersions(versions []api.CrdVersionStatus) string {
+	formatted := make([]string, 0, len(versions))
+	for _, status := range versions {
+		k8sContext := status.K8sContext
+		if k8sContext == "" {
+			k8sContext = "control plane"
+		}
+		version := status.Version
+		if version == "" {
+			version = "unknown"
+		}
+		formatted = append(formatted, fmt.Sprintf("%s: %s", k8sContext, version))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// setCrdVersionsDivergedCondition sets the CrdVersionsDiverged condition to true if the versions diverged, to unknown
+// if some of the versions are unknown, and back to false otherwise. The condition is only set once the versions have
+// diverged or are unknown.
+func setCrdVersionsDivergedCondition(kc *api.K8ssandraCluster, versions []api.CrdVersionStatus, diverged bool) {
+	unknown := false
+	for _, status := range versions {
+		if status.Version == "" {
+			unknown = true
+		}
+	}
+	if _, found := kc.Status.GetCondition(api.CrdVersionsDiverged); !found && !diverged && !unknown {
+		return
+	}
+	status := corev1.ConditionFalse
+	message := ""
+	if diverged {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("The CassandraDatacenter CRD versions of the k8s contexts are more than %d minor version apart: %s.",
+			maxTestedCrdMinorVersionSkew, formatCrdVersions(versions))
+	} else if unknown {
+		status = corev1.ConditionUnknown
+		message = fmt.Sprintf("The CassandraDatacenter CRD versions of some k8s contexts are unknown, they can not be checked: %s.",
+			formatCrdVersions(versions))
+	}
+	kc.Status.SetConditionStatus(api.CrdVersionsDiverged, status, message)
+}
@@ -0,0 +1,140 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckCrdVersions(t *testing.T) {
+	ctx := context.Background()
+	newCrd := func(version string) client.Object {
+		crd := &unstructured.Unstructured{}
+		crd.SetGroupVersionKind(crdGvk)
+		crd.SetName(cassandraDatacenterCrdName)
+		if version != "" {
+			crd.SetLabels(map[string]string{crdVersionLabel: version})
+		}
+		return crd
+	}
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "cluster-1"},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, K8sContext: "cluster-2"},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc3"}, K8sContext: "cluster-2"},
+					},
+				},
+			},
+		}
+	}
+	newReconciler := func(t *testing.T, versions map[string]string) *K8ssandraClusterReconciler {
+		localClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		r := newTracingTestReconciler(localClient)
+		for k8sContext, version := range versions {
+			remoteClient, err := test.NewFakeClient(newCrd(version))
+			require.NoError(t, err)
+			r.ClientCache.AddClient(k8sContext, remoteClient)
+		}
+		return r
+	}
+
+	t.Run("versions within the tested range", func(t *testing.T) {
+		kc := newKc()
+		r := newReconciler(t, map[string]string{"cluster-1": "v1.15.0", "cluster-2": "v1.14.2"})
+
+		r.checkCrdVersions(ctx, kc, testr.New(t))
+		assert.Equal(t, []api.CrdVersionStatus{
+			{K8sContext: "cluster-1", Version: "v1.15.0"},
+			{K8sContext: "cluster-2", Version: "v1.14.2"},
+		}, kc.Status.CrdVersions)
+		_, found := kc.Status.GetCondition(api.CrdVersionsDiverged)
+		assert.False(t, found)
+	})
+
+	t.Run("versions diverge", func(t *testing.T) {
+		kc := newKc()
+		r := newReconciler(t, map[string]string{"cluster-1": "v1.15.0", "cluster-2": "v1.12.0"})
+
+		r.checkCrdVersions(ctx, kc, testr.New(t))
+		condition, found := kc.Status.GetCondition(api.CrdVersionsDiverged)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, "The CassandraDatacenter CRD versions of the k8s contexts are more than 1 minor version apart: cluster-1: v1.15.0, cluster-2: v1.12.0.", condition.Message)
+
+		// the condition is set back to false once the CRD is upgraded
+		r = newReconciler(t, map[string]string{"cluster-1": "v1.15.0", "cluster-2": "v1.15.0"})
+		r.checkCrdVersions(ctx, kc, testr.New(t))
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.CrdVersionsDiverged))
+	})
+
+	t.Run("unknown and unreadable versions", func(t *testing.T) {
+		kc := newKc()
+		kc.Spec.Cassandra.Datacenters = append(kc.Spec.Cassandra.Datacenters,
+			api.CassandraDatacenterTemplate{Meta: api.EmbeddedObjectMeta{Name: "dc4"}, K8sContext: "cluster-3"})
+		r := newReconciler(t, map[string]string{"cluster-1": "v1.15.0", "cluster-2": ""})
+
+		r.checkCrdVersions(ctx, kc, testr.New(t))
+		assert.Equal(t, []api.CrdVersionStatus{
+			{K8sContext: "cluster-1", Version: "v1.15.0"},
+			{K8sContext: "cluster-2"},
+			{K8sContext: "cluster-3"},
+		}, kc.Status.CrdVersions)
+		condition, found := kc.Status.GetCondition(api.CrdVersionsDiverged)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionUnknown, condition.Status)
+		assert.Equal(t, "The CassandraDatacenter CRD versions of some k8s contexts are unknown, they can not be checked: cluster-1: v1.15.0, cluster-2: unknown, cluster-3: unknown.", condition.Message)
+
+		// diverging known versions are still reported
+		r = newReconciler(t, map[string]string{"cluster-1": "v1.15.0", "cluster-2": "v1.12.0"})
+		r.checkCrdVersions(ctx, kc, testr.New(t))
+		assert.Equal(t, corev1.ConditionTrue, kc.Status.GetConditionStatus(api.CrdVersionsDiverged))
+	})
+
+	t.Run("single context", func(t *testing.T) {
+		kc := newKc()
+		kc.Spec.Cassandra.Datacenters = kc.Spec.Cassandra.Datacenters[1:]
+		kc.Status.CrdVersions = []api.CrdVersionStatus{{K8sContext: "cluster-1", Version: "v1.15.0"}}
+		r := newReconciler(t, map[string]string{"cluster-2": "v1.15.0"})
+
+		r.checkCrdVersions(ctx, kc, testr.New(t))
+		assert.Nil(t, kc.Status.CrdVersions)
+	})
+}
+
+func TestCrdVersionsDiverged(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		want     bool
+	}{
+		{"same versions", []string{"v1.15.0", "v1.15.0"}, false},
+		{"patch versions", []string{"v1.15.0", "v1.15.3"}, false},
+		{"adjacent minor versions", []string{"1.14.0", "v1.15.1"}, false},
+		{"distant minor versions", []string{"v1.13.0", "v1.14.0", "v1.15.0"}, true},
+		{"major versions", []string{"v1.15.0", "v2.0.0"}, true},
+		{"invalid versions", []string{"v1.15.0", "latest", ""}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := make([]api.CrdVersionStatus, 0, len(tt.versions))
+			for _, version := range tt.versions {
+				versions = append(versions, api.CrdVersionStatus{Version: version})
+			}
+			assert.Equal(t, tt.want, crdVersionsDiverged(versions))
+		})
+	}
+}
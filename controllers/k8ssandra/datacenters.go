@@ -14,10 +14,13 @@ import (
 	telemetryapi "github.com/k8ssandra/k8ssandra-operator/apis/telemetry/v1alpha1"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	kerrors "github.com/k8ssandra/k8ssandra-operator/pkg/errors"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/secret"
 	agent "github.com/k8ssandra/k8ssandra-operator/pkg/telemetry/cassandra_agent"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/tracing"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,6 +47,9 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 
 	dcConfigs, err := r.createDatacenterConfigs(ctx, kc, logger, systemReplication)
 	if err != nil {
+		if recResult := r.handleIncompleteDatacenter(kc, err, logger); recResult != nil {
+			return recResult, nil
+		}
 		return result.Error(err), nil
 	}
 
@@ -52,13 +58,76 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 	cassClusterName := kc.CassClusterName()
 
 	seeds, err := r.findSeeds(ctx, kc, cassClusterName, logger)
-	if err != nil {
+	_, seedsMissing := err.(transientNoSeedsError)
+	if seedsMissing {
+		// Propagating no seeds for the datacenter would remove it from the seeds of the others, the previously known
+		// ones are propagated instead, and the seeds are resolved again once the other changes are applied.
+		logger.Info("Seed pods are missing, using the previously known seeds", "Reason", err.Error())
+	} else if err != nil {
 		logger.Error(err, "Failed to find seed nodes")
 		return result.Error(err), actualDcs
 	}
 
-	// Reconcile CassandraDatacenter objects only
-	for idx, dcConfig := range sortDatacentersByPriority(dcConfigs) {
+	r.checkSeedsReachability(ctx, kc, dcConfigs, seeds, logger)
+	r.checkCrdVersions(ctx, kc, logger)
+
+	// Schema operations are all sent to a single coordinator DC, the first non-stopped DC to be reconciled, in order to
+	// avoid conflicting concurrent schema changes.
+	var coordinatorDc *cassdcapi.CassandraDatacenter
+	var coordinatorClient client.Client
+
+	// Names of the DCs whose server upgrade is only rolled out to canary nodes.
+	canaryDcs := make([]string, 0)
+
+	// The DCs of the contexts in maintenance are not modified, and the reconcile stops once the other DCs are.
+	inMaintenance := contextsInMaintenance(kc)
+	setContextsInMaintenanceCondition(kc, inMaintenance)
+	skippedDcs := 0
+
+	// Changes to the seeds are held while too many nodes are down in ready DCs
+	deferSeeds := r.deferSeedPropagation(ctx, kc, dcConfigs, logger)
+	if r.checkNodeHealth(ctx, kc, dcConfigs, logger) {
+		deferSeeds = true
+	}
+
+	// Names of the DCs whose steps that depend on the management API were deferred because it was unreachable.
+	mgmtApiDeferredDcs := make([]string, 0)
+
+	// Each DC is reconciled within its own span, which is ended when moving on to the next DC or when returning.
+	parentCtx := ctx
+	var dcSpan trace.Span
+	defer func() { tracing.End(dcSpan, nil) }()
+
+	// Only the first DC in priority order creates the superuser, whichever DC the reconcile resumes with
+	sortedDcConfigs := sortDatacentersByPriority(dcConfigs)
+	firstDcName := ""
+	if len(sortedDcConfigs) > 0 {
+		firstDcName = sortedDcConfigs[0].Meta.Name
+	}
+
+	// Reconcile CassandraDatacenter objects only, resuming with the DC the previous reconcile timed out before
+	for idx, dcConfig := range resumeDatacenters(sortedDcConfigs, kc.Status.ResumeDatacenter) {
+		tracing.End(dcSpan, nil)
+		var ctx context.Context
+		ctx, dcSpan = tracing.Start(parentCtx, "reconcileDatacenter",
+			tracing.ClusterKey.String(cassClusterName),
+			tracing.DatacenterKey.String(dcConfig.Meta.Name),
+			tracing.K8sContextKey.String(dcConfig.K8sContext))
+
+		// At least one DC is reconciled each time, so that a reconcile always makes progress
+		if idx > 0 {
+			next := fmt.Sprintf("datacenter %s, %d of %d datacenters were reconciled", dcConfig.Meta.Name, idx, len(dcConfigs))
+			if recResult := r.checkReconcileTimeout(ctx, kc, next, logger); recResult.Completed() {
+				kc.Status.ResumeDatacenter = dcConfig.Meta.Name
+				return recResult, actualDcs
+			}
+		}
+
+		if inMaintenance[dcConfig.K8sContext] {
+			logger.Info("Skipping datacenter, its context is in maintenance", "CassandraDatacenter", dcConfig.Meta.Name, "K8SContext", dcConfig.K8sContext)
+			skippedDcs++
+			continue
+		}
 
 		if !kc.Spec.UseExternalSecrets() && !secret.HasReplicatedSecrets(ctx, r.Client, kcKey, dcConfig.K8sContext) {
 			// ReplicatedSecret has not replicated yet, wait until it has
@@ -74,6 +143,11 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 			dcLogger.Error(err, "Failed to get remote client")
 			return result.Error(err), actualDcs
 		}
+		remoteClient = tracing.NewClient(remoteClient, dcConfig.K8sContext)
+
+		if recResult := r.checkDcNamespace(ctx, dcKey.Namespace, dcConfig.K8sContext, dcLogger); recResult.Completed() {
+			return recResult, actualDcs
+		}
 
 		// Create Medusa related objects
 		if medusaResult := r.reconcileMedusa(ctx, kc, dcConfig, remoteClient, dcLogger); medusaResult.Completed() {
@@ -85,21 +159,36 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 			return recResult, actualDcs
 		}
 
+		// Track the contents of the secrets mounted in the Cassandra pods
+		if recResult := r.reconcileSecretsHash(ctx, kc, dcConfig, remoteClient, dcLogger); recResult.Completed() {
+			return recResult, actualDcs
+		}
+
 		// Create Vector related objects
 		if vectorResult := r.reconcileVector(ctx, kc, dcConfig, remoteClient, dcLogger); vectorResult.Completed() {
 			return vectorResult, actualDcs
 		}
 
+		// Replicate the log shipping configuration and add the sidecar
+		if recResult := r.reconcileLogShipping(ctx, kc, dcConfig, remoteClient, dcLogger); recResult.Completed() {
+			return recResult, actualDcs
+		}
+
+		// Replicate the files of the audit logging configuration and mount them
+		if recResult := r.reconcileAuditLogging(ctx, kc, dcConfig, remoteClient, dcLogger); recResult.Completed() {
+			return recResult, actualDcs
+		}
+
 		desiredDc, err := cassandra.NewDatacenter(kcKey, dcConfig)
 		if err != nil {
 			dcLogger.Error(err, "Failed to create new CassandraDatacenter")
 			return result.Error(err), actualDcs
 		}
-		if idx > 0 {
+		if dcConfig.Meta.Name != firstDcName {
 			desiredDc.Annotations[cassdcapi.SkipUserCreationAnnotation] = "true"
 		}
 
-		mergedTelemetrySpec := kc.Spec.Cassandra.Datacenters[idx].Telemetry.MergeWith(kc.Spec.Cassandra.Telemetry)
+		mergedTelemetrySpec := datacenterTemplate(kc, dcConfig.Meta.Name).Telemetry.MergeWith(kc.Spec.Cassandra.Telemetry)
 		if mergedTelemetrySpec == nil {
 			mergedTelemetrySpec = &telemetryapi.TelemetrySpec{}
 		}
@@ -121,6 +210,16 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 			return agentRes, actualDcs
 		}
 
+		if inCanary, err := r.applyCanaryUpgrade(ctx, dcConfig, desiredDc, remoteClient, dcLogger); err != nil {
+			dcLogger.Error(err, "Failed to check for canary upgrade")
+			return result.Error(err), actualDcs
+		} else if inCanary {
+			canaryDcs = append(canaryDcs, desiredDc.Name)
+			if kc.Status.GetConditionStatus(api.CanaryUpgradeInProgress) != corev1.ConditionTrue {
+				setCanaryUpgradeCondition(kc, canaryDcs)
+			}
+		}
+
 		// Note: desiredDc should not be modified from now on
 		annotations.AddHashAnnotation(desiredDc)
 
@@ -128,20 +227,50 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 			return recResult, actualDcs
 		}
 
+		if recResult := r.checkCassOperatorVersion(ctx, kc, desiredDc, dcConfig.K8sContext, remoteClient, dcLogger); recResult.Completed() {
+			return recResult, actualDcs
+		}
+
 		actualDc := &cassdcapi.CassandraDatacenter{}
 
-		if recResult := r.reconcileSeedsEndpoints(ctx, desiredDc, seeds, dcConfig.AdditionalSeeds, remoteClient, dcLogger); recResult.Completed() {
+		additionalSeeds := dcConfig.AdditionalSeeds
+		if dcConfig.SeedServiceRef != nil {
+			serviceSeeds, err := resolveServiceSeeds(ctx, dcKey.Namespace, dcConfig.SeedServiceRef, remoteClient)
+			if err != nil {
+				dcLogger.Error(err, "Failed to resolve seeds from service", "Service", dcConfig.SeedServiceRef.Name)
+				return result.Error(err), actualDcs
+			}
+			additionalSeeds = mergeSeeds(additionalSeeds, serviceSeeds)
+		}
+
+		if recResult := r.reconcileSeedsEndpoints(ctx, desiredDc, seeds, additionalSeeds, deferSeeds, remoteClient, dcLogger); recResult.Completed() {
+			return recResult, actualDcs
+		}
+
+		if recResult := r.reconcileNetworkPolicy(ctx, kc, dcConfig, dcKey.Namespace, seeds, remoteClient, dcLogger); recResult.Completed() {
 			return recResult, actualDcs
 		}
 
 		if err = remoteClient.Get(ctx, dcKey, actualDc); err == nil {
 			// Fail the reconcile if cluster name has changed
-			if actualDc.Spec.ClusterName != cassClusterName {
-				return result.Error(fmt.Errorf("CassandraDatacenter %s has cluster name %s, but expected %s. Cluster name cannot be changed in an existing cluster", dcKey, actualDc.Spec.ClusterName, cassClusterName)), actualDcs
+			if err := checkClusterName(kc, actualDc, cassClusterName); err != nil {
+				dcLogger.Error(err, "Refusing to rename the cluster of an existing datacenter")
+				return result.Error(err), actualDcs
 			}
 
 			r.setStatusForDatacenter(kc, actualDc)
 
+			if !annotations.CompareHashAnnotations(actualDc, desiredDc) && r.migrationPending(kc) {
+				restamped, err := r.restampDatacenter(ctx, actualDc, desiredDc, remoteClient)
+				if err != nil {
+					dcLogger.Error(err, "Failed to re-stamp datacenter")
+					return result.Error(err), actualDcs
+				}
+				if restamped {
+					dcLogger.Info("Re-stamped datacenter after operator upgrade")
+				}
+			}
+
 			if !annotations.CompareHashAnnotations(actualDc, desiredDc) {
 				dcLogger.Info("Updating datacenter")
 
@@ -162,15 +291,54 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 					return result.Error(fmt.Errorf("invalid Cassandra config: %v", err)), actualDcs
 				}
 
+				if deferDatacenterUpdate(kc, actualDc) {
+					dcLogger.Info("cass-operator is performing an operation on the datacenter, deferring the update")
+					return result.RequeueSoon(r.DefaultDelay), actualDcs
+				}
+
+				if scaling, err := r.deferDatacenterScaling(ctx, kc, actualDc, desiredDc, remoteClient); err != nil {
+					dcLogger.Error(err, "Failed to check the datacenters being scaled")
+					return result.Error(err), actualDcs
+				} else if scaling != nil {
+					dcLogger.Info("Too many datacenters are scaling, deferring the update", "ScalingDatacenters", scaling)
+					setScalingDeferredCondition(kc, dcKey.Name, scaling)
+					return result.RequeueSoon(r.DefaultDelay), actualDcs
+				}
+
+				if retainVolumesOnScaleDown(dcConfig, actualDc, desiredDc) {
+					if err := r.retainDecommissionedVolumes(ctx, actualDc, desiredDc.Spec.Size, dcConfig.K8sContext, remoteClient, dcLogger); err != nil {
+						dcLogger.Error(err, "Failed to retain the volumes of the nodes to decommission")
+						return result.Error(err), actualDcs
+					}
+				}
+
 				actualDc = actualDc.DeepCopy()
 				resourceVersion := actualDc.GetResourceVersion()
 				desiredDc.DeepCopyInto(actualDc)
 				actualDc.SetResourceVersion(resourceVersion)
 				if err = remoteClient.Update(ctx, actualDc); err != nil {
+					if kerrors.IsWebhookUnavailable(err) {
+						dcLogger.Info("cass-operator webhook is unavailable, will retry updating the datacenter", "Error", err.Error())
+						return result.RequeueSoon(r.WebhookUnavailableDelay), actualDcs
+					}
+					if recResult := r.handleSpecRejection(kc, dcKey.Name, err, dcLogger); recResult != nil {
+						return recResult, actualDcs
+					}
 					dcLogger.Error(err, "Failed to update datacenter")
 					return result.Error(err), actualDcs
 				}
 			}
+			clearDatacenterSpecRejectedCondition(kc, dcKey.Name)
+			setEffectiveConfigStatus(kc, actualDc, dcLogger)
+			setDatacenterConnectionStatus(kc, actualDc, dcConfig)
+
+			// Node replacements are handled before waiting for the datacenter to be ready, since a dead node usually
+			// prevents it from becoming ready.
+			if annotations.GetAnnotation(kc, api.ReplaceNodeAnnotation) != "" && !actualDc.Spec.Stopped {
+				if recResult := r.reconcileNodeReplacement(ctx, kc, actualDc, remoteClient, dcLogger); recResult.Completed() {
+					return recResult, actualDcs
+				}
+			}
 
 			if actualDc.Spec.Stopped {
 				if !cassandra.DatacenterStopped(actualDc) {
@@ -178,7 +346,12 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 					return result.Done(), actualDcs
 				}
 			} else {
-				if !cassandra.DatacenterReady(actualDc) {
+				ready, err := cassandra.CheckDatacenterReady(ctx, actualDc, dcConfig.ReadinessPolicy, remoteClient)
+				if err != nil {
+					dcLogger.Error(err, "Failed to check the readiness of the datacenter")
+					return result.Error(err), actualDcs
+				}
+				if !ready {
 					dcLogger.Info("Waiting for datacenter to satisfy Ready condition")
 					return result.Done(), actualDcs
 				}
@@ -196,11 +369,16 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 
 			if !actualDc.Spec.Stopped {
 
-				if recResult := r.checkSchemas(ctx, kc, actualDc, remoteClient, dcLogger); recResult.Completed() {
-					return recResult, actualDcs
+				if coordinatorDc == nil {
+					coordinatorDc, coordinatorClient = actualDc, remoteClient
 				}
 
-				if annotations.HasAnnotationWithValue(kc, api.RebuildDcAnnotation, dcKey.Name) {
+				if recResult := r.checkSchemas(ctx, kc, actualDc, coordinatorDc, coordinatorClient, dcLogger); recResult.Completed() {
+					if !deferManagementApiSteps(kc, dcKey.Name, recResult, dcLogger) {
+						return recResult, actualDcs
+					}
+					mgmtApiDeferredDcs = append(mgmtApiDeferredDcs, dcKey.Name)
+				} else if annotations.HasAnnotationWithValue(kc, api.RebuildDcAnnotation, dcKey.Name) {
 					if recResult := r.reconcileDcRebuild(ctx, kc, actualDc, remoteClient, dcLogger); recResult.Completed() {
 						return recResult, actualDcs
 					}
@@ -208,16 +386,7 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 			}
 		} else {
 			if errors.IsNotFound(err) {
-				if annotations.HasAnnotationWithValue(kc, api.RebuildDcAnnotation, dcKey.Name) && desiredDc.Spec.Stopped {
-					err := fmt.Errorf("cannot add a datacenter in stopped state to an existing cluster")
-					return result.Error(err), actualDcs
-				}
-				// cassdc doesn't exist, we'll create it
-				if err = remoteClient.Create(ctx, desiredDc); err != nil {
-					dcLogger.Error(err, "Failed to create datacenter")
-					return result.Error(err), actualDcs
-				}
-				return result.RequeueSoon(r.DefaultDelay), actualDcs
+				return r.reconcileMissingDatacenter(ctx, kc, desiredDc, remoteClient, dcLogger), actualDcs
 			} else {
 				dcLogger.Error(err, "Failed to get datacenter")
 				return result.Error(err), actualDcs
@@ -225,20 +394,119 @@ func (r *K8ssandraClusterReconciler) reconcileDatacenters(ctx context.Context, k
 		}
 	}
 
+	kc.Status.ResumeDatacenter = ""
+	setCanaryUpgradeCondition(kc, canaryDcs)
+	setManagementApiUnreachableCondition(kc, mgmtApiDeferredDcs)
+
+	if skippedDcs > 0 {
+		logger.Info("Waiting for the end of the maintenance of the contexts of datacenters", "SkippedDatacenters", skippedDcs)
+		return result.RequeueSoon(r.DefaultDelay), actualDcs
+	}
+
+	if seedsMissing {
+		return result.RequeueSoon(r.DefaultDelay), actualDcs
+	}
+
+	clearScalingDeferredCondition(kc)
+
+	if kc.Status.GetConditionStatus(api.DatacenterMissing) == corev1.ConditionTrue {
+		kc.Status.SetConditionStatus(api.DatacenterMissing, corev1.ConditionFalse, "")
+	}
+
 	// If we reach this point all CassandraDatacenters are ready. We only set the
 	// CassandraInitialized condition if it is unset, i.e., only once. This allows us to
 	// distinguish whether we are deploying a CassandraDatacenter as part of a new cluster
 	// or as part of an existing cluster.
 	if kc.Status.GetConditionStatus(api.CassandraInitialized) == corev1.ConditionUnknown {
-		now := metav1.Now()
-		kc.Status.SetCondition(api.K8ssandraClusterCondition{
-			Type:               api.CassandraInitialized,
-			Status:             corev1.ConditionTrue,
-			LastTransitionTime: &now,
-		})
+		kc.Status.SetConditionStatus(api.CassandraInitialized, corev1.ConditionTrue, "")
 	}
 
-	return result.Continue(), actualDcs
+	if recResult := r.recordMigration(ctx, kc); recResult.Completed() {
+		return recResult, actualDcs
+	}
+
+	return result.Continue(), sortDatacentersBySpec(kc, actualDcs)
+}
+
+// datacenterTemplate returns the template of the DC named dcName in the spec of kc, or nil if there is none.
+func datacenterTemplate(kc *api.K8ssandraCluster, dcName string) *api.CassandraDatacenterTemplate {
+	for i := range kc.Spec.Cassandra.Datacenters {
+		if kc.Spec.Cassandra.Datacenters[i].Meta.Name == dcName {
+			return &kc.Spec.Cassandra.Datacenters[i]
+		}
+	}
+	return nil
+}
+
+// sortDatacentersBySpec returns dcs in the order of their templates in the spec of kc, regardless of the order they
+// were reconciled in, so that they can be paired with the templates by index.
+func sortDatacentersBySpec(kc *api.K8ssandraCluster, dcs []*cassdcapi.CassandraDatacenter) []*cassdcapi.CassandraDatacenter {
+	positions := make(map[string]int, len(kc.Spec.Cassandra.Datacenters))
+	for i, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		positions[dcTemplate.Meta.Name] = i
+	}
+	sorted := make([]*cassdcapi.CassandraDatacenter, len(dcs))
+	copy(sorted, dcs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return positions[sorted[i].Name] < positions[sorted[j].Name]
+	})
+	return sorted
+}
+
+// deferDatacenterUpdate returns true if the update of actualDc must be postponed because the ConcurrentOperationPolicy
+// is Defer and cass-operator is performing an operation on the datacenter.
+func deferDatacenterUpdate(kc *api.K8ssandraCluster, actualDc *cassdcapi.CassandraDatacenter) bool {
+	return kc.Spec.Cassandra.ConcurrentOperationPolicy == api.ConcurrentOperationPolicyDefer &&
+		cassandra.DatacenterOperationInProgress(actualDc)
+}
+
+// reconcileMissingDatacenter handles a CassandraDatacenter that does not exist. New datacenters are created. A
+// datacenter that was previously created by the operator and has since disappeared is recreated as well, unless the
+// DeletedDatacenterPolicy is Report, in which case the DatacenterMissing condition is set and nothing is created.
+func (r *K8ssandraClusterReconciler) reconcileMissingDatacenter(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	desiredDc *cassdcapi.CassandraDatacenter,
+	remoteClient client.Client,
+	logger logr.Logger,
+) result.ReconcileResult {
+	if datacenterDeletedExternally(kc, desiredDc.Name) {
+		if kc.Spec.Cassandra.DeletedDatacenterPolicy == api.DeletedDatacenterPolicyReport {
+			logger.Info("Datacenter is missing and will not be recreated because of the deleted datacenter policy")
+			if kc.Status.GetConditionStatus(api.DatacenterMissing) != corev1.ConditionTrue {
+				kc.Status.SetConditionStatus(api.DatacenterMissing, corev1.ConditionTrue,
+					fmt.Sprintf("CassandraDatacenter %s was deleted and will not be recreated", utils.GetKey(desiredDc)))
+			}
+			return result.RequeueSoon(r.LongDelay)
+		}
+		logger.Info("Datacenter is missing, recreating it")
+	}
+
+	if annotations.HasAnnotationWithValue(kc, api.RebuildDcAnnotation, desiredDc.Name) && desiredDc.Spec.Stopped {
+		err := fmt.Errorf("cannot add a datacenter in stopped state to an existing cluster")
+		return result.Error(err)
+	}
+	// cassdc doesn't exist, we'll create it
+	if err := remoteClient.Create(ctx, desiredDc); err != nil {
+		if kerrors.IsWebhookUnavailable(err) {
+			logger.Info("cass-operator webhook is unavailable, will retry creating the datacenter", "Error", err.Error())
+			return result.RequeueSoon(r.WebhookUnavailableDelay)
+		}
+		if recResult := r.handleSpecRejection(kc, desiredDc.Name, err, logger); recResult != nil {
+			return recResult
+		}
+		logger.Error(err, "Failed to create datacenter")
+		return result.Error(err)
+	}
+	clearDatacenterSpecRejectedCondition(kc, desiredDc.Name)
+	return result.RequeueSoon(r.DefaultDelay)
+}
+
+// datacenterDeletedExternally returns true if the datacenter was created and observed by the operator in the past,
+// which is the case when it already has a Cassandra status in the K8ssandraCluster.
+func datacenterDeletedExternally(kc *api.K8ssandraCluster, dcName string) bool {
+	dcStatus, found := kc.Status.Datacenters[dcName]
+	return found && dcStatus.Cassandra != nil
 }
 
 func (r *K8ssandraClusterReconciler) setStatusForDatacenter(kc *api.K8ssandraCluster, dc *cassdcapi.CassandraDatacenter) {
@@ -257,6 +525,22 @@ func (r *K8ssandraClusterReconciler) setStatusForDatacenter(kc *api.K8ssandraClu
 	}
 }
 
+// setEffectiveConfigStatus reports the summary of the configuration applied to dc in the status of its datacenter. A
+// configuration that can not be parsed is only logged, since it is validated before being applied.
+func setEffectiveConfigStatus(kc *api.K8ssandraCluster, dc *cassdcapi.CassandraDatacenter, logger logr.Logger) {
+	dcStatus, found := kc.Status.Datacenters[dc.Name]
+	if !found {
+		return
+	}
+	summary, err := cassandra.EffectiveConfigSummary(dc)
+	if err != nil {
+		logger.Error(err, "Failed to summarize the configuration of the datacenter")
+		return
+	}
+	dcStatus.EffectiveConfig = summary
+	kc.Status.Datacenters[dc.Name] = dcStatus
+}
+
 func datacenterAddedToExistingCluster(kc *api.K8ssandraCluster, dcName string) bool {
 	_, found := kc.Status.Datacenters[dcName]
 	// Only request rebuild for the datacenter if it's not already in the cluster and if we have at least one datacenter already initialized.
@@ -345,6 +629,10 @@ func (r *K8ssandraClusterReconciler) reconcileDcRebuild(
 		if errors.IsNotFound(err) {
 			logger.Info("Creating rebuild task", "Task", taskKey)
 			if err = remoteClient.Create(ctx, desiredTask); err != nil {
+				if kerrors.IsWebhookUnavailable(err) {
+					logger.Info("cass-operator webhook is unavailable, will retry creating the rebuild task", "Task", taskKey, "Error", err.Error())
+					return result.RequeueSoon(r.WebhookUnavailableDelay)
+				}
 				logger.Error(err, "Failed to create rebuild task", "Task", taskKey)
 				return result.Error(err)
 			}
@@ -1,12 +1,30 @@
 package k8ssandra
 
 import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
 	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr/testr"
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/config"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
 	"github.com/stretchr/testify/assert"
-	"testing"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
@@ -135,3 +153,415 @@ func sortNoChangeTest(t *testing.T) {
 	assert.Equal("dc2", sortedDatacenters[1].Meta.Name, "Datacenter order should not change")
 	assert.Equal("dc3", sortedDatacenters[2].Meta.Name, "Datacenter order should not change")
 }
+
+func TestReconcileMissingDatacenter(t *testing.T) {
+	r := &K8ssandraClusterReconciler{
+		ReconcilerConfig: &config.ReconcilerConfig{DefaultDelay: time.Second, LongDelay: time.Minute},
+	}
+	newKc := func(policy api.DeletedDatacenterPolicy, observedDcs ...string) *api.K8ssandraCluster {
+		kc := &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					DeletedDatacenterPolicy: policy,
+					Datacenters:             []api.CassandraDatacenterTemplate{{Meta: api.EmbeddedObjectMeta{Name: "dc1"}}},
+				},
+			},
+		}
+		if len(observedDcs) > 0 {
+			kc.Status.Datacenters = map[string]api.K8ssandraStatus{}
+			for _, dc := range observedDcs {
+				kc.Status.Datacenters[dc] = api.K8ssandraStatus{Cassandra: &cassdcapi.CassandraDatacenterStatus{}}
+			}
+		}
+		return kc
+	}
+	newDc := func() *cassdcapi.CassandraDatacenter {
+		return &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"}}
+	}
+	dcExists := func(t *testing.T, remoteClient client.Client) bool {
+		err := remoteClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "dc1"}, &cassdcapi.CassandraDatacenter{})
+		if errors.IsNotFound(err) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+
+	tests := []struct {
+		name          string
+		kc            *api.K8ssandraCluster
+		wantResult    result.ReconcileResult
+		wantCreated   bool
+		wantCondition corev1.ConditionStatus
+	}{
+		{
+			name:          "new datacenter is created regardless of policy",
+			kc:            newKc(api.DeletedDatacenterPolicyReport),
+			wantResult:    result.RequeueSoon(time.Second),
+			wantCreated:   true,
+			wantCondition: corev1.ConditionUnknown,
+		},
+		{
+			name:          "deleted datacenter is recreated by default",
+			kc:            newKc("", "dc1"),
+			wantResult:    result.RequeueSoon(time.Second),
+			wantCreated:   true,
+			wantCondition: corev1.ConditionUnknown,
+		},
+		{
+			name:          "deleted datacenter is recreated with Recreate policy",
+			kc:            newKc(api.DeletedDatacenterPolicyRecreate, "dc1"),
+			wantResult:    result.RequeueSoon(time.Second),
+			wantCreated:   true,
+			wantCondition: corev1.ConditionUnknown,
+		},
+		{
+			name:          "deleted datacenter is reported with Report policy",
+			kc:            newKc(api.DeletedDatacenterPolicyReport, "dc1"),
+			wantResult:    result.RequeueSoon(time.Minute),
+			wantCreated:   false,
+			wantCondition: corev1.ConditionTrue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remoteClient, err := test.NewFakeClient()
+			require.NoError(t, err)
+
+			got := r.reconcileMissingDatacenter(context.Background(), tt.kc, newDc(), remoteClient, testr.New(t))
+
+			assert.Equal(t, tt.wantResult, got)
+			assert.Equal(t, tt.wantCreated, dcExists(t, remoteClient))
+			assert.Equal(t, tt.wantCondition, tt.kc.Status.GetConditionStatus(api.DatacenterMissing))
+		})
+	}
+}
+
+// failingCreateClient fails all Create calls with err.
+type failingCreateClient struct {
+	client.Client
+	err error
+}
+
+func (c *failingCreateClient) Create(context.Context, client.Object, ...client.CreateOption) error {
+	return c.err
+}
+
+func TestReconcileMissingDatacenterWebhookUnavailable(t *testing.T) {
+	r := &K8ssandraClusterReconciler{
+		ReconcilerConfig: &config.ReconcilerConfig{DefaultDelay: time.Second, LongDelay: time.Minute, WebhookUnavailableDelay: 30 * time.Second},
+	}
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{{Meta: api.EmbeddedObjectMeta{Name: "dc1"}}},
+			},
+		},
+	}
+	newDc := func() *cassdcapi.CassandraDatacenter {
+		return &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"}}
+	}
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	t.Run("webhook unavailable", func(t *testing.T) {
+		remoteClient := &failingCreateClient{Client: fakeClient, err: errors.NewInternalError(fmt.Errorf(
+			`failed calling webhook "vcassandradatacenter.kb.io": failed to call webhook: Post "https://cass-operator-webhook-service.default.svc:443/validate-cassandra-datacenter": dial tcp 10.96.10.10:443: connect: connection refused`))}
+
+		got := r.reconcileMissingDatacenter(context.Background(), kc, newDc(), remoteClient, testr.New(t))
+
+		assert.Equal(t, result.RequeueSoon(30*time.Second), got)
+	})
+
+	t.Run("other internal error", func(t *testing.T) {
+		remoteClient := &failingCreateClient{Client: fakeClient, err: errors.NewInternalError(fmt.Errorf("etcdserver: request timed out"))}
+
+		got := r.reconcileMissingDatacenter(context.Background(), kc, newDc(), remoteClient, testr.New(t))
+
+		assert.True(t, got.Completed())
+		_, err := got.Output()
+		assert.Error(t, err)
+	})
+}
+
+func TestReconcileMissingDatacenterSpecRejected(t *testing.T) {
+	r := &K8ssandraClusterReconciler{
+		ReconcilerConfig: &config.ReconcilerConfig{DefaultDelay: time.Second, LongDelay: time.Minute, SpecRejectedDelay: 5 * time.Minute},
+	}
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					Datacenters: []api.CassandraDatacenterTemplate{{Meta: api.EmbeddedObjectMeta{Name: "dc1"}}},
+				},
+			},
+		}
+	}
+	newDc := func() *cassdcapi.CassandraDatacenter {
+		return &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"}}
+	}
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	t.Run("invalid field", func(t *testing.T) {
+		kc := newKc()
+		remoteClient := &failingCreateClient{Client: fakeClient, err: errors.NewInvalid(
+			schema.GroupKind{Group: "cassandra.datastax.com", Kind: "CassandraDatacenter"}, "dc1",
+			field.ErrorList{field.Invalid(field.NewPath("spec", "serverVersion"), "3.0.0", "unsupported version")})}
+
+		got := r.reconcileMissingDatacenter(context.Background(), kc, newDc(), remoteClient, testr.New(t))
+
+		assert.Equal(t, result.RequeueSoon(5*time.Minute), got)
+		condition, found := kc.Status.GetCondition(api.DatacenterSpecRejected)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Contains(t, condition.Message, "CassandraDatacenter dc1 was rejected because of fields spec.serverVersion:")
+		assert.Contains(t, condition.Message, "unsupported version")
+
+		// The condition is cleared once the datacenter is accepted
+		got = r.reconcileMissingDatacenter(context.Background(), kc, newDc(), fakeClient, testr.New(t))
+		assert.Equal(t, result.RequeueSoon(time.Second), got)
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.DatacenterSpecRejected))
+	})
+
+	t.Run("denied by webhook", func(t *testing.T) {
+		kc := newKc()
+		remoteClient := &failingCreateClient{Client: fakeClient, err: errors.NewForbidden(
+			schema.GroupResource{Group: "cassandra.datastax.com", Resource: "cassandradatacenters"}, "dc1",
+			fmt.Errorf(`admission webhook "vcassandradatacenter.kb.io" denied the request: CassandraDatacenter write rejected, attempted to change serverType`))}
+
+		got := r.reconcileMissingDatacenter(context.Background(), kc, newDc(), remoteClient, testr.New(t))
+
+		assert.Equal(t, result.RequeueSoon(5*time.Minute), got)
+		condition, found := kc.Status.GetCondition(api.DatacenterSpecRejected)
+		require.True(t, found)
+		assert.Contains(t, condition.Message, "attempted to change serverType")
+	})
+
+	t.Run("other forbidden error", func(t *testing.T) {
+		kc := newKc()
+		remoteClient := &failingCreateClient{Client: fakeClient, err: errors.NewForbidden(
+			schema.GroupResource{Group: "cassandra.datastax.com", Resource: "cassandradatacenters"}, "dc1",
+			fmt.Errorf("user cannot create resource"))}
+
+		got := r.reconcileMissingDatacenter(context.Background(), kc, newDc(), remoteClient, testr.New(t))
+
+		_, err := got.Output()
+		assert.Error(t, err)
+		assert.Equal(t, corev1.ConditionUnknown, kc.Status.GetConditionStatus(api.DatacenterSpecRejected))
+	})
+}
+
+func TestCreateDatacenterConfigsIncomplete(t *testing.T) {
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	r.SpecRejectedDelay = 5 * time.Minute
+	newKc := func(serverVersion string, size int32) *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					DatacenterOptions: api.DatacenterOptions{
+						ServerVersion: serverVersion,
+						StorageConfig: &cassdcapi.StorageConfig{},
+					},
+					Datacenters: []api.CassandraDatacenterTemplate{{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, Size: size}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		kc           *api.K8ssandraCluster
+		missingField string
+	}{
+		{name: "zero size", kc: newKc("4.0.6", 0), missingField: "template.Size"},
+		{name: "empty version", kc: newKc("", 3), missingField: "template.ServerVersion"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.createDatacenterConfigs(context.Background(), tt.kc, testr.New(t), cassandra.SystemReplication{})
+			require.Error(t, err)
+
+			got := r.handleIncompleteDatacenter(tt.kc, err, testr.New(t))
+
+			assert.Equal(t, result.RequeueSoon(5*time.Minute), got)
+			condition, found := tt.kc.Status.GetCondition(api.DatacenterSpecRejected)
+			require.True(t, found)
+			assert.Equal(t, corev1.ConditionTrue, condition.Status)
+			assert.Contains(t, condition.Message, "CassandraDatacenter dc1 was rejected:")
+			assert.Contains(t, condition.Message, "missing field "+tt.missingField)
+		})
+	}
+
+	t.Run("other error", func(t *testing.T) {
+		kc := newKc("4.0.6", 3)
+		assert.Nil(t, r.handleIncompleteDatacenter(kc, fmt.Errorf("connection refused"), testr.New(t)))
+		assert.Equal(t, corev1.ConditionUnknown, kc.Status.GetConditionStatus(api.DatacenterSpecRejected))
+	})
+}
+
+func TestCreateDatacenterConfigsDivergentAuth(t *testing.T) {
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				DatacenterOptions: api.DatacenterOptions{
+					ServerVersion: "4.0.6",
+					StorageConfig: &cassdcapi.StorageConfig{},
+				},
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, Size: 3},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, Size: 3, DatacenterOptions: api.DatacenterOptions{
+						CassandraConfig: &api.CassandraConfig{CassandraYaml: unstructured.Unstructured{"authenticator": "AllowAllAuthenticator"}},
+					}},
+				},
+			},
+		},
+	}
+
+	// auth is changed one DC at a time, so the clusters whose DCs diverge are still reconciled
+	dcConfigs, err := r.createDatacenterConfigs(context.Background(), kc, testr.New(t), cassandra.SystemReplication{})
+	require.NoError(t, err)
+	assert.Len(t, dcConfigs, 2)
+	condition, found := kc.Status.GetCondition(api.AuthSettingsDiverged)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "datacenter dc1 uses authenticator PasswordAuthenticator while datacenter dc2 uses AllowAllAuthenticator")
+
+	kc.Spec.Cassandra.Datacenters[1].CassandraConfig = nil
+	_, err = r.createDatacenterConfigs(context.Background(), kc, testr.New(t), cassandra.SystemReplication{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.AuthSettingsDiverged))
+}
+
+func TestCreateDatacenterConfigsSoftPodAntiAffinity(t *testing.T) {
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				DatacenterOptions: api.DatacenterOptions{
+					ServerVersion: "4.0.6",
+					StorageConfig: &cassdcapi.StorageConfig{},
+				},
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, Size: 3},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, Size: 3},
+				},
+			},
+		},
+	}
+
+	_, err = r.createDatacenterConfigs(context.Background(), kc, testr.New(t), cassandra.SystemReplication{})
+	require.NoError(t, err)
+	_, found := kc.Status.GetCondition(api.MultipleNodesPerWorkerAllowed)
+	assert.False(t, found)
+
+	// enabled cluster-wide, and disabled again in dc2
+	kc.Spec.Cassandra.SoftPodAntiAffinity = pointer.Bool(true)
+	kc.Spec.Cassandra.Datacenters[1].SoftPodAntiAffinity = pointer.Bool(false)
+	_, err = r.createDatacenterConfigs(context.Background(), kc, testr.New(t), cassandra.SystemReplication{})
+	require.NoError(t, err)
+	condition, found := kc.Status.GetCondition(api.MultipleNodesPerWorkerAllowed)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "not suitable for production, in datacenters dc1")
+	assert.NotContains(t, condition.Message, "dc2")
+	assert.Len(t, recorder.Events, 1)
+
+	// the event is not repeated on every reconcile
+	_, err = r.createDatacenterConfigs(context.Background(), kc, testr.New(t), cassandra.SystemReplication{})
+	require.NoError(t, err)
+	assert.Len(t, recorder.Events, 1)
+
+	kc.Spec.Cassandra.SoftPodAntiAffinity = nil
+	_, err = r.createDatacenterConfigs(context.Background(), kc, testr.New(t), cassandra.SystemReplication{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.MultipleNodesPerWorkerAllowed))
+}
+
+func TestDeferDatacenterUpdate(t *testing.T) {
+	readyDc := &cassdcapi.CassandraDatacenter{
+		Status: cassdcapi.CassandraDatacenterStatus{
+			CassandraOperatorProgress: cassdcapi.ProgressReady,
+			Conditions: []cassdcapi.DatacenterCondition{
+				*cassdcapi.NewDatacenterCondition(cassdcapi.DatacenterReady, corev1.ConditionTrue),
+				*cassdcapi.NewDatacenterCondition(cassdcapi.DatacenterRollingRestart, corev1.ConditionFalse),
+			},
+		},
+	}
+	updatingDc := &cassdcapi.CassandraDatacenter{
+		Status: cassdcapi.CassandraDatacenterStatus{CassandraOperatorProgress: cassdcapi.ProgressUpdating},
+	}
+	scalingDc := &cassdcapi.CassandraDatacenter{
+		Status: cassdcapi.CassandraDatacenterStatus{
+			CassandraOperatorProgress: cassdcapi.ProgressReady,
+			Conditions: []cassdcapi.DatacenterCondition{
+				*cassdcapi.NewDatacenterCondition(cassdcapi.DatacenterScalingUp, corev1.ConditionTrue),
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		policy api.ConcurrentOperationPolicy
+		dc     *cassdcapi.CassandraDatacenter
+		want   bool
+	}{
+		{"default policy, operation in progress", "", updatingDc, false},
+		{"apply, operation in progress", api.ConcurrentOperationPolicyApply, updatingDc, false},
+		{"defer, no operation in progress", api.ConcurrentOperationPolicyDefer, readyDc, false},
+		{"defer, cass-operator updating", api.ConcurrentOperationPolicyDefer, updatingDc, true},
+		{"defer, scaling up", api.ConcurrentOperationPolicyDefer, scalingDc, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc := &api.K8ssandraCluster{
+				Spec: api.K8ssandraClusterSpec{
+					Cassandra: &api.CassandraClusterTemplate{ConcurrentOperationPolicy: tt.policy},
+				},
+			}
+			assert.Equal(t, tt.want, deferDatacenterUpdate(kc, tt.dc))
+		})
+	}
+}
+
+func TestSetEffectiveConfigStatus(t *testing.T) {
+	logger := testr.New(t)
+	kc := &api.K8ssandraCluster{}
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Name: "dc1"},
+		Spec: cassdcapi.CassandraDatacenterSpec{
+			Config: []byte(`{"cassandra-yaml":{"num_tokens":16},"jvm-server-options":{"initial_heap_size":"1G"}}`),
+		},
+	}
+
+	// nothing is reported until the status of the datacenter is known
+	setEffectiveConfigStatus(kc, dc, logger)
+	assert.Empty(t, kc.Status.Datacenters)
+
+	r := &K8ssandraClusterReconciler{}
+	r.setStatusForDatacenter(kc, dc)
+	setEffectiveConfigStatus(kc, dc, logger)
+	effectiveConfig := kc.Status.Datacenters["dc1"].EffectiveConfig
+	require.NotNil(t, effectiveConfig)
+	assert.NotEmpty(t, effectiveConfig.Hash)
+	assert.Equal(t, map[string]string{
+		"cassandra-yaml.num_tokens":            "16",
+		"jvm-server-options.initial_heap_size": "1G",
+	}, effectiveConfig.Settings)
+	assert.NotNil(t, kc.Status.Datacenters["dc1"].Cassandra)
+}
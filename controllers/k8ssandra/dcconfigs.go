@@ -2,6 +2,8 @@ package k8ssandra
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
@@ -9,6 +11,7 @@ import (
 	"github.com/k8ssandra/k8ssandra-operator/pkg/reaper"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/telemetry"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -23,6 +26,15 @@ func (r *K8ssandraClusterReconciler) createDatacenterConfigs(
 	systemReplication cassandra.SystemReplication,
 ) ([]*cassandra.DatacenterConfig, error) {
 
+	// The webhook may be disabled, or the maximum lowered after the cluster was admitted, in which case the cluster is
+	// only rejected if it grows past the nodes it already has.
+	if err := api.ValidateClusterSize(kc, deployedClusterSize(kc), r.MaxClusterSize); err != nil {
+		return nil, err
+	}
+	if err := api.ValidateSeedPropagationQuorum(kc); err != nil {
+		return nil, err
+	}
+
 	kcKey := utils.GetKey(kc)
 	var dcConfigs []*cassandra.DatacenterConfig
 
@@ -31,6 +43,11 @@ func (r *K8ssandraClusterReconciler) createDatacenterConfigs(
 		dcConfig := cassandra.Coalesce(kc.CassClusterName(), kc.Spec.Cassandra.DeepCopy(), dcTemplate.DeepCopy())
 		dcConfig.ExternalSecrets = kc.Spec.UseExternalSecrets()
 
+		// The config is completed below based on the required fields, check them first.
+		if err := cassandra.ValidateRequiredFields(dcConfig); err != nil {
+			return nil, err
+		}
+
 		dcKey := types.NamespacedName{Namespace: utils.FirstNonEmptyString(dcConfig.Meta.Namespace, kcKey.Namespace), Name: dcConfig.Meta.Name}
 		dcLogger := logger.WithValues("CassandraDatacenter", dcKey, "K8SContext", dcConfig.K8sContext)
 
@@ -92,10 +109,38 @@ func (r *K8ssandraClusterReconciler) createDatacenterConfigs(
 		if err := cassandra.ValidateDatacenterConfig(dcConfig); err != nil {
 			return nil, err
 		}
+		for _, warning := range cassandra.ResourceWarnings(dcConfig) {
+			dcLogger.Info("Problematic datacenter resources", "Warning", warning)
+		}
+		for _, warning := range cassandra.DeprecatedSettingWarnings(dcConfig) {
+			dcLogger.Info("Deprecated Cassandra setting", "Warning", warning)
+		}
+		cassandra.ApplyHintsTuning(dcConfig)
+		cassandra.ApplyMaterializedViews(dcConfig)
+		cassandra.ApplyMemtableAndCacheTuning(dcConfig)
+		cassandra.ApplySnitch(dcConfig)
+		cassandra.ApplyDiskFailurePolicies(dcConfig)
+		cassandra.ApplyRequestTimeouts(dcConfig)
+		cassandra.ApplyStreamingEncryption(dcConfig)
+		cassandra.ApplyAuditLogging(dcConfig)
+		cassandra.ApplyChangeDataCapture(dcConfig)
 
 		dcConfigs = append(dcConfigs, dcConfig)
 	}
 
+	if err := cassandra.ValidateSnitchConsistency(dcConfigs); err != nil {
+		return nil, err
+	}
+	authWarnings := cassandra.AuthConsistencyWarnings(dcConfigs)
+	if len(authWarnings) > 0 {
+		logger.Info("The datacenters use different auth settings", "Warnings", authWarnings)
+	}
+	setAuthSettingsDivergedCondition(kc, authWarnings)
+	r.setMultipleNodesPerWorkerAllowedCondition(kc, dcConfigs, logger)
+	for _, warning := range cassandra.RackZoneWarnings(dcConfigs) {
+		logger.Info("Inconsistent rack zones", "Warning", warning)
+	}
+
 	err := cassandra.ComputeInitialTokens(dcConfigs)
 	if err != nil {
 		logger.Info("Initial token computation could not be performed or is not required in this cluster", "error", err)
@@ -103,3 +148,60 @@ func (r *K8ssandraClusterReconciler) createDatacenterConfigs(
 
 	return dcConfigs, nil
 }
+
+// setAuthSettingsDivergedCondition sets the AuthSettingsDiverged condition to true if there are warnings, and to false
+// otherwise. The condition is not added until the settings first diverge.
+func setAuthSettingsDivergedCondition(kc *api.K8ssandraCluster, warnings []string) {
+	if _, found := kc.Status.GetCondition(api.AuthSettingsDiverged); !found && len(warnings) == 0 {
+		return
+	}
+	status := corev1.ConditionFalse
+	message := ""
+	if len(warnings) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("The datacenters use different auth settings: %s", strings.Join(warnings, "; "))
+	}
+	kc.Status.SetConditionStatus(api.AuthSettingsDiverged, status, message)
+}
+
+// setMultipleNodesPerWorkerAllowedCondition sets the MultipleNodesPerWorkerAllowed condition to true if soft pod
+// anti-affinity is enabled in some of dcConfigs, and to false otherwise. A warning event is recorded when the list of
+// datacenters changes. The condition is not added until soft pod anti-affinity is first enabled.
+func (r *K8ssandraClusterReconciler) setMultipleNodesPerWorkerAllowedCondition(kc *api.K8ssandraCluster, dcConfigs []*cassandra.DatacenterConfig, logger logr.Logger) {
+	dcNames := make([]string, 0)
+	for _, dcConfig := range dcConfigs {
+		if dcConfig.SoftPodAntiAffinity != nil && *dcConfig.SoftPodAntiAffinity {
+			dcNames = append(dcNames, dcConfig.Meta.Name)
+		}
+	}
+
+	condition, found := kc.Status.GetCondition(api.MultipleNodesPerWorkerAllowed)
+	if !found && len(dcNames) == 0 {
+		return
+	}
+	status := corev1.ConditionFalse
+	message := ""
+	if len(dcNames) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("softPodAntiAffinity allows multiple Cassandra nodes per worker, which is not suitable for production, in datacenters %s",
+			strings.Join(dcNames, ", "))
+		if condition.Message != message {
+			logger.Info("Soft pod anti-affinity is enabled, which is not suitable for production", "DCs", dcNames)
+			if r.Recorder != nil {
+				r.Recorder.Event(kc, corev1.EventTypeWarning, "MultipleNodesPerWorkerAllowed", message)
+			}
+		}
+	}
+	kc.Status.SetConditionStatus(api.MultipleNodesPerWorkerAllowed, status, message)
+}
+
+// deployedClusterSize returns the number of nodes of the datacenters of kc, as reported in its status.
+func deployedClusterSize(kc *api.K8ssandraCluster) int {
+	size := 0
+	for _, dcStatus := range kc.Status.Datacenters {
+		if dcStatus.Cassandra != nil {
+			size += len(dcStatus.Cassandra.NodeStatuses)
+		}
+	}
+	return size
+}
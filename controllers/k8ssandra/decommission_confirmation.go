@@ -0,0 +1,29 @@
+package k8ssandra
+
+import (
+	"fmt"
+
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// decommissionConfirmed returns true if the DC can be decommissioned, which is the case when confirmations are not
+// required, or when the DecommissionDcAnnotation names the DC.
+func decommissionConfirmed(kc *api.K8ssandraCluster, dcName string) bool {
+	return !kc.Spec.Cassandra.RequireDecommissionConfirmation ||
+		annotations.HasAnnotationWithValue(kc, api.DecommissionDcAnnotation, dcName)
+}
+
+func setDecommissionConfirmationCondition(kc *api.K8ssandraCluster, dcName string) {
+	message := fmt.Sprintf("Datacenter %s was removed from the spec, annotate the K8ssandraCluster with %s=%s to decommission it",
+		dcName, api.DecommissionDcAnnotation, dcName)
+	kc.Status.SetConditionStatus(api.DecommissionConfirmationRequired, corev1.ConditionTrue, message)
+}
+
+func clearDecommissionConfirmationCondition(kc *api.K8ssandraCluster) {
+	if kc.Status.GetConditionStatus(api.DecommissionConfirmationRequired) != corev1.ConditionTrue {
+		return
+	}
+	kc.Status.SetConditionStatus(api.DecommissionConfirmationRequired, corev1.ConditionFalse, "")
+}
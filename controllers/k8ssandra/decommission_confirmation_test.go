@@ -0,0 +1,92 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckDcDeletionConfirmation(t *testing.T) {
+	ctx := context.Background()
+	logger := testr.New(t)
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster1"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				RequireDecommissionConfirmation: true,
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+				},
+			},
+		},
+		Status: api.K8ssandraClusterStatus{
+			Datacenters: map[string]api.K8ssandraStatus{"dc1": {}, "dc2": {}},
+		},
+	}
+
+	fakeClient, err := test.NewFakeClient(kc)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	// without the annotation, the decommission waits for a confirmation
+	assert.Equal(t, result.Continue(), r.checkDcDeletion(ctx, kc, logger))
+	assert.Equal(t, api.DecommNone, kc.Status.Datacenters["dc2"].DecommissionProgress)
+	condition, found := kc.Status.GetCondition(api.DecommissionConfirmationRequired)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "dc2")
+
+	// an annotation naming another DC does not confirm the decommission
+	kc.Annotations = map[string]string{api.DecommissionDcAnnotation: "dc3"}
+	assert.Equal(t, result.Continue(), r.checkDcDeletion(ctx, kc, logger))
+	assert.Equal(t, api.DecommNone, kc.Status.Datacenters["dc2"].DecommissionProgress)
+	assert.Equal(t, corev1.ConditionTrue, kc.Status.GetConditionStatus(api.DecommissionConfirmationRequired))
+
+	// the matching annotation starts the decommission
+	kc.Annotations = map[string]string{api.DecommissionDcAnnotation: "dc2"}
+	require.NoError(t, fakeClient.Update(ctx, kc))
+	assert.Equal(t, result.Continue(), r.checkDcDeletion(ctx, kc, logger))
+	assert.Equal(t, api.DecommUpdatingReplication, kc.Status.Datacenters["dc2"].DecommissionProgress)
+	assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.DecommissionConfirmationRequired))
+
+	// the annotation is removed once the DC is gone
+	delete(kc.Status.Datacenters, "dc2")
+	assert.Equal(t, result.Continue(), r.checkDcDeletion(ctx, kc, logger))
+	actualKc := &api.K8ssandraCluster{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(kc), actualKc))
+	assert.NotContains(t, actualKc.Annotations, api.DecommissionDcAnnotation)
+}
+
+func TestCheckDcDeletionWithoutConfirmation(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster1"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+				},
+			},
+		},
+		Status: api.K8ssandraClusterStatus{
+			Datacenters: map[string]api.K8ssandraStatus{"dc1": {}, "dc2": {}},
+		},
+	}
+
+	fakeClient, err := test.NewFakeClient(kc)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	assert.Equal(t, result.Continue(), r.checkDcDeletion(context.Background(), kc, testr.New(t)))
+	assert.Equal(t, api.DecommUpdatingReplication, kc.Status.Datacenters["dc2"].DecommissionProgress)
+	_, found := kc.Status.GetCondition(api.DecommissionConfirmationRequired)
+	assert.False(t, found)
+}
@@ -0,0 +1,131 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkDiskUsage reports the highest data volume usage of each DC in the status of kc, and sets the DiskUsageHigh
+// condition when it exceeds the configured threshold. The usage of a node is estimated from the load it reports
+// through gossip, relative to the capacity requested for its data volume. Checks happen at most once per poll
+// interval. Failing to check the disk usage is logged but does not fail the reconcile.
+func (r *K8ssandraClusterReconciler) checkDiskUsage(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcs []*cassdcapi.CassandraDatacenter,
+	logger logr.Logger,
+) result.ReconcileResult {
+	monitoring := kc.Spec.Cassandra.DiskUsageMonitoring
+	if monitoring == nil || !monitoringCheckDue(kc, dcs, monitoring.GetPollInterval(), diskUsageLastCheckTime) {
+		return result.Continue()
+	}
+
+	states, err := r.coordinatorEndpointStates(ctx, kc, dcs, logger, "Failed to check disk usage")
+	if err != nil {
+		return result.Error(err)
+	}
+	if states == nil {
+		return result.Continue()
+	}
+
+	now := metav1.Now()
+	usages := computeDiskUsage(states, dcs)
+	for _, dc := range dcs {
+		usage, found := usages[dc.Name]
+		if !found {
+			continue
+		}
+		usage.LastCheckTime = &now
+		if kdcStatus, found := kc.Status.Datacenters[dc.Name]; found {
+			kdcStatus.DiskUsage = usage
+			kc.Status.Datacenters[dc.Name] = kdcStatus
+		}
+	}
+	setDiskUsageCondition(kc, usages, monitoring.GetThresholdPercent())
+	return result.Continue()
+}
+
+// diskUsageLastCheckTime returns the time the disk usage of the DC was last checked, or nil if it never was.
+func diskUsageLastCheckTime(status api.K8ssandraStatus) *metav1.Time {
+	if status.DiskUsage == nil {
+		return nil
+	}
+	return status.DiskUsage.LastCheckTime
+}
+
+// computeDiskUsage returns, for each of dcs, the usage of the node with the highest ratio of load to data volume
+// capacity. DCs for which the capacity is unknown, or for which no node reports its load, are omitted.
+func computeDiskUsage(states []httphelper.EndpointState, dcs []*cassdcapi.CassandraDatacenter) map[string]*api.DiskUsageStatus {
+	dcsByCassDcName := make(map[string]*cassdcapi.CassandraDatacenter, len(dcs))
+	for _, dc := range dcs {
+		dcsByCassDcName[dc.DatacenterName()] = dc
+	}
+
+	usages := make(map[string]*api.DiskUsageStatus)
+	for _, state := range states {
+		dc, found := dcsByCassDcName[state.Datacenter]
+		if !found {
+			continue
+		}
+		capacity := dataVolumeCapacity(dc)
+		load, err := strconv.ParseFloat(state.Load, 64)
+		if capacity <= 0 || err != nil {
+			continue
+		}
+		usedPercent := int32(load * 100 / float64(capacity))
+		if usage, found := usages[dc.Name]; !found || usedPercent > usage.UsedPercent {
+			usages[dc.Name] = &api.DiskUsageStatus{UsedPercent: usedPercent, Node: state.EndpointIP}
+		}
+	}
+	return usages
+}
+
+// dataVolumeCapacity returns the storage requested for the data volume of each node of dc, in bytes, or 0 if it is
+// not set.
+func dataVolumeCapacity(dc *cassdcapi.CassandraDatacenter) int64 {
+	if dc.Spec.StorageConfig.CassandraDataVolumeClaimSpec == nil {
+		return 0
+	}
+	return dc.Spec.StorageConfig.CassandraDataVolumeClaimSpec.Resources.Requests.Storage().Value()
+}
+
+// setDiskUsageCondition sets the DiskUsageHigh condition to true if the usage of at least one DC exceeds
+// thresholdPercent, and to false otherwise.
+func setDiskUsageCondition(kc *api.K8ssandraCluster, usages map[string]*api.DiskUsageStatus, thresholdPercent int32) {
+	highUsages := make([]string, 0)
+	for dcName, usage := range usages {
+		if usage.UsedPercent > thresholdPercent {
+			highUsages = append(highUsages, fmt.Sprintf("%s (node %s at %d%%)", dcName, usage.Node, usage.UsedPercent))
+		}
+	}
+	sort.Strings(highUsages)
+
+	status := corev1.ConditionFalse
+	message := ""
+	if len(highUsages) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("Disk usage exceeds %d%% in datacenters: %s", thresholdPercent, strings.Join(highUsages, ", "))
+	}
+	kc.Status.SetConditionStatus(api.DiskUsageHigh, status, message)
+}
+
+// getDatacenterK8sContext returns the k8s context of the DC named dcName.
+func getDatacenterK8sContext(kc *api.K8ssandraCluster, dcName string) string {
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if dcTemplate.Meta.Name == dcName {
+			return dcTemplate.K8sContext
+		}
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckDiskUsage(t *testing.T) {
+	const gi = 1024 * 1024 * 1024
+	newDc := func(name string) *cassdcapi.CassandraDatacenter {
+		return &cassdcapi.CassandraDatacenter{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: cassdcapi.CassandraDatacenterSpec{
+				StorageConfig: cassdcapi.StorageConfig{
+					CassandraDataVolumeClaimSpec: &corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				},
+			},
+		}
+	}
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					DiskUsageMonitoring: &api.DiskUsageMonitoring{ThresholdPercent: 70},
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+					},
+				},
+			},
+			Status: api.K8ssandraClusterStatus{
+				Datacenters: map[string]api.K8ssandraStatus{"dc1": {}, "dc2": {}},
+			},
+		}
+	}
+	dcs := []*cassdcapi.CassandraDatacenter{newDc("dc1"), newDc("dc2")}
+
+	newReconciler := func(t *testing.T, mgmtApi *test.FakeManagementApiFacade) *K8ssandraClusterReconciler {
+		fakeClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		factory := &test.FakeManagementApiFactory{}
+		factory.SetT(t)
+		factory.SetAdapter(func(context.Context, *cassdcapi.CassandraDatacenter, client.Client, logr.Logger) (cassandra.ManagementApiFacade, error) {
+			return mgmtApi, nil
+		})
+		r.ManagementApi = factory
+		return r
+	}
+	loads := func(dc1Load, dc2Load float64) []httphelper.EndpointState {
+		return []httphelper.EndpointState{
+			{Datacenter: "dc1", EndpointIP: "10.0.0.1", Load: fmt.Sprintf("%f", float64(2*gi))},
+			{Datacenter: "dc1", EndpointIP: "10.0.0.2", Load: fmt.Sprintf("%f", dc1Load)},
+			{Datacenter: "dc2", EndpointIP: "10.0.1.1", Load: fmt.Sprintf("%f", dc2Load)},
+		}
+	}
+
+	t.Run("usage above threshold", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.GetEndpointStates).Return(loads(8*gi, 5*gi), nil)
+		kc := newKc()
+
+		recResult := newReconciler(t, mgmtApi).checkDiskUsage(context.Background(), kc, dcs, testr.New(t))
+		assert.False(t, recResult.Completed())
+
+		assert.Equal(t, int32(80), kc.Status.Datacenters["dc1"].DiskUsage.UsedPercent)
+		assert.Equal(t, "10.0.0.2", kc.Status.Datacenters["dc1"].DiskUsage.Node)
+		assert.NotNil(t, kc.Status.Datacenters["dc1"].DiskUsage.LastCheckTime)
+		assert.Equal(t, int32(50), kc.Status.Datacenters["dc2"].DiskUsage.UsedPercent)
+		condition, found := kc.Status.GetCondition(api.DiskUsageHigh)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Contains(t, condition.Message, "dc1 (node 10.0.0.2 at 80%)")
+		assert.NotContains(t, condition.Message, "dc2")
+	})
+
+	t.Run("usage below threshold", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.GetEndpointStates).Return(loads(6*gi, 5*gi), nil)
+		kc := newKc()
+
+		newReconciler(t, mgmtApi).checkDiskUsage(context.Background(), kc, dcs, testr.New(t))
+
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.DiskUsageHigh))
+	})
+
+	t.Run("checked within poll interval", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		kc := newKc()
+		lastCheck := metav1.NewTime(time.Now().Add(-time.Minute))
+		for dcName := range kc.Status.Datacenters {
+			kc.Status.Datacenters[dcName] = api.K8ssandraStatus{DiskUsage: &api.DiskUsageStatus{UsedPercent: 10, LastCheckTime: &lastCheck}}
+		}
+
+		newReconciler(t, mgmtApi).checkDiskUsage(context.Background(), kc, dcs, testr.New(t))
+
+		mgmtApi.AssertNotCalled(t, test.GetEndpointStates)
+		assert.Equal(t, corev1.ConditionUnknown, kc.Status.GetConditionStatus(api.DiskUsageHigh))
+	})
+
+	t.Run("monitoring disabled", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		kc := newKc()
+		kc.Spec.Cassandra.DiskUsageMonitoring = nil
+
+		newReconciler(t, mgmtApi).checkDiskUsage(context.Background(), kc, dcs, testr.New(t))
+
+		mgmtApi.AssertNotCalled(t, test.GetEndpointStates)
+		assert.Nil(t, kc.Status.Datacenters["dc1"].DiskUsage)
+	})
+}
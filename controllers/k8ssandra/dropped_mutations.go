@@ -0,0 +1,127 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkDroppedMutations reports the number of mutations dropped by the nodes of each DC in the status of kc, and sets
+// the DroppedMutationsHigh condition when the mutations dropped by a DC since the previous check exceed the configured
+// threshold. The first check of a DC since the operator started only records the counters of its nodes as a baseline.
+// Checks happen at most once per poll interval. Failing to check the dropped mutations of a DC is logged
+// but does not fail the reconcile.
+func (r *K8ssandraClusterReconciler) checkDroppedMutations(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcs []*cassdcapi.CassandraDatacenter,
+	logger logr.Logger,
+) result.ReconcileResult {
+	monitoring := kc.Spec.Cassandra.DroppedMutationsMonitoring
+	if monitoring == nil || !monitoringCheckDue(kc, dcs, monitoring.GetPollInterval(), droppedMutationsLastCheckTime) {
+		return result.Continue()
+	}
+
+	kcKey := utils.GetKey(kc)
+	previousNodes, _ := r.droppedMutations.get(kcKey)
+	trackedNodes := make(map[string]map[string]int64)
+	for dcName, nodes := range previousNodes {
+		trackedNodes[dcName] = nodes
+	}
+
+	now := metav1.Now()
+	dropped := make(map[string]*api.DroppedMutationsStatus)
+	err := r.pollDatacenters(ctx, kc, dcs, logger, "Failed to check dropped mutations",
+		func(dc *cassdcapi.CassandraDatacenter, mgmtApi cassandra.ManagementApiFacade) error {
+			droppedMutations, err := mgmtApi.GetDroppedMutations()
+			if err != nil {
+				return err
+			}
+			status := computeDroppedMutations(droppedMutations, trackedNodes[dc.Name])
+			trackedNodes[dc.Name] = droppedMutations
+			if status == nil {
+				return nil
+			}
+			kdcStatus, found := kc.Status.Datacenters[dc.Name]
+			status.LastCheckTime = &now
+			dropped[dc.Name] = status
+			if found {
+				kdcStatus.DroppedMutations = status
+				kc.Status.Datacenters[dc.Name] = kdcStatus
+			}
+			return nil
+		})
+	r.droppedMutations.set(kcKey, trackedNodes)
+	if err != nil {
+		return result.Error(err)
+	}
+	setDroppedMutationsCondition(kc, dropped, monitoring.GetThreshold())
+	return result.Continue()
+}
+
+// droppedMutationsLastCheckTime returns the time the dropped mutations of the DC were last checked, or nil if it never was.
+func droppedMutationsLastCheckTime(status api.K8ssandraStatus) *metav1.Time {
+	if status.DroppedMutations == nil {
+		return nil
+	}
+	return status.DroppedMutations.LastCheckTime
+}
+
+// computeDroppedMutations sums the mutations dropped by each node, and computes how many were dropped since the
+// previous check, which recorded the counters in previous. Nil previous counters are the first check of the DC, which
+// only records a baseline: no mutation is counted as recent. A node that is not in previous joined since, and a node
+// whose counter is lower than in previous restarted since, so all the mutations it dropped are counted as recent.
+// Returns nil if there are no nodes. Ties are broken by pod name so that the result is stable.
+func computeDroppedMutations(droppedMutations map[string]int64, previous map[string]int64) *api.DroppedMutationsStatus {
+	if len(droppedMutations) == 0 {
+		return nil
+	}
+	status := &api.DroppedMutationsStatus{}
+	var maxDropped int64 = -1
+	for pod, dropped := range droppedMutations {
+		status.Total += dropped
+		if dropped > maxDropped || (dropped == maxDropped && pod < status.Node) {
+			maxDropped = dropped
+			status.Node = pod
+		}
+		if previous == nil {
+			continue
+		}
+		if previousDropped, found := previous[pod]; found && previousDropped <= dropped {
+			status.Recent += dropped - previousDropped
+		} else {
+			status.Recent += dropped
+		}
+	}
+	return status
+}
+
+// setDroppedMutationsCondition sets the DroppedMutationsHigh condition to true if the mutations recently dropped by at
+// least one DC exceed threshold, and to false otherwise.
+func setDroppedMutationsCondition(kc *api.K8ssandraCluster, dropped map[string]*api.DroppedMutationsStatus, threshold int64) {
+	highDropped := make([]string, 0)
+	for dcName, status := range dropped {
+		if status.Recent > threshold {
+			highDropped = append(highDropped, fmt.Sprintf("%s (%d dropped mutations)", dcName, status.Recent))
+		}
+	}
+	sort.Strings(highDropped)
+
+	status := corev1.ConditionFalse
+	message := ""
+	if len(highDropped) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("Mutations dropped since the previous check exceed %d in datacenters: %s", threshold, strings.Join(highDropped, ", "))
+	}
+	kc.Status.SetConditionStatus(api.DroppedMutationsHigh, status, message)
+}
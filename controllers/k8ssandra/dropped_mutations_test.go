@@ -0,0 +1,179 @@
+package k8ssandra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckDroppedMutations(t *testing.T) {
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					DroppedMutationsMonitoring: &api.DroppedMutationsMonitoring{Threshold: 10},
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+					},
+				},
+			},
+			Status: api.K8ssandraClusterStatus{
+				Datacenters: map[string]api.K8ssandraStatus{"dc1": {}, "dc2": {}},
+			},
+		}
+	}
+	dcs := []*cassdcapi.CassandraDatacenter{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc2"}},
+	}
+
+	newReconciler := func(t *testing.T) *K8ssandraClusterReconciler {
+		fakeClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		return newTracingTestReconciler(fakeClient)
+	}
+	// withManagementApis makes r poll the DCs through mgmtApis.
+	withManagementApis := func(t *testing.T, r *K8ssandraClusterReconciler, mgmtApis map[string]*test.FakeManagementApiFacade) *K8ssandraClusterReconciler {
+		factory := &test.FakeManagementApiFactory{}
+		factory.SetT(t)
+		factory.SetAdapter(func(_ context.Context, dc *cassdcapi.CassandraDatacenter, _ client.Client, _ logr.Logger) (cassandra.ManagementApiFacade, error) {
+			return mgmtApis[dc.Name], nil
+		})
+		r.ManagementApi = factory
+		return r
+	}
+	newMgmtApi := func(droppedMutations map[string]int64, err error) *test.FakeManagementApiFacade {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.GetDroppedMutations).Return(droppedMutations, err)
+		return mgmtApi
+	}
+
+	// checkAgain makes r run a check on kc with mgmtApis, once its previous check is older than the poll interval.
+	checkAgain := func(t *testing.T, r *K8ssandraClusterReconciler, kc *api.K8ssandraCluster, mgmtApis map[string]*test.FakeManagementApiFacade) {
+		lastCheck := metav1.NewTime(time.Now().Add(-time.Hour))
+		for dcName, kdcStatus := range kc.Status.Datacenters {
+			if kdcStatus.DroppedMutations != nil {
+				kdcStatus.DroppedMutations.LastCheckTime = &lastCheck
+				kc.Status.Datacenters[dcName] = kdcStatus
+			}
+		}
+		withManagementApis(t, r, mgmtApis).checkDroppedMutations(context.Background(), kc, dcs, testr.New(t))
+	}
+
+	t.Run("first check records a baseline", func(t *testing.T) {
+		kc := newKc()
+		r := newReconciler(t)
+		mgmtApis := map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(map[string]int64{"dc1-rack1-sts-0": 3, "dc1-rack1-sts-1": 42}, nil),
+			"dc2": newMgmtApi(map[string]int64{"dc2-rack1-sts-0": 5}, nil),
+		}
+
+		recResult := withManagementApis(t, r, mgmtApis).checkDroppedMutations(context.Background(), kc, dcs, testr.New(t))
+		assert.False(t, recResult.Completed())
+
+		dropped := kc.Status.Datacenters["dc1"].DroppedMutations
+		require.NotNil(t, dropped)
+		assert.Equal(t, int64(45), dropped.Total)
+		assert.Equal(t, int64(0), dropped.Recent, "the first check only records a baseline")
+		assert.Equal(t, "dc1-rack1-sts-1", dropped.Node)
+		assert.NotNil(t, dropped.LastCheckTime)
+		assert.Equal(t, int64(5), kc.Status.Datacenters["dc2"].DroppedMutations.Total)
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.DroppedMutationsHigh))
+	})
+
+	t.Run("dropped mutations above threshold", func(t *testing.T) {
+		kc := newKc()
+		r := newReconciler(t)
+		checkAgain(t, r, kc, map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(map[string]int64{"dc1-rack1-sts-0": 3, "dc1-rack1-sts-1": 42}, nil),
+			"dc2": newMgmtApi(map[string]int64{"dc2-rack1-sts-0": 5}, nil),
+		})
+
+		checkAgain(t, r, kc, map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(map[string]int64{"dc1-rack1-sts-0": 3, "dc1-rack1-sts-1": 62}, nil),
+			"dc2": newMgmtApi(map[string]int64{"dc2-rack1-sts-0": 9}, nil),
+		})
+
+		assert.Equal(t, int64(65), kc.Status.Datacenters["dc1"].DroppedMutations.Total)
+		assert.Equal(t, int64(20), kc.Status.Datacenters["dc1"].DroppedMutations.Recent)
+		assert.Equal(t, int64(4), kc.Status.Datacenters["dc2"].DroppedMutations.Recent)
+		condition, found := kc.Status.GetCondition(api.DroppedMutationsHigh)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Contains(t, condition.Message, "dc1 (20 dropped mutations)")
+		assert.NotContains(t, condition.Message, "dc2")
+	})
+
+	t.Run("counters reset by a restart", func(t *testing.T) {
+		kc := newKc()
+		r := newReconciler(t)
+		checkAgain(t, r, kc, map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(map[string]int64{"dc1-rack1-sts-0": 40, "dc1-rack1-sts-1": 5}, nil),
+			"dc2": newMgmtApi(map[string]int64{"dc2-rack1-sts-0": 0}, nil),
+		})
+
+		// dc1-rack1-sts-0 restarted, which must not hide the mutations dropped by dc1-rack1-sts-1 in the meantime
+		checkAgain(t, r, kc, map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(map[string]int64{"dc1-rack1-sts-0": 2, "dc1-rack1-sts-1": 14, "dc1-rack1-sts-2": 1}, nil),
+			"dc2": newMgmtApi(map[string]int64{"dc2-rack1-sts-0": 0}, nil),
+		})
+
+		assert.Equal(t, int64(17), kc.Status.Datacenters["dc1"].DroppedMutations.Total)
+		assert.Equal(t, int64(12), kc.Status.Datacenters["dc1"].DroppedMutations.Recent,
+			"the counters of restarted and new nodes are counted in full")
+		assert.Equal(t, corev1.ConditionTrue, kc.Status.GetConditionStatus(api.DroppedMutationsHigh))
+	})
+
+	t.Run("management API failure in one dc", func(t *testing.T) {
+		kc := newKc()
+		r := newReconciler(t)
+		checkAgain(t, r, kc, map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(nil, errors.New("connection refused")),
+			"dc2": newMgmtApi(map[string]int64{"dc2-rack1-sts-0": 5}, nil),
+		})
+
+		checkAgain(t, r, kc, map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(map[string]int64{"dc1-rack1-sts-0": 30}, nil),
+			"dc2": newMgmtApi(map[string]int64{"dc2-rack1-sts-0": 30}, nil),
+		})
+
+		assert.Equal(t, int64(0), kc.Status.Datacenters["dc1"].DroppedMutations.Recent,
+			"the first successful check of a dc records its baseline")
+		assert.Equal(t, int64(25), kc.Status.Datacenters["dc2"].DroppedMutations.Recent)
+		assert.Equal(t, corev1.ConditionTrue, kc.Status.GetConditionStatus(api.DroppedMutationsHigh))
+	})
+
+	t.Run("checked within poll interval", func(t *testing.T) {
+		mgmtApis := map[string]*test.FakeManagementApiFacade{
+			"dc1": test.NewFakeManagementApiFacade(),
+			"dc2": test.NewFakeManagementApiFacade(),
+		}
+		kc := newKc()
+		lastCheck := metav1.NewTime(time.Now().Add(-time.Minute))
+		for dcName := range kc.Status.Datacenters {
+			kc.Status.Datacenters[dcName] = api.K8ssandraStatus{DroppedMutations: &api.DroppedMutationsStatus{LastCheckTime: &lastCheck}}
+		}
+
+		withManagementApis(t, newReconciler(t), mgmtApis).checkDroppedMutations(context.Background(), kc, dcs, testr.New(t))
+
+		for _, mgmtApi := range mgmtApis {
+			mgmtApi.AssertNotCalled(t, test.GetDroppedMutations)
+		}
+		assert.Equal(t, corev1.ConditionUnknown, kc.Status.GetConditionStatus(api.DroppedMutationsHigh))
+	})
+}
@@ -0,0 +1,179 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	gossipStatusNormal  = "NORMAL"
+	gossipStatusJoining = "JOINING"
+	gossipStatusLeaving = "LEAVING"
+	gossipStatusUnknown = "UNKNOWN"
+)
+
+// checkGossipState reports the nodes of each DC that are not in the NORMAL gossip state in the status of kc, and sets
+// the GossipStateStuck condition when a node has been JOINING or LEAVING for longer than the configured threshold. The
+// time a node entered its status is carried over from the previous poll, as tracked in gossipNodes, or as
+// reported in the status of kc when the operator restarted since then. All the nodes are tracked and checked, only the
+// list reported in the status is truncated. The gossip state is polled at most once per poll interval. Failing to poll
+// it is logged but does not fail the reconcile.
+func (r *K8ssandraClusterReconciler) checkGossipState(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcs []*cassdcapi.CassandraDatacenter,
+	logger logr.Logger,
+) result.ReconcileResult {
+	monitoring := kc.Spec.Cassandra.GossipMonitoring
+	if monitoring == nil || !monitoringCheckDue(kc, dcs, monitoring.GetPollInterval(), gossipLastCheckTime) {
+		return result.Continue()
+	}
+
+	states, err := r.coordinatorEndpointStates(ctx, kc, dcs, logger, "Failed to check gossip state")
+	if err != nil {
+		return result.Error(err)
+	}
+	if states == nil {
+		return result.Continue()
+	}
+
+	kcKey := utils.GetKey(kc)
+	trackedNodes, _ := r.gossipNodes.get(kcKey)
+
+	now := metav1.Now()
+	stuckThreshold := monitoring.GetStuckThreshold()
+	var stuckNodes []string
+	nodesByDc := make(map[string][]api.NodeGossipState)
+	for _, dc := range dcs {
+		kdcStatus, found := kc.Status.Datacenters[dc.Name]
+		if !found {
+			continue
+		}
+		previous, tracked := trackedNodes[dc.Name]
+		if !tracked && kdcStatus.Gossip != nil {
+			previous = kdcStatus.Gossip.Nodes
+		}
+		gossip, nodes := computeGossipStatus(states, dc, previous, now, stuckThreshold)
+		for _, node := range nodes {
+			if node.Stuck {
+				stuckNodes = append(stuckNodes, fmt.Sprintf("%s (%s since %s)", node.Node, node.Status, node.Since.UTC().Format(time.RFC3339)))
+			}
+		}
+		nodesByDc[dc.Name] = nodes
+		kdcStatus.Gossip = gossip
+		kc.Status.Datacenters[dc.Name] = kdcStatus
+	}
+
+	r.gossipNodes.set(kcKey, nodesByDc)
+
+	setGossipStateStuckCondition(kc, stuckNodes, stuckThreshold)
+	return result.Continue()
+}
+
+// gossipLastCheckTime returns the time the gossip state of the DC was last polled, or nil if it never was.
+func gossipLastCheckTime(status api.K8ssandraStatus) *metav1.Time {
+	if status.Gossip == nil {
+		return nil
+	}
+	return status.Gossip.LastCheckTime
+}
+
+// computeGossipStatus returns the gossip status of dc built from the endpoint states, along with all the nodes of dc that
+// are not in the NORMAL gossip state. Nodes keep the time they entered their status in previous when their status did
+// not change. The nodes are ordered stuck nodes first, and at most api.MaxReportedGossipNodes of them are listed in the
+// status.
+func computeGossipStatus(
+	states []httphelper.EndpointState,
+	dc *cassdcapi.CassandraDatacenter,
+	previous []api.NodeGossipState,
+	now metav1.Time,
+	stuckThreshold time.Duration,
+) (*api.GossipStatus, []api.NodeGossipState) {
+	previousNodes := make(map[string]api.NodeGossipState)
+	for _, node := range previous {
+		previousNodes[node.Node] = node
+	}
+
+	gossip := &api.GossipStatus{LastCheckTime: &now}
+	var nodes []api.NodeGossipState
+	for _, state := range states {
+		if state.Datacenter != dc.DatacenterName() {
+			continue
+		}
+		status := gossipStatus(state)
+		if status == gossipStatusNormal {
+			gossip.NormalNodes++
+			continue
+		}
+		since := now
+		if previousNode, found := previousNodes[state.EndpointIP]; found && previousNode.Status == status && previousNode.Since != nil {
+			since = *previousNode.Since
+		}
+		nodes = append(nodes, api.NodeGossipState{
+			Node:   state.EndpointIP,
+			Status: status,
+			Alive:  state.IsAlive == "true",
+			Since:  &since,
+			Stuck:  (status == gossipStatusJoining || status == gossipStatusLeaving) && now.Sub(since.Time) >= stuckThreshold,
+		})
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Stuck != nodes[j].Stuck {
+			return nodes[i].Stuck
+		}
+		return nodes[i].Node < nodes[j].Node
+	})
+	reported := nodes
+	if len(reported) > api.MaxReportedGossipNodes {
+		reported = reported[:api.MaxReportedGossipNodes]
+	}
+	gossip.Nodes = append([]api.NodeGossipState(nil), reported...)
+	return gossip, nodes
+}
+
+// gossipStatus returns the status of the node from its gossip STATUS_WITH_PORT or STATUS application state, whose value
+// is the status followed by comma-separated tokens or addresses. Bootstrapping and replacing nodes are reported as
+// JOINING.
+func gossipStatus(state httphelper.EndpointState) string {
+	value := state.StatusWithPort
+	if value == "" {
+		value = state.Status
+	}
+	status := strings.ToUpper(strings.TrimSpace(strings.SplitN(value, ",", 2)[0]))
+	switch status {
+	case "":
+		return gossipStatusUnknown
+	case "BOOT", "BOOT_REPLACE":
+		return gossipStatusJoining
+	default:
+		return status
+	}
+}
+
+// setGossipStateStuckCondition sets the GossipStateStuck condition to true if at least one node is stuck, and to false
+// otherwise.
+func setGossipStateStuckCondition(kc *api.K8ssandraCluster, stuckNodes []string, stuckThreshold time.Duration) {
+	sort.Strings(stuckNodes)
+
+	status := corev1.ConditionFalse
+	message := ""
+	if len(stuckNodes) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("Nodes have been joining or leaving the cluster for more than %s: %s.",
+			stuckThreshold, strings.Join(stuckNodes, ", "))
+	}
+	kc.Status.SetConditionStatus(api.GossipStateStuck, status, message)
+}
@@ -0,0 +1,199 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckGossipState(t *testing.T) {
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					GossipMonitoring: &api.GossipMonitoring{StuckThreshold: &metav1.Duration{Duration: 10 * time.Minute}},
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+					},
+				},
+			},
+			Status: api.K8ssandraClusterStatus{
+				Datacenters: map[string]api.K8ssandraStatus{"dc1": {}, "dc2": {}},
+			},
+		}
+	}
+	dcs := []*cassdcapi.CassandraDatacenter{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc2"}},
+	}
+	states := []httphelper.EndpointState{
+		{Datacenter: "dc1", EndpointIP: "10.0.0.1", StatusWithPort: "NORMAL,-100", IsAlive: "true"},
+		{Datacenter: "dc1", EndpointIP: "10.0.0.2", StatusWithPort: "BOOT,200", IsAlive: "true"},
+		{Datacenter: "dc1", EndpointIP: "10.0.0.3", Status: "LEAVING,300", IsAlive: "false"},
+		{Datacenter: "dc2", EndpointIP: "10.0.1.1", StatusWithPort: "NORMAL,400", IsAlive: "true"},
+	}
+
+	newReconciler := func(t *testing.T, mgmtApi *test.FakeManagementApiFacade) *K8ssandraClusterReconciler {
+		fakeClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		factory := &test.FakeManagementApiFactory{}
+		factory.SetT(t)
+		factory.SetAdapter(func(context.Context, *cassdcapi.CassandraDatacenter, client.Client, logr.Logger) (cassandra.ManagementApiFacade, error) {
+			return mgmtApi, nil
+		})
+		r.ManagementApi = factory
+		return r
+	}
+
+	t.Run("nodes entering a transient state", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.GetEndpointStates).Return(states, nil)
+		kc := newKc()
+
+		recResult := newReconciler(t, mgmtApi).checkGossipState(context.Background(), kc, dcs, testr.New(t))
+		assert.False(t, recResult.Completed())
+
+		gossip := kc.Status.Datacenters["dc1"].Gossip
+		require.NotNil(t, gossip)
+		assert.Equal(t, int32(1), gossip.NormalNodes)
+		require.Len(t, gossip.Nodes, 2)
+		assert.Equal(t, "10.0.0.2", gossip.Nodes[0].Node)
+		assert.Equal(t, "JOINING", gossip.Nodes[0].Status)
+		assert.True(t, gossip.Nodes[0].Alive)
+		assert.False(t, gossip.Nodes[0].Stuck)
+		assert.Equal(t, "LEAVING", gossip.Nodes[1].Status)
+		assert.False(t, gossip.Nodes[1].Alive)
+		assert.Equal(t, int32(1), kc.Status.Datacenters["dc2"].Gossip.NormalNodes)
+		assert.Empty(t, kc.Status.Datacenters["dc2"].Gossip.Nodes)
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.GossipStateStuck))
+	})
+
+	t.Run("nodes stuck in a transient state", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.GetEndpointStates).Return(states, nil)
+		kc := newKc()
+		lastCheck := metav1.NewTime(time.Now().Add(-time.Hour))
+		joiningSince := metav1.NewTime(time.Now().Add(-time.Hour))
+		leavingSince := metav1.NewTime(time.Now().Add(-time.Minute))
+		kc.Status.Datacenters["dc1"] = api.K8ssandraStatus{Gossip: &api.GossipStatus{
+			LastCheckTime: &lastCheck,
+			Nodes: []api.NodeGossipState{
+				{Node: "10.0.0.2", Status: "JOINING", Since: &joiningSince},
+				// a node whose status changed is not stuck
+				{Node: "10.0.0.3", Status: "JOINING", Since: &joiningSince},
+			},
+		}}
+
+		newReconciler(t, mgmtApi).checkGossipState(context.Background(), kc, dcs, testr.New(t))
+
+		gossip := kc.Status.Datacenters["dc1"].Gossip
+		require.Len(t, gossip.Nodes, 2)
+		assert.Equal(t, "10.0.0.2", gossip.Nodes[0].Node)
+		assert.True(t, gossip.Nodes[0].Stuck)
+		assert.Equal(t, joiningSince.Unix(), gossip.Nodes[0].Since.Unix())
+		assert.False(t, gossip.Nodes[1].Stuck)
+		assert.True(t, gossip.Nodes[1].Since.After(leavingSince.Time))
+		condition, found := kc.Status.GetCondition(api.GossipStateStuck)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Contains(t, condition.Message, "10.0.0.2 (JOINING since")
+		assert.NotContains(t, condition.Message, "10.0.0.3")
+	})
+
+	t.Run("nodes that are not reported", func(t *testing.T) {
+		var manyStates []httphelper.EndpointState
+		for i := 0; i < api.MaxReportedGossipNodes+1; i++ {
+			manyStates = append(manyStates, httphelper.EndpointState{Datacenter: "dc1", EndpointIP: fmt.Sprintf("10.0.0.%d", 10+i), StatusWithPort: "MOVING,1"})
+		}
+		// listed last, it is not reported in the status
+		manyStates = append(manyStates, httphelper.EndpointState{Datacenter: "dc1", EndpointIP: "10.0.0.99", StatusWithPort: "BOOT,1"})
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.GetEndpointStates).Return(manyStates, nil)
+		kc := newKc()
+		r := newReconciler(t, mgmtApi)
+
+		r.checkGossipState(context.Background(), kc, dcs, testr.New(t))
+		require.Len(t, kc.Status.Datacenters["dc1"].Gossip.Nodes, api.MaxReportedGossipNodes)
+		for _, node := range kc.Status.Datacenters["dc1"].Gossip.Nodes {
+			assert.NotEqual(t, "10.0.0.99", node.Node)
+		}
+
+		// the node keeps the time it started joining, and is found stuck although it is not reported
+		trackedNodes, found := r.gossipNodes.get(utils.GetKey(kc))
+		require.True(t, found)
+		for i, node := range trackedNodes["dc1"] {
+			since := metav1.NewTime(node.Since.Add(-time.Hour))
+			trackedNodes["dc1"][i].Since = &since
+		}
+		lastCheck := metav1.NewTime(time.Now().Add(-time.Hour))
+		kc.Status.Datacenters["dc1"].Gossip.LastCheckTime = &lastCheck
+
+		r.checkGossipState(context.Background(), kc, dcs, testr.New(t))
+		condition, found := kc.Status.GetCondition(api.GossipStateStuck)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Contains(t, condition.Message, "10.0.0.99 (JOINING since")
+		assert.Equal(t, "10.0.0.99", kc.Status.Datacenters["dc1"].Gossip.Nodes[0].Node, "stuck nodes are reported first")
+	})
+
+	t.Run("checked within poll interval", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		kc := newKc()
+		lastCheck := metav1.NewTime(time.Now().Add(-time.Second))
+		for dcName := range kc.Status.Datacenters {
+			kc.Status.Datacenters[dcName] = api.K8ssandraStatus{Gossip: &api.GossipStatus{LastCheckTime: &lastCheck}}
+		}
+
+		newReconciler(t, mgmtApi).checkGossipState(context.Background(), kc, dcs, testr.New(t))
+
+		mgmtApi.AssertNotCalled(t, test.GetEndpointStates)
+		assert.Equal(t, corev1.ConditionUnknown, kc.Status.GetConditionStatus(api.GossipStateStuck))
+	})
+
+	t.Run("monitoring disabled", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		kc := newKc()
+		kc.Spec.Cassandra.GossipMonitoring = nil
+
+		newReconciler(t, mgmtApi).checkGossipState(context.Background(), kc, dcs, testr.New(t))
+
+		mgmtApi.AssertNotCalled(t, test.GetEndpointStates)
+		assert.Nil(t, kc.Status.Datacenters["dc1"].Gossip)
+	})
+}
+
+func TestComputeGossipStatusIsBounded(t *testing.T) {
+	dc := &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{Name: "dc1"}}
+	var states []httphelper.EndpointState
+	for i := 0; i < api.MaxReportedGossipNodes+5; i++ {
+		states = append(states, httphelper.EndpointState{Datacenter: "dc1", EndpointIP: "10.0.0." + string(rune('a'+i)), Status: "MOVING,1"})
+	}
+	since := metav1.NewTime(time.Now().Add(-time.Hour))
+	previous := []api.NodeGossipState{{Node: "10.0.0.o", Status: "LEAVING", Since: &since}}
+	states[len(states)-1].Status = "LEAVING,1"
+
+	gossip, nodes := computeGossipStatus(states, dc, previous, metav1.Now(), time.Minute)
+
+	require.Len(t, gossip.Nodes, api.MaxReportedGossipNodes)
+	assert.Equal(t, "10.0.0.o", gossip.Nodes[0].Node, "stuck nodes are listed first")
+	assert.True(t, gossip.Nodes[0].Stuck)
+	assert.Len(t, nodes, api.MaxReportedGossipNodes+5, "all the nodes are returned")
+}
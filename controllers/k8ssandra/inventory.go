@@ -0,0 +1,134 @@
+package k8ssandra
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	reaperapi "github.com/k8ssandra/k8ssandra-operator/apis/reaper/v1alpha1"
+	stargateapi "github.com/k8ssandra/k8ssandra-operator/apis/stargate/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// inventoryKinds are the kinds of objects that the operator creates for a K8ssandraCluster, with a constructor of
+// their list type.
+var inventoryKinds = []struct {
+	kind    string
+	newList func() client.ObjectList
+}{
+	{"CassandraDatacenter", func() client.ObjectList { return &cassdcapi.CassandraDatacenterList{} }},
+	{"ConfigMap", func() client.ObjectList { return &corev1.ConfigMapList{} }},
+	{"NetworkPolicy", func() client.ObjectList { return &networkingv1.NetworkPolicyList{} }},
+	{"Reaper", func() client.ObjectList { return &reaperapi.ReaperList{} }},
+	{"Secret", func() client.ObjectList { return &corev1.SecretList{} }},
+	{"Service", func() client.ObjectList { return &corev1.ServiceList{} }},
+	{"Stargate", func() client.ObjectList { return &stargateapi.StargateList{} }},
+}
+
+// recordInventory lists the objects managed by the operator for kc, in the namespace of kc in the control plane and in
+// the namespaces of the datacenters in their k8s contexts, and records them in the status of kc. Objects are found by
+// their management labels: objects created by the k8ssandra-cluster controller, and secrets replicated for kc. The
+// listed objects are bounded by api.MaxInventoryObjects. If some objects cannot be listed, the error is logged and the
+// previous inventory is kept, since a partial one would report objects as deleted.
+func (r *K8ssandraClusterReconciler) recordInventory(ctx context.Context, kc *api.K8ssandraCluster, logger logr.Logger) {
+	if kc.Spec.Cassandra == nil {
+		return
+	}
+
+	namespaces := map[string]map[string]bool{"": {kc.Namespace: true}}
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if namespaces[dcTemplate.K8sContext] == nil {
+			namespaces[dcTemplate.K8sContext] = make(map[string]bool)
+		}
+		namespaces[dcTemplate.K8sContext][utils.FirstNonEmptyString(dcTemplate.Meta.Namespace, kc.Namespace)] = true
+	}
+
+	objects := make([]api.ManagedObject, 0)
+	for k8sContext, contextNamespaces := range namespaces {
+		remoteClient, err := r.ClientCache.GetRemoteClient(k8sContext)
+		if err != nil {
+			logger.Info("Failed to get remote client to list the managed objects", "K8sContext", k8sContext, "Error", err.Error())
+			return
+		}
+		for namespace := range contextNamespaces {
+			found, err := listManagedObjects(ctx, kc, remoteClient, namespace)
+			if err != nil {
+				logger.Info("Failed to list the managed objects", "K8sContext", k8sContext, "Namespace", namespace, "Error", err.Error())
+				return
+			}
+			for _, object := range found {
+				object.K8sContext = k8sContext
+				objects = append(objects, object)
+			}
+		}
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		a, b := objects[i], objects[j]
+		if a.K8sContext != b.K8sContext {
+			return a.K8sContext < b.K8sContext
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	inventory := &api.InventoryStatus{Total: len(objects)}
+	if len(objects) > api.MaxInventoryObjects {
+		objects = objects[:api.MaxInventoryObjects]
+	}
+	if len(objects) > 0 {
+		inventory.Objects = objects
+	}
+	kc.Status.Inventory = inventory
+}
+
+// listManagedObjects returns the objects of the inventory kinds in the namespace that are managed for kc.
+func listManagedObjects(ctx context.Context, kc *api.K8ssandraCluster, remoteClient client.Client, namespace string) ([]api.ManagedObject, error) {
+	objects := make([]api.ManagedObject, 0)
+	selector := client.MatchingLabels{api.K8ssandraClusterNamespaceLabel: kc.Namespace}
+	for _, inventoryKind := range inventoryKinds {
+		list := inventoryKind.newList()
+		if err := remoteClient.List(ctx, list, client.InNamespace(namespace), selector); err != nil {
+			return nil, err
+		}
+		err := meta.EachListItem(list, func(item runtime.Object) error {
+			object, err := meta.Accessor(item)
+			if err != nil {
+				return err
+			}
+			if isManagedFor(object, kc) {
+				objects = append(objects, api.ManagedObject{Kind: inventoryKind.kind, Namespace: object.GetNamespace(), Name: object.GetName()})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+// isManagedFor returns true if the object was created by the k8ssandra-cluster controller for kc, or is a secret
+// replicated for kc. Objects only watched by kc, such as the ConfigMaps referenced by its spec, are not managed. Some
+// objects are labeled with the name of kc, and others with its sanitized name, so both are accepted.
+func isManagedFor(object labels.Labeled, kc *api.K8ssandraCluster) bool {
+	for _, name := range []string{kc.Name, kc.SanitizedName()} {
+		kcKey := client.ObjectKey{Namespace: kc.Namespace, Name: name}
+		if labels.IsPartOf(object, kcKey) || labels.IsReplicatedBy(object, kcKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRecordInventory(t *testing.T) {
+	ctx := context.Background()
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1", Namespace: "dc1-ns"}, K8sContext: "cluster-1"},
+				},
+			},
+		},
+	}
+	kcKey := client.ObjectKey{Namespace: "default", Name: "test"}
+	otherKcKey := client.ObjectKey{Namespace: "default", Name: "other"}
+	objectMeta := func(namespace, name string, objectLabels map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: objectLabels}
+	}
+
+	replicatedSecret := &corev1.Secret{ObjectMeta: objectMeta("dc1-ns", "test-superuser", labels.ReplicatedByLabels(kcKey))}
+	controlPlaneClient, err := test.NewFakeClient(
+		&corev1.Secret{ObjectMeta: objectMeta("default", "test-superuser", labels.PartOfLabels(kcKey))},
+		// only watched by the cluster, not managed
+		&corev1.ConfigMap{ObjectMeta: objectMeta("default", "log-shipping", labels.WatchedByK8ssandraClusterLabels(kcKey))},
+		// managed for another cluster
+		&corev1.Secret{ObjectMeta: objectMeta("default", "other-superuser", labels.PartOfLabels(otherKcKey))},
+	)
+	require.NoError(t, err)
+	remoteClient, err := test.NewFakeClient(
+		&cassdcapi.CassandraDatacenter{ObjectMeta: objectMeta("dc1-ns", "dc1", labels.PartOfLabels(kcKey))},
+		&corev1.Service{ObjectMeta: objectMeta("dc1-ns", "test-dc1-cql", labels.PartOfLabels(kcKey))},
+		replicatedSecret,
+		// outside of the namespaces of the cluster
+		&corev1.Service{ObjectMeta: objectMeta("elsewhere", "test-dc2-cql", labels.PartOfLabels(kcKey))},
+	)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(controlPlaneClient)
+	r.ClientCache.AddClient("cluster-1", remoteClient)
+
+	r.recordInventory(ctx, kc, testr.New(t))
+	assert.Equal(t, &api.InventoryStatus{
+		Total: 4,
+		Objects: []api.ManagedObject{
+			{Kind: "Secret", Namespace: "default", Name: "test-superuser"},
+			{K8sContext: "cluster-1", Kind: "CassandraDatacenter", Namespace: "dc1-ns", Name: "dc1"},
+			{K8sContext: "cluster-1", Kind: "Secret", Namespace: "dc1-ns", Name: "test-superuser"},
+			{K8sContext: "cluster-1", Kind: "Service", Namespace: "dc1-ns", Name: "test-dc1-cql"},
+		},
+	}, kc.Status.Inventory)
+
+	// deleted objects are removed from the inventory
+	require.NoError(t, remoteClient.Delete(ctx, replicatedSecret))
+	r.recordInventory(ctx, kc, testr.New(t))
+	assert.Equal(t, 3, kc.Status.Inventory.Total)
+	assert.NotContains(t, kc.Status.Inventory.Objects,
+		api.ManagedObject{K8sContext: "cluster-1", Kind: "Secret", Namespace: "dc1-ns", Name: "test-superuser"})
+
+	// the previous inventory is kept when a k8s context cannot be listed
+	kc.Spec.Cassandra.Datacenters = append(kc.Spec.Cassandra.Datacenters,
+		api.CassandraDatacenterTemplate{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, K8sContext: "cluster-2"})
+	r.recordInventory(ctx, kc, testr.New(t))
+	assert.Equal(t, 3, kc.Status.Inventory.Total)
+}
+
+func TestRecordInventoryTruncated(t *testing.T) {
+	ctx := context.Background()
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec:       api.K8ssandraClusterSpec{Cassandra: &api.CassandraClusterTemplate{}},
+	}
+	objects := make([]runtime.Object, 0)
+	for i := 0; i < api.MaxInventoryObjects+5; i++ {
+		objects = append(objects, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      fmt.Sprintf("config-%03d", i),
+			Labels:    labels.PartOfLabels(client.ObjectKey{Namespace: "default", Name: "test"}),
+		}})
+	}
+	controlPlaneClient, err := test.NewFakeClient(objects...)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(controlPlaneClient)
+
+	r.recordInventory(ctx, kc, testr.New(t))
+	assert.Equal(t, api.MaxInventoryObjects+5, kc.Status.Inventory.Total)
+	require.Len(t, kc.Status.Inventory.Objects, api.MaxInventoryObjects)
+	assert.Equal(t, "config-000", kc.Status.Inventory.Objects[0].Name)
+}
@@ -19,6 +19,7 @@ package k8ssandra
 import (
 	"context"
 	v1 "k8s.io/api/core/v1"
+	"time"
 
 	"github.com/go-logr/logr"
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
@@ -30,6 +31,8 @@ import (
 	"github.com/k8ssandra/k8ssandra-operator/pkg/config"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/summary"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/tracing"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -39,6 +42,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -58,6 +62,33 @@ type K8ssandraClusterReconciler struct {
 	ClientCache   *clientcache.ClientCache
 	ManagementApi cassandra.ManagementApiFactory
 	Recorder      record.EventRecorder
+
+	// Summary records the reconcile times served by the summary endpoint. It is optional.
+	Summary *summary.Tracker
+
+	// statusSubresourceDisabled is set when the controller is set up, if the status subresource of the K8ssandraCluster
+	// CRD is not enabled.
+	statusSubresourceDisabled bool
+
+	// seenClaims records when this instance first saw each cluster claimed by another instance without a renew time,
+	// so that such claims also expire.
+	seenClaims clusterState[seenClaim]
+
+	// gossipNodes records the nodes of each DC of each cluster that were not in the NORMAL gossip state at the last
+	// poll, along with the time they entered their status. Unlike the status of the cluster, which lists at most
+	// api.MaxReportedGossipNodes nodes per DC, it tracks all of them.
+	gossipNodes clusterState[map[string][]api.NodeGossipState]
+
+	// droppedMutations records the number of mutations dropped by each node of each DC of each cluster at the last
+	// check, keyed by pod name, so that the mutations dropped since then are counted per node.
+	droppedMutations clusterState[map[string]map[string]int64]
+
+	// seedDialer probes the seeds of the clusters that span multiple contexts, dialSeed is used when it is nil.
+	seedDialer seedDialer
+
+	// unreachableSeedAddresses records the seeds found unreachable by the last probe of each cluster, so that an event
+	// is only emitted when a seed becomes unreachable or reachable again, rather than on each reconcile.
+	unreachableSeedAddresses clusterState[map[string]bool]
 }
 
 // +kubebuilder:rbac:groups=k8ssandra.io,namespace="k8ssandra",resources=k8ssandraclusters;clientconfigs,verbs=get;list;watch;create;update;patch;delete
@@ -69,40 +100,88 @@ type K8ssandraClusterReconciler struct {
 // +kubebuilder:rbac:groups=stargate.k8ssandra.io,namespace="k8ssandra",resources=stargates,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=reaper.k8ssandra.io,namespace="k8ssandra",resources=reapers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,namespace="k8ssandra",resources=pods;secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,namespace="k8ssandra",resources=deployments,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,namespace="k8ssandra",resources=endpoints,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,namespace="k8ssandra",resources=persistentvolumeclaims,verbs=get
 // +kubebuilder:rbac:groups=monitoring.coreos.com,namespace="k8ssandra",resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=core,namespace="k8ssandra",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,namespace="k8ssandra",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,namespace="k8ssandra",resources=networkpolicies,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups="",namespace="k8ssandra",resources=events,verbs=create;patch
 
+// The permissions on cluster-scoped resources, such as namespaces, persistent volumes and CRDs, can not be granted by the namespaced Role generated
+// from the markers above. They are granted by the ClusterRole of config/rbac/cluster_role.yaml instead.
+
 func (r *K8ssandraClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx).WithValues("K8ssandraCluster", req.NamespacedName)
+	ctx, span := tracing.Start(ctx, "K8ssandraCluster.Reconcile",
+		tracing.NamespaceKey.String(req.Namespace), tracing.ClusterKey.String(req.Name))
 
 	kc := &api.K8ssandraCluster{}
 	err := r.Get(ctx, req.NamespacedName, kc)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			r.Summary.Forget(req.NamespacedName)
+			tracing.End(span, nil)
 			return ctrl.Result{}, nil
 		}
+		tracing.End(span, err)
 		return ctrl.Result{RequeueAfter: r.ReconcilerConfig.DefaultDelay}, err
 	}
 
 	kc = kc.DeepCopy()
-	patch := client.MergeFrom(kc.DeepCopy())
-	result, err := r.reconcile(ctx, kc, logger)
-	if kc.GetDeletionTimestamp() == nil {
+	if owned, retryAfter, err := r.claimOwnership(ctx, kc, logger); err != nil || !owned {
+		tracing.End(span, err)
 		if err != nil {
+			return ctrl.Result{RequeueAfter: r.ReconcilerConfig.DefaultDelay}, err
+		}
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+	original := kc.DeepCopy()
+	patch := client.MergeFrom(original)
+	r.setManagedByInstanceCondition(kc)
+	result, err := r.reconcile(withReconcileDeadline(ctx, kc, time.Now()), kc, logger)
+	r.Summary.Record(req.NamespacedName, time.Now())
+	certificateExpired := r.checkCertificateExpiry(kc, err)
+	secretRotating := !certificateExpired && r.isSecretRotationFailure(kc, err)
+	retryAfter, rateLimited := r.rateLimitDelay(err)
+	if kc.GetDeletionTimestamp() == nil {
+		// The failures during a secret rotation or caused by a rate limiting API server are not reported as errors, they
+		// are expected to go away once the new credentials are accepted, or once the requested delay elapsed.
+		if err != nil && !secretRotating && !rateLimited {
 			kc.Status.Error = err.Error()
 			r.Recorder.Event(kc, v1.EventTypeWarning, "Reconcile Error", err.Error())
-		} else {
+		} else if err == nil {
 			kc.Status.Error = "None"
 		}
-		if patchErr := r.Status().Patch(ctx, kc, patch); patchErr != nil {
-			logger.Error(patchErr, "failed to update k8ssandracluster status")
-		} else {
-			logger.Info("updated k8ssandracluster status")
+		r.recordInventory(ctx, kc, logger)
+		recordStatusHistory(kc, &original.Status)
+		// When the status subresource is disabled, this was reported once when the controller was set up
+		if !r.statusSubresourceDisabled {
+			if patchErr := r.Status().Patch(ctx, kc, patch); patchErr != nil {
+				logger.Error(patchErr, "failed to update k8ssandracluster status")
+			} else {
+				logger.Info("updated k8ssandracluster status")
+			}
 		}
 	}
-	return result, err
+	tracing.End(span, err)
+	if certificateExpired {
+		// Renewing the certificate requires a human intervention, retrying soon would only fail the same way
+		logger.Error(err, "Remote request failed because of an expired certificate")
+		return ctrl.Result{RequeueAfter: r.LongDelay}, nil
+	}
+	if secretRotating {
+		// Retried with the backoff of the controller's rate limiter until the grace period of the rotation elapses
+		logger.Info("Remote request was rejected shortly after a kubeconfig secret rotation, retrying", "Error", err.Error())
+		return ctrl.Result{Requeue: true}, nil
+	}
+	if rateLimited {
+		logger.Info("Request was rejected by a rate limiting API server, retrying after the delay it asked for",
+			"RetryAfter", retryAfter, "Error", err.Error())
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+	return r.withOwnershipRenewal(result), err
 }
 
 func (r *K8ssandraClusterReconciler) reconcile(ctx context.Context, kc *api.K8ssandraCluster, kcLogger logr.Logger) (ctrl.Result, error) {
@@ -124,6 +203,7 @@ func (r *K8ssandraClusterReconciler) reconcile(ctx context.Context, kc *api.K8ss
 	if recResult := r.reconcileSuperuserSecret(ctx, kc, kcLogger); recResult.Completed() {
 		return recResult.Output()
 	}
+	setClusterConnectionStatus(kc)
 
 	if recResult := r.reconcileReaperSecrets(ctx, kc, kcLogger); recResult.Completed() {
 		return recResult.Output()
@@ -148,20 +228,105 @@ func (r *K8ssandraClusterReconciler) reconcile(ctx context.Context, kc *api.K8ss
 
 	kcLogger.Info("All DCs reconciled")
 
+	if recResult := r.checkReconcileTimeout(ctx, kc, "the cluster CQL service", kcLogger); recResult.Completed() {
+		return recResult.Output()
+	}
+
+	if recResult := r.reconcileClusterCqlService(ctx, kc, kcLogger); recResult.Completed() {
+		return recResult.Output()
+	}
+
+	if recResult := r.checkSeedTopology(ctx, kc, actualDcs, kcLogger); recResult.Completed() {
+		return recResult.Output()
+	}
+
 	if recResult := r.afterCassandraReconciled(ctx, kc, actualDcs, kcLogger); recResult.Completed() {
 		return recResult.Output()
 	}
 
+	if recResult := r.checkReconcileTimeout(ctx, kc, "the monitoring checks", kcLogger); recResult.Completed() {
+		return recResult.Output()
+	}
+
+	if recResult := r.checkDiskUsage(ctx, kc, actualDcs, kcLogger); recResult.Completed() {
+		return recResult.Output()
+	}
+
+	if recResult := r.checkCompactionBacklog(ctx, kc, actualDcs, kcLogger); recResult.Completed() {
+		return recResult.Output()
+	}
+
+	if recResult := r.checkDroppedMutations(ctx, kc, actualDcs, kcLogger); recResult.Completed() {
+		return recResult.Output()
+	}
+
+	if recResult := r.checkManagementApiLatency(ctx, kc, actualDcs, kcLogger); recResult.Completed() {
+		return recResult.Output()
+	}
+
+	if recResult := r.checkTokenBalance(ctx, kc, actualDcs, kcLogger); recResult.Completed() {
+		return recResult.Output()
+	}
+
+	if recResult := r.checkGossipState(ctx, kc, actualDcs, kcLogger); recResult.Completed() {
+		return recResult.Output()
+	}
+
 	kcLogger.Info("Finished reconciling the k8ssandracluster")
+	clearReconcileTimedOutCondition(kc)
+
+	if kc.Status.GetConditionStatus(api.ManagementApiUnreachable) == v1.ConditionTrue {
+		// Retry the steps that were deferred because the management API was unreachable
+		return result.RequeueSoon(r.DefaultDelay).Output()
+	}
 
+	if pollInterval, found := monitoringPollInterval(kc); found {
+		return result.RequeueSoon(pollInterval).Output()
+	}
 	return result.Done().Output()
 }
 
+// monitoringPollInterval returns the shortest poll interval among the monitoring features enabled on kc, if any.
+func monitoringPollInterval(kc *api.K8ssandraCluster) (time.Duration, bool) {
+	var intervals []time.Duration
+	if monitoring := kc.Spec.Cassandra.DiskUsageMonitoring; monitoring != nil {
+		intervals = append(intervals, monitoring.GetPollInterval())
+	}
+	if monitoring := kc.Spec.Cassandra.CompactionBacklogMonitoring; monitoring != nil {
+		intervals = append(intervals, monitoring.GetPollInterval())
+	}
+	if monitoring := kc.Spec.Cassandra.DroppedMutationsMonitoring; monitoring != nil {
+		intervals = append(intervals, monitoring.GetPollInterval())
+	}
+	if monitoring := kc.Spec.Cassandra.ManagementApiLatencyMonitoring; monitoring != nil && len(kc.Spec.Cassandra.Datacenters) > 1 {
+		intervals = append(intervals, monitoring.GetPollInterval())
+	}
+	if monitoring := kc.Spec.Cassandra.TokenBalanceMonitoring; monitoring != nil {
+		intervals = append(intervals, monitoring.GetPollInterval())
+	}
+	if monitoring := kc.Spec.Cassandra.GossipMonitoring; monitoring != nil {
+		intervals = append(intervals, monitoring.GetPollInterval())
+	}
+	if len(intervals) == 0 {
+		return 0, false
+	}
+	pollInterval := intervals[0]
+	for _, interval := range intervals[1:] {
+		if interval < pollInterval {
+			pollInterval = interval
+		}
+	}
+	return pollInterval, true
+}
+
 func (r *K8ssandraClusterReconciler) afterCassandraReconciled(ctx context.Context, kc *api.K8ssandraCluster, dcs []*cassdcapi.CassandraDatacenter, logger logr.Logger) result.ReconcileResult {
 	for i, dcTemplate := range kc.Spec.Cassandra.Datacenters {
 		dc := dcs[i]
 		dcKey := utils.GetKey(dc)
 		logger := logger.WithValues("CassandraDatacenter", dcKey)
+		if recResult := r.checkReconcileTimeout(ctx, kc, "Stargate and Reaper of datacenter "+dc.Name, logger); recResult.Completed() {
+			return recResult
+		}
 		logger.Info("Reconciling Stargate and Reaper for dc " + dc.Name)
 		if remoteClient, err := r.ClientCache.GetRemoteClient(dcTemplate.K8sContext); err != nil {
 			logger.Error(err, "Failed to get remote client")
@@ -179,6 +344,10 @@ func (r *K8ssandraClusterReconciler) afterCassandraReconciled(ctx context.Contex
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *K8ssandraClusterReconciler) SetupWithManager(mgr ctrl.Manager, clusters []cluster.Cluster) error {
+	if err := r.checkStatusSubresource(context.Background(), mgr.GetAPIReader(), mgr.GetLogger().WithName("k8ssandracluster")); err != nil {
+		return err
+	}
+
 	cb := ctrl.NewControllerManagedBy(mgr).
 		For(&api.K8ssandraCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})) // No generation changed predicate here?
 
@@ -202,6 +371,21 @@ func (r *K8ssandraClusterReconciler) SetupWithManager(mgr ctrl.Manager, clusters
 		handler.EnqueueRequestsFromMapFunc(clusterLabelFilter))
 	cb = cb.Watches(&source.Kind{Type: &v1.ConfigMap{}},
 		handler.EnqueueRequestsFromMapFunc(clusterLabelFilter))
+	cb = cb.Watches(&source.Kind{Type: &v1.Secret{}},
+		handler.EnqueueRequestsFromMapFunc(clusterLabelFilter))
+
+	if r.ResyncPeriod > 0 {
+		events := make(chan event.GenericEvent)
+		if err := mgr.Add(&periodicResync{
+			client: mgr.GetClient(),
+			period: r.ResyncPeriod,
+			events: events,
+			logger: mgr.GetLogger().WithName("k8ssandracluster-resync"),
+		}); err != nil {
+			return err
+		}
+		cb = cb.Watches(&source.Channel{Source: events}, &handler.EnqueueRequestForObject{})
+	}
 
 	for _, c := range clusters {
 		cb = cb.Watches(source.NewKindWithCache(&cassdcapi.CassandraDatacenter{}, c.GetCache()),
@@ -212,6 +396,8 @@ func (r *K8ssandraClusterReconciler) SetupWithManager(mgr ctrl.Manager, clusters
 			handler.EnqueueRequestsFromMapFunc(clusterLabelFilter))
 		cb = cb.Watches(source.NewKindWithCache(&v1.ConfigMap{}, c.GetCache()),
 			handler.EnqueueRequestsFromMapFunc(clusterLabelFilter))
+		cb = cb.Watches(source.NewKindWithCache(&v1.Secret{}, c.GetCache()),
+			handler.EnqueueRequestsFromMapFunc(clusterLabelFilter))
 	}
 
 	return cb.Complete(r)
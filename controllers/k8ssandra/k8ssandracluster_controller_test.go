@@ -116,6 +116,10 @@ func TestK8ssandraCluster(t *testing.T) {
 	t.Run("PerNodeConfiguration", testEnv.ControllerTest(ctx, perNodeConfiguration))
 	t.Run("CreateSingleDcClusterWithVector", testEnv.ControllerTest(ctx, createSingleDcClusterWithVector))
 	t.Run("createSingleDcClusterWithMetricsAgent", testEnv.ControllerTest(ctx, createSingleDcClusterWithMetricsAgent))
+	t.Run("ContextInMaintenance", testEnv.ControllerTest(ctx, contextInMaintenance))
+	t.Run("ResumeFromSecondDatacenter", testEnv.ControllerTest(ctx, resumeFromSecondDatacenter))
+	t.Run("ManagementApiUnreachable", testEnv.ControllerTest(ctx, managementApiUnreachable))
+	t.Run("DcNamespaceMissing", testEnv.ControllerTest(ctx, dcNamespaceMissing))
 }
 
 // createSingleDcCluster verifies that the CassandraDatacenter is created and that the
@@ -267,6 +271,19 @@ func createSingleDcCluster(t *testing.T, ctx context.Context, f *framework.Frame
 		return true
 	}, timeout, interval, "timed out waiting for K8ssandraCluster status update")
 
+	t.Log("check that the connection info is reported in the K8ssandraCluster status")
+	err = f.Get(ctx, kcKey, kc)
+	require.NoError(err, "failed to get K8ssandraCluster")
+	require.NotNil(kc.Status.Connection, "cluster connection info not found")
+	assert.Equal(t, corev1.LocalObjectReference{Name: secret.DefaultSuperuserSecretName(kc.SanitizedName())}, kc.Status.Connection.SuperuserSecretRef)
+	verifySecretCreated(ctx, t, f, kc.Namespace, kc.Status.Connection.SuperuserSecretRef.Name)
+	assert.Equal(t, &api.DatacenterConnectionStatus{
+		K8sContext: f.DataPlaneContexts[1],
+		Namespace:  namespace,
+		Service:    dc.GetDatacenterServiceName(),
+		Port:       cassandra.NativePort,
+	}, kc.Status.Datacenters[dcKey.Name].Connection)
+
 	// Test that prometheus servicemonitor comes up when it is requested in the CassandraDatacenter.
 	kcPatch := client.MergeFrom(kc.DeepCopy())
 	kc.Spec.Cassandra.Datacenters[0].DatacenterOptions.Telemetry = &telemetryapi.TelemetrySpec{
@@ -931,7 +948,7 @@ func createSuperuserSecret(ctx context.Context, t *testing.T, f *framework.Frame
 			Namespace: kcKey.Namespace,
 			Name:      secretName,
 		},
-		Data: map[string][]byte{},
+		Data: map[string][]byte{"username": []byte(secretName), "password": []byte("password")},
 	}
 	labels.SetWatchedByK8ssandraCluster(secret, kcKey)
 
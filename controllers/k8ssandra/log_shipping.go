@@ -0,0 +1,89 @@
+package k8ssandra
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/reconciliation"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileLogShipping replicates the log shipping configuration of kc to the k8s context and namespace of the DC, and
+// adds the log shipping sidecar to the pod template of the DC. The source ConfigMap is labeled as watched by the
+// K8ssandraCluster so that changes to its contents are replicated, and roll out to the pods. When log shipping is
+// disabled, the replicated configuration is deleted.
+func (r *K8ssandraClusterReconciler) reconcileLogShipping(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcConfig *cassandra.DatacenterConfig,
+	remoteClient client.Client,
+	dcLogger logr.Logger,
+) result.ReconcileResult {
+	kcKey := utils.GetKey(kc)
+	configMapKey := client.ObjectKey{
+		Namespace: utils.FirstNonEmptyString(dcConfig.Meta.Namespace, kc.Namespace),
+		Name:      cassandra.LogShippingConfigMapName(kc.SanitizedName(), dcConfig.Meta.Name),
+	}
+
+	logShipping := kc.Spec.Cassandra.LogShipping
+	if logShipping == nil {
+		if err := deleteConfigMapIfExists(ctx, remoteClient, configMapKey, dcLogger); err != nil {
+			return result.Error(err)
+		}
+		return result.Continue()
+	}
+
+	source, err := r.getWatchedConfigMap(ctx, kc, logShipping.ConfigMapRef.Name, dcLogger)
+	if err != nil {
+		return result.Error(err)
+	}
+
+	desiredConfigMap := cassandra.NewLogShippingConfigMap(kc, dcConfig, source)
+	labels.SetWatchedByK8ssandraCluster(desiredConfigMap, kcKey)
+	recRes := reconciliation.ReconcileObject(ctx, remoteClient, r.DefaultDelay, *desiredConfigMap)
+	switch {
+	case recRes.IsError():
+		return recRes
+	case recRes.IsRequeue():
+		return recRes
+	}
+
+	configHash := utils.DeepHashString([]interface{}{source.Data, source.BinaryData})
+	cassandra.AddLogShippingSidecar(dcConfig, logShipping, configMapKey.Name, configHash)
+	dcLogger.Info("Log shipping ConfigMap successfully reconciled")
+	return result.Continue()
+}
+
+// getWatchedConfigMap returns the ConfigMap with the given name in the namespace of kc, after labeling it as watched by
+// the K8ssandraCluster so that changes to its contents trigger a reconcile.
+func (r *K8ssandraClusterReconciler) getWatchedConfigMap(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	name string,
+	logger logr.Logger,
+) (*corev1.ConfigMap, error) {
+	kcKey := utils.GetKey(kc)
+	configMapKey := client.ObjectKey{Namespace: kc.Namespace, Name: name}
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, configMapKey, configMap); err != nil {
+		logger.Error(err, "Failed to get ConfigMap", "ConfigMap", configMapKey)
+		return nil, err
+	}
+	if !labels.IsWatchedByK8ssandraCluster(configMap, kcKey) {
+		// Note that we do NOT set the ConfigMap as owned by the operator, we only want to be notified of changes to
+		// its contents.
+		patch := client.MergeFromWithOptions(configMap.DeepCopy())
+		labels.SetWatchedByK8ssandraCluster(configMap, kcKey)
+		if err := r.Client.Patch(ctx, configMap, patch); err != nil {
+			logger.Error(err, "Failed to set ConfigMap as watched by k8ssandra-operator", "ConfigMap", configMapKey)
+			return nil, err
+		}
+	}
+	return configMap, nil
+}
@@ -0,0 +1,131 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileLogShipping(t *testing.T) {
+	ctx := context.Background()
+	logger := testr.New(t)
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				LogShipping: &api.LogShipping{
+					Image:        "fluent/fluent-bit:2.1",
+					ConfigMapRef: corev1.LocalObjectReference{Name: "fluent-bit-config"},
+				},
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "cluster-1"},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2", Namespace: "dc2-ns"}, K8sContext: "cluster-2"},
+				},
+			},
+		},
+	}
+	source := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "fluent-bit-config"},
+		Data:       map[string]string{"fluent-bit.conf": "[INPUT]\n    Name tail\n"},
+	}
+	controlPlaneClient, err := test.NewFakeClient(source)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(controlPlaneClient)
+	remoteClients := map[string]client.Client{}
+	for _, k8sContext := range []string{"cluster-1", "cluster-2"} {
+		remoteClients[k8sContext], err = test.NewFakeClient()
+		require.NoError(t, err)
+	}
+	newDcConfigs := func() []*cassandra.DatacenterConfig {
+		return []*cassandra.DatacenterConfig{
+			{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "cluster-1"},
+			{Meta: api.EmbeddedObjectMeta{Name: "dc2", Namespace: "dc2-ns"}, K8sContext: "cluster-2"},
+		}
+	}
+	replicatedConfigMapKey := func(dcConfig *cassandra.DatacenterConfig) client.ObjectKey {
+		return client.ObjectKey{
+			Namespace: utils.FirstNonEmptyString(dcConfig.Meta.Namespace, kc.Namespace),
+			Name:      "test-" + dcConfig.Meta.Name + "-log-shipping",
+		}
+	}
+	reconcileDcs := func(dcConfigs []*cassandra.DatacenterConfig) {
+		for _, dcConfig := range dcConfigs {
+			// the first pass creates or updates the replicated ConfigMap and requeues
+			remoteClient := remoteClients[dcConfig.K8sContext]
+			for i := 0; i < 2; i++ {
+				if !r.reconcileLogShipping(ctx, kc, dcConfig, remoteClient, logger).Completed() {
+					break
+				}
+			}
+		}
+	}
+
+	dcConfigs := newDcConfigs()
+	reconcileDcs(dcConfigs)
+
+	require.NoError(t, controlPlaneClient.Get(ctx, client.ObjectKeyFromObject(source), source))
+	assert.True(t, labels.IsWatchedByK8ssandraCluster(source, utils.GetKey(kc)))
+
+	hashes := make([]string, 0, len(dcConfigs))
+	for _, dcConfig := range dcConfigs {
+		configMapKey := replicatedConfigMapKey(dcConfig)
+		replicated := &corev1.ConfigMap{}
+		require.NoError(t, remoteClients[dcConfig.K8sContext].Get(ctx, configMapKey, replicated))
+		assert.Equal(t, source.Data, replicated.Data)
+		assert.True(t, labels.IsPartOf(replicated, client.ObjectKey{Namespace: kc.Namespace, Name: kc.SanitizedName()}))
+
+		idx, found := cassandra.FindContainer(&dcConfig.PodTemplateSpec, cassandra.LogShippingContainerName)
+		require.True(t, found, "the sidecar must be added to %s", dcConfig.Meta.Name)
+		sidecar := dcConfig.PodTemplateSpec.Spec.Containers[idx]
+		assert.Equal(t, "fluent/fluent-bit:2.1", sidecar.Image)
+		assert.ElementsMatch(t, []corev1.VolumeMount{
+			{Name: "log-shipping-config", MountPath: "/fluent-bit/etc"},
+			{Name: "server-logs", MountPath: "/var/log/cassandra"},
+		}, sidecar.VolumeMounts)
+		volumeIdx, found := cassandra.FindVolume(&dcConfig.PodTemplateSpec, "log-shipping-config")
+		require.True(t, found)
+		assert.Equal(t, configMapKey.Name, dcConfig.PodTemplateSpec.Spec.Volumes[volumeIdx].ConfigMap.Name)
+
+		hash := dcConfig.PodTemplateSpec.Annotations[api.LogShippingConfigHashAnnotation]
+		assert.NotEmpty(t, hash)
+		hashes = append(hashes, hash)
+	}
+	assert.Equal(t, hashes[0], hashes[1])
+
+	// a change of the configuration is replicated, and rolls out to the pods
+	source.Data["fluent-bit.conf"] = "[INPUT]\n    Name tail\n    Path /var/log/cassandra/system.log\n"
+	require.NoError(t, controlPlaneClient.Update(ctx, source))
+	dcConfigs = newDcConfigs()
+	reconcileDcs(dcConfigs)
+	for _, dcConfig := range dcConfigs {
+		configMapKey := replicatedConfigMapKey(dcConfig)
+		replicated := &corev1.ConfigMap{}
+		require.NoError(t, remoteClients[dcConfig.K8sContext].Get(ctx, configMapKey, replicated))
+		assert.Equal(t, source.Data, replicated.Data)
+		assert.NotEqual(t, hashes[0], dcConfig.PodTemplateSpec.Annotations[api.LogShippingConfigHashAnnotation])
+	}
+
+	// disabling log shipping deletes the replicated configuration
+	kc.Spec.Cassandra.LogShipping = nil
+	dcConfigs = newDcConfigs()
+	reconcileDcs(dcConfigs)
+	for _, dcConfig := range dcConfigs {
+		configMapKey := replicatedConfigMapKey(dcConfig)
+		err := remoteClients[dcConfig.K8sContext].Get(ctx, configMapKey, &corev1.ConfigMap{})
+		assert.True(t, apierrors.IsNotFound(err))
+		_, found := cassandra.FindContainer(&dcConfig.PodTemplateSpec, cassandra.LogShippingContainerName)
+		assert.False(t, found)
+	}
+}
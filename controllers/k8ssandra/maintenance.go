@@ -0,0 +1,50 @@
+package k8ssandra
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// contextsInMaintenance returns the contexts put in maintenance through the spec of kc or the
+// ContextsInMaintenanceAnnotation.
+func contextsInMaintenance(kc *api.K8ssandraCluster) map[string]bool {
+	contexts := make(map[string]bool)
+	for _, k8sContext := range kc.Spec.ContextsInMaintenance {
+		contexts[k8sContext] = true
+	}
+	for _, k8sContext := range strings.Split(annotations.GetAnnotation(kc, api.ContextsInMaintenanceAnnotation), ",") {
+		if k8sContext = strings.TrimSpace(k8sContext); k8sContext != "" {
+			contexts[k8sContext] = true
+		}
+	}
+	return contexts
+}
+
+// setContextsInMaintenanceCondition sets the ContextsInMaintenance condition to true if at least one datacenter of kc
+// is deployed in a context in maintenance, and back to false otherwise.
+func setContextsInMaintenanceCondition(kc *api.K8ssandraCluster, inMaintenance map[string]bool) {
+	contexts := make([]string, 0)
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if inMaintenance[dcTemplate.K8sContext] && !utils.SliceContains(contexts, dcTemplate.K8sContext) {
+			contexts = append(contexts, dcTemplate.K8sContext)
+		}
+	}
+	sort.Strings(contexts)
+
+	if _, found := kc.Status.GetCondition(api.ContextsInMaintenance); !found && len(contexts) == 0 {
+		return
+	}
+	status := corev1.ConditionFalse
+	message := ""
+	if len(contexts) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("The datacenters of the following contexts are not reconciled during their maintenance: %s", strings.Join(contexts, ", "))
+	}
+	kc.Status.SetConditionStatus(api.ContextsInMaintenance, status, message)
+}
@@ -0,0 +1,188 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/test/framework"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestContextsInMaintenance(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "test",
+			Annotations: map[string]string{api.ContextsInMaintenanceAnnotation: "cluster-2, cluster-3,"},
+		},
+		Spec: api.K8ssandraClusterSpec{
+			ContextsInMaintenance: []string{"cluster-1"},
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "cluster-0"},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, K8sContext: "cluster-1"},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc3"}, K8sContext: "cluster-2"},
+				},
+			},
+		},
+	}
+
+	inMaintenance := contextsInMaintenance(kc)
+	assert.Equal(t, map[string]bool{"cluster-1": true, "cluster-2": true, "cluster-3": true}, inMaintenance)
+
+	setContextsInMaintenanceCondition(kc, inMaintenance)
+	condition, found := kc.Status.GetCondition(api.ContextsInMaintenance)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "The datacenters of the following contexts are not reconciled during their maintenance: cluster-1, cluster-2", condition.Message)
+
+	setContextsInMaintenanceCondition(kc, map[string]bool{})
+	assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.ContextsInMaintenance))
+
+	kc.Status.Conditions = nil
+	setContextsInMaintenanceCondition(kc, map[string]bool{})
+	_, found = kc.Status.GetCondition(api.ContextsInMaintenance)
+	assert.False(t, found, "the condition is only set once a context was in maintenance")
+}
+
+func TestFindSeedsFrozenDuringMaintenance(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			ContextsInMaintenance: []string{"cluster-2"},
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, K8sContext: "cluster-2"},
+				},
+			},
+		},
+		Status: api.K8ssandraClusterStatus{
+			Seeds: []api.SeedStatus{{Datacenter: "dc2", Name: "test-dc2-default-sts-0", Address: "10.0.1.1"}},
+		},
+	}
+	localClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(localClient)
+	r.ClientCache.AddClient("cluster-2", &failingListClient{Client: localClient, err: apierrors.NewServiceUnavailable("maintenance")})
+
+	seeds, err := r.findSeeds(context.Background(), kc, "test", testr.New(t))
+	require.NoError(t, err, "the pods of the contexts in maintenance are not listed")
+	require.Len(t, seeds, 1)
+	assert.Equal(t, "10.0.1.1", seeds[0].Status.PodIP)
+	assert.Equal(t, []api.SeedStatus{{Datacenter: "dc2", Name: "test-dc2-default-sts-0", Address: "10.0.1.1"}}, kc.Status.Seeds)
+}
+
+// contextInMaintenance verifies that the datacenters of a context in maintenance are not created until the
+// maintenance ends.
+func contextInMaintenance(t *testing.T, ctx context.Context, f *framework.Framework, namespace string) {
+	require := require.New(t)
+
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "maintenance",
+		},
+		Spec: api.K8ssandraClusterSpec{
+			ContextsInMaintenance: []string{f.DataPlaneContexts[1]},
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{
+						Meta:       api.EmbeddedObjectMeta{Name: "dc1"},
+						K8sContext: f.DataPlaneContexts[0],
+						Size:       3,
+						DatacenterOptions: api.DatacenterOptions{
+							ServerVersion: "3.11.14",
+							StorageConfig: &cassdcapi.StorageConfig{
+								CassandraDataVolumeClaimSpec: &corev1.PersistentVolumeClaimSpec{
+									StorageClassName: &defaultStorageClass,
+								},
+							},
+						},
+					},
+					{
+						Meta:       api.EmbeddedObjectMeta{Name: "dc2"},
+						K8sContext: f.DataPlaneContexts[1],
+						Size:       3,
+						DatacenterOptions: api.DatacenterOptions{
+							ServerVersion: "3.11.14",
+							StorageConfig: &cassdcapi.StorageConfig{
+								CassandraDataVolumeClaimSpec: &corev1.PersistentVolumeClaimSpec{
+									StorageClassName: &defaultStorageClass,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := f.Client.Create(ctx, kc)
+	require.NoError(err, "failed to create K8ssandraCluster")
+
+	verifyFinalizerAdded(ctx, t, f, client.ObjectKey{Namespace: kc.Namespace, Name: kc.Name})
+	verifySuperuserSecretCreated(ctx, t, f, kc)
+	verifyReplicatedSecretReconciled(ctx, t, f, kc)
+	verifySystemReplicationAnnotationSet(ctx, t, f, kc)
+
+	t.Log("check that dc1 was created")
+	dc1Key := framework.ClusterKey{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "dc1"}, K8sContext: f.DataPlaneContexts[0]}
+	require.Eventually(f.DatacenterExists(ctx, dc1Key), timeout, interval)
+
+	t.Log("update dc1 status to ready")
+	err = f.SetDatacenterStatusReady(ctx, dc1Key)
+	require.NoError(err, "failed to set dc1 status ready")
+
+	t.Log("check that the ContextsInMaintenance condition is set")
+	kcKey := framework.ClusterKey{K8sContext: f.ControlPlaneContext, NamespacedName: types.NamespacedName{Namespace: namespace, Name: kc.Name}}
+	require.Eventually(func() bool {
+		kc := &api.K8ssandraCluster{}
+		if err := f.Get(ctx, kcKey, kc); err != nil {
+			t.Logf("failed to get K8ssandraCluster: %v", err)
+			return false
+		}
+		return kc.Status.GetConditionStatus(api.ContextsInMaintenance) == corev1.ConditionTrue
+	}, timeout, interval, "timed out waiting for the ContextsInMaintenance condition")
+
+	t.Log("check that dc2 is not created during the maintenance of its context")
+	dc2Key := framework.ClusterKey{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "dc2"}, K8sContext: f.DataPlaneContexts[1]}
+	require.Never(f.DatacenterExists(ctx, dc2Key), timeout, interval)
+
+	t.Log("end the maintenance")
+	err = f.Get(ctx, kcKey, kc)
+	require.NoError(err, "failed to get K8ssandraCluster")
+	patch := client.MergeFromWithOptions(kc.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	kc.Spec.ContextsInMaintenance = nil
+	err = f.Client.Patch(ctx, kc, patch)
+	require.NoError(err, "failed to update K8ssandraCluster")
+
+	t.Log("check that dc2 was created")
+	require.Eventually(f.DatacenterExists(ctx, dc2Key), timeout, interval)
+
+	t.Log("check that the ContextsInMaintenance condition is cleared")
+	require.Eventually(func() bool {
+		kc := &api.K8ssandraCluster{}
+		if err := f.Get(ctx, kcKey, kc); err != nil {
+			t.Logf("failed to get K8ssandraCluster: %v", err)
+			return false
+		}
+		return kc.Status.GetConditionStatus(api.ContextsInMaintenance) == corev1.ConditionFalse
+	}, timeout, interval, "timed out waiting for the ContextsInMaintenance condition to be cleared")
+
+	t.Log("deleting K8ssandraCluster")
+	err = f.DeleteK8ssandraCluster(ctx, client.ObjectKey{Namespace: kc.Namespace, Name: kc.Name}, timeout, interval)
+	require.NoError(err, "failed to delete K8ssandraCluster")
+	f.AssertObjectDoesNotExist(ctx, t, dc1Key, &cassdcapi.CassandraDatacenter{}, timeout, interval)
+	f.AssertObjectDoesNotExist(ctx, t, dc2Key, &cassdcapi.CassandraDatacenter{}, timeout, interval)
+}
@@ -0,0 +1,88 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkManagementApiLatency reports the round-trip time of the management API requests sent to each DC in the status
+// of kc, and sets the ManagementApiLatencyHigh condition when it exceeds the configured threshold. Measurements happen
+// at most once per poll interval, and only when the cluster has more than one DC. Failing to measure the latency of a
+// DC is logged but does not fail the reconcile.
+func (r *K8ssandraClusterReconciler) checkManagementApiLatency(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcs []*cassdcapi.CassandraDatacenter,
+	logger logr.Logger,
+) result.ReconcileResult {
+	monitoring := kc.Spec.Cassandra.ManagementApiLatencyMonitoring
+	if monitoring == nil || len(dcs) < 2 || !monitoringCheckDue(kc, dcs, monitoring.GetPollInterval(), latencyLastCheckTime) {
+		return result.Continue()
+	}
+
+	now := metav1.Now()
+	latencies := make(map[string]time.Duration)
+	err := r.pollDatacenters(ctx, kc, dcs, logger, "Failed to measure latency",
+		func(dc *cassdcapi.CassandraDatacenter, mgmtApi cassandra.ManagementApiFacade) error {
+			latency, err := mgmtApi.MeasureManagementApiLatency()
+			if err != nil {
+				return err
+			}
+			latencies[dc.Name] = latency
+			if kdcStatus, found := kc.Status.Datacenters[dc.Name]; found {
+				kdcStatus.Latency = &api.DatacenterLatencyStatus{
+					RoundTripTime: metav1.Duration{Duration: latency},
+					LastCheckTime: &now,
+				}
+				kc.Status.Datacenters[dc.Name] = kdcStatus
+			}
+			return nil
+		})
+	if err != nil {
+		return result.Error(err)
+	}
+	setManagementApiLatencyCondition(kc, latencies, monitoring.GetThreshold())
+	return result.Continue()
+}
+
+// latencyLastCheckTime returns the time the latency of the DC was last measured, or nil if it never was.
+func latencyLastCheckTime(status api.K8ssandraStatus) *metav1.Time {
+	if status.Latency == nil {
+		return nil
+	}
+	return status.Latency.LastCheckTime
+}
+
+// setManagementApiLatencyCondition sets the ManagementApiLatencyHigh condition to true if the latency of at least one DC
+// exceeds threshold, and to false otherwise.
+func setManagementApiLatencyCondition(kc *api.K8ssandraCluster, latencies map[string]time.Duration, threshold time.Duration) {
+	highLatencies := make([]string, 0)
+	for dcName, latency := range latencies {
+		if latency > threshold {
+			highLatencies = append(highLatencies, fmt.Sprintf("%s (%s)", dcName, latency.Round(time.Millisecond)))
+		}
+	}
+	sort.Strings(highLatencies)
+
+	status := corev1.ConditionFalse
+	message := ""
+	if len(highLatencies) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("Round-trip time of the management API requests from the operator exceeds %s in "+
+			"datacenters: %s. This is not the latency between the nodes, but when the operator runs next to one of the "+
+			"datacenters it is a sign that the latency between datacenters may destabilize gossip.",
+			threshold, strings.Join(highLatencies, ", "))
+	}
+	kc.Status.SetConditionStatus(api.ManagementApiLatencyHigh, status, message)
+}
@@ -0,0 +1,140 @@
+package k8ssandra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckManagementApiLatency(t *testing.T) {
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					ManagementApiLatencyMonitoring: &api.ManagementApiLatencyMonitoring{Threshold: &metav1.Duration{Duration: 50 * time.Millisecond}},
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+					},
+				},
+			},
+			Status: api.K8ssandraClusterStatus{
+				Datacenters: map[string]api.K8ssandraStatus{"dc1": {}, "dc2": {}},
+			},
+		}
+	}
+	dcs := []*cassdcapi.CassandraDatacenter{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc2"}},
+	}
+
+	newReconciler := func(t *testing.T, mgmtApis map[string]*test.FakeManagementApiFacade) *K8ssandraClusterReconciler {
+		fakeClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		factory := &test.FakeManagementApiFactory{}
+		factory.SetT(t)
+		factory.SetAdapter(func(_ context.Context, dc *cassdcapi.CassandraDatacenter, _ client.Client, _ logr.Logger) (cassandra.ManagementApiFacade, error) {
+			return mgmtApis[dc.Name], nil
+		})
+		r.ManagementApi = factory
+		return r
+	}
+	newMgmtApi := func(latency time.Duration, err error) *test.FakeManagementApiFacade {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.MeasureManagementApiLatency).Return(latency, err)
+		return mgmtApi
+	}
+
+	t.Run("latency above threshold", func(t *testing.T) {
+		mgmtApis := map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(2*time.Millisecond, nil),
+			"dc2": newMgmtApi(120*time.Millisecond, nil),
+		}
+		kc := newKc()
+
+		recResult := newReconciler(t, mgmtApis).checkManagementApiLatency(context.Background(), kc, dcs, testr.New(t))
+		assert.False(t, recResult.Completed())
+
+		latency := kc.Status.Datacenters["dc2"].Latency
+		require.NotNil(t, latency)
+		assert.Equal(t, 120*time.Millisecond, latency.RoundTripTime.Duration)
+		assert.NotNil(t, latency.LastCheckTime)
+		assert.Equal(t, 2*time.Millisecond, kc.Status.Datacenters["dc1"].Latency.RoundTripTime.Duration)
+		condition, found := kc.Status.GetCondition(api.ManagementApiLatencyHigh)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Contains(t, condition.Message, "dc2 (120ms)")
+		assert.Contains(t, condition.Message, "not the latency between the nodes")
+		assert.NotContains(t, condition.Message, "dc1")
+	})
+
+	t.Run("latency below threshold", func(t *testing.T) {
+		mgmtApis := map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(2*time.Millisecond, nil),
+			"dc2": newMgmtApi(30*time.Millisecond, nil),
+		}
+		kc := newKc()
+
+		newReconciler(t, mgmtApis).checkManagementApiLatency(context.Background(), kc, dcs, testr.New(t))
+
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.ManagementApiLatencyHigh))
+	})
+
+	t.Run("management API failure in one dc", func(t *testing.T) {
+		mgmtApis := map[string]*test.FakeManagementApiFacade{
+			"dc1": newMgmtApi(0, errors.New("connection refused")),
+			"dc2": newMgmtApi(80*time.Millisecond, nil),
+		}
+		kc := newKc()
+
+		recResult := newReconciler(t, mgmtApis).checkManagementApiLatency(context.Background(), kc, dcs, testr.New(t))
+		assert.False(t, recResult.Completed())
+
+		assert.Nil(t, kc.Status.Datacenters["dc1"].Latency)
+		assert.Equal(t, corev1.ConditionTrue, kc.Status.GetConditionStatus(api.ManagementApiLatencyHigh))
+	})
+
+	t.Run("single dc", func(t *testing.T) {
+		mgmtApis := map[string]*test.FakeManagementApiFacade{"dc1": test.NewFakeManagementApiFacade()}
+		kc := newKc()
+
+		newReconciler(t, mgmtApis).checkManagementApiLatency(context.Background(), kc, dcs[:1], testr.New(t))
+
+		mgmtApis["dc1"].AssertNotCalled(t, test.MeasureManagementApiLatency)
+		assert.Equal(t, corev1.ConditionUnknown, kc.Status.GetConditionStatus(api.ManagementApiLatencyHigh))
+	})
+
+	t.Run("measured within poll interval", func(t *testing.T) {
+		mgmtApis := map[string]*test.FakeManagementApiFacade{
+			"dc1": test.NewFakeManagementApiFacade(),
+			"dc2": test.NewFakeManagementApiFacade(),
+		}
+		kc := newKc()
+		lastCheck := metav1.NewTime(time.Now().Add(-time.Minute))
+		for dcName := range kc.Status.Datacenters {
+			kc.Status.Datacenters[dcName] = api.K8ssandraStatus{Latency: &api.DatacenterLatencyStatus{LastCheckTime: &lastCheck}}
+		}
+
+		newReconciler(t, mgmtApis).checkManagementApiLatency(context.Background(), kc, dcs, testr.New(t))
+
+		for _, mgmtApi := range mgmtApis {
+			mgmtApi.AssertNotCalled(t, test.MeasureManagementApiLatency)
+		}
+		assert.Equal(t, corev1.ConditionUnknown, kc.Status.GetConditionStatus(api.ManagementApiLatencyHigh))
+	})
+}
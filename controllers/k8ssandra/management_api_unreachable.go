@@ -0,0 +1,45 @@
+package k8ssandra
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	kerrors "github.com/k8ssandra/k8ssandra-operator/pkg/errors"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// deferManagementApiSteps returns true if recResult, the result of the steps that depend on the management API for
+// the datacenter dcName, failed because the management API is unreachable and the ManagementApiUnreachablePolicy of kc
+// is Defer. In that case the reconcile carries on without those steps.
+func deferManagementApiSteps(kc *api.K8ssandraCluster, dcName string, recResult result.ReconcileResult, logger logr.Logger) bool {
+	if kc.Spec.Cassandra.ManagementApiUnreachablePolicy != api.ManagementApiUnreachablePolicyDefer {
+		return false
+	}
+	if _, err := recResult.Output(); !kerrors.IsManagementApiUnreachable(err) {
+		return false
+	}
+	logger.Info("The management API is unreachable, deferring the steps that depend on it", "CassandraDatacenter", dcName)
+	return true
+}
+
+// setManagementApiUnreachableCondition sets the ManagementApiUnreachable condition to true if the steps that depend
+// on the management API were deferred for at least one datacenter, and back to false otherwise.
+func setManagementApiUnreachableCondition(kc *api.K8ssandraCluster, deferredDcs []string) {
+	if _, found := kc.Status.GetCondition(api.ManagementApiUnreachable); !found && len(deferredDcs) == 0 {
+		return
+	}
+	sorted := append([]string{}, deferredDcs...)
+	sort.Strings(sorted)
+
+	status := corev1.ConditionFalse
+	message := ""
+	if len(sorted) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("The management API is unreachable, the steps that depend on it are deferred for datacenters: %s", strings.Join(sorted, ", "))
+	}
+	kc.Status.SetConditionStatus(api.ManagementApiUnreachable, status, message)
+}
@@ -0,0 +1,171 @@
+package k8ssandra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	kerrors "github.com/k8ssandra/k8ssandra-operator/pkg/errors"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/mocks"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/test/framework"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDeferManagementApiSteps(t *testing.T) {
+	unreachable := result.Error(kerrors.NewManagementApiUnreachableError("no healthy coordinator found among datacenter dc1 pods"))
+	tests := []struct {
+		name      string
+		policy    api.ManagementApiUnreachablePolicy
+		recResult result.ReconcileResult
+		want      bool
+	}{
+		{"default policy", "", unreachable, false},
+		{"fail", api.ManagementApiUnreachablePolicyFail, unreachable, false},
+		{"defer", api.ManagementApiUnreachablePolicyDefer, unreachable, true},
+		{"defer, other error", api.ManagementApiUnreachablePolicyDefer, result.Error(errors.New("invalid replication")), false},
+		{"defer, requeue", api.ManagementApiUnreachablePolicyDefer, result.RequeueSoon(0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc := &api.K8ssandraCluster{
+				Spec: api.K8ssandraClusterSpec{
+					Cassandra: &api.CassandraClusterTemplate{ManagementApiUnreachablePolicy: tt.policy},
+				},
+			}
+			assert.Equal(t, tt.want, deferManagementApiSteps(kc, "dc1", tt.recResult, logr.Discard()))
+		})
+	}
+}
+
+func TestSetManagementApiUnreachableCondition(t *testing.T) {
+	kc := &api.K8ssandraCluster{}
+
+	setManagementApiUnreachableCondition(kc, []string{})
+	_, found := kc.Status.GetCondition(api.ManagementApiUnreachable)
+	assert.False(t, found, "the condition is only set once steps have been deferred")
+
+	setManagementApiUnreachableCondition(kc, []string{"dc2", "dc1"})
+	condition, found := kc.Status.GetCondition(api.ManagementApiUnreachable)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "The management API is unreachable, the steps that depend on it are deferred for datacenters: dc1, dc2", condition.Message)
+
+	setManagementApiUnreachableCondition(kc, []string{})
+	assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.ManagementApiUnreachable))
+}
+
+// managementApiUnreachable verifies that, with the Defer ManagementApiUnreachablePolicy, the datacenters are still
+// reconciled when the management API is unreachable, while the steps that depend on it are deferred until it is
+// reachable again.
+func managementApiUnreachable(t *testing.T, ctx context.Context, f *framework.Framework, namespace string) {
+	require := require.New(t)
+
+	mgmtApi := new(mocks.ManagementApiFacade)
+	mgmtApi.On(test.EnsureKeyspaceReplication, mock.Anything, mock.Anything).
+		Return(kerrors.NewManagementApiUnreachableError("no pods in READY state found in datacenter dc1"))
+	managementApiFactory.SetAdapter(func(context.Context, *cassdcapi.CassandraDatacenter, client.Client, logr.Logger) (cassandra.ManagementApiFacade, error) {
+		return mgmtApi, nil
+	})
+
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "unreachable",
+		},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				ManagementApiUnreachablePolicy: api.ManagementApiUnreachablePolicyDefer,
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{
+						Meta:       api.EmbeddedObjectMeta{Name: "dc1"},
+						K8sContext: f.DataPlaneContexts[0],
+						Size:       3,
+						DatacenterOptions: api.DatacenterOptions{
+							ServerVersion: "3.11.14",
+							StorageConfig: &cassdcapi.StorageConfig{
+								CassandraDataVolumeClaimSpec: &corev1.PersistentVolumeClaimSpec{
+									StorageClassName: &defaultStorageClass,
+								},
+							},
+						},
+					},
+					{
+						Meta:       api.EmbeddedObjectMeta{Name: "dc2"},
+						K8sContext: f.DataPlaneContexts[1],
+						Size:       3,
+						DatacenterOptions: api.DatacenterOptions{
+							ServerVersion: "3.11.14",
+							StorageConfig: &cassdcapi.StorageConfig{
+								CassandraDataVolumeClaimSpec: &corev1.PersistentVolumeClaimSpec{
+									StorageClassName: &defaultStorageClass,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := f.Client.Create(ctx, kc)
+	require.NoError(err, "failed to create K8ssandraCluster")
+
+	verifyFinalizerAdded(ctx, t, f, client.ObjectKey{Namespace: kc.Namespace, Name: kc.Name})
+	verifySuperuserSecretCreated(ctx, t, f, kc)
+	verifyReplicatedSecretReconciled(ctx, t, f, kc)
+	verifySystemReplicationAnnotationSet(ctx, t, f, kc)
+
+	t.Log("check that dc1 was created")
+	dc1Key := framework.ClusterKey{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "dc1"}, K8sContext: f.DataPlaneContexts[0]}
+	require.Eventually(f.DatacenterExists(ctx, dc1Key), timeout, interval)
+
+	t.Log("update dc1 status to ready")
+	err = f.SetDatacenterStatusReady(ctx, dc1Key)
+	require.NoError(err, "failed to set dc1 status ready")
+
+	t.Log("check that dc2 is created although the management API of dc1 is unreachable")
+	dc2Key := framework.ClusterKey{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "dc2"}, K8sContext: f.DataPlaneContexts[1]}
+	require.Eventually(f.DatacenterExists(ctx, dc2Key), timeout, interval)
+
+	t.Log("update dc2 status to ready")
+	err = f.SetDatacenterStatusReady(ctx, dc2Key)
+	require.NoError(err, "failed to set dc2 status ready")
+
+	t.Log("check that the ManagementApiUnreachable condition is set")
+	kcKey := framework.ClusterKey{K8sContext: f.ControlPlaneContext, NamespacedName: types.NamespacedName{Namespace: namespace, Name: kc.Name}}
+	require.Eventually(func() bool {
+		kc := &api.K8ssandraCluster{}
+		if err := f.Get(ctx, kcKey, kc); err != nil {
+			t.Logf("failed to get K8ssandraCluster: %v", err)
+			return false
+		}
+		condition, found := kc.Status.GetCondition(api.ManagementApiUnreachable)
+		return found && condition.Status == corev1.ConditionTrue && condition.Message ==
+			"The management API is unreachable, the steps that depend on it are deferred for datacenters: dc1, dc2"
+	}, timeout, interval, "timed out waiting for the ManagementApiUnreachable condition")
+
+	t.Log("make the management API reachable again")
+	managementApiFactory.UseDefaultAdapter()
+
+	t.Log("check that the ManagementApiUnreachable condition is cleared")
+	require.Eventually(func() bool {
+		kc := &api.K8ssandraCluster{}
+		if err := f.Get(ctx, kcKey, kc); err != nil {
+			t.Logf("failed to get K8ssandraCluster: %v", err)
+			return false
+		}
+		return kc.Status.GetConditionStatus(api.ManagementApiUnreachable) == corev1.ConditionFalse
+	}, timeout, interval, "timed out waiting for the ManagementApiUnreachable condition to be cleared")
+}
@@ -0,0 +1,66 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// migrationVersion must be incremented whenever a change to the operator alters the hash or the labels of the
+// CassandraDatacenters it generates without changing their spec, so that existing datacenters are re-stamped instead
+// of updated after the upgrade.
+const migrationVersion = "1"
+
+// migrationPending returns true if the CassandraDatacenters of kc have not been re-stamped since the operator was
+// upgraded.
+func (r *K8ssandraClusterReconciler) migrationPending(kc *api.K8ssandraCluster) bool {
+	return r.RestampOnUpgrade && !annotations.HasAnnotationWithValue(kc, api.MigrationVersionAnnotation, migrationVersion)
+}
+
+// restampDatacenter merges the annotations, including the hash, and the labels of desiredDc into actualDc when their
+// specs are semantically equal, which avoids sending a spec update for a change that only affects the operator
+// metadata. A field set in actualDc but unset in desiredDc makes the specs differ: it may be a setting that the new
+// operator version removes, which must be sent. When the difference is only a field defaulted by the API server, the
+// datacenter is updated as it would be without the migration. It returns true if actualDc was re-stamped.
+func (r *K8ssandraClusterReconciler) restampDatacenter(
+	ctx context.Context,
+	actualDc, desiredDc *cassdcapi.CassandraDatacenter,
+	remoteClient client.Client) (bool, error) {
+
+	if !equality.Semantic.DeepEqual(desiredDc.Spec, actualDc.Spec) {
+		return false, nil
+	}
+
+	patch := client.MergeFrom(actualDc.DeepCopy())
+	actualDc.Labels = utils.MergeMap(actualDc.Labels, desiredDc.Labels)
+	actualDc.Annotations = utils.MergeMap(actualDc.Annotations, desiredDc.Annotations)
+	if err := remoteClient.Patch(ctx, actualDc, patch); err != nil {
+		return false, fmt.Errorf("failed to re-stamp CassandraDatacenter %s: %v", utils.GetKey(actualDc), err)
+	}
+	return true, nil
+}
+
+// recordMigration marks the migration as done once all the CassandraDatacenters of kc have been reconciled.
+func (r *K8ssandraClusterReconciler) recordMigration(ctx context.Context, kc *api.K8ssandraCluster) result.ReconcileResult {
+	if !r.migrationPending(kc) {
+		return result.Continue()
+	}
+
+	// Patching kc overwrites its status with the persisted one, which would discard the changes made by this
+	// reconciliation.
+	status := kc.Status.DeepCopy()
+	patch := client.MergeFrom(kc.DeepCopy())
+	annotations.AddAnnotation(kc, api.MigrationVersionAnnotation, migrationVersion)
+	if err := r.Client.Patch(ctx, kc, patch); err != nil {
+		return result.Error(fmt.Errorf("failed to add %s annotation: %v", api.MigrationVersionAnnotation, err))
+	}
+	kc.Status = *status
+	return result.Continue()
+}
@@ -0,0 +1,124 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// writeCountingClient counts the writes made through it.
+type writeCountingClient struct {
+	client.Client
+	updates int
+	patches int
+}
+
+func (c *writeCountingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.updates++
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *writeCountingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.patches++
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func TestRestampDatacenter(t *testing.T) {
+	ctx := context.Background()
+	newDc := func(hash string, labels map[string]string) *cassdcapi.CassandraDatacenter {
+		return &cassdcapi.CassandraDatacenter{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "dc1",
+				Labels:      labels,
+				Annotations: map[string]string{api.ResourceHashAnnotation: hash},
+			},
+			Spec: cassdcapi.CassandraDatacenterSpec{ClusterName: "cluster1", Size: 3, ServerVersion: "4.0.6"},
+		}
+	}
+
+	t.Run("spec unchanged", func(t *testing.T) {
+		actualDc := newDc("old-hash", map[string]string{"env": "prod"})
+		fakeClient, err := test.NewFakeClient(actualDc)
+		require.NoError(t, err)
+		countingClient := &writeCountingClient{Client: fakeClient}
+		r := newTracingTestReconciler(fakeClient)
+
+		desiredDc := newDc("new-hash", map[string]string{api.PartOfLabel: api.PartOfLabelValue})
+		restamped, err := r.restampDatacenter(ctx, actualDc, desiredDc, countingClient)
+		require.NoError(t, err)
+		assert.True(t, restamped)
+		assert.Equal(t, 0, countingClient.updates, "the spec should not be updated")
+
+		storedDc := &cassdcapi.CassandraDatacenter{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(actualDc), storedDc))
+		assert.Equal(t, "new-hash", storedDc.Annotations[api.ResourceHashAnnotation])
+		assert.Equal(t, map[string]string{"env": "prod", api.PartOfLabel: api.PartOfLabelValue}, storedDc.Labels)
+		assert.Equal(t, int32(3), storedDc.Spec.Size)
+	})
+
+	t.Run("spec changed", func(t *testing.T) {
+		actualDc := newDc("old-hash", nil)
+		fakeClient, err := test.NewFakeClient(actualDc)
+		require.NoError(t, err)
+		countingClient := &writeCountingClient{Client: fakeClient}
+		r := newTracingTestReconciler(fakeClient)
+
+		desiredDc := newDc("new-hash", nil)
+		desiredDc.Spec.Size = 6
+		restamped, err := r.restampDatacenter(ctx, actualDc, desiredDc, countingClient)
+		require.NoError(t, err)
+		assert.False(t, restamped)
+		assert.Equal(t, 0, countingClient.patches)
+		assert.Equal(t, "old-hash", actualDc.Annotations[api.ResourceHashAnnotation])
+	})
+
+	t.Run("field removed from the spec", func(t *testing.T) {
+		actualDc := newDc("old-hash", nil)
+		actualDc.Spec.ConfigBuilderImage = "datastax/cass-config-builder:1.0-ubi7"
+		fakeClient, err := test.NewFakeClient(actualDc)
+		require.NoError(t, err)
+		countingClient := &writeCountingClient{Client: fakeClient}
+		r := newTracingTestReconciler(fakeClient)
+
+		restamped, err := r.restampDatacenter(ctx, actualDc, newDc("new-hash", nil), countingClient)
+		require.NoError(t, err)
+		assert.False(t, restamped, "the removal of a field must be sent as a spec update")
+		assert.Equal(t, 0, countingClient.patches)
+	})
+}
+
+func TestRecordMigration(t *testing.T) {
+	ctx := context.Background()
+	kc := &api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster1"}}
+	fakeClient, err := test.NewFakeClient(kc)
+	require.NoError(t, err)
+	countingClient := &writeCountingClient{Client: fakeClient}
+	r := newTracingTestReconciler(countingClient)
+	r.RestampOnUpgrade = true
+
+	assert.True(t, r.migrationPending(kc))
+	kc.Status.Error = "None"
+	assert.Equal(t, result.Continue(), r.recordMigration(ctx, kc))
+	assert.Equal(t, "None", kc.Status.Error, "the status of the reconciliation should be kept")
+	assert.False(t, r.migrationPending(kc))
+
+	assert.Equal(t, result.Continue(), r.recordMigration(ctx, kc))
+	assert.Equal(t, 1, countingClient.patches, "the migration should only be recorded once")
+
+	storedKc := &api.K8ssandraCluster{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(kc), storedKc))
+	assert.Equal(t, migrationVersion, storedKc.Annotations[api.MigrationVersionAnnotation])
+
+	r.RestampOnUpgrade = false
+	storedKc.Annotations = nil
+	assert.False(t, r.migrationPending(storedKc), "migrations are disabled")
+}
@@ -0,0 +1,98 @@
+package k8ssandra
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The monitoring checks poll the management API of the Cassandra nodes at most once per poll interval, record what
+// they found in the status of each DC along with the time of the check, and set a condition when a threshold is
+// exceeded. Failing to poll a DC is logged but does not fail the reconcile.
+
+// monitoringCheckDue returns true if at least one of dcs has not been checked within pollInterval. lastCheckTime
+// returns the time a DC was last checked, from its status, or nil if it was never checked.
+func monitoringCheckDue(
+	kc *api.K8ssandraCluster,
+	dcs []*cassdcapi.CassandraDatacenter,
+	pollInterval time.Duration,
+	lastCheckTime func(status api.K8ssandraStatus) *metav1.Time,
+) bool {
+	for _, dc := range dcs {
+		checkTime := lastCheckTime(kc.Status.Datacenters[dc.Name])
+		if checkTime == nil || time.Since(checkTime.Time) >= pollInterval {
+			return true
+		}
+	}
+	return false
+}
+
+// pollDatacenters calls poll with the management API of each DC of dcs that is not stopped. When the management API
+// of a DC cannot be reached, or poll fails, the error is logged with failureMessage and the DC is skipped. Only
+// failing to get the client of a k8s context is returned.
+func (r *K8ssandraClusterReconciler) pollDatacenters(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcs []*cassdcapi.CassandraDatacenter,
+	logger logr.Logger,
+	failureMessage string,
+	poll func(dc *cassdcapi.CassandraDatacenter, mgmtApi cassandra.ManagementApiFacade) error,
+) error {
+	for _, dc := range dcs {
+		if dc.Spec.Stopped {
+			continue
+		}
+		dcLogger := logger.WithValues("CassandraDatacenter", dc.Name)
+		remoteClient, err := r.ClientCache.GetRemoteClient(getDatacenterK8sContext(kc, dc.Name))
+		if err != nil {
+			dcLogger.Error(err, "Failed to get remote client")
+			return err
+		}
+		mgmtApi, err := r.ManagementApi.NewManagementApiFacade(ctx, dc, remoteClient, dcLogger)
+		if err != nil {
+			dcLogger.Error(err, failureMessage)
+			continue
+		}
+		if err := poll(dc, mgmtApi); err != nil {
+			dcLogger.Error(err, failureMessage)
+		}
+	}
+	return nil
+}
+
+// coordinatorEndpointStates returns the gossip state of all the nodes of the cluster, as seen by the first DC of dcs
+// that is not stopped, for the checks that look at the whole cluster at once. Returns nil if all the DCs are stopped,
+// or if the state could not be fetched, which is logged with failureMessage.
+func (r *K8ssandraClusterReconciler) coordinatorEndpointStates(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcs []*cassdcapi.CassandraDatacenter,
+	logger logr.Logger,
+	failureMessage string,
+) ([]httphelper.EndpointState, error) {
+	var coordinatorDc *cassdcapi.CassandraDatacenter
+	for _, dc := range dcs {
+		if !dc.Spec.Stopped {
+			coordinatorDc = dc
+			break
+		}
+	}
+	if coordinatorDc == nil {
+		return nil, nil
+	}
+
+	var states []httphelper.EndpointState
+	err := r.pollDatacenters(ctx, kc, []*cassdcapi.CassandraDatacenter{coordinatorDc}, logger, failureMessage,
+		func(_ *cassdcapi.CassandraDatacenter, mgmtApi cassandra.ManagementApiFacade) error {
+			var err error
+			states, err = mgmtApi.GetEndpointStates()
+			return err
+		})
+	return states, err
+}
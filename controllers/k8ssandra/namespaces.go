@@ -0,0 +1,55 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/tracing"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// checkDcNamespace verifies that the namespace of a datacenter exists in its target context before any object is
+// created in it. A missing namespace is created when CreateDcNamespaces is enabled, and reported as an error otherwise.
+// When the operator is not allowed to read namespaces, for example because it is namespace-scoped, the check is skipped.
+// Namespaces are read without going through the cache of the manager, which would otherwise start watching all the
+// namespaces of the cluster.
+func (r *K8ssandraClusterReconciler) checkDcNamespace(
+	ctx context.Context,
+	namespace string,
+	k8sContext string,
+	logger logr.Logger,
+) result.ReconcileResult {
+	remoteClient, err := r.ClientCache.GetRemoteNonCacheClient(k8sContext)
+	if err != nil {
+		logger.Error(err, "Failed to get remote client")
+		return result.Error(err)
+	}
+	remoteClient = tracing.NewClient(remoteClient, k8sContext)
+
+	ns := &corev1.Namespace{}
+	err = remoteClient.Get(ctx, types.NamespacedName{Name: namespace}, ns)
+	if err == nil || errors.IsForbidden(err) {
+		return result.Continue()
+	}
+	if !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to get namespace", "Namespace", namespace)
+		return result.Error(err)
+	}
+
+	if !r.CreateDcNamespaces {
+		return result.Error(fmt.Errorf("namespace %s does not exist in context %s", namespace, k8sContext))
+	}
+
+	ns = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	if err := remoteClient.Create(ctx, ns); err != nil && !errors.IsAlreadyExists(err) {
+		logger.Error(err, "Failed to create namespace", "Namespace", namespace)
+		return result.Error(err)
+	}
+	logger.Info("Created namespace", "Namespace", namespace)
+	return result.Continue()
+}
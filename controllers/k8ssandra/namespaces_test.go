@@ -0,0 +1,153 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/test/framework"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// forbiddenNamespacesClient denies all reads of namespaces, like a namespace-scoped operator would be.
+type forbiddenNamespacesClient struct {
+	client.Client
+}
+
+func (c forbiddenNamespacesClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, ok := obj.(*corev1.Namespace); ok {
+		return errors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, key.Name, nil)
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func TestCheckDcNamespace(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("namespace exists", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dc-ns"}})
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		r.ClientCache.AddClient("cluster-1", fakeClient)
+
+		recResult := r.checkDcNamespace(ctx, "dc-ns", "cluster-1", testr.New(t))
+		assert.Equal(t, result.Continue(), recResult)
+	})
+
+	t.Run("namespace missing", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		r.ClientCache.AddClient("cluster-1", fakeClient)
+
+		recResult := r.checkDcNamespace(ctx, "dc-ns", "cluster-1", testr.New(t))
+		require.True(t, recResult.Completed())
+		_, err = recResult.Output()
+		assert.EqualError(t, err, "namespace dc-ns does not exist in context cluster-1")
+
+		err = fakeClient.Get(ctx, types.NamespacedName{Name: "dc-ns"}, &corev1.Namespace{})
+		assert.True(t, errors.IsNotFound(err), "the namespace should not have been created")
+	})
+
+	t.Run("namespace missing and created", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		r.ClientCache.AddClient("cluster-1", fakeClient)
+		r.CreateDcNamespaces = true
+
+		recResult := r.checkDcNamespace(ctx, "dc-ns", "cluster-1", testr.New(t))
+		assert.Equal(t, result.Continue(), recResult)
+
+		err = fakeClient.Get(ctx, types.NamespacedName{Name: "dc-ns"}, &corev1.Namespace{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("namespaces forbidden", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		r.ClientCache.AddClient("cluster-1", forbiddenNamespacesClient{fakeClient})
+
+		recResult := r.checkDcNamespace(ctx, "dc-ns", "cluster-1", testr.New(t))
+		assert.Equal(t, result.Continue(), recResult)
+	})
+}
+
+// dcNamespaceMissing verifies that a datacenter is not created until its namespace exists, and that the namespace is
+// read from the API server rather than from a cache that could miss its creation.
+func dcNamespaceMissing(t *testing.T, ctx context.Context, f *framework.Framework, namespace string) {
+	require := require.New(t)
+
+	dcNamespace := namespace + "-dc"
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "dc-namespace",
+		},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{
+						Meta:       api.EmbeddedObjectMeta{Name: "dc1", Namespace: dcNamespace},
+						K8sContext: f.DataPlaneContexts[0],
+						Size:       1,
+						DatacenterOptions: api.DatacenterOptions{
+							ServerVersion: "3.11.14",
+							StorageConfig: &cassdcapi.StorageConfig{
+								CassandraDataVolumeClaimSpec: &corev1.PersistentVolumeClaimSpec{
+									StorageClassName: &defaultStorageClass,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := f.Client.Create(ctx, kc)
+	require.NoError(err, "failed to create K8ssandraCluster")
+
+	verifyFinalizerAdded(ctx, t, f, client.ObjectKey{Namespace: kc.Namespace, Name: kc.Name})
+	verifySuperuserSecretCreated(ctx, t, f, kc)
+	verifyReplicatedSecretReconciled(ctx, t, f, kc)
+
+	t.Log("check that the missing namespace is reported")
+	kcKey := framework.ClusterKey{K8sContext: f.ControlPlaneContext, NamespacedName: types.NamespacedName{Namespace: namespace, Name: kc.Name}}
+	require.Eventually(func() bool {
+		kc := &api.K8ssandraCluster{}
+		if err := f.Get(ctx, kcKey, kc); err != nil {
+			t.Logf("failed to get K8ssandraCluster: %v", err)
+			return false
+		}
+		return strings.Contains(kc.Status.Error, fmt.Sprintf("namespace %s does not exist", dcNamespace))
+	}, timeout, interval, "timed out waiting for the missing namespace to be reported")
+
+	dcKey := framework.ClusterKey{NamespacedName: types.NamespacedName{Namespace: dcNamespace, Name: "dc1"}, K8sContext: f.DataPlaneContexts[0]}
+	require.Never(f.DatacenterExists(ctx, dcKey), timeout, interval)
+
+	t.Log("create the namespace")
+	require.NoError(f.CreateNamespace(dcNamespace), "failed to create namespace")
+
+	t.Log("check that dc1 was created")
+	require.Eventually(f.DatacenterExists(ctx, dcKey), timeout, interval)
+
+	t.Log("deleting K8ssandraCluster")
+	err = f.DeleteK8ssandraCluster(ctx, client.ObjectKey{Namespace: kc.Namespace, Name: kc.Name}, timeout, interval)
+	require.NoError(err, "failed to delete K8ssandraCluster")
+	f.AssertObjectDoesNotExist(ctx, t, dcKey, &cassdcapi.CassandraDatacenter{}, timeout, interval)
+}
@@ -0,0 +1,118 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileNetworkPolicy creates or updates the NetworkPolicy of the DC when the DC has a network policy, and deletes
+// the NetworkPolicy previously created otherwise. The seeds of the DCs deployed in other k8s contexts are allowed to
+// exchange internode traffic with the DC.
+func (r *K8ssandraClusterReconciler) reconcileNetworkPolicy(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcConfig *cassandra.DatacenterConfig,
+	namespace string,
+	seeds []corev1.Pod,
+	remoteClient client.Client,
+	logger logr.Logger,
+) result.ReconcileResult {
+	kcKey := utils.GetKey(kc)
+	policyKey := cassandra.NetworkPolicyKey(dcConfig, namespace)
+	policyLogger := logger.WithValues("NetworkPolicy", policyKey)
+
+	actualPolicy := &networkingv1.NetworkPolicy{}
+	err := remoteClient.Get(ctx, policyKey, actualPolicy)
+	if err != nil && !apierrors.IsNotFound(err) {
+		policyLogger.Error(err, "Failed to get NetworkPolicy")
+		return result.Error(err)
+	}
+	found := err == nil
+
+	desiredPolicy := cassandra.NewNetworkPolicy(kcKey, dcConfig, namespace, remoteSeedAddresses(kc, dcConfig.K8sContext, seeds))
+	if desiredPolicy == nil {
+		if found && labels.IsPartOf(actualPolicy, kcKey) {
+			if err := remoteClient.Delete(ctx, actualPolicy); err != nil && !apierrors.IsNotFound(err) {
+				policyLogger.Error(err, "Failed to delete NetworkPolicy")
+				return result.Error(err)
+			}
+			policyLogger.Info("Deleted NetworkPolicy")
+		}
+		return result.Continue()
+	}
+
+	if !found {
+		// Note: cannot set controller reference on remote objects
+		if err := remoteClient.Create(ctx, desiredPolicy); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return result.RequeueSoon(r.DefaultDelay)
+			}
+			policyLogger.Error(err, "Failed to create NetworkPolicy")
+			return result.Error(err)
+		}
+		policyLogger.Info("Created NetworkPolicy")
+	} else if !annotations.CompareHashAnnotations(actualPolicy, desiredPolicy) {
+		resourceVersion := actualPolicy.GetResourceVersion()
+		desiredPolicy.DeepCopyInto(actualPolicy)
+		actualPolicy.SetResourceVersion(resourceVersion)
+		if err := remoteClient.Update(ctx, actualPolicy); err != nil {
+			policyLogger.Error(err, "Failed to update NetworkPolicy")
+			return result.Error(err)
+		}
+		policyLogger.Info("Updated NetworkPolicy")
+	}
+	return result.Continue()
+}
+
+// remoteSeedAddresses returns the addresses of the seeds that belong to DCs deployed in a k8s context other than
+// k8sContext.
+func remoteSeedAddresses(kc *api.K8ssandraCluster, k8sContext string, seeds []corev1.Pod) []string {
+	localDcs := make(map[string]bool)
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if dcTemplate.K8sContext == k8sContext {
+			localDcs[dcTemplate.Meta.Name] = true
+		}
+	}
+	var addresses []string
+	for _, seed := range seeds {
+		if seed.Status.PodIP != "" && !localDcs[seed.Labels[cassdcapi.DatacenterLabel]] {
+			addresses = append(addresses, seed.Status.PodIP)
+		}
+	}
+	return addresses
+}
+
+// deleteNetworkPolicies deletes the NetworkPolicies created for the DC named dcName, in all the k8s contexts known to
+// the operator, once the DC is removed from the K8ssandraCluster of kcKey.
+func (r *K8ssandraClusterReconciler) deleteNetworkPolicies(ctx context.Context, kcKey client.ObjectKey, dcName string, logger logr.Logger) error {
+	for _, remoteClient := range r.ClientCache.GetAllClients() {
+		policies := &networkingv1.NetworkPolicyList{}
+		if err := remoteClient.List(ctx, policies, client.MatchingLabels(labels.PartOfLabels(kcKey))); err != nil {
+			return fmt.Errorf("failed to list NetworkPolicies for DC (%s) deletion: %v", dcName, err)
+		}
+		for i := range policies.Items {
+			policy := &policies.Items[i]
+			if policy.Spec.PodSelector.MatchLabels[cassdcapi.DatacenterLabel] != dcName {
+				continue
+			}
+			if err := remoteClient.Delete(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete NetworkPolicy for DC (%s): %v", dcName, err)
+			}
+			logger.Info("Deleted NetworkPolicy", "NetworkPolicy", utils.GetKey(policy))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	ctx := context.Background()
+	logger := testr.New(t)
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "east"},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, K8sContext: "east"},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc3"}, K8sContext: "west"},
+				},
+			},
+		},
+	}
+	dcConfig := &cassandra.DatacenterConfig{
+		Meta:          api.EmbeddedObjectMeta{Name: "dc1"},
+		K8sContext:    "east",
+		Cluster:       "test",
+		NetworkPolicy: &api.NetworkPolicyConfig{},
+	}
+	newSeed := func(dcName, ip string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: dcName + "-seed", Labels: map[string]string{cassdcapi.DatacenterLabel: dcName}},
+			Status:     corev1.PodStatus{PodIP: ip},
+		}
+	}
+	seeds := []corev1.Pod{newSeed("dc1", "10.0.0.1"), newSeed("dc2", "10.0.0.2"), newSeed("dc3", "10.1.0.1")}
+
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	policyKey := cassandra.NetworkPolicyKey(dcConfig, "default")
+
+	require.False(t, r.reconcileNetworkPolicy(ctx, kc, dcConfig, "default", seeds, fakeClient, logger).Completed())
+	policy := &networkingv1.NetworkPolicy{}
+	require.NoError(t, fakeClient.Get(ctx, policyKey, policy))
+	assert.Equal(t, []string{"10.1.0.1/32"}, ipBlocks(policy.Spec.Ingress[0].From), "only the seeds of the other contexts are allowed by address")
+
+	// the policy follows the seeds
+	seeds = append(seeds, newSeed("dc3", "10.1.0.2"))
+	require.False(t, r.reconcileNetworkPolicy(ctx, kc, dcConfig, "default", seeds, fakeClient, logger).Completed())
+	require.NoError(t, fakeClient.Get(ctx, policyKey, policy))
+	assert.Equal(t, []string{"10.1.0.1/32", "10.1.0.2/32"}, ipBlocks(policy.Spec.Ingress[0].From))
+
+	// removing the network policy from the spec deletes it
+	dcConfig.NetworkPolicy = nil
+	require.False(t, r.reconcileNetworkPolicy(ctx, kc, dcConfig, "default", seeds, fakeClient, logger).Completed())
+	err = fakeClient.Get(ctx, policyKey, policy)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestDeleteNetworkPolicies(t *testing.T) {
+	ctx := context.Background()
+	kcKey := client.ObjectKey{Namespace: "default", Name: "test"}
+	newPolicy := func(dcName string, kcKey client.ObjectKey) *networkingv1.NetworkPolicy {
+		dcConfig := &cassandra.DatacenterConfig{
+			Meta:          api.EmbeddedObjectMeta{Name: dcName},
+			Cluster:       kcKey.Name,
+			NetworkPolicy: &api.NetworkPolicyConfig{},
+		}
+		return cassandra.NewNetworkPolicy(kcKey, dcConfig, "default", nil)
+	}
+	otherKcKey := client.ObjectKey{Namespace: "default", Name: "other"}
+	policies := []*networkingv1.NetworkPolicy{newPolicy("dc1", kcKey), newPolicy("dc2", kcKey), newPolicy("dc1", otherKcKey)}
+
+	fakeClient, err := test.NewFakeClient(policies[0], policies[1], policies[2])
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	r.ClientCache.AddClient("east", fakeClient)
+
+	require.NoError(t, r.deleteNetworkPolicies(ctx, kcKey, "dc1", testr.New(t)))
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(policies[0]), &networkingv1.NetworkPolicy{})
+	assert.True(t, apierrors.IsNotFound(err), "the policy of the removed DC is deleted")
+	assert.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(policies[1]), &networkingv1.NetworkPolicy{}),
+		"the policies of the other DCs are kept")
+	assert.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(policies[2]), &networkingv1.NetworkPolicy{}),
+		"the policies of the other clusters are kept")
+}
+
+func ipBlocks(peers []networkingv1.NetworkPolicyPeer) []string {
+	var cidrs []string
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			cidrs = append(cidrs, peer.IPBlock.CIDR)
+		}
+	}
+	return cidrs
+}
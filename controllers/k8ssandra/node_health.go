@@ -0,0 +1,120 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkNodeHealth returns true if the seed propagation must be held because more nodes than allowed by the
+// NodeHealthGate of kc are down in a ready datacenter, and sets the NodesDown condition accordingly. The gossip state
+// of the cluster is queried through the first ready datacenter. Failing to query it is logged, and neither holds the
+// seed propagation nor changes the condition.
+func (r *K8ssandraClusterReconciler) checkNodeHealth(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcConfigs []*cassandra.DatacenterConfig,
+	logger logr.Logger,
+) bool {
+	gate := kc.Spec.Cassandra.NodeHealthGate
+	readyDcs := make([]*cassandra.DatacenterConfig, 0, len(dcConfigs))
+	for _, dcConfig := range dcConfigs {
+		if datacenterReadyInStatus(kc, dcConfig.Meta.Name) {
+			readyDcs = append(readyDcs, dcConfig)
+		}
+	}
+	if gate == nil || len(readyDcs) == 0 {
+		setNodesDownCondition(kc, nil, gate.GetMaxDownNodes())
+		return false
+	}
+
+	coordinator := readyDcs[0]
+	remoteClient, err := r.ClientCache.GetRemoteClient(coordinator.K8sContext)
+	if err != nil {
+		logger.Error(err, "Failed to get remote client", "K8sContext", coordinator.K8sContext)
+		return false
+	}
+	dc := &cassdcapi.CassandraDatacenter{}
+	dcKey := client.ObjectKey{Namespace: utils.FirstNonEmptyString(coordinator.Meta.Namespace, kc.Namespace), Name: coordinator.Meta.Name}
+	if err := remoteClient.Get(ctx, dcKey, dc); err != nil {
+		logger.Error(err, "Failed to check node health", "CassandraDatacenter", dcKey)
+		return false
+	}
+	mgmtApi, err := r.ManagementApi.NewManagementApiFacade(ctx, dc, remoteClient, logger)
+	if err != nil {
+		logger.Error(err, "Failed to check node health", "CassandraDatacenter", dcKey)
+		return false
+	}
+	states, err := mgmtApi.GetEndpointStates()
+	if err != nil {
+		logger.Error(err, "Failed to check node health", "CassandraDatacenter", dcKey)
+		return false
+	}
+
+	maxDownNodes := gate.GetMaxDownNodes()
+	downNodes := downNodesByDc(states)
+	var unhealthyDcs []string
+	for _, dcConfig := range readyDcs {
+		if nodes := downNodes[dcConfig.CassDcName()]; len(nodes) > maxDownNodes {
+			unhealthyDcs = append(unhealthyDcs, fmt.Sprintf("%s (%s)", dcConfig.Meta.Name, strings.Join(nodes, ", ")))
+		}
+	}
+	if len(unhealthyDcs) > 0 {
+		logger.Info("Holding seed propagation, nodes are down in ready datacenters", "Datacenters", unhealthyDcs)
+	}
+	setNodesDownCondition(kc, unhealthyDcs, maxDownNodes)
+	return len(unhealthyDcs) > 0
+}
+
+// datacenterReadyInStatus returns true if the datacenter dcName is ready and running according to the status of kc.
+func datacenterReadyInStatus(kc *api.K8ssandraCluster, dcName string) bool {
+	dcStatus, found := kc.Status.Datacenters[dcName]
+	if !found || dcStatus.Cassandra == nil {
+		return false
+	}
+	return dcStatus.Cassandra.GetConditionStatus(cassdcapi.DatacenterReady) == corev1.ConditionTrue &&
+		dcStatus.Cassandra.GetConditionStatus(cassdcapi.DatacenterStopped) != corev1.ConditionTrue &&
+		dcStatus.Cassandra.CassandraOperatorProgress == cassdcapi.ProgressReady
+}
+
+// downNodesByDc returns the addresses of the nodes in the NORMAL gossip state that gossip reports as not alive, sorted
+// and grouped by Cassandra datacenter name. The endpoints of the nodes that left the cluster or were removed are kept in
+// gossip for a few days, they are not counted as down.
+func downNodesByDc(states []httphelper.EndpointState) map[string][]string {
+	downNodes := make(map[string][]string)
+	for _, state := range states {
+		if state.IsAlive != "true" && gossipStatus(state) == gossipStatusNormal {
+			downNodes[state.Datacenter] = append(downNodes[state.Datacenter], state.EndpointIP)
+		}
+	}
+	for _, nodes := range downNodes {
+		sort.Strings(nodes)
+	}
+	return downNodes
+}
+
+// setNodesDownCondition sets the NodesDown condition to true if nodes are down in unhealthyDcs, and back to false
+// otherwise. The condition is only set once nodes have been found down.
+func setNodesDownCondition(kc *api.K8ssandraCluster, unhealthyDcs []string, maxDownNodes int) {
+	if _, found := kc.Status.GetCondition(api.NodesDown); !found && len(unhealthyDcs) == 0 {
+		return
+	}
+	status := corev1.ConditionFalse
+	message := ""
+	if len(unhealthyDcs) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("Too many nodes are down in ready datacenters, at most %d allowed, the seed propagation is held: %s.",
+			maxDownNodes, strings.Join(unhealthyDcs, "; "))
+	}
+	kc.Status.SetConditionStatus(api.NodesDown, status, message)
+}
@@ -0,0 +1,114 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckNodeHealth(t *testing.T) {
+	ctx := context.Background()
+	ready := func() api.K8ssandraStatus {
+		status := &cassdcapi.CassandraDatacenterStatus{CassandraOperatorProgress: cassdcapi.ProgressReady}
+		status.SetCondition(cassdcapi.DatacenterCondition{Type: cassdcapi.DatacenterReady, Status: corev1.ConditionTrue})
+		return api.K8ssandraStatus{Cassandra: status}
+	}
+	newKc := func(maxDownNodes int32) *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					NodeHealthGate: &api.NodeHealthGate{MaxDownNodes: pointer.Int32(maxDownNodes)},
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, DatacenterOptions: api.DatacenterOptions{DatacenterName: "DC 2"}},
+					},
+				},
+			},
+			Status: api.K8ssandraClusterStatus{
+				Datacenters: map[string]api.K8ssandraStatus{"dc1": ready(), "dc2": ready()},
+			},
+		}
+	}
+	dcConfigs := []*cassandra.DatacenterConfig{
+		{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+		{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, DatacenterName: "DC 2"},
+	}
+	states := []httphelper.EndpointState{
+		{Datacenter: "dc1", EndpointIP: "10.0.0.1", IsAlive: "true", Status: "NORMAL,-9223372036854775808"},
+		{Datacenter: "dc1", EndpointIP: "10.0.0.2", IsAlive: "true", Status: "NORMAL,-3074457345618258603"},
+		{Datacenter: "dc1", EndpointIP: "10.0.0.3", IsAlive: "false", Status: "LEFT,3074457345618258602,1697414400000"},
+		{Datacenter: "DC 2", EndpointIP: "10.0.1.1", IsAlive: "true", StatusWithPort: "NORMAL,-9223372036854775807"},
+		{Datacenter: "DC 2", EndpointIP: "10.0.1.3", IsAlive: "false", StatusWithPort: "NORMAL,-3074457345618258602"},
+		{Datacenter: "DC 2", EndpointIP: "10.0.1.2", IsAlive: "false", StatusWithPort: "NORMAL,3074457345618258603"},
+		{Datacenter: "DC 2", EndpointIP: "10.0.1.4", IsAlive: "false", Status: "removed,3074457345618258604,1697414400000"},
+	}
+	newReconciler := func(t *testing.T, mgmtApi *test.FakeManagementApiFacade) *K8ssandraClusterReconciler {
+		dc := &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"}}
+		fakeClient, err := test.NewFakeClient(dc)
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		factory := &test.FakeManagementApiFactory{}
+		factory.SetT(t)
+		factory.SetAdapter(func(context.Context, *cassdcapi.CassandraDatacenter, client.Client, logr.Logger) (cassandra.ManagementApiFacade, error) {
+			return mgmtApi, nil
+		})
+		r.ManagementApi = factory
+		return r
+	}
+
+	t.Run("too many nodes down", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.GetEndpointStates).Return(states, nil)
+		kc := newKc(1)
+
+		assert.True(t, newReconciler(t, mgmtApi).checkNodeHealth(ctx, kc, dcConfigs, testr.New(t)))
+		condition, found := kc.Status.GetCondition(api.NodesDown)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, "Too many nodes are down in ready datacenters, at most 1 allowed, the seed propagation is held: dc2 (10.0.1.2, 10.0.1.3).", condition.Message)
+	})
+
+	t.Run("nodes down within the threshold", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.GetEndpointStates).Return(states, nil)
+		kc := newKc(2)
+		kc.Status.SetCondition(api.K8ssandraClusterCondition{Type: api.NodesDown, Status: corev1.ConditionTrue})
+
+		assert.False(t, newReconciler(t, mgmtApi).checkNodeHealth(ctx, kc, dcConfigs, testr.New(t)))
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.NodesDown))
+	})
+
+	t.Run("datacenter not ready", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		mgmtApi.On(test.GetEndpointStates).Return(states, nil)
+		kc := newKc(0)
+		kc.Status.Datacenters["dc2"].Cassandra.CassandraOperatorProgress = cassdcapi.ProgressUpdating
+
+		assert.False(t, newReconciler(t, mgmtApi).checkNodeHealth(ctx, kc, dcConfigs, testr.New(t)))
+		_, found := kc.Status.GetCondition(api.NodesDown)
+		assert.False(t, found)
+	})
+
+	t.Run("gate disabled", func(t *testing.T) {
+		mgmtApi := test.NewFakeManagementApiFacade()
+		kc := newKc(0)
+		kc.Spec.Cassandra.NodeHealthGate = nil
+
+		assert.False(t, newReconciler(t, mgmtApi).checkNodeHealth(ctx, kc, dcConfigs, testr.New(t)))
+		mgmtApi.AssertNotCalled(t, test.GetEndpointStates)
+	})
+}
@@ -0,0 +1,127 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	cassctlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	kerrors "github.com/k8ssandra/k8ssandra-operator/pkg/errors"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileNodeReplacement replaces the node named by the ReplaceNodeAnnotation if its pod belongs to dc. The
+// replacement is delegated to cass-operator with a replacenode CassandraTask, which restarts the pod with
+// replace_address_first_boot. Once the task has finished, it is deleted and the annotation is removed, so that the
+// same pod can be replaced again later.
+func (r *K8ssandraClusterReconciler) reconcileNodeReplacement(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dc *cassdcapi.CassandraDatacenter,
+	remoteClient client.Client,
+	logger logr.Logger,
+) result.ReconcileResult {
+	podName := kc.Annotations[api.ReplaceNodeAnnotation]
+
+	pod := &corev1.Pod{}
+	if err := remoteClient.Get(ctx, client.ObjectKey{Namespace: dc.Namespace, Name: podName}, pod); err != nil {
+		if errors.IsNotFound(err) {
+			return result.Continue()
+		}
+		logger.Error(err, "Failed to get pod to replace", "Pod", podName)
+		return result.Error(err)
+	}
+	if !labels.SelectorFromSet(dc.GetDatacenterLabels()).Matches(labels.Set(pod.Labels)) {
+		// The pod belongs to another datacenter
+		return result.Continue()
+	}
+
+	desiredTask := newReplaceNodeTask(dc, podName)
+	taskKey := client.ObjectKey{Namespace: desiredTask.Namespace, Name: desiredTask.Name}
+	task := &cassctlapi.CassandraTask{}
+
+	if err := remoteClient.Get(ctx, taskKey, task); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to get replace node task", "Task", taskKey)
+			return result.Error(err)
+		}
+		logger.Info("Creating replace node task", "Task", taskKey, "Pod", podName)
+		if err = remoteClient.Create(ctx, desiredTask); err != nil {
+			if kerrors.IsWebhookUnavailable(err) {
+				logger.Info("cass-operator webhook is unavailable, will retry creating the replace node task", "Task", taskKey, "Error", err.Error())
+				return result.RequeueSoon(r.WebhookUnavailableDelay)
+			}
+			logger.Error(err, "Failed to create replace node task", "Task", taskKey)
+			return result.Error(err)
+		}
+		return result.RequeueSoon(r.DefaultDelay)
+	}
+
+	if task.Status.CompletionTime.IsZero() {
+		logger.Info("Waiting for node replacement to complete", "Task", taskKey)
+		return result.RequeueSoon(r.DefaultDelay)
+	}
+
+	if err := remoteClient.Delete(ctx, task); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete replace node task", "Task", taskKey)
+		return result.Error(err)
+	}
+	if recResult := r.removeReplaceNodeAnnotation(ctx, kc); recResult.Completed() {
+		return recResult
+	}
+	if task.Status.Failed > 0 {
+		return result.Error(fmt.Errorf("replacement of node %s failed, see CassandraTask %s for details", podName, taskKey))
+	}
+	logger.Info("Node replacement finished", "Pod", podName)
+	return result.Continue()
+}
+
+func newReplaceNodeTask(dc *cassdcapi.CassandraDatacenter, podName string) *cassctlapi.CassandraTask {
+	now := metav1.Now()
+	task := &cassctlapi.CassandraTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: dc.Namespace,
+			Name:      podName + "-replace",
+		},
+		Spec: cassctlapi.CassandraTaskSpec{
+			Datacenter: corev1.ObjectReference{
+				Namespace: dc.Namespace,
+				Name:      dc.Name,
+			},
+			CassandraTaskTemplate: cassctlapi.CassandraTaskTemplate{
+				ScheduledTime: &now,
+				Jobs: []cassctlapi.CassandraJob{
+					{
+						Name:    podName + "-replace",
+						Command: cassctlapi.CommandReplaceNode,
+						Arguments: cassctlapi.JobArguments{
+							PodName: podName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	annotations.AddHashAnnotation(task)
+
+	return task
+}
+
+func (r *K8ssandraClusterReconciler) removeReplaceNodeAnnotation(ctx context.Context, kc *api.K8ssandraCluster) result.ReconcileResult {
+	patch := client.MergeFrom(kc.DeepCopy())
+	delete(kc.Annotations, api.ReplaceNodeAnnotation)
+	if err := r.Client.Patch(ctx, kc, patch); err != nil {
+		err = fmt.Errorf("failed to remove %s annotation: %v", api.ReplaceNodeAnnotation, err)
+		return result.Error(err)
+	}
+	return result.Continue()
+}
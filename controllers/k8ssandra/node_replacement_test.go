@@ -0,0 +1,109 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	cassctlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileNodeReplacement(t *testing.T) {
+	ctx := context.Background()
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "cluster1"},
+	}
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "cluster1",
+				Annotations: map[string]string{api.ReplaceNodeAnnotation: "cluster1-dc1-default-sts-1"},
+			},
+		}
+	}
+	newPod := func(name, dcName string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    map[string]string{cassdcapi.ClusterLabel: "cluster1", cassdcapi.DatacenterLabel: dcName},
+		}}
+	}
+	taskKey := client.ObjectKey{Namespace: "default", Name: "cluster1-dc1-default-sts-1-replace"}
+
+	t.Run("replacement requested and annotation cleared", func(t *testing.T) {
+		kc := newKc()
+		fakeClient, err := test.NewFakeClient(kc, newPod("cluster1-dc1-default-sts-1", "dc1"))
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+
+		recResult := r.reconcileNodeReplacement(ctx, kc, dc, fakeClient, testr.New(t))
+		assert.Equal(t, result.RequeueSoon(r.DefaultDelay), recResult)
+
+		task := &cassctlapi.CassandraTask{}
+		require.NoError(t, fakeClient.Get(ctx, taskKey, task))
+		require.Len(t, task.Spec.Jobs, 1)
+		assert.Equal(t, cassctlapi.CommandReplaceNode, task.Spec.Jobs[0].Command)
+		assert.Equal(t, "cluster1-dc1-default-sts-1", task.Spec.Jobs[0].Arguments.PodName)
+		assert.Equal(t, "dc1", task.Spec.Datacenter.Name)
+
+		recResult = r.reconcileNodeReplacement(ctx, kc, dc, fakeClient, testr.New(t))
+		assert.Equal(t, result.RequeueSoon(r.DefaultDelay), recResult, "the task has not completed yet")
+
+		now := metav1.Now()
+		task.Status.CompletionTime = &now
+		task.Status.Succeeded = 1
+		require.NoError(t, fakeClient.Status().Update(ctx, task))
+
+		recResult = r.reconcileNodeReplacement(ctx, kc, dc, fakeClient, testr.New(t))
+		assert.Equal(t, result.Continue(), recResult)
+
+		err = fakeClient.Get(ctx, taskKey, &cassctlapi.CassandraTask{})
+		assert.True(t, errors.IsNotFound(err), "the task should have been deleted")
+		actualKc := &api.K8ssandraCluster{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(kc), actualKc))
+		assert.NotContains(t, actualKc.Annotations, api.ReplaceNodeAnnotation)
+	})
+
+	t.Run("failed replacement", func(t *testing.T) {
+		kc := newKc()
+		now := metav1.Now()
+		task := newReplaceNodeTask(dc, "cluster1-dc1-default-sts-1")
+		task.Status.CompletionTime = &now
+		task.Status.Failed = 1
+		fakeClient, err := test.NewFakeClient(kc, newPod("cluster1-dc1-default-sts-1", "dc1"), task)
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+
+		recResult := r.reconcileNodeReplacement(ctx, kc, dc, fakeClient, testr.New(t))
+		require.True(t, recResult.Completed())
+		_, err = recResult.Output()
+		assert.Error(t, err)
+		assert.NotContains(t, kc.Annotations, api.ReplaceNodeAnnotation)
+	})
+
+	t.Run("pod of another datacenter", func(t *testing.T) {
+		kc := newKc()
+		fakeClient, err := test.NewFakeClient(kc, newPod("cluster1-dc1-default-sts-1", "dc2"))
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+
+		recResult := r.reconcileNodeReplacement(ctx, kc, dc, fakeClient, testr.New(t))
+		assert.Equal(t, result.Continue(), recResult)
+
+		err = fakeClient.Get(ctx, taskKey, &cassctlapi.CassandraTask{})
+		assert.True(t, errors.IsNotFound(err))
+		assert.Contains(t, kc.Annotations, api.ReplaceNodeAnnotation)
+	})
+}
@@ -0,0 +1,127 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// claimOwnership returns whether this operator instance manages kc. When the operator instances are given an id, kc
+// is managed by the instance named by its OperatorInstanceAnnotation; an unclaimed K8ssandraCluster is claimed by
+// annotating it with the id of this instance. The owner renews its claim in the OperatorInstanceRenewTimeAnnotation,
+// and a claim that was not renewed within the OwnershipLeaseDuration is taken over. The claim is written with an
+// optimistic lock, so that only one of the instances racing for a cluster wins it. When kc is not managed by this
+// instance, the returned delay is when to check again whether it can be taken over, or zero if it never can. Every
+// K8ssandraCluster is managed when no id is configured.
+func (r *K8ssandraClusterReconciler) claimOwnership(ctx context.Context, kc *api.K8ssandraCluster, logger logr.Logger) (bool, time.Duration, error) {
+	instanceId := r.InstanceId
+	if instanceId == "" {
+		return true, 0, nil
+	}
+
+	now := time.Now()
+	owner := kc.Annotations[api.OperatorInstanceAnnotation]
+	renewTime, renewed := claimRenewTime(kc)
+	if owner == instanceId {
+		if r.OwnershipLeaseDuration == 0 || (renewed && now.Sub(renewTime) < r.OwnershipLeaseDuration/2) {
+			return true, 0, nil
+		}
+	} else if owner != "" {
+		if r.OwnershipLeaseDuration == 0 {
+			logger.Info("K8ssandraCluster is managed by another operator instance, skipping", "Owner", owner, "InstanceId", instanceId)
+			return false, 0, nil
+		}
+		if !renewed {
+			// Claimed by an instance that does not renew its claim, the lease starts when this instance first sees it
+			renewTime = r.firstSeenClaim(kc, owner, now)
+		}
+		if expiry := renewTime.Add(r.OwnershipLeaseDuration); now.Before(expiry) {
+			logger.Info("K8ssandraCluster is managed by another operator instance, skipping", "Owner", owner, "InstanceId", instanceId)
+			return false, expiry.Sub(now), nil
+		}
+		logger.Info("Taking over K8ssandraCluster, its owner did not renew its claim", "Owner", owner, "InstanceId", instanceId,
+			"RenewTime", renewTime)
+	}
+
+	patch := client.MergeFromWithOptions(kc.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if kc.Annotations == nil {
+		kc.Annotations = make(map[string]string)
+	}
+	kc.Annotations[api.OperatorInstanceAnnotation] = instanceId
+	kc.Annotations[api.OperatorInstanceRenewTimeAnnotation] = now.UTC().Format(time.RFC3339)
+	if err := r.Client.Patch(ctx, kc, patch); err != nil {
+		if errors.IsConflict(err) {
+			// Another instance may have claimed the cluster in the meantime, which is checked on the next attempt
+			logger.Info("K8ssandraCluster was modified while claiming it, retrying", "InstanceId", instanceId)
+			return false, r.DefaultDelay, nil
+		}
+		return false, 0, fmt.Errorf("failed to claim ownership of K8ssandraCluster: %v", err)
+	}
+	r.seenClaims.forget(utils.GetKey(kc))
+	if owner != instanceId {
+		logger.Info("Claimed ownership of K8ssandraCluster", "InstanceId", instanceId)
+	}
+	return true, 0, nil
+}
+
+// claimRenewTime returns the time recorded in the OperatorInstanceRenewTimeAnnotation of kc, and false if it is
+// missing or invalid.
+func claimRenewTime(kc *api.K8ssandraCluster) (time.Time, bool) {
+	value, found := kc.Annotations[api.OperatorInstanceRenewTimeAnnotation]
+	if !found {
+		return time.Time{}, false
+	}
+	renewTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return renewTime, true
+}
+
+// seenClaim is a claim of another instance that was seen without a renew time, such as a claim made by an older
+// version of the operator.
+type seenClaim struct {
+	owner string
+	time  time.Time
+}
+
+// firstSeenClaim returns when this instance first saw kc claimed by owner without a renew time, as recorded in
+// seenClaims.
+func (r *K8ssandraClusterReconciler) firstSeenClaim(kc *api.K8ssandraCluster, owner string, now time.Time) time.Time {
+	kcKey := utils.GetKey(kc)
+	if seen, found := r.seenClaims.get(kcKey); found && seen.owner == owner {
+		return seen.time
+	}
+	r.seenClaims.set(kcKey, seenClaim{owner: owner, time: now})
+	return now
+}
+
+// withOwnershipRenewal makes the owner of a K8ssandraCluster reconcile it again before its claim expires, so that the
+// claim is renewed even when nothing else triggers a reconcile.
+func (r *K8ssandraClusterReconciler) withOwnershipRenewal(res ctrl.Result) ctrl.Result {
+	if r.InstanceId == "" || r.OwnershipLeaseDuration == 0 || res.Requeue && res.RequeueAfter == 0 {
+		return res
+	}
+	if renewal := r.OwnershipLeaseDuration / 2; res.RequeueAfter == 0 || res.RequeueAfter > renewal {
+		res.RequeueAfter = renewal
+	}
+	return res
+}
+
+// setManagedByInstanceCondition sets the ManagedByInstance condition, naming this operator instance, when the operator
+// instances are given an id.
+func (r *K8ssandraClusterReconciler) setManagedByInstanceCondition(kc *api.K8ssandraCluster) {
+	if r.InstanceId == "" {
+		return
+	}
+	message := fmt.Sprintf("Managed by operator instance %s", r.InstanceId)
+	kc.Status.SetConditionStatus(api.ManagedByInstance, corev1.ConditionTrue, message)
+}
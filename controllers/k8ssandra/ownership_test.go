@@ -0,0 +1,182 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestClaimOwnership(t *testing.T) {
+	ctx := context.Background()
+	logger := testr.New(t)
+	kc := &api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	fakeClient, err := test.NewFakeClient(kc)
+	require.NoError(t, err)
+
+	// without an instance id, every cluster is managed and none is claimed
+	first := newTracingTestReconciler(fakeClient)
+	owned, _, err := first.claimOwnership(ctx, kc, logger)
+	require.NoError(t, err)
+	assert.True(t, owned)
+	assert.NotContains(t, kc.Annotations, api.OperatorInstanceAnnotation)
+
+	// the first instance with an id claims the cluster
+	first.InstanceId = "first"
+	owned, _, err = first.claimOwnership(ctx, kc, logger)
+	require.NoError(t, err)
+	assert.True(t, owned)
+	actual := &api.K8ssandraCluster{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(kc), actual))
+	assert.Equal(t, "first", actual.Annotations[api.OperatorInstanceAnnotation])
+
+	first.setManagedByInstanceCondition(kc)
+	condition, found := kc.Status.GetCondition(api.ManagedByInstance)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "first")
+
+	// a second instance defers to the owner
+	second := newTracingTestReconciler(fakeClient)
+	second.InstanceId = "second"
+	owned, retryAfter, err := second.claimOwnership(ctx, actual, logger)
+	require.NoError(t, err)
+	assert.False(t, owned)
+	assert.Zero(t, retryAfter, "claims never expire without a lease duration")
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(kc), actual))
+	assert.Equal(t, "first", actual.Annotations[api.OperatorInstanceAnnotation])
+}
+
+func TestClaimOwnershipLease(t *testing.T) {
+	ctx := context.Background()
+	logger := testr.New(t)
+	newReconciler := func(fakeClient client.Client, instanceId string) *K8ssandraClusterReconciler {
+		r := newTracingTestReconciler(fakeClient)
+		r.InstanceId = instanceId
+		r.OwnershipLeaseDuration = 10 * time.Minute
+		return r
+	}
+	newKc := func(owner string, renewTime time.Time) *api.K8ssandraCluster {
+		kc := &api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test", Annotations: map[string]string{
+			api.OperatorInstanceAnnotation: owner,
+		}}}
+		if !renewTime.IsZero() {
+			kc.Annotations[api.OperatorInstanceRenewTimeAnnotation] = renewTime.UTC().Format(time.RFC3339)
+		}
+		return kc
+	}
+	getKc := func(t *testing.T, fakeClient client.Client) *api.K8ssandraCluster {
+		actual := &api.K8ssandraCluster{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, actual))
+		return actual
+	}
+
+	t.Run("renewed claim", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(newKc("first", time.Now().Add(-time.Minute)))
+		require.NoError(t, err)
+
+		owned, retryAfter, err := newReconciler(fakeClient, "second").claimOwnership(ctx, getKc(t, fakeClient), logger)
+		require.NoError(t, err)
+		assert.False(t, owned)
+		assert.InDelta(t, 9*time.Minute, retryAfter, float64(5*time.Second), "checked again once the claim expires")
+	})
+
+	t.Run("expired claim", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(newKc("first", time.Now().Add(-time.Hour)))
+		require.NoError(t, err)
+
+		owned, _, err := newReconciler(fakeClient, "second").claimOwnership(ctx, getKc(t, fakeClient), logger)
+		require.NoError(t, err)
+		assert.True(t, owned, "the cluster is taken over")
+		actual := getKc(t, fakeClient)
+		assert.Equal(t, "second", actual.Annotations[api.OperatorInstanceAnnotation])
+		renewTime, renewed := claimRenewTime(actual)
+		require.True(t, renewed)
+		assert.WithinDuration(t, time.Now(), renewTime, 5*time.Second)
+	})
+
+	t.Run("claim without renew time", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(newKc("first", time.Time{}))
+		require.NoError(t, err)
+		r := newReconciler(fakeClient, "second")
+
+		owned, retryAfter, err := r.claimOwnership(ctx, getKc(t, fakeClient), logger)
+		require.NoError(t, err)
+		assert.False(t, owned, "the lease starts when the claim is first seen")
+		assert.Equal(t, 10*time.Minute, retryAfter)
+
+		firstSeen, found := r.seenClaims.get(client.ObjectKey{Namespace: "default", Name: "test"})
+		require.True(t, found)
+		assert.Equal(t, "first", firstSeen.owner)
+		_, found = newReconciler(fakeClient, "third").seenClaims.get(client.ObjectKey{Namespace: "default", Name: "test"})
+		assert.False(t, found, "the claims seen are not shared between reconcilers")
+	})
+
+	t.Run("owner renews its claim", func(t *testing.T) {
+		renewTime := time.Now().Add(-6 * time.Minute).UTC().Truncate(time.Second)
+		fakeClient, err := test.NewFakeClient(newKc("first", renewTime))
+		require.NoError(t, err)
+		r := newReconciler(fakeClient, "first")
+
+		owned, _, err := r.claimOwnership(ctx, getKc(t, fakeClient), logger)
+		require.NoError(t, err)
+		assert.True(t, owned)
+		actualRenewTime, _ := claimRenewTime(getKc(t, fakeClient))
+		assert.True(t, actualRenewTime.After(renewTime), "the claim is renewed past half of the lease")
+
+		assert.Equal(t, ctrl.Result{RequeueAfter: 5 * time.Minute}, r.withOwnershipRenewal(ctrl.Result{}))
+		assert.Equal(t, ctrl.Result{RequeueAfter: time.Second}, r.withOwnershipRenewal(ctrl.Result{RequeueAfter: time.Second}))
+	})
+
+	t.Run("concurrent claims", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(&api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}})
+		require.NoError(t, err)
+		kc := getKc(t, fakeClient)
+		stale := kc.DeepCopy()
+
+		owned, _, err := newReconciler(fakeClient, "first").claimOwnership(ctx, kc, logger)
+		require.NoError(t, err)
+		assert.True(t, owned)
+
+		owned, retryAfter, err := newReconciler(fakeClient, "second").claimOwnership(ctx, stale, logger)
+		require.NoError(t, err)
+		assert.False(t, owned, "the claim is written with an optimistic lock")
+		assert.Equal(t, time.Second, retryAfter)
+		assert.Equal(t, "first", getKc(t, fakeClient).Annotations[api.OperatorInstanceAnnotation])
+	})
+}
+
+func TestReconcileDefersToOwner(t *testing.T) {
+	ctx := context.Background()
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "test",
+			Annotations: map[string]string{api.OperatorInstanceAnnotation: "first"},
+		},
+		Spec: api.K8ssandraClusterSpec{Cassandra: &api.CassandraClusterTemplate{}},
+	}
+	fakeClient, err := test.NewFakeClient(kc)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	r.InstanceId = "second"
+
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(kc)})
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+
+	actual := &api.K8ssandraCluster{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(kc), actual))
+	assert.Empty(t, actual.Finalizers, "the second instance must not modify the cluster")
+	assert.Empty(t, actual.Status.Conditions)
+	assert.Empty(t, actual.Status.Error)
+}
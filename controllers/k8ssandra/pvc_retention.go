@@ -0,0 +1,113 @@
+package k8ssandra
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// retainVolumesOnScaleDown returns true if the PersistentVolumes of the nodes removed by the update of actualDc to
+// desiredDc must be retained.
+func retainVolumesOnScaleDown(dcConfig *cassandra.DatacenterConfig, actualDc, desiredDc *cassdcapi.CassandraDatacenter) bool {
+	return dcConfig.PvcRetentionPolicy == api.PvcRetentionPolicyRetain && desiredDc.Spec.Size < actualDc.Spec.Size
+}
+
+// retainDecommissionedVolumes switches the reclaim policy of the PersistentVolumes bound to the pods that cass-operator
+// will decommission when actualDc is scaled down to newSize. cass-operator deletes the PVCs of decommissioned pods, and
+// the Retain reclaim policy keeps their volumes, and therefore their data, around. PVCs and PersistentVolumes are read
+// without going through the cache of the manager, which would otherwise start watching all the volumes of the cluster.
+func (r *K8ssandraClusterReconciler) retainDecommissionedVolumes(
+	ctx context.Context,
+	actualDc *cassdcapi.CassandraDatacenter,
+	newSize int32,
+	k8sContext string,
+	remoteClient client.Client,
+	logger logr.Logger,
+) error {
+	pods := &corev1.PodList{}
+	if err := remoteClient.List(ctx, pods, client.InNamespace(actualDc.Namespace), client.MatchingLabels(actualDc.GetDatacenterLabels())); err != nil {
+		return err
+	}
+	volumeClient, err := r.ClientCache.GetRemoteNonCacheClient(k8sContext)
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range decommissionedPods(actualDc, pods.Items, newSize) {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+			pvc := &corev1.PersistentVolumeClaim{}
+			pvcKey := client.ObjectKey{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}
+			if err := volumeClient.Get(ctx, pvcKey, pvc); err != nil {
+				logger.Error(err, "Failed to get PVC", "PVC", pvcKey)
+				return err
+			}
+			if pvc.Spec.VolumeName == "" {
+				continue
+			}
+			pv := &corev1.PersistentVolume{}
+			if err := volumeClient.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, pv); err != nil {
+				logger.Error(err, "Failed to get PersistentVolume", "PersistentVolume", pvc.Spec.VolumeName)
+				return err
+			}
+			if pv.Spec.PersistentVolumeReclaimPolicy == corev1.PersistentVolumeReclaimRetain {
+				continue
+			}
+			patch := client.MergeFrom(pv.DeepCopy())
+			pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+			if err := volumeClient.Patch(ctx, pv, patch); err != nil {
+				logger.Error(err, "Failed to retain PersistentVolume", "PersistentVolume", pv.Name)
+				return err
+			}
+			logger.Info("Retaining PersistentVolume of decommissioned node", "Pod", pod.Name, "PersistentVolume", pv.Name)
+		}
+	}
+	return nil
+}
+
+// decommissionedPods returns the pods that cass-operator removes when dc is scaled down to newSize. Nodes are spread
+// evenly across racks, the first racks getting the remainder, and each rack is scaled down by removing the pods with
+// the highest StatefulSet ordinals.
+func decommissionedPods(dc *cassdcapi.CassandraDatacenter, pods []corev1.Pod, newSize int32) []corev1.Pod {
+	racks := dc.GetRacks()
+	rackSizes := make(map[string]int, len(racks))
+	for i, rack := range racks {
+		size := int(newSize) / len(racks)
+		if i < int(newSize)%len(racks) {
+			size++
+		}
+		rackSizes[rack.Name] = size
+	}
+
+	var decommissioned []corev1.Pod
+	for _, pod := range pods {
+		rackSize, found := rackSizes[pod.Labels[cassdcapi.RackLabel]]
+		if !found {
+			continue
+		}
+		if ordinal, ok := podOrdinal(pod.Name); ok && ordinal >= rackSize {
+			decommissioned = append(decommissioned, pod)
+		}
+	}
+	sort.Slice(decommissioned, func(i, j int) bool { return decommissioned[i].Name < decommissioned[j].Name })
+	return decommissioned
+}
+
+func podOrdinal(podName string) (int, bool) {
+	i := strings.LastIndex(podName, "-")
+	if i < 0 {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(podName[i+1:])
+	return ordinal, err == nil
+}
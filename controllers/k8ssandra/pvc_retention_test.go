@@ -0,0 +1,108 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRetainVolumesOnScaleDown(t *testing.T) {
+	actualDc := &cassdcapi.CassandraDatacenter{Spec: cassdcapi.CassandraDatacenterSpec{Size: 3}}
+	smallerDc := &cassdcapi.CassandraDatacenter{Spec: cassdcapi.CassandraDatacenterSpec{Size: 2}}
+	largerDc := &cassdcapi.CassandraDatacenter{Spec: cassdcapi.CassandraDatacenterSpec{Size: 4}}
+
+	assert.True(t, retainVolumesOnScaleDown(&cassandra.DatacenterConfig{PvcRetentionPolicy: api.PvcRetentionPolicyRetain}, actualDc, smallerDc))
+	assert.False(t, retainVolumesOnScaleDown(&cassandra.DatacenterConfig{PvcRetentionPolicy: api.PvcRetentionPolicyRetain}, actualDc, largerDc))
+	assert.False(t, retainVolumesOnScaleDown(&cassandra.DatacenterConfig{PvcRetentionPolicy: api.PvcRetentionPolicyDelete}, actualDc, smallerDc))
+	assert.False(t, retainVolumesOnScaleDown(&cassandra.DatacenterConfig{}, actualDc, smallerDc))
+}
+
+func TestRetainDecommissionedVolumes(t *testing.T) {
+	ctx := context.Background()
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"},
+		Spec: cassdcapi.CassandraDatacenterSpec{
+			ClusterName: "cluster1",
+			Size:        4,
+			Racks:       []cassdcapi.Rack{{Name: "r1"}, {Name: "r2"}},
+		},
+	}
+
+	objs := []runtime.Object{}
+	for _, rack := range []string{"r1", "r2"} {
+		for _, podName := range []string{"cluster1-dc1-" + rack + "-sts-0", "cluster1-dc1-" + rack + "-sts-1"} {
+			podLabels := dc.GetDatacenterLabels()
+			podLabels[cassdcapi.RackLabel] = rack
+			objs = append(objs,
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: podName, Labels: podLabels},
+					Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+						Name: "server-data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "server-data-" + podName},
+						},
+					}}},
+				},
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "server-data-" + podName},
+					Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-" + podName},
+				},
+				&corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "pv-" + podName},
+					Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete},
+				},
+			)
+		}
+	}
+
+	fakeClient, err := test.NewFakeClient(objs...)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	r.ClientCache.AddClient("cluster-1", fakeClient)
+
+	require.NoError(t, r.retainDecommissionedVolumes(ctx, dc, 2, "cluster-1", fakeClient, testr.New(t)))
+
+	expected := map[string]corev1.PersistentVolumeReclaimPolicy{
+		"pv-cluster1-dc1-r1-sts-0": corev1.PersistentVolumeReclaimDelete,
+		"pv-cluster1-dc1-r1-sts-1": corev1.PersistentVolumeReclaimRetain,
+		"pv-cluster1-dc1-r2-sts-0": corev1.PersistentVolumeReclaimDelete,
+		"pv-cluster1-dc1-r2-sts-1": corev1.PersistentVolumeReclaimRetain,
+	}
+	for pvName, policy := range expected {
+		pv := &corev1.PersistentVolume{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: pvName}, pv))
+		assert.Equal(t, policy, pv.Spec.PersistentVolumeReclaimPolicy, pvName)
+	}
+}
+
+func TestDecommissionedPods(t *testing.T) {
+	dc := &cassdcapi.CassandraDatacenter{
+		Spec: cassdcapi.CassandraDatacenterSpec{Racks: []cassdcapi.Rack{{Name: "r1"}, {Name: "r2"}, {Name: "r3"}}},
+	}
+	newPod := func(name, rack string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{cassdcapi.RackLabel: rack}}}
+	}
+	pods := []corev1.Pod{
+		newPod("c-dc1-r1-sts-0", "r1"), newPod("c-dc1-r1-sts-1", "r1"),
+		newPod("c-dc1-r2-sts-0", "r2"), newPod("c-dc1-r2-sts-1", "r2"),
+		newPod("c-dc1-r3-sts-0", "r3"), newPod("c-dc1-r3-sts-1", "r3"),
+	}
+
+	// 4 nodes over 3 racks: the first rack keeps 2 nodes, the other ones 1
+	var names []string
+	for _, pod := range decommissionedPods(dc, pods, 4) {
+		names = append(names, pod.Name)
+	}
+	assert.Equal(t, []string{"c-dc1-r2-sts-1", "c-dc1-r3-sts-1"}, names)
+}
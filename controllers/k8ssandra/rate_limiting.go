@@ -0,0 +1,22 @@
+package k8ssandra
+
+import (
+	"time"
+
+	kerrors "github.com/k8ssandra/k8ssandra-operator/pkg/errors"
+)
+
+// rateLimitDelay returns true if err, the error of the reconcile, is the rejection of a request by an API server that
+// is rate limiting its clients, along with the delay before the reconcile is retried: the delay asked for by the
+// server in its Retry-After header, or the default delay when it did not ask for one. Retrying sooner, as the backoff
+// of the controller's rate limiter would for the first failures, would only add to the load of the server.
+func (r *K8ssandraClusterReconciler) rateLimitDelay(err error) (time.Duration, bool) {
+	delay, rateLimited := kerrors.RateLimitDelay(err)
+	if !rateLimited {
+		return 0, false
+	}
+	if delay <= 0 {
+		delay = r.DefaultDelay
+	}
+	return delay, true
+}
@@ -0,0 +1,76 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileHonorsRetryAfterWhenRateLimited(t *testing.T) {
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       "test",
+				Finalizers: []string{k8ssandraClusterFinalizer},
+			},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					SuperuserSecretRef: corev1.LocalObjectReference{Name: "test-superuser"},
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "east"},
+					},
+				},
+			},
+		}
+	}
+	newReconciler := func(t *testing.T, kc *api.K8ssandraCluster, err error) (*K8ssandraClusterReconciler, client.Client) {
+		fakeClient, fakeErr := test.NewFakeClient(kc)
+		require.NoError(t, fakeErr)
+		r := newTracingTestReconciler(&secretErrorClient{Client: fakeClient, err: err})
+		r.Recorder = record.NewFakeRecorder(10)
+		return r, fakeClient
+	}
+
+	t.Run("with Retry-After", func(t *testing.T) {
+		kc := newKc()
+		r, fakeClient := newReconciler(t, kc, apierrors.NewTooManyRequests("too many requests, please try again later", 30))
+
+		res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(kc)})
+		require.NoError(t, err, "the failure is transient, it is retried after the requested delay")
+		assert.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, res)
+
+		actual := &api.K8ssandraCluster{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(kc), actual))
+		assert.NotContains(t, actual.Status.Error, "too many requests")
+		assert.Empty(t, r.Recorder.(*record.FakeRecorder).Events, "no warning event is emitted")
+	})
+
+	t.Run("without Retry-After", func(t *testing.T) {
+		kc := newKc()
+		r, _ := newReconciler(t, kc, apierrors.NewTooManyRequests("too many requests", 0))
+
+		res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(kc)})
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: r.DefaultDelay}, res)
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		kc := newKc()
+		r, _ := newReconciler(t, kc, apierrors.NewServiceUnavailable("unavailable"))
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(kc)})
+		assert.True(t, apierrors.IsServiceUnavailable(err), "only rate limiting errors are retried after a delay")
+	})
+}
@@ -224,11 +224,8 @@ func (r *K8ssandraClusterReconciler) setStatusForReaper(kc *api.K8ssandraCluster
 
 func (r *K8ssandraClusterReconciler) removeReaperStatus(kc *api.K8ssandraCluster, dcName string) {
 	if kdcStatus, found := kc.Status.Datacenters[dcName]; found {
-		kc.Status.Datacenters[dcName] = api.K8ssandraStatus{
-			Reaper:    nil,
-			Cassandra: kdcStatus.Cassandra.DeepCopy(),
-			Stargate:  kdcStatus.Stargate.DeepCopy(),
-		}
+		kdcStatus.Reaper = nil
+		kc.Status.Datacenters[dcName] = kdcStatus
 	}
 }
 
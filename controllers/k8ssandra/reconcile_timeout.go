@@ -0,0 +1,61 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type reconcileDeadlineKey struct{}
+
+// withReconcileDeadline returns a context carrying the deadline of the reconcile of kc, computed from its
+// ReconcileTimeout, if any. The context itself is not cancelled when the deadline passes, so that the step in progress
+// can complete and the reconcile stops at the next checkReconcileTimeout.
+func withReconcileDeadline(ctx context.Context, kc *api.K8ssandraCluster, now time.Time) context.Context {
+	if kc.Spec.ReconcileTimeout == nil || kc.Spec.ReconcileTimeout.Duration <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, reconcileDeadlineKey{}, now.Add(kc.Spec.ReconcileTimeout.Duration))
+}
+
+// checkReconcileTimeout requeues the reconcile and sets the ReconcileTimedOut condition if the deadline of the
+// reconcile has passed. next describes the step the reconcile stops before; the progress made so far has already been
+// recorded in the status of kc.
+func (r *K8ssandraClusterReconciler) checkReconcileTimeout(ctx context.Context, kc *api.K8ssandraCluster, next string, logger logr.Logger) result.ReconcileResult {
+	deadline, found := ctx.Value(reconcileDeadlineKey{}).(time.Time)
+	if !found || time.Now().Before(deadline) {
+		return result.Continue()
+	}
+	logger.Info("Reconcile timeout elapsed, requeuing", "Timeout", kc.Spec.ReconcileTimeout.Duration, "Next", next)
+	kc.Status.SetConditionStatus(api.ReconcileTimedOut, corev1.ConditionTrue,
+		fmt.Sprintf("Reconcile timeout of %s elapsed before %s", kc.Spec.ReconcileTimeout.Duration, next))
+	return result.RequeueSoon(0)
+}
+
+// resumeDatacenters returns dcConfigs starting with the DC named resumeDc, followed by the DCs that come after it and
+// then by the ones that come before it, so that a reconcile that timed out resumes where it stopped. dcConfigs is
+// returned as is when resumeDc is empty or not found.
+func resumeDatacenters(dcConfigs []*cassandra.DatacenterConfig, resumeDc string) []*cassandra.DatacenterConfig {
+	for i, dcConfig := range dcConfigs {
+		if dcConfig.Meta.Name == resumeDc {
+			resumed := make([]*cassandra.DatacenterConfig, 0, len(dcConfigs))
+			resumed = append(resumed, dcConfigs[i:]...)
+			return append(resumed, dcConfigs[:i]...)
+		}
+	}
+	return dcConfigs
+}
+
+// clearReconcileTimedOutCondition sets the ReconcileTimedOut condition back to false once a reconcile completes.
+func clearReconcileTimedOutCondition(kc *api.K8ssandraCluster) {
+	if kc.Status.GetConditionStatus(api.ReconcileTimedOut) != corev1.ConditionTrue {
+		return
+	}
+	kc.Status.SetConditionStatus(api.ReconcileTimedOut, corev1.ConditionFalse, "")
+}
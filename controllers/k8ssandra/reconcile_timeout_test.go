@@ -0,0 +1,245 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	telemetryapi "github.com/k8ssandra/k8ssandra-operator/apis/telemetry/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/test/framework"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckReconcileTimeout(t *testing.T) {
+	newKc := func(timeout *metav1.Duration) *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec:       api.K8ssandraClusterSpec{ReconcileTimeout: timeout},
+		}
+	}
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	t.Run("no timeout", func(t *testing.T) {
+		kc := newKc(nil)
+		ctx := withReconcileDeadline(context.Background(), kc, time.Now().Add(-time.Hour))
+		assert.False(t, r.checkReconcileTimeout(ctx, kc, "datacenter dc2", logr.Discard()).Completed())
+		_, found := kc.Status.GetCondition(api.ReconcileTimedOut)
+		assert.False(t, found)
+	})
+
+	t.Run("within budget", func(t *testing.T) {
+		kc := newKc(&metav1.Duration{Duration: time.Minute})
+		ctx := withReconcileDeadline(context.Background(), kc, time.Now())
+		assert.False(t, r.checkReconcileTimeout(ctx, kc, "datacenter dc2", logr.Discard()).Completed())
+		_, found := kc.Status.GetCondition(api.ReconcileTimedOut)
+		assert.False(t, found)
+	})
+
+	t.Run("budget exceeded", func(t *testing.T) {
+		kc := newKc(&metav1.Duration{Duration: time.Minute})
+		ctx := withReconcileDeadline(context.Background(), kc, time.Now().Add(-time.Hour))
+		recResult := r.checkReconcileTimeout(ctx, kc, "datacenter dc2", logr.Discard())
+		require.True(t, recResult.Completed())
+		res, err := recResult.Output()
+		require.NoError(t, err)
+		assert.True(t, res.Requeue)
+
+		condition, found := kc.Status.GetCondition(api.ReconcileTimedOut)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, "Reconcile timeout of 1m0s elapsed before datacenter dc2", condition.Message)
+
+		clearReconcileTimedOutCondition(kc)
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.ReconcileTimedOut))
+	})
+}
+
+func TestAfterCassandraReconciledRespectsReconcileTimeout(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			ReconcileTimeout: &metav1.Duration{Duration: time.Second},
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+				},
+			},
+		},
+		Status: api.K8ssandraClusterStatus{
+			Datacenters: map[string]api.K8ssandraStatus{"dc1": {}},
+		},
+	}
+	dcs := []*cassdcapi.CassandraDatacenter{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc2"}},
+	}
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	ctx := withReconcileDeadline(context.Background(), kc, time.Now().Add(-time.Minute))
+	res, err := r.afterCassandraReconciled(ctx, kc, dcs, logr.Discard()).Output()
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{Requeue: true}, res, "the reconcile is requeued instead of failing")
+
+	condition, found := kc.Status.GetCondition(api.ReconcileTimedOut)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "Reconcile timeout of 1s elapsed before Stargate and Reaper of datacenter dc1", condition.Message)
+	assert.Contains(t, kc.Status.Datacenters, "dc1", "the progress recorded in the status is kept")
+}
+
+func TestResumeDatacenters(t *testing.T) {
+	dcConfigs := []*cassandra.DatacenterConfig{
+		{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+		{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+		{Meta: api.EmbeddedObjectMeta{Name: "dc3"}},
+	}
+	names := func(dcConfigs []*cassandra.DatacenterConfig) []string {
+		var names []string
+		for _, dcConfig := range dcConfigs {
+			names = append(names, dcConfig.Meta.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"dc1", "dc2", "dc3"}, names(resumeDatacenters(dcConfigs, "")))
+	assert.Equal(t, []string{"dc2", "dc3", "dc1"}, names(resumeDatacenters(dcConfigs, "dc2")))
+	assert.Equal(t, []string{"dc3", "dc1", "dc2"}, names(resumeDatacenters(dcConfigs, "dc3")))
+	assert.Equal(t, []string{"dc1", "dc2", "dc3"}, names(resumeDatacenters(dcConfigs, "removed")), "a DC removed since is ignored")
+	assert.Equal(t, []string{"dc1", "dc2", "dc3"}, names(dcConfigs), "the DCs are not reordered in place")
+}
+
+func TestSortDatacentersBySpec(t *testing.T) {
+	kc := &api.K8ssandraCluster{
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+					{Meta: api.EmbeddedObjectMeta{Name: "dc3"}},
+				},
+			},
+		},
+	}
+	resumed := []*cassdcapi.CassandraDatacenter{
+		{ObjectMeta: metav1.ObjectMeta{Name: "dc2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dc3"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dc1"}},
+	}
+
+	sorted := sortDatacentersBySpec(kc, resumed)
+	require.Len(t, sorted, 3)
+	for i, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		assert.Equal(t, dcTemplate.Meta.Name, sorted[i].Name)
+	}
+	assert.Equal(t, "dc2", resumed[0].Name, "the DCs are not reordered in place")
+}
+
+// resumeFromSecondDatacenter verifies that a reconcile resuming with a datacenter other than the first one applies the
+// settings of that datacenter, and does not let it create the superuser.
+func resumeFromSecondDatacenter(t *testing.T, ctx context.Context, f *framework.Framework, namespace string) {
+	require := require.New(t)
+
+	dcTemplate := func(name, k8sContext string) api.CassandraDatacenterTemplate {
+		return api.CassandraDatacenterTemplate{
+			Meta:       api.EmbeddedObjectMeta{Name: name},
+			K8sContext: k8sContext,
+			Size:       3,
+			DatacenterOptions: api.DatacenterOptions{
+				ServerVersion: "3.11.14",
+				StorageConfig: &cassdcapi.StorageConfig{
+					CassandraDataVolumeClaimSpec: &corev1.PersistentVolumeClaimSpec{
+						StorageClassName: &defaultStorageClass,
+					},
+				},
+			},
+		}
+	}
+	dc2Template := dcTemplate("dc2", f.DataPlaneContexts[1])
+	dc2Template.Telemetry = &telemetryapi.TelemetrySpec{
+		Cassandra: &telemetryapi.CassandraAgentSpec{
+			Endpoint: &telemetryapi.Endpoint{Port: "9999"},
+		},
+	}
+
+	// Every reconcile times out after its first DC, so that the next one resumes with the other DC
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "resume",
+		},
+		Spec: api.K8ssandraClusterSpec{
+			ReconcileTimeout: &metav1.Duration{Duration: time.Nanosecond},
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					dcTemplate("dc1", f.DataPlaneContexts[0]),
+					dc2Template,
+				},
+			},
+		},
+	}
+
+	err := f.Client.Create(ctx, kc)
+	require.NoError(err, "failed to create K8ssandraCluster")
+
+	verifyFinalizerAdded(ctx, t, f, client.ObjectKey{Namespace: kc.Namespace, Name: kc.Name})
+	verifySuperuserSecretCreated(ctx, t, f, kc)
+	verifyReplicatedSecretReconciled(ctx, t, f, kc)
+	verifySystemReplicationAnnotationSet(ctx, t, f, kc)
+
+	t.Log("check that dc1 was created")
+	dc1Key := framework.ClusterKey{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "dc1"}, K8sContext: f.DataPlaneContexts[0]}
+	require.Eventually(f.DatacenterExists(ctx, dc1Key), timeout, interval)
+
+	t.Log("update dc1 status to ready")
+	err = f.SetDatacenterStatusReady(ctx, dc1Key)
+	require.NoError(err, "failed to set dc1 status ready")
+
+	t.Log("check that dc2 was created by a reconcile resuming with it")
+	dc2Key := framework.ClusterKey{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "dc2"}, K8sContext: f.DataPlaneContexts[1]}
+	require.Eventually(f.DatacenterExists(ctx, dc2Key), timeout, interval)
+
+	dc1 := &cassdcapi.CassandraDatacenter{}
+	require.NoError(f.Get(ctx, dc1Key, dc1))
+	assert.NotContains(t, dc1.Annotations, cassdcapi.SkipUserCreationAnnotation, "dc1 creates the superuser")
+
+	dc2 := &cassdcapi.CassandraDatacenter{}
+	require.NoError(f.Get(ctx, dc2Key, dc2))
+	assert.Equal(t, "true", dc2.Annotations[cassdcapi.SkipUserCreationAnnotation], "dc2 must not create the superuser again")
+
+	t.Log("check that the telemetry of each DC was applied to it")
+	dc1AgentCm := &corev1.ConfigMap{}
+	dc1AgentCmKey := framework.ClusterKey{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "resume-dc1-metrics-agent-config"}, K8sContext: f.DataPlaneContexts[0]}
+	require.NoError(f.Get(ctx, dc1AgentCmKey, dc1AgentCm))
+	for _, config := range dc1AgentCm.Data {
+		assert.NotContains(t, config, "9999")
+	}
+	dc2AgentCm := &corev1.ConfigMap{}
+	dc2AgentCmKey := framework.ClusterKey{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "resume-dc2-metrics-agent-config"}, K8sContext: f.DataPlaneContexts[1]}
+	require.NoError(f.Get(ctx, dc2AgentCmKey, dc2AgentCm))
+	require.Len(dc2AgentCm.Data, 1)
+	for _, config := range dc2AgentCm.Data {
+		assert.Contains(t, config, "9999")
+	}
+
+	t.Log("deleting K8ssandraCluster")
+	err = f.DeleteK8ssandraCluster(ctx, client.ObjectKey{Namespace: kc.Namespace, Name: kc.Name}, timeout, interval)
+	require.NoError(err, "failed to delete K8ssandraCluster")
+	f.AssertObjectDoesNotExist(ctx, t, dc1Key, &cassdcapi.CassandraDatacenter{}, timeout, interval)
+	f.AssertObjectDoesNotExist(ctx, t, dc2Key, &cassdcapi.CassandraDatacenter{}, timeout, interval)
+}
@@ -0,0 +1,84 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// releaseDc detaches the DC named by the ReleaseDcAnnotation from the K8ssandraCluster. The Stargate and Reaper of
+// the DC are deleted since they cannot run without the operator, but the CassandraDatacenter is left running: only
+// the operator metadata is removed from it so that it is no longer reconciled. The keyspace replication is not
+// modified. The annotation itself is removed on the next reconciliation, once the DC is gone from the status.
+func (r *K8ssandraClusterReconciler) releaseDc(ctx context.Context, kc *api.K8ssandraCluster, dcName string, logger logr.Logger) result.ReconcileResult {
+	kcKey := utils.GetKey(kc)
+
+	remoteClient, err := r.deleteDcComponents(ctx, kcKey, dcName, logger)
+	if err != nil {
+		return result.Error(err)
+	}
+
+	dc, remoteClient, err := r.findDcForDeletion(ctx, kcKey, dcName, remoteClient)
+	if err != nil {
+		return result.Error(err)
+	}
+
+	if dc != nil {
+		patch := client.MergeFrom(dc.DeepCopy())
+		removeOperatorMetadata(dc)
+		if err = remoteClient.Patch(ctx, dc, patch); err != nil {
+			return result.Error(fmt.Errorf("failed to remove operator metadata from CassandraDatacenter (%s): %v", dcName, err))
+		}
+		logger.Info("Released CassandraDatacenter", "CassandraDatacenter", utils.GetKey(dc))
+	}
+
+	delete(kc.Status.Datacenters, dcName)
+	logger.Info("DC release finished", "DC", dcName)
+	return result.Continue()
+}
+
+// removeOperatorMetadata removes the labels and annotations that the operator uses to select and track a
+// CassandraDatacenter. Labels that merely describe the workload, like the component label, are kept.
+func removeOperatorMetadata(dc *cassdcapi.CassandraDatacenter) {
+	if dc.Labels[api.NameLabel] == api.NameLabelValue {
+		delete(dc.Labels, api.NameLabel)
+	}
+	if dc.Labels[api.PartOfLabel] == api.PartOfLabelValue {
+		delete(dc.Labels, api.PartOfLabel)
+	}
+	delete(dc.Labels, api.CreatedByLabel)
+	delete(dc.Labels, api.K8ssandraClusterNameLabel)
+	delete(dc.Labels, api.K8ssandraClusterNamespaceLabel)
+	delete(dc.Annotations, api.ResourceHashAnnotation)
+}
+
+// removeDcAnnotation removes annotation, which names a DC to release or to decommission, once that DC has been
+// processed, i.e. when the DC is neither in the spec nor in the status anymore. The annotation is kept if it was set
+// ahead of removing the DC from the spec.
+func (r *K8ssandraClusterReconciler) removeDcAnnotation(ctx context.Context, kc *api.K8ssandraCluster, annotation string) result.ReconcileResult {
+	dcName, found := kc.Annotations[annotation]
+	if !found {
+		return result.Continue()
+	}
+	if _, found = kc.Status.Datacenters[dcName]; found {
+		return result.Continue()
+	}
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if dcTemplate.Meta.Name == dcName {
+			return result.Continue()
+		}
+	}
+
+	patch := client.MergeFrom(kc.DeepCopy())
+	delete(kc.Annotations, annotation)
+	if err := r.Client.Patch(ctx, kc, patch); err != nil {
+		return result.Error(fmt.Errorf("failed to remove %s annotation: %v", annotation, err))
+	}
+	return result.Continue()
+}
@@ -0,0 +1,112 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckDcDeletionRelease(t *testing.T) {
+	ctx := context.Background()
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "cluster1",
+			Annotations: map[string]string{api.ReleaseDcAnnotation: "dc2"},
+		},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+				},
+			},
+		},
+		Status: api.K8ssandraClusterStatus{
+			Datacenters: map[string]api.K8ssandraStatus{"dc1": {}, "dc2": {}},
+		},
+	}
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "dc2",
+			Labels: map[string]string{
+				api.NameLabel:                      api.NameLabelValue,
+				api.PartOfLabel:                    api.PartOfLabelValue,
+				api.ComponentLabel:                 api.ComponentLabelValueCassandra,
+				api.CreatedByLabel:                 api.CreatedByLabelValueK8ssandraClusterController,
+				api.K8ssandraClusterNameLabel:      "cluster1",
+				api.K8ssandraClusterNamespaceLabel: "default",
+				"env":                              "prod",
+			},
+			Annotations: map[string]string{
+				api.ResourceHashAnnotation: "hash",
+				"team":                     "storage",
+			},
+		},
+		Spec: cassdcapi.CassandraDatacenterSpec{ClusterName: "cluster1"},
+	}
+
+	fakeClient, err := test.NewFakeClient(kc, dc)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	r.ClientCache.AddClient("cluster-1", fakeClient)
+
+	recResult := r.checkDcDeletion(ctx, kc, testr.New(t))
+	assert.Equal(t, result.Continue(), recResult)
+
+	actualDc := &cassdcapi.CassandraDatacenter{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(dc), actualDc), "the CassandraDatacenter should not be deleted")
+	assert.Equal(t, map[string]string{api.ComponentLabel: api.ComponentLabelValueCassandra, "env": "prod"}, actualDc.Labels)
+	assert.Equal(t, map[string]string{"team": "storage"}, actualDc.Annotations)
+	assert.NotContains(t, kc.Status.Datacenters, "dc2")
+	assert.Contains(t, kc.Status.Datacenters, "dc1")
+	assert.Equal(t, api.DecommNone, kc.Status.Datacenters["dc1"].DecommissionProgress)
+
+	recResult = r.checkDcDeletion(ctx, kc, testr.New(t))
+	assert.Equal(t, result.Continue(), recResult)
+
+	actualKc := &api.K8ssandraCluster{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(kc), actualKc))
+	assert.NotContains(t, actualKc.Annotations, api.ReleaseDcAnnotation)
+}
+
+func TestCheckDcDeletionReleaseAnnotationKept(t *testing.T) {
+	ctx := context.Background()
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "cluster1",
+			Annotations: map[string]string{api.ReleaseDcAnnotation: "dc1"},
+		},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+				},
+			},
+		},
+		Status: api.K8ssandraClusterStatus{
+			Datacenters: map[string]api.K8ssandraStatus{"dc1": {}},
+		},
+	}
+
+	fakeClient, err := test.NewFakeClient(kc)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	recResult := r.checkDcDeletion(ctx, kc, testr.New(t))
+	assert.Equal(t, result.Continue(), recResult)
+
+	actualKc := &api.K8ssandraCluster{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(kc), actualKc))
+	assert.Equal(t, "dc1", actualKc.Annotations[api.ReleaseDcAnnotation], "the DC is still in the spec")
+}
@@ -0,0 +1,51 @@
+package k8ssandra
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// periodicResync is a manager runnable that enqueues all the K8ssandraClusters for reconciliation every period, by
+// sending a generic event for each of them.
+type periodicResync struct {
+	client client.Client
+	period time.Duration
+	events chan<- event.GenericEvent
+	logger logr.Logger
+}
+
+func (p *periodicResync) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := p.enqueueAll(ctx); err != nil {
+				p.logger.Error(err, "Failed to enqueue K8ssandraClusters for periodic resync")
+			}
+		}
+	}
+}
+
+func (p *periodicResync) enqueueAll(ctx context.Context) error {
+	kcList := &api.K8ssandraClusterList{}
+	if err := p.client.List(ctx, kcList); err != nil {
+		return err
+	}
+	p.logger.V(1).Info("Enqueuing K8ssandraClusters for periodic resync", "count", len(kcList.Items))
+	for i := range kcList.Items {
+		select {
+		case p.events <- event.GenericEvent{Object: &kcList.Items[i]}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
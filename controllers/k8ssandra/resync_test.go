@@ -0,0 +1,60 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestPeriodicResync(t *testing.T) {
+	fakeClient, err := test.NewFakeClient(
+		&api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster1"}},
+		&api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "cluster2"}},
+	)
+	require.NoError(t, err)
+
+	events := make(chan event.GenericEvent)
+	resync := &periodicResync{
+		client: fakeClient,
+		period: 50 * time.Millisecond,
+		events: events,
+		logger: testr.New(t),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- resync.Start(ctx)
+	}()
+
+	start := time.Now()
+	// Both clusters are enqueued on each tick, for two consecutive ticks.
+	for tick := 0; tick < 2; tick++ {
+		enqueued := make([]string, 0, 2)
+		for len(enqueued) < 2 {
+			select {
+			case e := <-events:
+				enqueued = append(enqueued, e.Object.GetNamespace()+"/"+e.Object.GetName())
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "timed out waiting for the clusters to be enqueued")
+			}
+		}
+		assert.ElementsMatch(t, []string{"ns1/cluster1", "ns2/cluster2"}, enqueued)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 2*resync.period)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for the resync to stop")
+	}
+}
@@ -0,0 +1,104 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// deferDatacenterScaling returns the names of the other datacenters being scaled if the update of actualDc to
+// desiredDc changes its size while MaxConcurrentScalingDatacenters other datacenters are already scaling, in which
+// case the update must be postponed. It returns nil otherwise. remoteClient is the client of the context of actualDc.
+func (r *K8ssandraClusterReconciler) deferDatacenterScaling(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	actualDc *cassdcapi.CassandraDatacenter,
+	desiredDc *cassdcapi.CassandraDatacenter,
+	remoteClient client.Client,
+) ([]string, error) {
+	maxScaling := kc.Spec.Cassandra.MaxConcurrentScalingDatacenters
+	if maxScaling == nil || actualDc.Spec.Size == desiredDc.Spec.Size {
+		return nil, nil
+	}
+	if scaling, err := datacenterScaling(ctx, actualDc, remoteClient); err != nil || scaling {
+		return nil, err
+	}
+	scaling, err := r.scalingDatacenters(ctx, kc, actualDc.Name)
+	if err != nil || len(scaling) < int(*maxScaling) {
+		return nil, err
+	}
+	return scaling, nil
+}
+
+// scalingDatacenters returns the names of the datacenters of kc other than dcName that cass-operator is scaling. Their
+// current state is fetched rather than read from the status of kc, since the status of the datacenters that come
+// after a deferred datacenter is not refreshed until it is reconciled.
+func (r *K8ssandraClusterReconciler) scalingDatacenters(ctx context.Context, kc *api.K8ssandraCluster, dcName string) ([]string, error) {
+	scaling := make([]string, 0)
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if dcTemplate.Meta.Name == dcName {
+			continue
+		}
+		remoteClient, err := r.ClientCache.GetRemoteClient(dcTemplate.K8sContext)
+		if err != nil {
+			return nil, err
+		}
+		dc := &cassdcapi.CassandraDatacenter{}
+		dcKey := types.NamespacedName{Namespace: utils.FirstNonEmptyString(dcTemplate.Meta.Namespace, kc.Namespace), Name: dcTemplate.Meta.Name}
+		if err := remoteClient.Get(ctx, dcKey, dc); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if dcScaling, err := datacenterScaling(ctx, dc, remoteClient); err != nil {
+			return nil, err
+		} else if dcScaling {
+			scaling = append(scaling, dc.Name)
+		}
+	}
+	return scaling, nil
+}
+
+// datacenterScaling returns true if cass-operator reports that dc is scaling, or if the number of its ready nodes
+// differs from its size, as when the nodes added by a scale up are not ready yet, or before cass-operator sets its
+// scaling conditions. Stopped datacenters are not scaling.
+func datacenterScaling(ctx context.Context, dc *cassdcapi.CassandraDatacenter, remoteClient client.Client) (bool, error) {
+	if dc.GetConditionStatus(cassdcapi.DatacenterScalingUp) == corev1.ConditionTrue ||
+		dc.GetConditionStatus(cassdcapi.DatacenterScalingDown) == corev1.ConditionTrue {
+		return true, nil
+	}
+	if dc.Spec.Stopped {
+		return false, nil
+	}
+	ready, err := cassandra.ReadyNodeCount(ctx, dc, remoteClient)
+	if err != nil {
+		return false, err
+	}
+	return ready != dc.Spec.Size, nil
+}
+
+// setScalingDeferredCondition sets the ScalingDeferred condition to true, reporting that the scaling of the datacenter
+// dcName waits for the datacenters in scaling.
+func setScalingDeferredCondition(kc *api.K8ssandraCluster, dcName string, scaling []string) {
+	message := fmt.Sprintf("The scaling of datacenter %s is deferred until fewer datacenters are scaling: %s", dcName, strings.Join(scaling, ", "))
+
+	kc.Status.SetConditionStatus(api.ScalingDeferred, corev1.ConditionTrue, message)
+}
+
+// clearScalingDeferredCondition sets the ScalingDeferred condition back to false once no scaling is deferred anymore.
+func clearScalingDeferredCondition(kc *api.K8ssandraCluster) {
+	if kc.Status.GetConditionStatus(api.ScalingDeferred) != corev1.ConditionTrue {
+		return
+	}
+	kc.Status.SetConditionStatus(api.ScalingDeferred, corev1.ConditionFalse, "")
+}
@@ -0,0 +1,146 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDeferDatacenterScaling(t *testing.T) {
+	newKc := func(maxScaling *int32) *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					MaxConcurrentScalingDatacenters: maxScaling,
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "cluster-1", Size: 3},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, K8sContext: "cluster-1", Size: 3},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc3"}, K8sContext: "cluster-1", Size: 3},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc4"}, K8sContext: "cluster-1", Size: 3},
+					},
+				},
+			},
+		}
+	}
+	newDc := func(name string, size int32, scaling cassdcapi.DatacenterConditionType) *cassdcapi.CassandraDatacenter {
+		dc := &cassdcapi.CassandraDatacenter{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "test", Size: size},
+		}
+		if scaling != "" {
+			dc.Status.SetCondition(cassdcapi.DatacenterCondition{Type: scaling, Status: corev1.ConditionTrue})
+		}
+		return dc
+	}
+
+	newPod := func(dcName string, i int, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      fmt.Sprintf("test-%s-default-sts-%d", dcName, i),
+				Labels:    map[string]string{cassdcapi.ClusterLabel: "test", cassdcapi.DatacenterLabel: dcName},
+			},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+
+	// dc2 and dc3 are being scaled, dc4 is not
+	objects := []client.Object{
+		newDc("dc2", 4, cassdcapi.DatacenterScalingUp),
+		newDc("dc3", 2, cassdcapi.DatacenterScalingDown),
+		newDc("dc4", 3, ""),
+	}
+	for i := 0; i < 3; i++ {
+		objects = append(objects, newPod("dc1", i, corev1.ConditionTrue), newPod("dc4", i, corev1.ConditionTrue))
+	}
+	fakeClient, err := test.NewFakeClient(objects...)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	r.ClientCache.AddClient("cluster-1", fakeClient)
+
+	tests := []struct {
+		name       string
+		maxScaling *int32
+		actualDc   *cassdcapi.CassandraDatacenter
+		desiredDc  *cassdcapi.CassandraDatacenter
+		want       []string
+	}{
+		{
+			name:      "unlimited",
+			actualDc:  newDc("dc1", 3, ""),
+			desiredDc: newDc("dc1", 4, ""),
+		},
+		{
+			name:       "cap reached",
+			maxScaling: pointer.Int32(2),
+			actualDc:   newDc("dc1", 3, ""),
+			desiredDc:  newDc("dc1", 4, ""),
+			want:       []string{"dc2", "dc3"},
+		},
+		{
+			name:       "cap not reached",
+			maxScaling: pointer.Int32(3),
+			actualDc:   newDc("dc1", 3, ""),
+			desiredDc:  newDc("dc1", 4, ""),
+		},
+		{
+			name:       "size unchanged",
+			maxScaling: pointer.Int32(1),
+			actualDc:   newDc("dc1", 3, ""),
+			desiredDc:  newDc("dc1", 3, ""),
+		},
+		{
+			name:       "already scaling",
+			maxScaling: pointer.Int32(1),
+			actualDc:   newDc("dc2", 4, cassdcapi.DatacenterScalingUp),
+			desiredDc:  newDc("dc2", 5, ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.deferDatacenterScaling(context.Background(), newKc(tt.maxScaling), tt.actualDc, tt.desiredDc, fakeClient)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("ready nodes differ from the size", func(t *testing.T) {
+		// cass-operator did not set the scaling conditions of dc4 yet, but one of its nodes is not ready
+		pod := newPod("dc4", 2, corev1.ConditionFalse)
+		actualPod := &corev1.Pod{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(pod), actualPod))
+		actualPod.Status = pod.Status
+		require.NoError(t, fakeClient.Update(context.Background(), actualPod))
+
+		got, err := r.deferDatacenterScaling(context.Background(), newKc(pointer.Int32(3)), newDc("dc1", 3, ""), newDc("dc1", 4, ""), fakeClient)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dc2", "dc3", "dc4"}, got)
+	})
+}
+
+func TestScalingDeferredCondition(t *testing.T) {
+	kc := &api.K8ssandraCluster{}
+
+	clearScalingDeferredCondition(kc)
+	assert.Equal(t, corev1.ConditionUnknown, kc.Status.GetConditionStatus(api.ScalingDeferred))
+
+	setScalingDeferredCondition(kc, "dc1", []string{"dc2", "dc3"})
+	condition, found := kc.Status.GetCondition(api.ScalingDeferred)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "The scaling of datacenter dc1 is deferred until fewer datacenters are scaling: dc2, dc3", condition.Message)
+
+	clearScalingDeferredCondition(kc)
+	assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.ScalingDeferred))
+}
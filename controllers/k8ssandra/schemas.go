@@ -28,14 +28,17 @@ type SystemReplicationOldFormat struct {
 	ReplicationFactor int      `json:"replicationFactor"`
 }
 
+// checkSchemas performs the schema operations required after dc has been reconciled. The operations are sent to the
+// management API of coordinatorDc, which is fetched with coordinatorClient.
 func (r *K8ssandraClusterReconciler) checkSchemas(
 	ctx context.Context,
 	kc *api.K8ssandraCluster,
 	dc *cassdcapi.CassandraDatacenter,
-	remoteClient client.Client,
+	coordinatorDc *cassdcapi.CassandraDatacenter,
+	coordinatorClient client.Client,
 	logger logr.Logger) result.ReconcileResult {
 
-	mgmtApi, err := r.ManagementApi.NewManagementApiFacade(ctx, dc, remoteClient, logger)
+	mgmtApi, err := r.ManagementApi.NewManagementApiFacade(ctx, coordinatorDc, coordinatorClient, logger)
 	if err != nil {
 		return result.Error(err)
 	}
@@ -60,7 +63,7 @@ func (r *K8ssandraClusterReconciler) checkSchemas(
 	status := kc.Status.Datacenters[decommDcName]
 
 	if decommission {
-		if recResult := r.checkUserKeyspacesReplicationForDecommission(kc, decommDcName, mgmtApi, logger); recResult.Completed() {
+		if recResult := r.checkUserKeyspacesReplicationForDecommission(ctx, kc, decommDcName, mgmtApi, logger); recResult.Completed() {
 			return recResult
 		}
 		status.DecommissionProgress = api.DecommDeleting
@@ -105,7 +108,7 @@ func (r *K8ssandraClusterReconciler) checkInitialSystemReplication(
 		// Cassandra 4.1 writes the superuser role with CL=EACH_QUORUM, so we can't reference DCs before they are created.
 		// Only reference the first DC in the replication; for subsequent DCs, the replication will be altered.
 		firstDc := kc.Spec.Cassandra.Datacenters[0]
-		replication = cassandra.ComputeReplicationFromDatacenters(3, kc.Spec.ExternalDatacenters, firstDc)
+		replication = cassandra.ComputeSystemReplication(kc.Spec.ExternalDatacenters, firstDc)
 	}
 
 	bytes, err := json.Marshal(replication)
@@ -132,7 +135,8 @@ func (r *K8ssandraClusterReconciler) checkInitialSystemReplication(
 // updateReplicationOfSystemKeyspaces ensures that the replication for the system_auth,
 // system_traces, and system_distributed keyspaces is up to date.
 // DSE has a few specific system keyspaces that need to be updated as well.
-// It ensures that there are replicas for each DC and that there is a max of 3 replicas per DC.
+// It ensures that there are replicas for each DC and that there is a max of 3 replicas per DC, unless the DC overrides
+// its SystemReplicationFactor.
 func (r *K8ssandraClusterReconciler) updateReplicationOfSystemKeyspaces(
 	ctx context.Context,
 	kc *api.K8ssandraCluster,
@@ -143,9 +147,17 @@ func (r *K8ssandraClusterReconciler) updateReplicationOfSystemKeyspaces(
 		return recResult
 	}
 
-	replication := cassandra.ComputeReplicationFromDatacenters(3, kc.Spec.ExternalDatacenters, kc.GetInitializedDatacenters()...)
+	replication := cassandra.ComputeSystemReplication(kc.Spec.ExternalDatacenters, kc.GetInitializedDatacenters()...)
 
 	logger.Info("Preparing to update replication for system keyspaces", "replication", replication)
+	for _, dcTemplate := range kc.GetInitializedDatacenters() {
+		if dcConfig := getDatacenterConfig(kc, dcTemplate.Meta.Name); dcConfig != nil {
+			if err := cassandra.ValidateReplicationFactor(dcConfig, replication[dcTemplate.CassDcName()]); err != nil {
+				// System keyspaces must be replicated to every DC, so this is only reported.
+				logger.Info("Replicas of system keyspaces are not evenly spread across racks", "reason", err.Error())
+			}
+		}
+	}
 
 	systemKeyspaces := append([]string{}, api.SystemKeyspaces...)
 	if kc.Spec.Cassandra.ServerType == api.ServerDistributionDse {
@@ -219,6 +231,7 @@ func (r *K8ssandraClusterReconciler) updateUserKeyspacesReplication(
 	}
 
 	replication = getReplicationForDeployedDcs(kc, replication)
+	dcConfig := getDatacenterConfig(kc, dc.Name)
 
 	for _, ks := range userKeyspaces {
 		replicationFactor := replication.ReplicationFactor(dc.Name, ks)
@@ -226,7 +239,15 @@ func (r *K8ssandraClusterReconciler) updateUserKeyspacesReplication(
 		if replicationFactor == 0 {
 			continue
 		}
-		if err = ensureKeyspaceReplication(mgmtApi, ks, dc.Name, replicationFactor); err != nil {
+		if dcConfig != nil {
+			if err = cassandra.ValidateReplicationFactor(dcConfig, replicationFactor); err != nil {
+				err = fmt.Errorf("invalid replication for keyspace %s: %v", ks, err)
+				logger.Error(err, "Invalid "+api.DcReplicationAnnotation+" annotation")
+				return result.Error(err)
+			}
+		}
+		// The annotation is keyed by the object name of the DC, but Cassandra only knows its Cassandra name.
+		if err = ensureKeyspaceReplication(mgmtApi, ks, dc.DatacenterName(), replicationFactor); err != nil {
 			if kerrors.IsSchemaDisagreement(err) {
 				return result.RequeueSoon(r.DefaultDelay)
 			}
@@ -239,8 +260,10 @@ func (r *K8ssandraClusterReconciler) updateUserKeyspacesReplication(
 }
 
 // checkUserKeyspacesReplicationForDecommission checks if no user keyspace still has replicas
-// for a DC going being decommissioned.
+// for a DC going being decommissioned. The replicas are looked up by the Cassandra name of the
+// DC, which is read from its CassandraDatacenter if it still exists.
 func (r *K8ssandraClusterReconciler) checkUserKeyspacesReplicationForDecommission(
+	ctx context.Context,
 	kc *api.K8ssandraCluster,
 	decommDc string,
 	mgmtApi cassandra.ManagementApiFacade,
@@ -253,12 +276,21 @@ func (r *K8ssandraClusterReconciler) checkUserKeyspacesReplicationForDecommissio
 		return result.Error(fmt.Errorf("failed to get user keyspaces: %v", err))
 	}
 
+	cassDcName := decommDc
+	dc, _, err := r.findDcForDeletion(ctx, utils.GetKey(kc), decommDc, nil)
+	if err != nil {
+		return result.Error(err)
+	}
+	if dc != nil {
+		cassDcName = dc.DatacenterName()
+	}
+
 	for _, ks := range userKeyspaces {
 		replication, err := getKeyspaceReplication(mgmtApi, ks)
 		if err != nil {
 			return result.Error(fmt.Errorf("failed to get replication for keyspace (%s): %v", ks, err))
 		}
-		if _, hasReplicas := replication[decommDc]; hasReplicas {
+		if _, hasReplicas := replication[cassDcName]; hasReplicas {
 			return result.Error(fmt.Errorf("cannot decommission DC %s: keyspace %s still has replicas on it", decommDc, ks))
 		}
 	}
@@ -312,6 +344,16 @@ func getReplicationForDeployedDcs(kc *api.K8ssandraCluster, replication *cassand
 	return replication.ForDcs(dcNames...)
 }
 
+// getDatacenterConfig returns the merged configuration of the DC named dcName, or nil if kc does not manage such a DC.
+func getDatacenterConfig(kc *api.K8ssandraCluster, dcName string) *cassandra.DatacenterConfig {
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if dcTemplate.Meta.Name == dcName {
+			return cassandra.Coalesce(kc.CassClusterName(), kc.Spec.Cassandra.DeepCopy(), dcTemplate.DeepCopy())
+		}
+	}
+	return nil
+}
+
 func ensureKeyspaceReplication(mgmtApi cassandra.ManagementApiFacade, ks, dcName string, replicationFactor int) error {
 	replication, err := getKeyspaceReplication(mgmtApi, ks)
 	if err != nil {
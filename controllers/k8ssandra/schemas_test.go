@@ -0,0 +1,129 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/mocks"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newDatacenterNameOverrideCluster returns a K8ssandraCluster with two initialized datacenters whose Cassandra names
+// differ from their object names.
+func newDatacenterNameOverrideCluster() *api.K8ssandraCluster {
+	initialized := func() api.K8ssandraStatus {
+		status := &cassdcapi.CassandraDatacenterStatus{}
+		status.SetCondition(cassdcapi.DatacenterCondition{Type: cassdcapi.DatacenterInitialized, Status: corev1.ConditionTrue})
+		return api.K8ssandraStatus{Cassandra: status}
+	}
+	return &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "test",
+			Annotations: map[string]string{api.DcReplicationAnnotation: `{"dc2": {"ks1": 2}}`},
+		},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{
+						Meta:              api.EmbeddedObjectMeta{Name: "dc1"},
+						K8sContext:        "cluster-1",
+						Size:              3,
+						DatacenterOptions: api.DatacenterOptions{DatacenterName: "Real DC1"},
+					},
+					{
+						Meta:              api.EmbeddedObjectMeta{Name: "dc2"},
+						K8sContext:        "cluster-1",
+						Size:              3,
+						DatacenterOptions: api.DatacenterOptions{DatacenterName: "Real DC2"},
+					},
+				},
+			},
+		},
+		Status: api.K8ssandraClusterStatus{
+			Datacenters: map[string]api.K8ssandraStatus{"dc1": initialized(), "dc2": initialized()},
+		},
+	}
+}
+
+func TestUpdateReplicationOfSystemKeyspacesDatacenterName(t *testing.T) {
+	ctx := context.Background()
+	kc := newDatacenterNameOverrideCluster()
+	fakeClient, err := test.NewFakeClient(kc.DeepCopy())
+	require.NoError(t, err)
+	// The version check patches the K8ssandraCluster with an optimistic lock, it needs its resource version.
+	require.NoError(t, fakeClient.Get(ctx, utils.GetKey(kc), kc))
+	r := newTracingTestReconciler(fakeClient)
+
+	expected := map[string]int{"Real DC1": 3, "Real DC2": 3}
+	mgmtApi := new(mocks.ManagementApiFacade)
+	for _, ks := range api.SystemKeyspaces {
+		mgmtApi.On(test.EnsureKeyspaceReplication, ks, expected).Return(nil)
+	}
+
+	recResult := r.updateReplicationOfSystemKeyspaces(ctx, kc, mgmtApi, logr.Discard())
+
+	assert.False(t, recResult.Completed())
+	mgmtApi.AssertNumberOfCalls(t, test.EnsureKeyspaceReplication, len(api.SystemKeyspaces))
+}
+
+func TestUpdateUserKeyspacesReplicationDatacenterName(t *testing.T) {
+	kc := newDatacenterNameOverrideCluster()
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc2"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{Size: 3, DatacenterName: "Real DC2"},
+	}
+	r := newTracingTestReconciler(nil)
+
+	mgmtApi := new(mocks.ManagementApiFacade)
+	mgmtApi.On(test.ListKeyspaces, "").Return([]string{"ks1"}, nil)
+	mgmtApi.On(test.GetKeyspaceReplication, "ks1").Return(map[string]string{"class": cassandra.NetworkTopology, "Real DC1": "3"}, nil)
+	mgmtApi.On(test.EnsureKeyspaceReplication, "ks1", map[string]int{"Real DC1": 3, "Real DC2": 2}).Return(nil)
+
+	recResult := r.updateUserKeyspacesReplication(kc, dc, mgmtApi, logr.Discard())
+
+	assert.False(t, recResult.Completed())
+	mgmtApi.AssertCalled(t, test.EnsureKeyspaceReplication, "ks1", map[string]int{"Real DC1": 3, "Real DC2": 2})
+}
+
+func TestCheckUserKeyspacesReplicationForDecommissionDatacenterName(t *testing.T) {
+	kc := newDatacenterNameOverrideCluster()
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "dc2",
+			Labels:    labels.PartOfLabels(client.ObjectKey{Namespace: "default", Name: "test"}),
+		},
+		Spec: cassdcapi.CassandraDatacenterSpec{Size: 3, DatacenterName: "Real DC2"},
+	}
+	fakeClient, err := test.NewFakeClient(dc)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	mgmtApi := new(mocks.ManagementApiFacade)
+	mgmtApi.On(test.ListKeyspaces, "").Return([]string{"ks1"}, nil)
+	mgmtApi.On(test.GetKeyspaceReplication, "ks1").Return(map[string]string{"class": cassandra.NetworkTopology, "Real DC1": "3", "Real DC2": "2"}, nil).Once()
+
+	recResult := r.checkUserKeyspacesReplicationForDecommission(context.Background(), kc, "dc2", mgmtApi, logr.Discard())
+
+	require.True(t, recResult.Completed())
+	_, err = recResult.Output()
+	assert.EqualError(t, err, "cannot decommission DC dc2: keyspace ks1 still has replicas on it")
+
+	mgmtApi.On(test.GetKeyspaceReplication, "ks1").Return(map[string]string{"class": cassandra.NetworkTopology, "Real DC1": "3"}, nil)
+
+	recResult = r.checkUserKeyspacesReplicationForDecommission(context.Background(), kc, "dc2", mgmtApi, logr.Discard())
+
+	assert.False(t, recResult.Completed())
+}
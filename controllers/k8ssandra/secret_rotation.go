@@ -0,0 +1,26 @@
+package k8ssandra
+
+import (
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	kerrors "github.com/k8ssandra/k8ssandra-operator/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// isSecretRotationFailure returns true if err, the error of the reconcile, is an authentication or authorization
+// failure that is likely caused by the recent rotation of the kubeconfig secret of a k8s context used by the
+// datacenters of kc: the new credentials may not be accepted by the remote cluster yet. Such failures are transient
+// while the rotation is within the configured grace period, and permanent afterwards.
+func (r *K8ssandraClusterReconciler) isSecretRotationFailure(kc *api.K8ssandraCluster, err error) bool {
+	if r.SecretRotationGracePeriod <= 0 || r.ClientCache == nil || kc.Spec.Cassandra == nil {
+		return false
+	}
+	if !apierrors.IsUnauthorized(err) && !(apierrors.IsForbidden(err) && !kerrors.IsSpecRejected(err)) {
+		return false
+	}
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if dcTemplate.K8sContext != "" && r.ClientCache.SecretRotatedWithin(dcTemplate.K8sContext, r.SecretRotationGracePeriod) {
+			return true
+		}
+	}
+	return false
+}
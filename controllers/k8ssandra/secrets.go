@@ -7,12 +7,16 @@ import (
 
 	"github.com/go-logr/logr"
 	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/reaper"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/secret"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -48,6 +52,27 @@ func (r *K8ssandraClusterReconciler) reconcileSuperuserSecret(ctx context.Contex
 		return result.Error(err)
 	}
 
+	return r.checkSuperuserSecretKeys(ctx, kc, logger)
+}
+
+// checkSuperuserSecretKeys reports a superuser secret without credentials in the SuperuserSecretMalformed condition,
+// and stops the reconciliation until it is fixed, rather than letting cass-operator create the superuser from it.
+func (r *K8ssandraClusterReconciler) checkSuperuserSecretKeys(ctx context.Context, kc *api.K8ssandraCluster, logger logr.Logger) result.ReconcileResult {
+	superuserSecret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: kc.Namespace, Name: kc.Spec.Cassandra.SuperuserSecretRef.Name}
+	if err := r.Get(ctx, key, superuserSecret); err != nil {
+		return result.Error(fmt.Errorf("failed to get superuser secret: %v", err))
+	}
+
+	if err := secret.ValidateCredentials(superuserSecret); err != nil {
+		logger.Info("The superuser secret is malformed", "Error", err.Error())
+		kc.Status.SetConditionStatus(api.SuperuserSecretMalformed, corev1.ConditionTrue, err.Error())
+		return result.RequeueSoon(r.DefaultDelay)
+	}
+
+	if kc.Status.GetConditionStatus(api.SuperuserSecretMalformed) == corev1.ConditionTrue {
+		kc.Status.SetConditionStatus(api.SuperuserSecretMalformed, corev1.ConditionFalse, "")
+	}
 	return result.Continue()
 }
 
@@ -105,3 +130,45 @@ func (r *K8ssandraClusterReconciler) reconcileReplicatedSecret(ctx context.Conte
 	}
 	return result.Continue()
 }
+
+// reconcileSecretsHash computes a hash of the encryption stores secrets mounted in the Cassandra pods and stores it in
+// the pod template of the datacenter, so that any change to those secrets results in a rolling restart. The secrets
+// are also labeled as watched by the K8ssandraCluster so that changes to their contents trigger a reconciliation.
+func (r *K8ssandraClusterReconciler) reconcileSecretsHash(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcConfig *cassandra.DatacenterConfig,
+	remoteClient client.Client,
+	dcLogger logr.Logger,
+) result.ReconcileResult {
+	secretNames := cassandra.EncryptionStoresSecretNames(dcConfig)
+	if len(secretNames) == 0 {
+		return result.Continue()
+	}
+
+	kcKey := utils.GetKey(kc)
+	secretsData := make([]map[string][]byte, 0, len(secretNames))
+	for _, name := range secretNames {
+		s := &corev1.Secret{}
+		if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: kcKey.Namespace, Name: name}, s); err != nil {
+			dcLogger.Error(err, "Failed to get secret", "Secret", name)
+			return result.Error(err)
+		}
+		if !labels.IsWatchedByK8ssandraCluster(s, kcKey) {
+			// Note that we do NOT set the secret as owned by the operator, we only want to be notified of changes to
+			// its contents.
+			labels.SetWatchedByK8ssandraCluster(s, kcKey)
+			if err := remoteClient.Update(ctx, s); err != nil {
+				if errors.IsConflict(err) {
+					return result.RequeueSoon(r.DefaultDelay)
+				}
+				dcLogger.Error(err, "Failed to set secret as watched by k8ssandra-operator", "Secret", name)
+				return result.Error(err)
+			}
+		}
+		secretsData = append(secretsData, s.Data)
+	}
+
+	annotations.AddAnnotation(&dcConfig.PodTemplateSpec, api.SecretsHashAnnotation, utils.DeepHashString(secretsData))
+	return result.Continue()
+}
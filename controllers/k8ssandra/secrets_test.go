@@ -0,0 +1,145 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/encryption"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/labels"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileSecretsHash(t *testing.T) {
+	ctx := context.Background()
+	logger := testr.New(t)
+
+	kc := &api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	keystore := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "server-keystore"},
+		Data:       map[string][]byte{"keystore": []byte("keystore-v1"), "keystore-password": []byte("changeit")},
+	}
+	truststore := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "server-truststore"},
+		Data:       map[string][]byte{"truststore": []byte("truststore-v1"), "truststore-password": []byte("changeit")},
+	}
+	fakeClient, err := test.NewFakeClient(keystore, truststore)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	newDcConfig := func() *cassandra.DatacenterConfig {
+		return &cassandra.DatacenterConfig{
+			CassandraConfig: api.CassandraConfig{
+				CassandraYaml: unstructured.Unstructured{
+					"server_encryption_options": map[string]interface{}{
+						"internode_encryption": "all",
+					},
+				},
+			},
+			ServerEncryptionStores: &encryption.Stores{
+				KeystoreSecretRef: &encryption.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{
+					Name: "server-keystore",
+				}},
+				TruststoreSecretRef: &encryption.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{
+					Name: "server-truststore",
+				}},
+			},
+		}
+	}
+
+	dcConfig := newDcConfig()
+	recResult := r.reconcileSecretsHash(ctx, kc, dcConfig, fakeClient, logger)
+	require.False(t, recResult.Completed())
+	hash := dcConfig.PodTemplateSpec.Annotations[api.SecretsHashAnnotation]
+	require.NotEmpty(t, hash)
+	podTemplateHash := utils.DeepHashString(dcConfig.PodTemplateSpec)
+
+	// the secrets are now watched by the cluster
+	for _, s := range []*corev1.Secret{keystore, truststore} {
+		actual := &corev1.Secret{}
+		require.NoError(t, fakeClient.Get(ctx, utils.GetKey(s), actual))
+		assert.True(t, labels.IsWatchedByK8ssandraCluster(actual, utils.GetKey(kc)))
+	}
+
+	// a reconcile without any secret change produces the same hash
+	dcConfig = newDcConfig()
+	require.False(t, r.reconcileSecretsHash(ctx, kc, dcConfig, fakeClient, logger).Completed())
+	assert.Equal(t, hash, dcConfig.PodTemplateSpec.Annotations[api.SecretsHashAnnotation])
+
+	// changing the contents of a secret changes the pod template, which triggers a rolling restart
+	actual := &corev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx, utils.GetKey(keystore), actual))
+	actual.Data["keystore"] = []byte("keystore-v2")
+	require.NoError(t, fakeClient.Update(ctx, actual))
+
+	dcConfig = newDcConfig()
+	require.False(t, r.reconcileSecretsHash(ctx, kc, dcConfig, fakeClient, logger).Completed())
+	assert.NotEqual(t, hash, dcConfig.PodTemplateSpec.Annotations[api.SecretsHashAnnotation])
+	assert.NotEqual(t, podTemplateHash, utils.DeepHashString(dcConfig.PodTemplateSpec))
+}
+
+func TestReconcileSecretsHash_ExternalSecrets(t *testing.T) {
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	kc := &api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	dcConfig := &cassandra.DatacenterConfig{
+		ExternalSecrets: true,
+		CassandraConfig: api.CassandraConfig{
+			CassandraYaml: unstructured.Unstructured{
+				"server_encryption_options": map[string]interface{}{
+					"internode_encryption": "all",
+				},
+			},
+		},
+	}
+
+	recResult := r.reconcileSecretsHash(context.Background(), kc, dcConfig, fakeClient, testr.New(t))
+	assert.False(t, recResult.Completed())
+	assert.NotContains(t, dcConfig.PodTemplateSpec.Annotations, api.SecretsHashAnnotation)
+}
+
+func TestReconcileSuperuserSecret_Malformed(t *testing.T) {
+	ctx := context.Background()
+	logger := testr.New(t)
+
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				SuperuserSecretRef: corev1.LocalObjectReference{Name: "test-superuser"},
+			},
+		},
+	}
+	superuserSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-superuser"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": {}},
+	}
+	fakeClient, err := test.NewFakeClient(superuserSecret)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	recResult := r.reconcileSuperuserSecret(ctx, kc, logger)
+	assert.Equal(t, result.RequeueSoon(r.DefaultDelay), recResult)
+	condition, found := kc.Status.GetCondition(api.SuperuserSecretMalformed)
+	require.True(t, found)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "secret default/test-superuser is missing non-empty keys [password]", condition.Message)
+
+	require.NoError(t, fakeClient.Get(ctx, utils.GetKey(superuserSecret), superuserSecret))
+	superuserSecret.Data["password"] = []byte("secret")
+	require.NoError(t, fakeClient.Update(ctx, superuserSecret))
+
+	recResult = r.reconcileSuperuserSecret(ctx, kc, logger)
+	assert.Equal(t, result.Continue(), recResult)
+	assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.SuperuserSecretMalformed))
+}
@@ -0,0 +1,133 @@
+package k8ssandra
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// seedDialer probes the internode port of a seed at address, failing if it cannot be reached within timeout.
+type seedDialer func(ctx context.Context, address string, timeout time.Duration) error
+
+// dialSeed is the seedDialer used by default, it opens a TCP connection to the seed.
+func dialSeed(ctx context.Context, address string, timeout time.Duration) error {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// checkSeedsReachability probes the internode port of the seeds of a cluster that spans multiple contexts before they
+// are propagated. The port is the one the nodes of the seed's DC listen on, as configured in dcConfigs. Pod IPs can
+// only be used as seeds across Kubernetes clusters with flat networking, so a seed that the operator cannot reach is
+// likely not routable from the other contexts either.
+//
+// The probe runs from the operator pod, whose network path to the seeds is not the one of the Cassandra pods of the
+// other contexts: a seed reachable from the operator may still be unreachable from them, or the other way around, for
+// instance when a firewall or a network policy treats the operator and Cassandra traffic differently. The result is
+// thus only a hint, reported by a warning event when a seed becomes unreachable and a normal event when it is
+// reachable again.
+func (r *K8ssandraClusterReconciler) checkSeedsReachability(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcConfigs []*cassandra.DatacenterConfig,
+	seeds []corev1.Pod,
+	logger logr.Logger,
+) {
+	if r.SeedReachabilityTimeout <= 0 || !spansMultipleContexts(kc) {
+		return
+	}
+
+	ports := make(map[string]int, len(dcConfigs))
+	for _, dcConfig := range dcConfigs {
+		ports[dcConfig.Meta.Name] = cassandra.InternodePort(dcConfig)
+	}
+	dial := r.seedDialer
+	if dial == nil {
+		dial = dialSeed
+	}
+	unreachable := make(map[string]bool)
+	for _, seed := range unreachableSeeds(ctx, seeds, ports, r.SeedReachabilityTimeout, dial) {
+		unreachable[seedAddress(seed)] = true
+	}
+
+	kcKey := utils.GetKey(kc)
+	previous, _ := r.unreachableSeedAddresses.get(kcKey)
+	r.unreachableSeedAddresses.set(kcKey, unreachable)
+
+	for _, seed := range seeds {
+		address := seedAddress(seed)
+		dcName := seed.Labels[cassdcapi.DatacenterLabel]
+		if unreachable[address] && !previous[address] {
+			logger.Info("Seed is not reachable from the operator, pod IPs may not be routable between contexts",
+				"DC", dcName, "Pod", seed.Name, "Address", seed.Status.PodIP)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(kc, corev1.EventTypeWarning, "SeedUnreachable",
+					"Seed %s (%s) of DC %s is not reachable from the operator, pod IPs may not be routable between contexts",
+					seed.Name, seed.Status.PodIP, dcName)
+			}
+		} else if !unreachable[address] && previous[address] {
+			logger.Info("Seed is reachable again from the operator", "DC", dcName, "Pod", seed.Name, "Address", seed.Status.PodIP)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(kc, corev1.EventTypeNormal, "SeedReachable",
+					"Seed %s (%s) of DC %s is reachable again from the operator", seed.Name, seed.Status.PodIP, dcName)
+			}
+		}
+	}
+}
+
+// seedAddress identifies a seed by its pod name and IP, so that a seed recreated with another IP is probed anew.
+func seedAddress(seed corev1.Pod) string {
+	return seed.Name + "/" + seed.Status.PodIP
+}
+
+// spansMultipleContexts returns true if the DCs of kc are deployed in more than one context.
+func spansMultipleContexts(kc *api.K8ssandraCluster) bool {
+	contexts := make(map[string]bool)
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		contexts[dcTemplate.K8sContext] = true
+	}
+	return len(contexts) > 1
+}
+
+// unreachableSeeds concurrently probes the seeds that have an IP with dial and returns those that could not be reached,
+// in the order of seeds. Each seed is probed on the port of its DC in ports, or on the default storage port.
+func unreachableSeeds(ctx context.Context, seeds []corev1.Pod, ports map[string]int, timeout time.Duration, dial seedDialer) []corev1.Pod {
+	reachable := make([]bool, len(seeds))
+	var wg sync.WaitGroup
+	for i, seed := range seeds {
+		if seed.Status.PodIP == "" {
+			reachable[i] = true
+			continue
+		}
+		port, found := ports[seed.Labels[cassdcapi.DatacenterLabel]]
+		if !found {
+			port = cassandra.DefaultStoragePort
+		}
+		wg.Add(1)
+		go func(i int, address string) {
+			defer wg.Done()
+			reachable[i] = dial(ctx, address, timeout) == nil
+		}(i, net.JoinHostPort(seed.Status.PodIP, strconv.Itoa(port)))
+	}
+	wg.Wait()
+
+	unreachable := make([]corev1.Pod, 0)
+	for i, seed := range seeds {
+		if !reachable[i] {
+			unreachable = append(unreachable, seed)
+		}
+	}
+	return unreachable
+}
@@ -0,0 +1,134 @@
+package k8ssandra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestCheckSeedsReachability(t *testing.T) {
+	ctx := context.Background()
+	newKc := func(contexts ...string) *api.K8ssandraCluster {
+		kc := &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster1"},
+			Spec:       api.K8ssandraClusterSpec{Cassandra: &api.CassandraClusterTemplate{}},
+		}
+		for i, k8sContext := range contexts {
+			kc.Spec.Cassandra.Datacenters = append(kc.Spec.Cassandra.Datacenters, api.CassandraDatacenterTemplate{
+				Meta:       api.EmbeddedObjectMeta{Name: fmt.Sprintf("dc%d", i+1)},
+				K8sContext: k8sContext,
+			})
+		}
+		return kc
+	}
+	newSeed := func(name, dcName, ip string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{cassdcapi.DatacenterLabel: dcName}},
+			Status:     corev1.PodStatus{PodIP: ip},
+		}
+	}
+	seeds := []corev1.Pod{
+		newSeed("cluster1-dc1-default-sts-0", "dc1", "10.0.0.1"),
+		newSeed("cluster1-dc2-default-sts-0", "dc2", "10.1.0.1"),
+		newSeed("cluster1-dc2-default-sts-1", "dc2", ""),
+	}
+
+	// dc2 listens on a custom internode port, dc1 on the default one
+	dc2Config := &cassandra.DatacenterConfig{Meta: api.EmbeddedObjectMeta{Name: "dc2"}}
+	dc2Config.CassandraConfig.CassandraYaml = unstructured.Unstructured{"storage_port": 7010}
+	dcConfigs := []*cassandra.DatacenterConfig{dc2Config}
+
+	dialed := make(chan string, 10)
+	unroutable := "10.1.0.1:7010"
+	dial := func(ctx context.Context, address string, timeout time.Duration) error {
+		dialed <- address
+		if address == unroutable {
+			return errors.New("i/o timeout")
+		}
+		return nil
+	}
+
+	newReconciler := func(timeout time.Duration) (*K8ssandraClusterReconciler, *record.FakeRecorder) {
+		fakeClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		r.SeedReachabilityTimeout = timeout
+		r.seedDialer = dial
+		recorder := record.NewFakeRecorder(10)
+		r.Recorder = recorder
+		return r, recorder
+	}
+
+	t.Run("unroutable seed", func(t *testing.T) {
+		r, recorder := newReconciler(time.Second)
+		r.checkSeedsReachability(ctx, newKc("cluster-0", "cluster-1"), dcConfigs, seeds, testr.New(t))
+
+		require.Len(t, recorder.Events, 1)
+		event := <-recorder.Events
+		assert.Contains(t, event, "Warning SeedUnreachable")
+		assert.Contains(t, event, "cluster1-dc2-default-sts-0 (10.1.0.1) of DC dc2")
+		assert.ElementsMatch(t, []string{"10.0.0.1:7000", "10.1.0.1:7010"}, drainAddresses(dialed))
+	})
+
+	t.Run("transitions only", func(t *testing.T) {
+		r, recorder := newReconciler(time.Second)
+		kc := newKc("cluster-0", "cluster-1")
+		r.checkSeedsReachability(ctx, kc, dcConfigs, seeds, testr.New(t))
+		require.Len(t, recorder.Events, 1)
+		<-recorder.Events
+
+		r.checkSeedsReachability(ctx, kc, dcConfigs, seeds, testr.New(t))
+		assert.Empty(t, recorder.Events, "the seed is still unreachable, no new event is emitted")
+
+		unroutable = ""
+		t.Cleanup(func() { unroutable = "10.1.0.1:7010" })
+		r.checkSeedsReachability(ctx, kc, dcConfigs, seeds, testr.New(t))
+		require.Len(t, recorder.Events, 1)
+		event := <-recorder.Events
+		assert.Contains(t, event, "Normal SeedReachable")
+		assert.Contains(t, event, "cluster1-dc2-default-sts-0 (10.1.0.1) of DC dc2")
+		drainAddresses(dialed)
+	})
+
+	t.Run("single context", func(t *testing.T) {
+		r, recorder := newReconciler(time.Second)
+		r.checkSeedsReachability(ctx, newKc("cluster-0", "cluster-0"), dcConfigs, seeds, testr.New(t))
+
+		assert.Empty(t, recorder.Events)
+		assert.Empty(t, drainAddresses(dialed), "seeds should not be probed")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		r, recorder := newReconciler(0)
+		r.checkSeedsReachability(ctx, newKc("cluster-0", "cluster-1"), dcConfigs, seeds, testr.New(t))
+
+		assert.Empty(t, recorder.Events)
+		assert.Empty(t, drainAddresses(dialed), "seeds should not be probed")
+	})
+}
+
+func drainAddresses(ch chan string) []string {
+	values := make([]string, 0)
+	for {
+		select {
+		case value := <-ch:
+			values = append(values, value)
+		default:
+			return values
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkSeedTopology verifies that the seeds configured in the ready DCs are consistent, and sets the
+// SeedTopologyInconsistent condition otherwise. The seeds of a DC are its own seed pods, plus the addresses of its
+// additional seeds Endpoints. They are consistent when they include at least one seed of each ready DC, and when the
+// DCs are all connected to each other, directly or not, through the seeds they know about. The seeds recorded in the
+// status of kc are used to find out which DC a seed address belongs to. They must not contain the same address twice,
+// and each ready DC must contribute as many seeds as cass-operator labels among its ready pods. The contributions of
+// the DCs of the contexts in maintenance are not checked, since their seeds are frozen.
+func (r *K8ssandraClusterReconciler) checkSeedTopology(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcs []*cassdcapi.CassandraDatacenter,
+	logger logr.Logger,
+) result.ReconcileResult {
+	seedDcs := make(map[string]string, len(kc.Status.Seeds))
+	for _, seed := range kc.Status.Seeds {
+		seedDcs[seed.Address] = seed.Datacenter
+	}
+
+	excludedRacks := make(map[string][]string)
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		excludedRacks[dcTemplate.Meta.Name] = dcTemplate.SeedExcludedRacks
+	}
+	inMaintenance := contextsInMaintenance(kc)
+
+	knownSeeds := make(map[string][]string)
+	expectedSeeds := make(map[string]int)
+	for _, dc := range dcs {
+		remoteClient, err := r.ClientCache.GetRemoteClient(getDatacenterK8sContext(kc, dc.Name))
+		if err != nil {
+			logger.Error(err, "Failed to get remote client", "CassandraDatacenter", dc.Name)
+			return result.Error(err)
+		}
+		if ready, err := cassandra.IsDatacenterReady(ctx, dc, remoteClient); err != nil {
+			logger.Error(err, "Failed to check the readiness of the datacenter", "CassandraDatacenter", dc.Name)
+			return result.Error(err)
+		} else if !ready {
+			continue
+		}
+		if !inMaintenance[getDatacenterK8sContext(kc, dc.Name)] {
+			readyByRack, err := cassandra.ReadyNodeCountByRack(ctx, dc, remoteClient)
+			if err != nil {
+				logger.Error(err, "Failed to count the ready pods of the datacenter", "CassandraDatacenter", dc.Name)
+				return result.Error(err)
+			}
+			expectedSeeds[dc.Name] = expectedSeedCount(dc, excludedRacks[dc.Name], readyByRack)
+		}
+		endpoints := &corev1.Endpoints{}
+		endpointsKey := client.ObjectKey{Namespace: dc.Namespace, Name: dc.GetAdditionalSeedsServiceName()}
+		if err := remoteClient.Get(ctx, endpointsKey, endpoints); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to get seeds endpoints", "Endpoints", endpointsKey)
+			return result.Error(err)
+		}
+		seeds := make([]string, 0)
+		for _, seed := range kc.Status.Seeds {
+			if seed.Datacenter == dc.Name {
+				seeds = append(seeds, seed.Address)
+			}
+		}
+		for _, subset := range endpoints.Subsets {
+			for _, address := range subset.Addresses {
+				seeds = append(seeds, address.IP)
+			}
+		}
+		knownSeeds[dc.Name] = seeds
+	}
+
+	problems := seedContributionProblems(kc.Status.Seeds, expectedSeeds)
+	problems = append(problems, seedTopologyProblems(knownSeeds, seedDcs)...)
+	setSeedTopologyCondition(kc, problems)
+	return result.Continue()
+}
+
+// seedContributionProblems returns the addresses contributed by more than one of seeds, and the DCs of expectedSeeds
+// that do not contribute the expected number of seeds.
+func seedContributionProblems(seeds []api.SeedStatus, expectedSeeds map[string]int) []string {
+	problems := make([]string, 0)
+
+	seedsByAddress := make(map[string][]string)
+	seedsByDc := make(map[string]int)
+	for _, seed := range seeds {
+		seedsByDc[seed.Datacenter]++
+		seedsByAddress[seed.Address] = append(seedsByAddress[seed.Address], seed.Datacenter+"/"+seed.Name)
+	}
+	addresses := make([]string, 0, len(seedsByAddress))
+	for address, names := range seedsByAddress {
+		if len(names) > 1 {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+	for _, address := range addresses {
+		names := seedsByAddress[address]
+		sort.Strings(names)
+		problems = append(problems, fmt.Sprintf("seed address %s is contributed by %s", address, strings.Join(names, ", ")))
+	}
+
+	dcNames := make([]string, 0, len(expectedSeeds))
+	for dcName := range expectedSeeds {
+		dcNames = append(dcNames, dcName)
+	}
+	sort.Strings(dcNames)
+	for _, dcName := range dcNames {
+		if actual, expected := seedsByDc[dcName], expectedSeeds[dcName]; actual != expected {
+			problems = append(problems, fmt.Sprintf("datacenter %s contributes %d seeds, expected %d", dcName, actual, expected))
+		}
+	}
+	return problems
+}
+
+// expectedSeedCount returns the number of seeds that dc contributes. cass-operator labels 3 seeds per datacenter, all
+// the nodes if there are fewer, or one per rack if there are more than 3 racks, and spreads them evenly across the
+// racks. Only the ready pods are labeled, so a rack with fewer ready pods than its share contributes all of them,
+// readyByRack giving the number of ready pods of each rack. The seeds of the excluded racks are not contributed.
+func expectedSeedCount(dc *cassdcapi.CassandraDatacenter, excludedRacks []string, readyByRack map[string]int) int {
+	if dc.Spec.Stopped {
+		return 0
+	}
+	racks := dc.GetRacks()
+	seedCount := 3
+	if dc.Spec.Size < 3 {
+		seedCount = int(dc.Spec.Size)
+	} else if len(racks) > 3 {
+		seedCount = len(racks)
+	}
+	expected := 0
+	for i, rackSeeds := range cassdcapi.SplitRacks(seedCount, len(racks)) {
+		if utils.SliceContains(excludedRacks, racks[i].Name) {
+			continue
+		}
+		if ready := readyByRack[racks[i].Name]; ready < rackSeeds {
+			rackSeeds = ready
+		}
+		expected += rackSeeds
+	}
+	return expected
+}
+
+// seedTopologyProblems returns the inconsistencies of the seeds known by each DC, as described in checkSeedTopology.
+// seedDcs maps seed addresses to the DC they belong to; addresses that are not in seedDcs, such as additional seeds
+// outside of the cluster, are ignored. The result is empty when there are less than two DCs.
+func seedTopologyProblems(knownSeeds map[string][]string, seedDcs map[string]string) []string {
+	if len(knownSeeds) < 2 {
+		return nil
+	}
+	dcNames := make([]string, 0, len(knownSeeds))
+	for dcName := range knownSeeds {
+		dcNames = append(dcNames, dcName)
+	}
+	sort.Strings(dcNames)
+
+	links := make(map[string]map[string]bool, len(knownSeeds))
+	for _, dcName := range dcNames {
+		links[dcName] = make(map[string]bool)
+	}
+	dcsWithSeeds := make(map[string]bool)
+	for _, dcName := range dcNames {
+		for _, address := range knownSeeds[dcName] {
+			seedDc, found := seedDcs[address]
+			if !found {
+				continue
+			}
+			dcsWithSeeds[seedDc] = true
+			if _, found := links[seedDc]; found && seedDc != dcName {
+				links[dcName][seedDc] = true
+				links[seedDc][dcName] = true
+			}
+		}
+	}
+
+	problems := make([]string, 0)
+	for _, dcName := range dcNames {
+		if !dcsWithSeeds[dcName] {
+			problems = append(problems, fmt.Sprintf("no seed of datacenter %s is configured", dcName))
+		}
+	}
+
+	connected := map[string]bool{dcNames[0]: true}
+	toVisit := []string{dcNames[0]}
+	for len(toVisit) > 0 {
+		dcName := toVisit[0]
+		toVisit = toVisit[1:]
+		for linkedDc := range links[dcName] {
+			if !connected[linkedDc] {
+				connected[linkedDc] = true
+				toVisit = append(toVisit, linkedDc)
+			}
+		}
+	}
+	disconnected := make([]string, 0)
+	for _, dcName := range dcNames {
+		if !connected[dcName] {
+			disconnected = append(disconnected, dcName)
+		}
+	}
+	if len(disconnected) > 0 {
+		problems = append(problems, fmt.Sprintf("datacenters %s are not connected through seeds to datacenter %s",
+			strings.Join(disconnected, ", "), dcNames[0]))
+	}
+	return problems
+}
+
+// setSeedTopologyCondition sets the SeedTopologyInconsistent condition to true if there are problems, and to false
+// otherwise.
+func setSeedTopologyCondition(kc *api.K8ssandraCluster, problems []string) {
+	status := corev1.ConditionFalse
+	message := ""
+	if len(problems) > 0 {
+		status = corev1.ConditionTrue
+		message = "Seed configuration can lead to a split-brain cluster: " + strings.Join(problems, "; ")
+	}
+	kc.Status.SetConditionStatus(api.SeedTopologyInconsistent, status, message)
+}
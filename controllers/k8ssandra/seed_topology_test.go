@@ -0,0 +1,285 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSeedTopologyProblems(t *testing.T) {
+	seedDcs := map[string]string{
+		"10.0.1.1": "dc1",
+		"10.0.2.1": "dc2",
+		"10.0.3.1": "dc3",
+		"10.0.4.1": "dc4",
+	}
+	tests := []struct {
+		name       string
+		knownSeeds map[string][]string
+		want       []string
+	}{
+		{
+			name:       "single dc",
+			knownSeeds: map[string][]string{"dc1": {"10.0.1.1"}},
+		},
+		{
+			name: "all dcs know each other",
+			knownSeeds: map[string][]string{
+				"dc1": {"10.0.1.1", "10.0.2.1", "10.0.3.1"},
+				"dc2": {"10.0.2.1", "10.0.1.1", "10.0.3.1"},
+				"dc3": {"10.0.3.1", "10.0.1.1", "10.0.2.1"},
+			},
+		},
+		{
+			name: "dcs connected through another dc",
+			knownSeeds: map[string][]string{
+				"dc1": {"10.0.1.1", "10.0.2.1"},
+				"dc2": {"10.0.2.1"},
+				"dc3": {"10.0.3.1", "10.0.2.1", "192.168.0.1"},
+			},
+		},
+		{
+			name: "disjoint seed sets",
+			knownSeeds: map[string][]string{
+				"dc1": {"10.0.1.1", "10.0.2.1"},
+				"dc2": {"10.0.2.1", "10.0.1.1"},
+				"dc3": {"10.0.3.1", "10.0.4.1"},
+				"dc4": {"10.0.4.1", "10.0.3.1"},
+			},
+			want: []string{"datacenters dc3, dc4 are not connected through seeds to datacenter dc1"},
+		},
+		{
+			name: "dc without seeds",
+			knownSeeds: map[string][]string{
+				"dc1": {"10.0.1.1", "10.0.2.1"},
+				"dc2": {"10.0.2.1", "10.0.1.1"},
+				"dc3": {"10.0.1.1"},
+			},
+			want: []string{"no seed of datacenter dc3 is configured"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.want, seedTopologyProblems(tt.knownSeeds, seedDcs))
+		})
+	}
+}
+
+func TestSeedContributionProblems(t *testing.T) {
+	seeds := []api.SeedStatus{
+		{Datacenter: "dc1", Name: "test-dc1-default-sts-0", Address: "10.0.0.1"},
+		{Datacenter: "dc1", Name: "test-dc1-default-sts-1", Address: "10.0.0.2"},
+		{Datacenter: "dc2", Name: "test-dc2-r1-sts-0", Address: "10.1.0.1"},
+		{Datacenter: "dc2", Name: "test-dc2-r2-sts-0", Address: "10.1.0.2"},
+	}
+
+	t.Run("consistent", func(t *testing.T) {
+		assert.Empty(t, seedContributionProblems(seeds, map[string]int{"dc1": 2, "dc2": 2}))
+	})
+
+	t.Run("duplicate address", func(t *testing.T) {
+		duplicate := append([]api.SeedStatus{}, seeds...)
+		duplicate[3].Address = "10.0.0.1"
+		assert.Equal(t, []string{"seed address 10.0.0.1 is contributed by dc1/test-dc1-default-sts-0, dc2/test-dc2-r2-sts-0"},
+			seedContributionProblems(duplicate, map[string]int{"dc1": 2, "dc2": 2}))
+	})
+
+	t.Run("unexpected contributions", func(t *testing.T) {
+		assert.Equal(t, []string{"datacenter dc1 contributes 2 seeds, expected 3", "datacenter dc2 contributes 2 seeds, expected 1"},
+			seedContributionProblems(seeds, map[string]int{"dc1": 3, "dc2": 1}))
+	})
+
+	t.Run("unchecked datacenters", func(t *testing.T) {
+		assert.Empty(t, seedContributionProblems(seeds, map[string]int{"dc2": 2}))
+	})
+}
+
+func TestExpectedSeedCount(t *testing.T) {
+	newDc := func(size int32, stopped bool, racks ...string) *cassdcapi.CassandraDatacenter {
+		dc := &cassdcapi.CassandraDatacenter{Spec: cassdcapi.CassandraDatacenterSpec{Size: size, Stopped: stopped}}
+		for _, rack := range racks {
+			dc.Spec.Racks = append(dc.Spec.Racks, cassdcapi.Rack{Name: rack})
+		}
+		return dc
+	}
+	tests := []struct {
+		name          string
+		dc            *cassdcapi.CassandraDatacenter
+		excludedRacks []string
+		readyByRack   map[string]int
+		want          int
+	}{
+		{"single node", newDc(1, false), nil, map[string]int{"default": 1}, 1},
+		{"default rack", newDc(6, false), nil, map[string]int{"default": 6}, 3},
+		{"stopped", newDc(6, true), nil, map[string]int{}, 0},
+		{"three racks", newDc(9, false, "r1", "r2", "r3"), nil, map[string]int{"r1": 3, "r2": 3, "r3": 3}, 3},
+		{"five racks", newDc(10, false, "r1", "r2", "r3", "r4", "r5"), nil, map[string]int{"r1": 2, "r2": 2, "r3": 2, "r4": 2, "r5": 2}, 5},
+		{"excluded rack", newDc(4, false, "r1", "r2"), []string{"r1"}, map[string]int{"r1": 2, "r2": 2}, 1},
+		{"down node", newDc(3, false), nil, map[string]int{"default": 2}, 2},
+		{"rack down", newDc(9, false, "r1", "r2", "r3"), nil, map[string]int{"r1": 3, "r2": 3}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expectedSeedCount(tt.dc, tt.excludedRacks, tt.readyByRack))
+		})
+	}
+}
+
+func TestCheckSeedTopology(t *testing.T) {
+	readyDc := func(name string) *cassdcapi.CassandraDatacenter {
+		return &cassdcapi.CassandraDatacenter{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "cluster1", Size: 1},
+			Status: cassdcapi.CassandraDatacenterStatus{
+				CassandraOperatorProgress: cassdcapi.ProgressReady,
+				Conditions: []cassdcapi.DatacenterCondition{{
+					Type:   cassdcapi.DatacenterReady,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+		}
+	}
+	seedsEndpoints := func(dc *cassdcapi.CassandraDatacenter, addresses ...string) *corev1.Endpoints {
+		return newEndpoints(dc, nil, addresses)
+	}
+	readyPod := func(dc *cassdcapi.CassandraDatacenter) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: dc.Namespace,
+				Name:      dc.Name + "-rack1-sts-0",
+				Labels: map[string]string{
+					cassdcapi.ClusterLabel:    "cluster1",
+					cassdcapi.DatacenterLabel: dc.Name,
+					cassdcapi.RackLabel:       "default",
+				},
+			},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+		}
+	}
+	dc1, dc2, dc3 := readyDc("dc1"), readyDc("dc2"), readyDc("dc3")
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc3"}},
+					},
+				},
+			},
+			Status: api.K8ssandraClusterStatus{
+				Seeds: []api.SeedStatus{
+					{Datacenter: "dc1", Name: "dc1-rack1-sts-0", Address: "10.0.1.1"},
+					{Datacenter: "dc2", Name: "dc2-rack1-sts-0", Address: "10.0.2.1"},
+					{Datacenter: "dc3", Name: "dc3-rack1-sts-0", Address: "10.0.3.1"},
+				},
+			},
+		}
+	}
+
+	t.Run("consistent seeds", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(
+			seedsEndpoints(dc1, "10.0.2.1", "10.0.3.1"),
+			seedsEndpoints(dc2, "10.0.1.1", "10.0.3.1"),
+			seedsEndpoints(dc3, "10.0.1.1", "10.0.2.1"),
+			readyPod(dc1), readyPod(dc2), readyPod(dc3),
+		)
+		require.NoError(t, err)
+		kc := newKc()
+
+		recResult := newTracingTestReconciler(fakeClient).checkSeedTopology(context.Background(), kc, []*cassdcapi.CassandraDatacenter{dc1, dc2, dc3}, testr.New(t))
+		assert.False(t, recResult.Completed())
+
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.SeedTopologyInconsistent))
+	})
+
+	t.Run("disjoint seeds", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(
+			seedsEndpoints(dc1, "10.0.2.1"),
+			seedsEndpoints(dc2, "10.0.1.1"),
+			readyPod(dc1), readyPod(dc2), readyPod(dc3),
+		)
+		require.NoError(t, err)
+		kc := newKc()
+
+		recResult := newTracingTestReconciler(fakeClient).checkSeedTopology(context.Background(), kc, []*cassdcapi.CassandraDatacenter{dc1, dc2, dc3}, testr.New(t))
+		assert.False(t, recResult.Completed())
+
+		condition, found := kc.Status.GetCondition(api.SeedTopologyInconsistent)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Contains(t, condition.Message, "datacenters dc3 are not connected through seeds to datacenter dc1")
+	})
+
+	t.Run("missing contribution", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(
+			seedsEndpoints(dc1, "10.0.2.1", "10.0.3.1"),
+			seedsEndpoints(dc2, "10.0.1.1", "10.0.3.1"),
+			seedsEndpoints(dc3, "10.0.1.1", "10.0.2.1"),
+			readyPod(dc1), readyPod(dc2), readyPod(dc3),
+		)
+		require.NoError(t, err)
+		kc := newKc()
+		kc.Status.Seeds = kc.Status.Seeds[1:]
+
+		newTracingTestReconciler(fakeClient).checkSeedTopology(context.Background(), kc, []*cassdcapi.CassandraDatacenter{dc1, dc2, dc3}, testr.New(t))
+
+		condition, found := kc.Status.GetCondition(api.SeedTopologyInconsistent)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Contains(t, condition.Message, "datacenter dc1 contributes 0 seeds, expected 1")
+	})
+
+	t.Run("down nodes are not expected to contribute", func(t *testing.T) {
+		largeDc1 := dc1.DeepCopy()
+		largeDc1.Spec.Size = 3
+		downPod := readyPod(dc1)
+		downPod.Status.Conditions[0].Status = corev1.ConditionFalse
+		pod1, pod2 := readyPod(dc1), readyPod(dc1)
+		pod1.Name, pod2.Name = "dc1-rack1-sts-1", "dc1-rack1-sts-2"
+		fakeClient, err := test.NewFakeClient(
+			seedsEndpoints(dc1, "10.0.2.1", "10.0.3.1"),
+			seedsEndpoints(dc2, "10.0.1.2", "10.0.3.1"),
+			seedsEndpoints(dc3, "10.0.1.2", "10.0.2.1"),
+			downPod, pod1, pod2, readyPod(dc2), readyPod(dc3),
+		)
+		require.NoError(t, err)
+		kc := newKc()
+		kc.Status.Seeds = []api.SeedStatus{
+			{Datacenter: "dc1", Name: "dc1-rack1-sts-1", Address: "10.0.1.2"},
+			{Datacenter: "dc1", Name: "dc1-rack1-sts-2", Address: "10.0.1.3"},
+			{Datacenter: "dc2", Name: "dc2-rack1-sts-0", Address: "10.0.2.1"},
+			{Datacenter: "dc3", Name: "dc3-rack1-sts-0", Address: "10.0.3.1"},
+		}
+
+		newTracingTestReconciler(fakeClient).checkSeedTopology(context.Background(), kc, []*cassdcapi.CassandraDatacenter{largeDc1, dc2, dc3}, testr.New(t))
+
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.SeedTopologyInconsistent))
+	})
+
+	t.Run("dcs not ready are ignored", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(
+			seedsEndpoints(dc1, "10.0.2.1"),
+			seedsEndpoints(dc2, "10.0.1.1"),
+			readyPod(dc1), readyPod(dc2),
+		)
+		require.NoError(t, err)
+		kc := newKc()
+		notReadyDc3 := dc3.DeepCopy()
+		notReadyDc3.Status = cassdcapi.CassandraDatacenterStatus{}
+
+		newTracingTestReconciler(fakeClient).checkSeedTopology(context.Background(), kc, []*cassdcapi.CassandraDatacenter{dc1, dc2, notReadyDc3}, testr.New(t))
+
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.SeedTopologyInconsistent))
+	})
+}
@@ -3,12 +3,19 @@ package k8ssandra
 import (
 	"context"
 	"fmt"
+	"net"
+	"sort"
+	"time"
 
 	"github.com/go-logr/logr"
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	kerrors "github.com/k8ssandra/k8ssandra-operator/pkg/errors"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/tracing"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,21 +23,38 @@ import (
 )
 
 // findSeeds queries for pods labeled as seeds. It does this for each DC, across all
-// clusters.
-func (r *K8ssandraClusterReconciler) findSeeds(ctx context.Context, kc *api.K8ssandraCluster, cassClusterName string, logger logr.Logger) ([]corev1.Pod, error) {
-	pods := make([]corev1.Pod, 0)
+// clusters. When the SeedRefreshInterval of kc has not elapsed since the previous query, the seeds recorded in the
+// status are returned instead. When a DC transiently has no seed pods, its seeds recorded in the status are returned
+// along with a transientNoSeedsError, so that the caller can apply the other changes and resolve the seeds again later.
+func (r *K8ssandraClusterReconciler) findSeeds(ctx context.Context, kc *api.K8ssandraCluster, cassClusterName string, logger logr.Logger) (pods []corev1.Pod, err error) {
+	ctx, span := tracing.Start(ctx, "findSeeds", tracing.ClusterKey.String(cassClusterName))
+	defer func() { tracing.End(span, err) }()
+
+	pods = make([]corev1.Pod, 0)
+	if !seedsRefreshDue(kc) {
+		for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+			pods = append(pods, getCachedSeeds(kc, seedsNamespace(kc, dcTemplate), dcTemplate.Meta.Name)...)
+		}
+		return pods, nil
+	}
+	inMaintenance := contextsInMaintenance(kc)
+	var noSeedsErr error
 
 	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		namespace := seedsNamespace(kc, dcTemplate)
+
+		// The seeds of the DCs in maintenance are frozen to the ones known before the maintenance
+		if inMaintenance[dcTemplate.K8sContext] {
+			pods = append(pods, getCachedSeeds(kc, namespace, dcTemplate.Meta.Name)...)
+			continue
+		}
+
 		remoteClient, err := r.ClientCache.GetRemoteClient(dcTemplate.K8sContext)
 		if err != nil {
 			logger.Error(err, "Failed to get remote client", "K8sContext", dcTemplate.K8sContext)
 			return nil, err
 		}
-
-		namespace := kc.Namespace
-		if dcTemplate.Meta.Namespace != "" {
-			namespace = dcTemplate.Meta.Namespace
-		}
+		remoteClient = tracing.NewClient(remoteClient, dcTemplate.K8sContext)
 
 		list := &corev1.PodList{}
 		selector := map[string]string{
@@ -42,21 +66,264 @@ func (r *K8ssandraClusterReconciler) findSeeds(ctx context.Context, kc *api.K8ss
 		dcKey := client.ObjectKey{Namespace: namespace, Name: dcTemplate.Meta.Name}
 
 		if err := remoteClient.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels(selector)); err != nil {
+			if cachedSeeds := getCachedSeeds(kc, namespace, dcTemplate.Meta.Name); useCachedSeeds(kc, err) && len(cachedSeeds) > 0 {
+				logger.Info("Failed to get seed pods, using previously known seeds", "K8sContext", dcTemplate.K8sContext, "DC", dcKey, "error", err.Error())
+				pods = append(pods, cachedSeeds...)
+				continue
+			}
 			logger.Error(err, "Failed to get seed pods", "K8sContext", dcTemplate.K8sContext, "DC", dcKey)
 			return nil, err
 		}
 
-		pods = append(pods, list.Items...)
+		if len(list.Items) == 0 && seedsExpected(kc, dcTemplate) {
+			pods = append(pods, getCachedSeeds(kc, namespace, dcTemplate.Meta.Name)...)
+			if noSeedsErr == nil {
+				noSeedsErr = transientNoSeedsError{dcKey: dcKey}
+			}
+			continue
+		}
+
+		pods = append(pods, excludeSeedRacks(list.Items, dcTemplate.SeedExcludedRacks)...)
 	}
 
+	setSeedsStatus(kc, pods)
+	if noSeedsErr != nil {
+		// The seeds must be resolved again on the next reconcile, regardless of the refresh interval
+		kc.Status.SeedsRefreshTime = nil
+		return pods, noSeedsErr
+	}
+	if kc.Spec.Cassandra.SeedRefreshInterval != nil {
+		now := metav1.Now()
+		kc.Status.SeedsRefreshTime = &now
+	} else {
+		kc.Status.SeedsRefreshTime = nil
+	}
 	return pods, nil
 }
 
+func seedsNamespace(kc *api.K8ssandraCluster, dcTemplate api.CassandraDatacenterTemplate) string {
+	if dcTemplate.Meta.Namespace != "" {
+		return dcTemplate.Meta.Namespace
+	}
+	return kc.Namespace
+}
+
+// seedsRefreshDue returns true if the seeds must be re-resolved, which is the case on every reconcile when the
+// SeedRefreshInterval of kc is unset. Otherwise, the seeds are re-resolved once the interval has elapsed since the
+// previous resolution, or right away if a datacenter that is not stopped has no seeds recorded in the status, such as
+// a datacenter that is being created.
+func seedsRefreshDue(kc *api.K8ssandraCluster) bool {
+	interval := kc.Spec.Cassandra.SeedRefreshInterval
+	lastRefresh := kc.Status.SeedsRefreshTime
+	if interval == nil || lastRefresh == nil || time.Since(lastRefresh.Time) >= interval.Duration {
+		return true
+	}
+	for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+		if !dcTemplate.Stopped && len(getCachedSeeds(kc, seedsNamespace(kc, dcTemplate), dcTemplate.Meta.Name)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// transientNoSeedsError is returned by findSeeds when no seed pods are found in a datacenter that should have some.
+type transientNoSeedsError struct {
+	dcKey client.ObjectKey
+}
+
+func (e transientNoSeedsError) Error() string {
+	return fmt.Sprintf("no seed pods found in ready datacenter %s", e.dcKey)
+}
+
+// seedsExpected returns true if the EmptySeedsPolicy of kc is Requeue and the datacenter of dcTemplate is ready and
+// running according to the status of kc, in which case having no seed pods is transient. A stopped datacenter
+// legitimately has no seeds.
+func seedsExpected(kc *api.K8ssandraCluster, dcTemplate api.CassandraDatacenterTemplate) bool {
+	if kc.Spec.Cassandra.EmptySeedsPolicy != api.EmptySeedsPolicyRequeue || dcTemplate.Stopped {
+		return false
+	}
+	dcStatus, found := kc.Status.Datacenters[dcTemplate.Meta.Name]
+	if !found || dcStatus.Cassandra == nil {
+		return false
+	}
+	return dcStatus.Cassandra.GetConditionStatus(cassdcapi.DatacenterReady) == corev1.ConditionTrue &&
+		dcStatus.Cassandra.GetConditionStatus(cassdcapi.DatacenterStopped) != corev1.ConditionTrue
+}
+
+// excludeSeedRacks returns the seeds that are not in one of the excluded racks.
+func excludeSeedRacks(seeds []corev1.Pod, excludedRacks []string) []corev1.Pod {
+	if len(excludedRacks) == 0 {
+		return seeds
+	}
+	filtered := make([]corev1.Pod, 0, len(seeds))
+	for _, seed := range seeds {
+		excluded := false
+		for _, rack := range excludedRacks {
+			if seed.Labels[cassdcapi.RackLabel] == cassdcapi.CleanLabelValue(rack) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			filtered = append(filtered, seed)
+		}
+	}
+	return filtered
+}
+
+// useCachedSeeds returns true if the seeds recorded in the status of kc should be used because listing fresh seeds
+// failed with err.
+func useCachedSeeds(kc *api.K8ssandraCluster, err error) bool {
+	return kc.Spec.Cassandra.SeedResolutionFailurePolicy == api.SeedResolutionFailurePolicyUseCachedSeeds &&
+		kerrors.IsTransient(err)
+}
+
+// getCachedSeeds returns the seeds of the given DC recorded in the status of kc. Only the fields used to configure
+// seeds are set on the returned pods.
+func getCachedSeeds(kc *api.K8ssandraCluster, namespace, dcName string) []corev1.Pod {
+	pods := make([]corev1.Pod, 0)
+	for _, seed := range kc.Status.Seeds {
+		if seed.Datacenter == dcName {
+			pods = append(pods, corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      seed.Name,
+					Labels: map[string]string{
+						cassdcapi.DatacenterLabel: seed.Datacenter,
+						cassdcapi.SeedNodeLabel:   "true",
+					},
+				},
+				Status: corev1.PodStatus{PodIP: seed.Address},
+			})
+		}
+	}
+	return pods
+}
+
+// setSeedsStatus records seeds in the status of kc, so that they can be used if they cannot be listed later on.
+func setSeedsStatus(kc *api.K8ssandraCluster, seeds []corev1.Pod) {
+	statuses := make([]api.SeedStatus, 0, len(seeds))
+	for _, seed := range seeds {
+		if seed.Status.PodIP == "" {
+			continue
+		}
+		statuses = append(statuses, api.SeedStatus{
+			Datacenter: seed.Labels[cassdcapi.DatacenterLabel],
+			Name:       seed.Name,
+			Address:    seed.Status.PodIP,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Datacenter != statuses[j].Datacenter {
+			return statuses[i].Datacenter < statuses[j].Datacenter
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	kc.Status.Seeds = statuses
+}
+
+// deferSeedPropagation returns true if the SeedPropagationQuorum of kc is set and fewer of the datacenters of dcConfigs
+// are ready, according to their readiness policy. A datacenter whose readiness cannot be checked is not counted.
+func (r *K8ssandraClusterReconciler) deferSeedPropagation(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcConfigs []*cassandra.DatacenterConfig,
+	logger logr.Logger,
+) bool {
+	quorum := kc.Spec.Cassandra.SeedPropagationQuorum
+	if quorum == nil {
+		return false
+	}
+	readyDcs := 0
+	for _, dcConfig := range dcConfigs {
+		dcKey := client.ObjectKey{Namespace: utils.FirstNonEmptyString(dcConfig.Meta.Namespace, kc.Namespace), Name: dcConfig.Meta.Name}
+		remoteClient, err := r.ClientCache.GetRemoteClient(dcConfig.K8sContext)
+		if err != nil {
+			logger.Error(err, "Failed to get remote client", "CassandraDatacenter", dcKey)
+			continue
+		}
+		dc := &cassdcapi.CassandraDatacenter{}
+		if err := remoteClient.Get(ctx, dcKey, dc); err != nil {
+			if !errors.IsNotFound(err) {
+				logger.Error(err, "Failed to get CassandraDatacenter", "CassandraDatacenter", dcKey)
+			}
+			continue
+		}
+		ready, err := cassandra.CheckDatacenterReady(ctx, dc, dcConfig.ReadinessPolicy, remoteClient)
+		if err != nil {
+			logger.Error(err, "Failed to check the readiness of the datacenter", "CassandraDatacenter", dcKey)
+			continue
+		}
+		if ready {
+			readyDcs++
+		}
+	}
+	return readyDcs < int(*quorum)
+}
+
+// lookupHost resolves the external name of ExternalName seed services. Tests can replace it to avoid DNS queries.
+var lookupHost = net.DefaultResolver.LookupHost
+
+// resolveServiceSeeds returns the addresses of the seed Service referenced by serviceRef. The addresses of the
+// Endpoints of the Service are returned, or the IPs its external name resolves to for an ExternalName Service.
+func resolveServiceSeeds(ctx context.Context, namespace string, serviceRef *corev1.LocalObjectReference, remoteClient client.Client) ([]string, error) {
+	serviceKey := client.ObjectKey{Namespace: namespace, Name: serviceRef.Name}
+	service := &corev1.Service{}
+	if err := remoteClient.Get(ctx, serviceKey, service); err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		hosts, err := lookupHost(ctx, service.Spec.ExternalName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve external name %s of seed service %s: %v", service.Spec.ExternalName, serviceKey, err)
+		}
+		for _, host := range hosts {
+			if net.ParseIP(host) != nil {
+				addresses = append(addresses, host)
+			}
+		}
+	} else {
+		endpoints := &corev1.Endpoints{}
+		if err := remoteClient.Get(ctx, serviceKey, endpoints); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		for _, subset := range endpoints.Subsets {
+			for _, address := range subset.Addresses {
+				addresses = append(addresses, address.IP)
+			}
+		}
+	}
+	return mergeSeeds(nil, addresses), nil
+}
+
+// mergeSeeds returns the sorted union of seeds and otherSeeds.
+func mergeSeeds(seeds, otherSeeds []string) []string {
+	unique := make(map[string]bool, len(seeds)+len(otherSeeds))
+	merged := make([]string, 0, len(seeds)+len(otherSeeds))
+	for _, seed := range append(append([]string{}, seeds...), otherSeeds...) {
+		if seed != "" && !unique[seed] {
+			unique[seed] = true
+			merged = append(merged, seed)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
 func (r *K8ssandraClusterReconciler) reconcileSeedsEndpoints(
 	ctx context.Context,
 	dc *cassdcapi.CassandraDatacenter,
 	seeds []corev1.Pod,
 	additionalSeeds []string,
+	deferUpdates bool,
 	remoteClient client.Client,
 	logger logr.Logger) result.ReconcileResult {
 	logger.Info("Reconciling seeds")
@@ -69,6 +336,17 @@ func (r *K8ssandraClusterReconciler) reconcileSeedsEndpoints(
 		}
 	}
 
+	// Nor should the addresses of its own pods, which additional seeds and seed services can contain
+	localAddresses, err := datacenterPodAddresses(ctx, dc, remoteClient)
+	if err != nil {
+		logger.Error(err, "Failed to get the pods of the datacenter")
+		return result.Error(err)
+	}
+	filteredSeeds, additionalSeeds, localSeeds := excludeLocalSeeds(filteredSeeds, additionalSeeds, localAddresses)
+	if len(localSeeds) > 0 {
+		logger.Info("Ignoring seeds that are pods of the datacenter itself", "Seeds", localSeeds)
+	}
+
 	// The following if block was basically taken straight out of cass-operator. See
 	// https://github.com/k8ssandra/k8ssandra-operator/issues/210 for a detailed
 	// explanation of why this is being done.
@@ -78,6 +356,11 @@ func (r *K8ssandraClusterReconciler) reconcileSeedsEndpoints(
 	endpointsKey := client.ObjectKey{Namespace: desiredEndpoints.Namespace, Name: desiredEndpoints.Name}
 
 	if err := remoteClient.Get(ctx, endpointsKey, actualEndpoints); err == nil {
+		if deferUpdates && !annotations.CompareHashAnnotations(actualEndpoints, desiredEndpoints) {
+			logger.Info("Deferring seeds update", "Endpoints", endpointsKey)
+			return result.Continue()
+		}
+
 		// We can't have an Endpoints object that has no addresses or notReadyAddresses for
 		// its EndpointSubset elements. This would be the case if both seeds and
 		// additionalSeeds are empty, so we delete the Endpoints.
@@ -123,6 +406,49 @@ func (r *K8ssandraClusterReconciler) reconcileSeedsEndpoints(
 	return result.Continue()
 }
 
+// datacenterPodAddresses returns the IP addresses of the pods of dc.
+func datacenterPodAddresses(ctx context.Context, dc *cassdcapi.CassandraDatacenter, remoteClient client.Client) (map[string]bool, error) {
+	pods := &corev1.PodList{}
+	if err := remoteClient.List(ctx, pods, client.InNamespace(dc.Namespace), client.MatchingLabels(dc.GetDatacenterLabels())); err != nil {
+		return nil, err
+	}
+	addresses := make(map[string]bool, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Status.PodIP != "" {
+			addresses[pod.Status.PodIP] = true
+		}
+	}
+	return addresses, nil
+}
+
+// excludeLocalSeeds removes the seeds and additional seeds whose address is one of localAddresses, since a datacenter
+// seeding from itself does not help its nodes join the cluster and can mask a partition from the other datacenters.
+// The removed addresses are returned sorted.
+func excludeLocalSeeds(seeds []corev1.Pod, additionalSeeds []string, localAddresses map[string]bool) ([]corev1.Pod, []string, []string) {
+	if len(localAddresses) == 0 {
+		return seeds, additionalSeeds, nil
+	}
+	var removed []string
+	filteredSeeds := make([]corev1.Pod, 0, len(seeds))
+	for _, seed := range seeds {
+		if localAddresses[seed.Status.PodIP] {
+			removed = append(removed, seed.Status.PodIP)
+		} else {
+			filteredSeeds = append(filteredSeeds, seed)
+		}
+	}
+	var filteredAdditionalSeeds []string
+	for _, seed := range additionalSeeds {
+		if localAddresses[seed] {
+			removed = append(removed, seed)
+		} else {
+			filteredAdditionalSeeds = append(filteredAdditionalSeeds, seed)
+		}
+	}
+	sort.Strings(removed)
+	return filteredSeeds, filteredAdditionalSeeds, removed
+}
+
 // newEndpoints returns an Endpoints object who is named after the additional seeds service
 // of dc.
 func newEndpoints(dc *cassdcapi.CassandraDatacenter, seeds []corev1.Pod, additionalSeeds []string) *corev1.Endpoints {
@@ -0,0 +1,480 @@
+package k8ssandra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// failingListClient fails all List calls with err.
+type failingListClient struct {
+	client.Client
+	err error
+}
+
+func (c *failingListClient) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return c.err
+}
+
+func TestFindSeedsWithCachedSeeds(t *testing.T) {
+	newKc := func(policy api.SeedResolutionFailurePolicy) *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					SeedResolutionFailurePolicy: policy,
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, K8sContext: "cluster-2"},
+					},
+				},
+			},
+			Status: api.K8ssandraClusterStatus{
+				Seeds: []api.SeedStatus{
+					{Datacenter: "dc1", Name: "test-dc1-default-sts-0", Address: "10.0.0.1"},
+					{Datacenter: "dc2", Name: "test-dc2-default-sts-0", Address: "10.0.1.1"},
+				},
+			},
+		}
+	}
+	seed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-dc1-default-sts-1",
+			Labels: map[string]string{
+				cassdcapi.ClusterLabel:    "test",
+				cassdcapi.DatacenterLabel: "dc1",
+				cassdcapi.SeedNodeLabel:   "true",
+			},
+		},
+		Status: corev1.PodStatus{PodIP: "10.0.0.2"},
+	}
+	newReconciler := func(t *testing.T, dc2Err error) *K8ssandraClusterReconciler {
+		localClient, err := test.NewFakeClient(seed)
+		require.NoError(t, err)
+		r := newTracingTestReconciler(localClient)
+		r.ClientCache.AddClient("cluster-2", &failingListClient{Client: localClient, err: dc2Err})
+		return r
+	}
+
+	t.Run("transient failure with UseCachedSeeds", func(t *testing.T) {
+		kc := newKc(api.SeedResolutionFailurePolicyUseCachedSeeds)
+		r := newReconciler(t, apierrors.NewTimeoutError("list timed out", 1))
+
+		seeds, err := r.findSeeds(context.Background(), kc, "test", testr.New(t))
+		require.NoError(t, err)
+		require.Len(t, seeds, 2)
+		assert.Equal(t, "10.0.0.2", seeds[0].Status.PodIP, "dc1 seeds should be fresh")
+		assert.Equal(t, "10.0.1.1", seeds[1].Status.PodIP, "dc2 seeds should come from the status")
+		assert.Equal(t, "dc2", seeds[1].Labels[cassdcapi.DatacenterLabel])
+		assert.Equal(t, []api.SeedStatus{
+			{Datacenter: "dc1", Name: "test-dc1-default-sts-1", Address: "10.0.0.2"},
+			{Datacenter: "dc2", Name: "test-dc2-default-sts-0", Address: "10.0.1.1"},
+		}, kc.Status.Seeds)
+	})
+
+	t.Run("transient failure with Fail", func(t *testing.T) {
+		kc := newKc(api.SeedResolutionFailurePolicyFail)
+		r := newReconciler(t, apierrors.NewTimeoutError("list timed out", 1))
+
+		_, err := r.findSeeds(context.Background(), kc, "test", testr.New(t))
+		assert.Error(t, err)
+	})
+
+	t.Run("permanent failure with UseCachedSeeds", func(t *testing.T) {
+		kc := newKc(api.SeedResolutionFailurePolicyUseCachedSeeds)
+		r := newReconciler(t, apierrors.NewForbidden(corev1.Resource("pods"), "", errors.New("forbidden")))
+
+		_, err := r.findSeeds(context.Background(), kc, "test", testr.New(t))
+		assert.Error(t, err)
+	})
+
+	t.Run("transient failure without cached seeds", func(t *testing.T) {
+		kc := newKc(api.SeedResolutionFailurePolicyUseCachedSeeds)
+		kc.Status.Seeds = nil
+		r := newReconciler(t, apierrors.NewTimeoutError("list timed out", 1))
+
+		_, err := r.findSeeds(context.Background(), kc, "test", testr.New(t))
+		assert.Error(t, err)
+	})
+}
+
+func TestFindSeedsExcludingRacks(t *testing.T) {
+	newSeed := func(name, rack string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels: map[string]string{
+					cassdcapi.ClusterLabel:    "test",
+					cassdcapi.DatacenterLabel: "dc1",
+					cassdcapi.RackLabel:       rack,
+					cassdcapi.SeedNodeLabel:   "true",
+				},
+			},
+			Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+		}
+	}
+	fakeClient, err := test.NewFakeClient(
+		newSeed("test-dc1-rack1-sts-0", "rack1"),
+		newSeed("test-dc1-spot-sts-0", "spot"),
+		newSeed("test-dc1-rack3-sts-0", "rack3"),
+	)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				Datacenters: []api.CassandraDatacenterTemplate{
+					{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, SeedExcludedRacks: []string{"spot"}},
+				},
+			},
+		},
+	}
+
+	seeds, err := r.findSeeds(context.Background(), kc, "test", testr.New(t))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(seeds))
+	for _, seed := range seeds {
+		names = append(names, seed.Name)
+	}
+	assert.ElementsMatch(t, []string{"test-dc1-rack1-sts-0", "test-dc1-rack3-sts-0"}, names)
+}
+
+func TestFindSeedsWithoutSeedPods(t *testing.T) {
+	newKc := func(policy api.EmptySeedsPolicy, conditions ...cassdcapi.DatacenterCondition) *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					EmptySeedsPolicy: policy,
+					Datacenters:      []api.CassandraDatacenterTemplate{{Meta: api.EmbeddedObjectMeta{Name: "dc1"}}},
+				},
+			},
+			Status: api.K8ssandraClusterStatus{
+				Datacenters: map[string]api.K8ssandraStatus{
+					"dc1": {Cassandra: &cassdcapi.CassandraDatacenterStatus{Conditions: conditions}},
+				},
+			},
+		}
+	}
+	ready := cassdcapi.DatacenterCondition{Type: cassdcapi.DatacenterReady, Status: corev1.ConditionTrue}
+	stopped := cassdcapi.DatacenterCondition{Type: cassdcapi.DatacenterStopped, Status: corev1.ConditionTrue}
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	t.Run("ready datacenter", func(t *testing.T) {
+		kc := newKc(api.EmptySeedsPolicyRequeue, ready)
+		kc.Status.Seeds = []api.SeedStatus{{Datacenter: "dc1", Name: "test-dc1-default-sts-0", Address: "10.0.0.1"}}
+		kc.Status.SeedsRefreshTime = &metav1.Time{Time: time.Now()}
+		seeds, err := r.findSeeds(context.Background(), kc, "test", testr.New(t))
+		assert.Equal(t, transientNoSeedsError{dcKey: client.ObjectKey{Namespace: "default", Name: "dc1"}}, err)
+		require.Len(t, seeds, 1, "the previously known seeds are kept")
+		assert.Equal(t, "test-dc1-default-sts-0", seeds[0].Name)
+		assert.Equal(t, "10.0.0.1", seeds[0].Status.PodIP)
+		assert.Equal(t, []api.SeedStatus{{Datacenter: "dc1", Name: "test-dc1-default-sts-0", Address: "10.0.0.1"}}, kc.Status.Seeds)
+		assert.Nil(t, kc.Status.SeedsRefreshTime, "the seeds are resolved again on the next reconcile")
+	})
+
+	t.Run("ready datacenter with Propagate", func(t *testing.T) {
+		for _, policy := range []api.EmptySeedsPolicy{"", api.EmptySeedsPolicyPropagate} {
+			seeds, err := r.findSeeds(context.Background(), newKc(policy, ready), "test", testr.New(t))
+			require.NoError(t, err)
+			assert.Empty(t, seeds)
+		}
+	})
+
+	t.Run("datacenter not ready yet", func(t *testing.T) {
+		seeds, err := r.findSeeds(context.Background(), newKc(api.EmptySeedsPolicyRequeue), "test", testr.New(t))
+		require.NoError(t, err)
+		assert.Empty(t, seeds)
+	})
+
+	t.Run("stopped datacenter", func(t *testing.T) {
+		kc := newKc(api.EmptySeedsPolicyRequeue, ready)
+		kc.Spec.Cassandra.Datacenters[0].Stopped = true
+		seeds, err := r.findSeeds(context.Background(), kc, "test", testr.New(t))
+		require.NoError(t, err)
+		assert.Empty(t, seeds)
+
+		seeds, err = r.findSeeds(context.Background(), newKc(api.EmptySeedsPolicyRequeue, ready, stopped), "test", testr.New(t))
+		require.NoError(t, err, "a datacenter still reported as stopped should not be retried")
+		assert.Empty(t, seeds)
+	})
+}
+
+func TestFindSeedsWithRefreshInterval(t *testing.T) {
+	newSeed := func(name, address string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels: map[string]string{
+					cassdcapi.ClusterLabel:    "test",
+					cassdcapi.DatacenterLabel: "dc1",
+					cassdcapi.SeedNodeLabel:   "true",
+				},
+			},
+			Status: corev1.PodStatus{PodIP: address},
+		}
+	}
+	fakeClient, err := test.NewFakeClient(newSeed("test-dc1-default-sts-0", "10.0.0.1"))
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: api.K8ssandraClusterSpec{
+			Cassandra: &api.CassandraClusterTemplate{
+				SeedRefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				Datacenters:         []api.CassandraDatacenterTemplate{{Meta: api.EmbeddedObjectMeta{Name: "dc1"}}},
+			},
+		},
+	}
+
+	seeds, err := r.findSeeds(context.Background(), kc, "test", testr.New(t))
+	require.NoError(t, err)
+	require.Len(t, seeds, 1)
+	require.NotNil(t, kc.Status.SeedsRefreshTime, "the first resolution should be recorded")
+	firstRefresh := *kc.Status.SeedsRefreshTime
+
+	t.Log("add a seed, which should be ignored until the interval elapses")
+	require.NoError(t, fakeClient.Create(context.Background(), newSeed("test-dc1-default-sts-1", "10.0.0.2")))
+	seeds, err = r.findSeeds(context.Background(), kc, "test", testr.New(t))
+	require.NoError(t, err)
+	require.Len(t, seeds, 1)
+	assert.Equal(t, "test-dc1-default-sts-0", seeds[0].Name)
+	assert.Equal(t, "10.0.0.1", seeds[0].Status.PodIP)
+	assert.Equal(t, firstRefresh, *kc.Status.SeedsRefreshTime)
+
+	t.Log("let the interval elapse")
+	elapsed := metav1.NewTime(time.Now().Add(-11 * time.Minute))
+	kc.Status.SeedsRefreshTime = &elapsed
+	seeds, err = r.findSeeds(context.Background(), kc, "test", testr.New(t))
+	require.NoError(t, err)
+	assert.Len(t, seeds, 2)
+	assert.Len(t, kc.Status.Seeds, 2)
+	assert.True(t, kc.Status.SeedsRefreshTime.After(elapsed.Time))
+}
+
+func TestSeedsRefreshDue(t *testing.T) {
+	recent := metav1.NewTime(time.Now().Add(-time.Minute))
+	newKc := func(interval *metav1.Duration, lastRefresh *metav1.Time, seeds ...api.SeedStatus) *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					SeedRefreshInterval: interval,
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, Stopped: true},
+					},
+				},
+			},
+			Status: api.K8ssandraClusterStatus{Seeds: seeds, SeedsRefreshTime: lastRefresh},
+		}
+	}
+	interval := &metav1.Duration{Duration: 5 * time.Minute}
+	dc1Seed := api.SeedStatus{Datacenter: "dc1", Name: "test-dc1-default-sts-0", Address: "10.0.0.1"}
+
+	assert.True(t, seedsRefreshDue(newKc(nil, &recent, dc1Seed)), "seeds are refreshed on every reconcile without an interval")
+	assert.True(t, seedsRefreshDue(newKc(interval, nil, dc1Seed)), "seeds were never refreshed")
+	assert.False(t, seedsRefreshDue(newKc(interval, &recent, dc1Seed)), "a stopped datacenter needs no seeds")
+	assert.True(t, seedsRefreshDue(newKc(&metav1.Duration{Duration: 30 * time.Second}, &recent, dc1Seed)))
+	assert.True(t, seedsRefreshDue(newKc(interval, &recent)), "dc1 has no recorded seeds")
+}
+
+func TestResolveServiceSeeds(t *testing.T) {
+	ctx := context.Background()
+	serviceRef := &corev1.LocalObjectReference{Name: "seeds"}
+
+	t.Run("endpoints of a service", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "seeds"}},
+			&corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "seeds"},
+				Subsets: []corev1.EndpointSubset{{
+					Addresses: []corev1.EndpointAddress{{IP: "10.0.0.2"}, {IP: "10.0.0.1"}},
+				}},
+			},
+		)
+		require.NoError(t, err)
+
+		seeds, err := resolveServiceSeeds(ctx, "default", serviceRef, fakeClient)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, seeds)
+		assert.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, mergeSeeds([]string{"10.0.0.3", "10.0.0.1"}, seeds))
+	})
+
+	t.Run("ExternalName service", func(t *testing.T) {
+		defer func(original func(context.Context, string) ([]string, error)) { lookupHost = original }(lookupHost)
+		lookupHost = func(_ context.Context, host string) ([]string, error) {
+			require.Equal(t, "seeds.example.com", host)
+			return []string{"192.168.1.10", "fe80::1"}, nil
+		}
+		fakeClient, err := test.NewFakeClient(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "seeds"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "seeds.example.com"},
+		})
+		require.NoError(t, err)
+
+		seeds, err := resolveServiceSeeds(ctx, "default", serviceRef, fakeClient)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"192.168.1.10", "fe80::1"}, seeds)
+	})
+
+	t.Run("missing service", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient()
+		require.NoError(t, err)
+
+		_, err = resolveServiceSeeds(ctx, "default", serviceRef, fakeClient)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestDeferSeedPropagation(t *testing.T) {
+	ctx := context.Background()
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec:       api.K8ssandraClusterSpec{Cassandra: &api.CassandraClusterTemplate{}},
+	}
+	dc1 := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "test", Size: 3},
+		Status: cassdcapi.CassandraDatacenterStatus{
+			CassandraOperatorProgress: cassdcapi.ProgressReady,
+			Conditions:                []cassdcapi.DatacenterCondition{*cassdcapi.NewDatacenterCondition(cassdcapi.DatacenterReady, corev1.ConditionTrue)},
+		},
+	}
+	dc2 := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc2"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "test", Size: 3},
+		Status:     cassdcapi.CassandraDatacenterStatus{CassandraOperatorProgress: cassdcapi.ProgressUpdating},
+	}
+	newPod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{
+				cassdcapi.ClusterLabel:    "test",
+				cassdcapi.DatacenterLabel: "dc2",
+			}},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	fakeClient, err := test.NewFakeClient(kc, dc1, dc2,
+		newPod("dc2-0", corev1.ConditionTrue), newPod("dc2-1", corev1.ConditionTrue), newPod("dc2-2", corev1.ConditionFalse))
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	r.ClientCache.AddClient("east", fakeClient)
+	dcConfigs := []*cassandra.DatacenterConfig{
+		{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, K8sContext: "east"},
+		{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, K8sContext: "east"},
+	}
+	logger := testr.New(t)
+
+	assert.False(t, r.deferSeedPropagation(ctx, kc, dcConfigs, logger), "no quorum configured")
+
+	kc.Spec.Cassandra.SeedPropagationQuorum = pointer.Int32(2)
+	assert.True(t, r.deferSeedPropagation(ctx, kc, dcConfigs, logger))
+
+	// dc2 is ready according to its readiness policy, although cass-operator does not report it ready
+	dcConfigs[1].ReadinessPolicy = &api.ReadinessPolicy{Mode: api.ReadinessModeQuorum}
+	assert.False(t, r.deferSeedPropagation(ctx, kc, dcConfigs, logger))
+
+	// a datacenter that does not exist yet is not ready
+	dcConfigs = append(dcConfigs, &cassandra.DatacenterConfig{Meta: api.EmbeddedObjectMeta{Name: "dc3"}, K8sContext: "east"})
+	kc.Spec.Cassandra.SeedPropagationQuorum = pointer.Int32(3)
+	assert.True(t, r.deferSeedPropagation(ctx, kc, dcConfigs, logger))
+}
+
+func TestReconcileSeedsEndpointsDeferred(t *testing.T) {
+	ctx := context.Background()
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc2"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "cluster1"},
+	}
+	newSeed := func(name, ip string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{cassdcapi.DatacenterLabel: "dc1"}},
+			Status:     corev1.PodStatus{PodIP: ip},
+		}
+	}
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	endpointsKey := client.ObjectKey{Namespace: "default", Name: dc.GetAdditionalSeedsServiceName()}
+	seedIps := func() []string {
+		endpoints := &corev1.Endpoints{}
+		require.NoError(t, fakeClient.Get(ctx, endpointsKey, endpoints))
+		ips := make([]string, 0)
+		for _, address := range endpoints.Subsets[0].Addresses {
+			ips = append(ips, address.IP)
+		}
+		return ips
+	}
+
+	// A datacenter without seeds always gets them, so that it joins the existing cluster
+	recResult := r.reconcileSeedsEndpoints(ctx, dc, []corev1.Pod{newSeed("dc1-0", "10.0.0.1")}, nil, true, fakeClient, testr.New(t))
+	require.False(t, recResult.Completed())
+	assert.Equal(t, []string{"10.0.0.1"}, seedIps())
+
+	// Changes are not propagated until the quorum is reached
+	seeds := []corev1.Pod{newSeed("dc1-0", "10.0.0.1"), newSeed("dc1-1", "10.0.0.2")}
+	recResult = r.reconcileSeedsEndpoints(ctx, dc, seeds, nil, true, fakeClient, testr.New(t))
+	require.False(t, recResult.Completed())
+	assert.Equal(t, []string{"10.0.0.1"}, seedIps())
+
+	recResult = r.reconcileSeedsEndpoints(ctx, dc, seeds, nil, false, fakeClient, testr.New(t))
+	require.False(t, recResult.Completed())
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, seedIps())
+}
+
+func TestReconcileSeedsEndpointsExcludesLocalPods(t *testing.T) {
+	ctx := context.Background()
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc2"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "cluster1"},
+	}
+	localPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster1-dc2-default-sts-0", Labels: dc.GetDatacenterLabels()},
+		Status:     corev1.PodStatus{PodIP: "10.0.1.1"},
+	}
+	// A seed of another DC that ended up with the address of a local pod, e.g. when the status is stale
+	staleSeed := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1-1", Labels: map[string]string{cassdcapi.DatacenterLabel: "dc1"}},
+		Status:     corev1.PodStatus{PodIP: "10.0.1.1"},
+	}
+	seed := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1-0", Labels: map[string]string{cassdcapi.DatacenterLabel: "dc1"}},
+		Status:     corev1.PodStatus{PodIP: "10.0.0.1"},
+	}
+	fakeClient, err := test.NewFakeClient(localPod)
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+
+	recResult := r.reconcileSeedsEndpoints(ctx, dc, []corev1.Pod{seed, staleSeed}, []string{"10.0.1.1", "192.168.0.1"}, false, fakeClient, testr.New(t))
+	require.False(t, recResult.Completed())
+
+	endpoints := &corev1.Endpoints{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: dc.GetAdditionalSeedsServiceName()}, endpoints))
+	ips := make([]string, 0)
+	for _, address := range endpoints.Subsets[0].Addresses {
+		ips = append(ips, address.IP)
+	}
+	assert.Equal(t, []string{"10.0.0.1", "192.168.0.1"}, ips, "the addresses of the pods of the DC are never its seeds")
+}
@@ -0,0 +1,66 @@
+package k8ssandra
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	kerrors "github.com/k8ssandra/k8ssandra-operator/pkg/errors"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// handleSpecRejection checks whether err is the rejection of the spec of the datacenter dcName. If so, the rejection
+// is reported in the DatacenterSpecRejected condition, and the returned result retries after SpecRejectedDelay rather
+// than with the short backoff of reconcile errors, since the same spec is rejected until the K8ssandraCluster is
+// changed. It returns nil if err is not a rejection.
+func (r *K8ssandraClusterReconciler) handleSpecRejection(kc *api.K8ssandraCluster, dcName string, err error, logger logr.Logger) result.ReconcileResult {
+	if !kerrors.IsSpecRejected(err) {
+		return nil
+	}
+	logger.Info("The datacenter spec was rejected, fix the K8ssandraCluster spec", "Error", err.Error())
+	setDatacenterSpecRejectedCondition(kc, dcName, err)
+	return result.RequeueSoon(r.SpecRejectedDelay)
+}
+
+// handleIncompleteDatacenter checks whether err reports a datacenter template missing required fields, typically
+// because the K8ssandraCluster was admitted without the webhook. If so, the datacenter is not created, and the missing
+// field is reported in the DatacenterSpecRejected condition rather than left for cass-operator to reject. It returns nil
+// if err is not about an incomplete datacenter.
+func (r *K8ssandraClusterReconciler) handleIncompleteDatacenter(kc *api.K8ssandraCluster, err error, logger logr.Logger) result.ReconcileResult {
+	var incomplete cassandra.DCConfigIncomplete
+	if !errors.As(err, &incomplete) {
+		return nil
+	}
+	logger.Info("The datacenter template is missing required fields, fix the K8ssandraCluster spec", "Error", err.Error())
+	setDatacenterSpecRejectedCondition(kc, incomplete.Datacenter(), err)
+	return result.RequeueSoon(r.SpecRejectedDelay)
+}
+
+func specRejectedMessagePrefix(dcName string) string {
+	return fmt.Sprintf("CassandraDatacenter %s was rejected", dcName)
+}
+
+func setDatacenterSpecRejectedCondition(kc *api.K8ssandraCluster, dcName string, err error) {
+	message := specRejectedMessagePrefix(dcName)
+	if fields := kerrors.RejectedFields(err); len(fields) > 0 {
+		message += fmt.Sprintf(" because of fields %s", strings.Join(fields, ", "))
+	}
+	message += ": " + err.Error()
+
+	kc.Status.SetConditionStatus(api.DatacenterSpecRejected, corev1.ConditionTrue, message)
+}
+
+// clearDatacenterSpecRejectedCondition sets the DatacenterSpecRejected condition back to false if it was set because
+// of the datacenter dcName, which has since been accepted.
+func clearDatacenterSpecRejectedCondition(kc *api.K8ssandraCluster, dcName string) {
+	condition, found := kc.Status.GetCondition(api.DatacenterSpecRejected)
+	if !found || condition.Status != corev1.ConditionTrue || !strings.HasPrefix(condition.Message, specRejectedMessagePrefix(dcName)+" ") &&
+		!strings.HasPrefix(condition.Message, specRejectedMessagePrefix(dcName)+":") {
+		return
+	}
+	kc.Status.SetConditionStatus(api.DatacenterSpecRejected, corev1.ConditionFalse, "")
+}
@@ -166,10 +166,7 @@ func (r *K8ssandraClusterReconciler) reconcileStargateAuthSchema(
 
 func (r *K8ssandraClusterReconciler) removeStargateStatus(kc *api.K8ssandraCluster, dcName string) {
 	if kdcStatus, found := kc.Status.Datacenters[dcName]; found {
-		kc.Status.Datacenters[dcName] = api.K8ssandraStatus{
-			Stargate:  nil,
-			Cassandra: kdcStatus.Cassandra.DeepCopy(),
-			Reaper:    kdcStatus.Reaper.DeepCopy(),
-		}
+		kdcStatus.Stargate = nil
+		kc.Status.Datacenters[dcName] = kdcStatus
 	}
 }
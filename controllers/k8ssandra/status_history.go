@@ -0,0 +1,51 @@
+package k8ssandra
+
+import (
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const errorTransitionType = "Error"
+
+// recordStatusHistory appends to the status history of kc the transitions between the original status and the
+// current one: conditions whose status or message changed, and changes of the reconcile error. The history is bounded
+// by the configured limit, the oldest transitions being discarded first. Nothing is recorded when StatusHistory is
+// not set.
+func recordStatusHistory(kc *api.K8ssandraCluster, original *api.K8ssandraClusterStatus) {
+	if kc.Spec.StatusHistory == nil {
+		return
+	}
+	now := metav1.Now()
+	for _, condition := range kc.Status.Conditions {
+		previous, found := original.GetCondition(condition.Type)
+		if found && previous.Status == condition.Status && previous.Message == condition.Message {
+			continue
+		}
+		kc.Status.History = append(kc.Status.History, api.StatusTransition{
+			Time:   now,
+			Type:   string(condition.Type),
+			Status: condition.Status,
+			Reason: condition.Message,
+		})
+	}
+	if previousError, currentError := reconcileError(original.Error), reconcileError(kc.Status.Error); previousError != currentError {
+		transition := api.StatusTransition{Time: now, Type: errorTransitionType, Status: corev1.ConditionFalse}
+		if currentError != "" {
+			transition.Status = corev1.ConditionTrue
+			transition.Reason = currentError
+		}
+		kc.Status.History = append(kc.Status.History, transition)
+	}
+	if limit := kc.Spec.StatusHistory.GetLimit(); len(kc.Status.History) > limit {
+		kc.Status.History = kc.Status.History[len(kc.Status.History)-limit:]
+	}
+}
+
+// reconcileError returns the given status error, or an empty string if it does not denote an error.
+func reconcileError(statusError string) string {
+	if statusError == "None" {
+		return ""
+	}
+	return statusError
+}
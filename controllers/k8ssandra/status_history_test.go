@@ -0,0 +1,71 @@
+package k8ssandra
+
+import (
+	"fmt"
+	"testing"
+
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRecordStatusHistory(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		kc := &api.K8ssandraCluster{}
+		original := kc.Status.DeepCopy()
+		kc.Status.Error = "failed"
+		kc.Status.SetCondition(api.K8ssandraClusterCondition{Type: api.DiskUsageHigh, Status: corev1.ConditionTrue})
+
+		recordStatusHistory(kc, original)
+
+		assert.Empty(t, kc.Status.History)
+	})
+
+	t.Run("transitions are recorded", func(t *testing.T) {
+		kc := &api.K8ssandraCluster{Spec: api.K8ssandraClusterSpec{StatusHistory: &api.StatusHistory{}}}
+		kc.Status.Error = "None"
+		kc.Status.SetCondition(api.K8ssandraClusterCondition{Type: api.CassandraInitialized, Status: corev1.ConditionTrue})
+		original := kc.Status.DeepCopy()
+
+		kc.Status.Error = "failed to reconcile datacenter"
+		kc.Status.SetCondition(api.K8ssandraClusterCondition{Type: api.DiskUsageHigh, Status: corev1.ConditionTrue, Message: "dc1 is full"})
+		recordStatusHistory(kc, original)
+
+		require.Len(t, kc.Status.History, 2)
+		assert.Equal(t, string(api.DiskUsageHigh), kc.Status.History[0].Type)
+		assert.Equal(t, corev1.ConditionTrue, kc.Status.History[0].Status)
+		assert.Equal(t, "dc1 is full", kc.Status.History[0].Reason)
+		assert.False(t, kc.Status.History[0].Time.IsZero())
+		assert.Equal(t, "Error", kc.Status.History[1].Type)
+		assert.Equal(t, corev1.ConditionTrue, kc.Status.History[1].Status)
+		assert.Equal(t, "failed to reconcile datacenter", kc.Status.History[1].Reason)
+
+		original = kc.Status.DeepCopy()
+		kc.Status.Error = "None"
+		recordStatusHistory(kc, original)
+
+		require.Len(t, kc.Status.History, 3)
+		assert.Equal(t, "Error", kc.Status.History[2].Type)
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.History[2].Status)
+		assert.Empty(t, kc.Status.History[2].Reason)
+
+		original = kc.Status.DeepCopy()
+		recordStatusHistory(kc, original)
+
+		assert.Len(t, kc.Status.History, 3, "nothing should be recorded when the status did not change")
+	})
+
+	t.Run("history is bounded", func(t *testing.T) {
+		kc := &api.K8ssandraCluster{Spec: api.K8ssandraClusterSpec{StatusHistory: &api.StatusHistory{Limit: 3}}}
+		for i := 0; i < 5; i++ {
+			original := kc.Status.DeepCopy()
+			kc.Status.Error = fmt.Sprintf("error %d", i)
+			recordStatusHistory(kc, original)
+		}
+
+		require.Len(t, kc.Status.History, 3)
+		assert.Equal(t, "error 2", kc.Status.History[0].Reason)
+		assert.Equal(t, "error 4", kc.Status.History[2].Reason)
+	})
+}
@@ -0,0 +1,53 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const k8ssandraClusterCrdName = "k8ssandraclusters.k8ssandra.io"
+
+// checkStatusSubresource reads the K8ssandraCluster CRD once, when the controller is set up, to find out whether the
+// status subresource of its served version is enabled, which is not the case with CRDs installed by old versions of
+// the operator. Without it, the status updates are skipped for the lifetime of the operator, unless
+// RequireStatusSubresource is set, in which case an error is returned so that the operator does not start. A CRD that
+// cannot be read, for example because the operator is not allowed to read CRDs, is logged and assumed to have the
+// subresource.
+func (r *K8ssandraClusterReconciler) checkStatusSubresource(ctx context.Context, reader client.Reader, logger logr.Logger) error {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGvk)
+	if err := reader.Get(ctx, client.ObjectKey{Name: k8ssandraClusterCrdName}, crd); err != nil {
+		logger.Info("Failed to read the K8ssandraCluster CRD, assuming its status subresource is enabled", "Error", err.Error())
+		return nil
+	}
+	if statusSubresourceEnabled(crd) {
+		return nil
+	}
+	message := "The status subresource of the K8ssandraCluster CRD is not enabled, upgrade the CRDs of the operator to enable it"
+	if r.RequireStatusSubresource {
+		return fmt.Errorf("%s", message)
+	}
+	logger.Error(nil, message+", the status of the K8ssandraClusters will not be updated")
+	r.statusSubresourceDisabled = true
+	return nil
+}
+
+// statusSubresourceEnabled returns true if the status subresource is enabled for the version of crd that the operator
+// uses.
+func statusSubresourceEnabled(crd *unstructured.Unstructured) bool {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok || version["name"] != api.GroupVersion.Version {
+			continue
+		}
+		_, found, _ := unstructured.NestedMap(version, "subresources", "status")
+		return found
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newK8ssandraClusterCrd(statusSubresource bool) *unstructured.Unstructured {
+	version := map[string]interface{}{"name": api.GroupVersion.Version, "served": true, "storage": true}
+	if statusSubresource {
+		version["subresources"] = map[string]interface{}{"status": map[string]interface{}{}}
+	}
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGvk)
+	crd.SetName(k8ssandraClusterCrdName)
+	crd.Object["spec"] = map[string]interface{}{"versions": []interface{}{version}}
+	return crd
+}
+
+func TestCheckStatusSubresource(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		objects  []client.Object
+		require  bool
+		disabled bool
+		wantErr  bool
+	}{
+		{name: "enabled", objects: []client.Object{newK8ssandraClusterCrd(true)}},
+		{name: "enabled and required", objects: []client.Object{newK8ssandraClusterCrd(true)}, require: true},
+		{name: "disabled", objects: []client.Object{newK8ssandraClusterCrd(false)}, disabled: true},
+		{name: "disabled and required", objects: []client.Object{newK8ssandraClusterCrd(false)}, require: true, wantErr: true},
+		{name: "unreadable CRD", require: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, err := test.NewFakeClient(tt.objects...)
+			require.NoError(t, err)
+			r := newTracingTestReconciler(fakeClient)
+			r.RequireStatusSubresource = tt.require
+
+			err = r.checkStatusSubresource(ctx, fakeClient, logr.Discard())
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.disabled, r.statusSubresourceDisabled)
+		})
+	}
+}
+
+func TestReconcileWithoutStatusSubresource(t *testing.T) {
+	ctx := context.Background()
+	kc := &api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	fakeClient, err := test.NewFakeClient(kc, newK8ssandraClusterCrd(false))
+	require.NoError(t, err)
+	r := newTracingTestReconciler(fakeClient)
+	r.Recorder = record.NewFakeRecorder(10)
+	require.NoError(t, r.checkStatusSubresource(ctx, fakeClient, logr.Discard()))
+	require.True(t, r.statusSubresourceDisabled)
+
+	request := ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "test"}}
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	actual := &api.K8ssandraCluster{}
+	require.NoError(t, fakeClient.Get(ctx, request.NamespacedName, actual))
+	assert.Contains(t, actual.Finalizers, k8ssandraClusterFinalizer, "the reconcile must carry on without the status")
+}
@@ -0,0 +1,108 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkTokenBalance reports the token ownership imbalance of each DC in the status of kc, and sets the
+// TokenOwnershipImbalanced condition when it exceeds the configured threshold. Checks happen at most once per poll
+// interval. Failing to check the ownership of a DC is logged but does not fail the reconcile.
+func (r *K8ssandraClusterReconciler) checkTokenBalance(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	dcs []*cassdcapi.CassandraDatacenter,
+	logger logr.Logger,
+) result.ReconcileResult {
+	monitoring := kc.Spec.Cassandra.TokenBalanceMonitoring
+	if monitoring == nil || !monitoringCheckDue(kc, dcs, monitoring.GetPollInterval(), tokenOwnershipLastCheckTime) {
+		return result.Continue()
+	}
+
+	now := metav1.Now()
+	imbalances := make(map[string]*api.TokenOwnershipStatus)
+	err := r.pollDatacenters(ctx, kc, dcs, logger, "Failed to check token ownership",
+		func(dc *cassdcapi.CassandraDatacenter, mgmtApi cassandra.ManagementApiFacade) error {
+			ownership, err := mgmtApi.GetTokenOwnership()
+			if err != nil {
+				return err
+			}
+			imbalance := tokenOwnershipImbalance(ownership)
+			if imbalance == nil {
+				return nil
+			}
+			imbalance.LastCheckTime = &now
+			imbalances[dc.Name] = imbalance
+			if kdcStatus, found := kc.Status.Datacenters[dc.Name]; found {
+				kdcStatus.TokenOwnership = imbalance
+				kc.Status.Datacenters[dc.Name] = kdcStatus
+			}
+			return nil
+		})
+	if err != nil {
+		return result.Error(err)
+	}
+	setTokenBalanceCondition(kc, imbalances, monitoring.GetThresholdPercent())
+	return result.Continue()
+}
+
+// tokenOwnershipLastCheckTime returns the time the token ownership of the DC was last checked, or nil if it never was.
+func tokenOwnershipLastCheckTime(status api.K8ssandraStatus) *metav1.Time {
+	if status.TokenOwnership == nil {
+		return nil
+	}
+	return status.TokenOwnership.LastCheckTime
+}
+
+// tokenOwnershipImbalance returns how much more than an even share of the ring the node with the highest ownership
+// owns, or nil if there are no nodes. Ties are broken by pod name so that the result is stable.
+func tokenOwnershipImbalance(ownership map[string]float64) *api.TokenOwnershipStatus {
+	if len(ownership) == 0 {
+		return nil
+	}
+	node := ""
+	highest := 0.0
+	for pod, owned := range ownership {
+		if node == "" || owned > highest || (owned == highest && pod < node) {
+			node, highest = pod, owned
+		}
+	}
+	imbalance := int32(math.Round((highest*float64(len(ownership)) - 1) * 100))
+	if imbalance < 0 {
+		imbalance = 0
+	}
+	return &api.TokenOwnershipStatus{ImbalancePercent: imbalance, Node: node}
+}
+
+// setTokenBalanceCondition sets the TokenOwnershipImbalanced condition to true if the imbalance of at least one DC
+// exceeds threshold, and to false otherwise.
+func setTokenBalanceCondition(kc *api.K8ssandraCluster, imbalances map[string]*api.TokenOwnershipStatus, threshold int32) {
+	imbalancedDcs := make([]string, 0)
+	for dcName, imbalance := range imbalances {
+		if imbalance.ImbalancePercent > threshold {
+			imbalancedDcs = append(imbalancedDcs, fmt.Sprintf("%s (pod %s owns %d%% more than an even share)", dcName, imbalance.Node, imbalance.ImbalancePercent))
+		}
+	}
+	sort.Strings(imbalancedDcs)
+
+	status := corev1.ConditionFalse
+	message := ""
+	if len(imbalancedDcs) > 0 {
+		status = corev1.ConditionTrue
+		message = fmt.Sprintf("Token ownership imbalance exceeds %d%% in datacenters: %s. "+
+			"Consider rebalancing the tokens of the datacenter, and running a cleanup on its nodes afterwards.",
+			threshold, strings.Join(imbalancedDcs, ", "))
+	}
+	kc.Status.SetConditionStatus(api.TokenOwnershipImbalanced, status, message)
+}
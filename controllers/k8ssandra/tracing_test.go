@@ -0,0 +1,115 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/clientcache"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/config"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	t.Run("Reconcile", func(t *testing.T) {
+		exporter.Reset()
+		kc := &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       "test",
+				Finalizers: []string{k8ssandraClusterFinalizer},
+			},
+		}
+		fakeClient, err := test.NewFakeClient(kc)
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+
+		_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(kc)})
+		require.NoError(t, err)
+
+		span := findSpan(t, exporter.GetSpans(), "K8ssandraCluster.Reconcile")
+		assert.Contains(t, span.Attributes, tracing.NamespaceKey.String("default"))
+		assert.Contains(t, span.Attributes, tracing.ClusterKey.String("test"))
+	})
+
+	t.Run("findSeeds", func(t *testing.T) {
+		exporter.Reset()
+		kc := &api.K8ssandraCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: api.K8ssandraClusterSpec{
+				Cassandra: &api.CassandraClusterTemplate{
+					Datacenters: []api.CassandraDatacenterTemplate{
+						{Meta: api.EmbeddedObjectMeta{Name: "dc1"}},
+						{Meta: api.EmbeddedObjectMeta{Name: "dc2"}},
+					},
+				},
+			},
+		}
+		seed := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-dc1-default-sts-0",
+				Labels: map[string]string{
+					cassdcapi.ClusterLabel:    "test",
+					cassdcapi.DatacenterLabel: "dc1",
+					cassdcapi.SeedNodeLabel:   "true",
+				},
+			},
+		}
+		fakeClient, err := test.NewFakeClient(seed)
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+
+		seeds, err := r.findSeeds(context.Background(), kc, "test", testr.New(t))
+		require.NoError(t, err)
+		assert.Len(t, seeds, 1)
+
+		spans := exporter.GetSpans()
+		findSeedsSpan := findSpan(t, spans, "findSeeds")
+		assert.Contains(t, findSeedsSpan.Attributes, tracing.ClusterKey.String("test"))
+		listCalls := 0
+		for _, span := range spans {
+			if span.Name == "Client.List" {
+				listCalls++
+				assert.Equal(t, findSeedsSpan.SpanContext.SpanID(), span.Parent.SpanID())
+			}
+		}
+		assert.Equal(t, 2, listCalls, "expected one List call per DC")
+	})
+}
+
+func newTracingTestReconciler(fakeClient client.Client) *K8ssandraClusterReconciler {
+	return &K8ssandraClusterReconciler{
+		ReconcilerConfig: &config.ReconcilerConfig{DefaultDelay: time.Second, LongDelay: time.Minute},
+		Client:           fakeClient,
+		ClientCache:      clientcache.New(fakeClient, fakeClient, nil),
+	}
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "no span named %s", name)
+	return tracetest.SpanStub{}
+}
@@ -0,0 +1,85 @@
+package k8ssandra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/cassandra"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkCassOperatorVersion checks that the cass-operator deployed in k8sContext supports all the fields set in
+// desiredDc. If it does not, the datacenter is neither created nor updated, the skew is reported in the
+// CassOperatorVersionSkew condition and the returned result retries after SpecRejectedDelay. cass-operator is looked
+// up with the configured CassOperatorSelector, in the configured CassOperatorNamespace or else in the namespace of the
+// datacenter. When its version cannot be determined, the datacenter is reconciled but the condition is set to unknown,
+// unless it reports a skew of another datacenter.
+func (r *K8ssandraClusterReconciler) checkCassOperatorVersion(
+	ctx context.Context,
+	kc *api.K8ssandraCluster,
+	desiredDc *cassdcapi.CassandraDatacenter,
+	k8sContext string,
+	remoteClient client.Client,
+	logger logr.Logger,
+) result.ReconcileResult {
+	namespace := r.CassOperatorNamespace
+	if namespace == "" {
+		namespace = desiredDc.Namespace
+	}
+	version, err := cassandra.CassOperatorVersion(ctx, namespace, r.CassOperatorSelector, remoteClient)
+	if err != nil {
+		logger.Info("Failed to determine the cass-operator version, the version skew is unknown", "Error", err.Error())
+		setCassOperatorVersionUnknownCondition(kc, desiredDc.Name, k8sContext, err)
+		return result.Continue()
+	}
+	if unsupported := cassandra.UnsupportedCassOperatorFields(desiredDc, version); len(unsupported) > 0 {
+		logger.Info("cass-operator is too old for the datacenter spec, upgrade it or remove the unsupported fields",
+			"CassOperatorVersion", version.String(), "Fields", unsupported)
+		setCassOperatorVersionSkewCondition(kc, desiredDc.Name, k8sContext, version.String(), unsupported)
+		return result.RequeueSoon(r.SpecRejectedDelay)
+	}
+	clearCassOperatorVersionSkewCondition(kc, desiredDc.Name)
+	return result.Continue()
+}
+
+// versionSkewMessagePrefix is the prefix of the messages of the CassOperatorVersionSkew condition when it is set
+// because of the datacenter dcName.
+func versionSkewMessagePrefix(dcName string) string {
+	return fmt.Sprintf("CassandraDatacenter %s ", dcName)
+}
+
+func setCassOperatorVersionSkewCondition(kc *api.K8ssandraCluster, dcName, k8sContext, version string, unsupported []string) {
+	message := fmt.Sprintf("%suses fields not supported by cass-operator %s in context %s: %s",
+		versionSkewMessagePrefix(dcName), version, k8sContext, strings.Join(unsupported, ", "))
+
+	kc.Status.SetConditionStatus(api.CassOperatorVersionSkew, corev1.ConditionTrue, message)
+}
+
+// setCassOperatorVersionUnknownCondition sets the CassOperatorVersionSkew condition to unknown, because the version of
+// the cass-operator deployed in the context of the datacenter dcName could not be determined. A skew reported for
+// another datacenter is kept.
+func setCassOperatorVersionUnknownCondition(kc *api.K8ssandraCluster, dcName, k8sContext string, err error) {
+	condition, found := kc.Status.GetCondition(api.CassOperatorVersionSkew)
+	if found && condition.Status == corev1.ConditionTrue && !strings.HasPrefix(condition.Message, versionSkewMessagePrefix(dcName)) {
+		return
+	}
+	message := fmt.Sprintf("%scould not be checked against the version of cass-operator in context %s: %v",
+		versionSkewMessagePrefix(dcName), k8sContext, err)
+	kc.Status.SetConditionStatus(api.CassOperatorVersionSkew, corev1.ConditionUnknown, message)
+}
+
+// clearCassOperatorVersionSkewCondition sets the CassOperatorVersionSkew condition back to false if it was set to true
+// or unknown because of the datacenter dcName.
+func clearCassOperatorVersionSkewCondition(kc *api.K8ssandraCluster, dcName string) {
+	condition, found := kc.Status.GetCondition(api.CassOperatorVersionSkew)
+	if !found || condition.Status == corev1.ConditionFalse || !strings.HasPrefix(condition.Message, versionSkewMessagePrefix(dcName)) {
+		return
+	}
+	kc.Status.SetConditionStatus(api.CassOperatorVersionSkew, corev1.ConditionFalse, "")
+}
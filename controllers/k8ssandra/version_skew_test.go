@@ -0,0 +1,137 @@
+package k8ssandra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func newCassOperatorDeployment(namespace, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "cass-operator-controller-manager",
+			Labels:    map[string]string{"app.kubernetes.io/name": "cass-operator"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "manager", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func TestCheckCassOperatorVersion(t *testing.T) {
+	newDc := func() *cassdcapi.CassandraDatacenter {
+		return &cassdcapi.CassandraDatacenter{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"},
+			Spec: cassdcapi.CassandraDatacenterSpec{
+				AdditionalServiceConfig: cassdcapi.ServiceConfig{
+					DatacenterService: cassdcapi.ServiceConfigAdditions{Labels: map[string]string{"team": "db"}},
+				},
+			},
+		}
+	}
+	newKc := func() *api.K8ssandraCluster {
+		return &api.K8ssandraCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	}
+
+	t.Run("old cass-operator", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(newCassOperatorDeployment("default", "docker.io/k8ssandra/cass-operator:v1.6.0"))
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		r.SpecRejectedDelay = time.Minute
+		kc := newKc()
+
+		recResult := r.checkCassOperatorVersion(context.Background(), kc, newDc(), "cluster-1", fakeClient, logr.Discard())
+		require.True(t, recResult.Completed(), "the datacenter is not created with fields cass-operator would drop")
+		res, err := recResult.Output()
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, res.RequeueAfter)
+
+		condition, found := kc.Status.GetCondition(api.CassOperatorVersionSkew)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t,
+			"CassandraDatacenter dc1 uses fields not supported by cass-operator 1.6.0 in context cluster-1: additionalServiceConfig (requires 1.7.0)",
+			condition.Message)
+
+		// Once cass-operator is upgraded, the condition is cleared
+		fakeClient, err = test.NewFakeClient(newCassOperatorDeployment("default", "docker.io/k8ssandra/cass-operator:v1.15.0"))
+		require.NoError(t, err)
+		r = newTracingTestReconciler(fakeClient)
+		assert.False(t, r.checkCassOperatorVersion(context.Background(), kc, newDc(), "cluster-1", fakeClient, logr.Discard()).Completed())
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.CassOperatorVersionSkew))
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(newCassOperatorDeployment("default", "k8ssandra/cass-operator:latest"))
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		kc := newKc()
+
+		assert.False(t, r.checkCassOperatorVersion(context.Background(), kc, newDc(), "cluster-1", fakeClient, logr.Discard()).Completed())
+		condition, found := kc.Status.GetCondition(api.CassOperatorVersionSkew)
+		require.True(t, found)
+		assert.Equal(t, corev1.ConditionUnknown, condition.Status)
+		assert.Contains(t, condition.Message, "CassandraDatacenter dc1 could not be checked against the version of cass-operator in context cluster-1")
+
+		// An unknown version does not hide the skew of another datacenter
+		setCassOperatorVersionSkewCondition(kc, "dc2", "cluster-2", "1.6.0", []string{"cdc (requires 1.12.0)"})
+		assert.False(t, r.checkCassOperatorVersion(context.Background(), kc, newDc(), "cluster-1", fakeClient, logr.Discard()).Completed())
+		assert.Equal(t, corev1.ConditionTrue, kc.Status.GetConditionStatus(api.CassOperatorVersionSkew))
+	})
+
+	t.Run("image pinned by digest", func(t *testing.T) {
+		deployment := newCassOperatorDeployment("default", "k8ssandra/cass-operator@sha256:0123456789abcdef")
+		deployment.Labels[api.VersionLabel] = "v1.6.0"
+		fakeClient, err := test.NewFakeClient(deployment)
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		kc := newKc()
+
+		assert.True(t, r.checkCassOperatorVersion(context.Background(), kc, newDc(), "cluster-1", fakeClient, logr.Discard()).Completed())
+		assert.Equal(t, corev1.ConditionTrue, kc.Status.GetConditionStatus(api.CassOperatorVersionSkew))
+	})
+
+	t.Run("configured namespace and selector", func(t *testing.T) {
+		other := newCassOperatorDeployment("default", "k8ssandra/cass-operator:v1.6.0")
+		other.Name = "other-cass-operator"
+		other.Labels = map[string]string{"app.kubernetes.io/name": "other"}
+		fakeClient, err := test.NewFakeClient(other, newCassOperatorDeployment("cass-operator", "k8ssandra/cass-operator:v1.15.0"))
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		r.CassOperatorNamespace = "cass-operator"
+		r.CassOperatorSelector = labels.SelectorFromSet(labels.Set{"app.kubernetes.io/name": "cass-operator"})
+		kc := newKc()
+
+		assert.False(t, r.checkCassOperatorVersion(context.Background(), kc, newDc(), "cluster-1", fakeClient, logr.Discard()).Completed())
+		assert.Equal(t, corev1.ConditionFalse, kc.Status.GetConditionStatus(api.CassOperatorVersionSkew))
+	})
+
+	t.Run("unused fields", func(t *testing.T) {
+		fakeClient, err := test.NewFakeClient(newCassOperatorDeployment("default", "k8ssandra/cass-operator:v1.6.0"))
+		require.NoError(t, err)
+		r := newTracingTestReconciler(fakeClient)
+		kc := newKc()
+		dc := newDc()
+		dc.Spec.AdditionalServiceConfig = cassdcapi.ServiceConfig{}
+
+		assert.False(t, r.checkCassOperatorVersion(context.Background(), kc, dc, "cluster-1", fakeClient, logr.Discard()).Completed())
+		_, found := kc.Status.GetCondition(api.CassOperatorVersionSkew)
+		assert.False(t, found)
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	medusav1alpha1 "github.com/k8ssandra/k8ssandra-operator/apis/medusa/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
 	cron "github.com/robfig/cron/v3"
 )
 
@@ -91,10 +92,10 @@ func (r *MedusaBackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl
 	// Update the status if there are modifications
 	if backupSchedule.Status.LastExecution.Time.Before(previousExecution) ||
 		backupSchedule.Status.NextSchedule.Time.Before(nextExecution) {
-		backupSchedule.Status.NextSchedule = metav1.NewTime(nextExecution)
-		backupSchedule.Status.LastExecution = metav1.NewTime(previousExecution)
-
-		if err := r.Client.Status().Update(ctx, backupSchedule); err != nil {
+		if err := utils.UpdateStatus(ctx, r.Client, backupSchedule, func(backupSchedule *medusav1alpha1.MedusaBackupSchedule) {
+			backupSchedule.Status.NextSchedule = metav1.NewTime(nextExecution)
+			backupSchedule.Status.LastExecution = metav1.NewTime(previousExecution)
+		}); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
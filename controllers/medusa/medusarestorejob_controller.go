@@ -61,6 +61,7 @@ type MedusaRestoreJobReconciler struct {
 // +kubebuilder:rbac:groups=medusa.k8ssandra.io,namespace="k8ssandra",resources=medusabackups,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cassandra.datastax.com,namespace="k8ssandra",resources=cassandradatacenters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,namespace="k8ssandra",resources=statefulsets,verbs=list;watch
+// +kubebuilder:rbac:groups="",namespace="k8ssandra",resources=pods,verbs=get;list;watch
 
 func (r *MedusaRestoreJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx).WithValues("medusarestorejob", req.NamespacedName)
@@ -159,7 +160,10 @@ func (r *MedusaRestoreJobReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return r.applyUpdatesAndRequeue(ctx, request)
 	}
 
-	if !cassandra.DatacenterReady(request.Datacenter) {
+	if ready, err := cassandra.IsDatacenterReady(ctx, request.Datacenter, r.Client); err != nil {
+		request.Log.Error(err, "Failed to check the readiness of the datacenter")
+		return ctrl.Result{RequeueAfter: r.DefaultDelay}, err
+	} else if !ready {
 		request.Log.Info("Waiting for datacenter to come back online")
 		return r.applyUpdatesAndRequeue(ctx, request)
 	}
@@ -18,6 +18,7 @@ package reaper
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
@@ -30,6 +31,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
@@ -115,6 +117,9 @@ func (r *ReaperReconciler) reconcile(ctx context.Context, actualReaper *reaperap
 	actualReaper.Status.SetReady()
 
 	logger.Info("Reaper successfully reconciled")
+	if pollInterval := actualReaper.Spec.GetRepairStatusPollInterval(); pollInterval > 0 {
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -141,7 +146,10 @@ func (r *ReaperReconciler) reconcileDatacenter(
 		}
 	}
 	actualDc = actualDc.DeepCopy()
-	if !cassandra.DatacenterReady(actualDc) {
+	if ready, err := cassandra.IsDatacenterReady(ctx, actualDc, r.Client); err != nil {
+		logger.Error(err, "Failed to check the readiness of the datacenter")
+		return nil, ctrl.Result{}, err
+	} else if !ready {
 		logger.Info("Waiting for datacenter to become ready")
 		return nil, ctrl.Result{RequeueAfter: r.DefaultDelay}, nil
 	}
@@ -322,9 +330,38 @@ func (r *ReaperReconciler) configureReaper(ctx context.Context, actualReaper *re
 			}
 		}
 	}
+	r.updateRepairStatus(ctx, manager, actualReaper, actualDc, logger)
 	return ctrl.Result{}, nil
 }
 
+// updateRepairStatus fetches the repair runs of the cluster from Reaper, at most once per poll interval, and records
+// them in the Reaper status. Failures are logged but don't fail the reconciliation, since repair status is purely
+// informational; the previously reported status is kept in that case.
+func (r *ReaperReconciler) updateRepairStatus(
+	ctx context.Context,
+	manager reaper.Manager,
+	actualReaper *reaperapi.Reaper,
+	actualDc *cassdcapi.CassandraDatacenter,
+	logger logr.Logger,
+) {
+	pollInterval := actualReaper.Spec.GetRepairStatusPollInterval()
+	if pollInterval <= 0 {
+		return
+	}
+	lastCheck := actualReaper.Status.LastRepairStatusCheck
+	if lastCheck != nil && time.Since(lastCheck.Time) < pollInterval {
+		return
+	}
+	repairs, err := manager.GetRepairStatus(ctx, actualDc)
+	if err != nil {
+		logger.Error(err, "failed to fetch repair status from reaper")
+		return
+	}
+	now := metav1.Now()
+	actualReaper.Status.Repairs = repairs
+	actualReaper.Status.LastRepairStatusCheck = &now
+}
+
 func (r *ReaperReconciler) getReaperUICredentials(ctx context.Context, actualReaper *reaperapi.Reaper, logger logr.Logger) (string, string, error) {
 	if actualReaper.Spec.UiUserSecretRef.Name == "" {
 		// The UI user secret doesn't exist, meaning auth is disabled
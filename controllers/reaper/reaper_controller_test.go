@@ -2,9 +2,11 @@ package reaper
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr/testr"
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	k8ssandraapi "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
 	reaperapi "github.com/k8ssandra/k8ssandra-operator/apis/reaper/v1alpha1"
@@ -68,10 +70,80 @@ func newMockManager() reaper.Manager {
 	m.On("Connect", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	m.On("AddClusterToReaper", mock.Anything, mock.Anything).Return(nil)
 	m.On("VerifyClusterIsConfigured", mock.Anything, mock.Anything).Return(true, nil)
+	m.On("GetRepairStatus", mock.Anything, mock.Anything).Return([]reaperapi.KeyspaceRepairStatus{}, nil)
 	m.Test(currentTest)
 	return m
 }
 
+func TestUpdateRepairStatus(t *testing.T) {
+	ctx := context.Background()
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: cassandraDatacenterName},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: cassandraClusterName},
+	}
+	repairTime := metav1.NewTime(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))
+	repairs := []reaperapi.KeyspaceRepairStatus{
+		{Keyspace: "ks1", LastRepairTime: &repairTime, PercentRepaired: 100},
+		{Keyspace: "ks2", PercentRepaired: 40},
+	}
+	r := &ReaperReconciler{ReconcilerConfig: config.InitConfig()}
+
+	t.Run("status updated", func(t *testing.T) {
+		m := new(mocks.ReaperManager)
+		m.Test(t)
+		m.On("GetRepairStatus", mock.Anything, dc).Return(repairs, nil).Once()
+		actualReaper := newReaper("default")
+
+		r.updateRepairStatus(ctx, m, actualReaper, dc, testr.New(t))
+
+		m.AssertExpectations(t)
+		assert.Equal(t, repairs, actualReaper.Status.Repairs)
+		assert.NotNil(t, actualReaper.Status.LastRepairStatusCheck)
+	})
+
+	t.Run("polled recently", func(t *testing.T) {
+		m := new(mocks.ReaperManager)
+		m.Test(t)
+		actualReaper := newReaper("default")
+		now := metav1.Now()
+		actualReaper.Status.LastRepairStatusCheck = &now
+		actualReaper.Status.Repairs = repairs
+
+		r.updateRepairStatus(ctx, m, actualReaper, dc, testr.New(t))
+
+		m.AssertNotCalled(t, "GetRepairStatus", mock.Anything, mock.Anything)
+		assert.Equal(t, repairs, actualReaper.Status.Repairs)
+	})
+
+	t.Run("polling disabled", func(t *testing.T) {
+		m := new(mocks.ReaperManager)
+		m.Test(t)
+		actualReaper := newReaper("default")
+		actualReaper.Spec.RepairStatusPollInterval = &metav1.Duration{}
+
+		r.updateRepairStatus(ctx, m, actualReaper, dc, testr.New(t))
+
+		m.AssertNotCalled(t, "GetRepairStatus", mock.Anything, mock.Anything)
+		assert.Nil(t, actualReaper.Status.LastRepairStatusCheck)
+	})
+
+	t.Run("reaper error keeps previous status", func(t *testing.T) {
+		m := new(mocks.ReaperManager)
+		m.Test(t)
+		m.On("GetRepairStatus", mock.Anything, dc).Return(nil, errors.New("connection refused")).Once()
+		actualReaper := newReaper("default")
+		lastCheck := metav1.NewTime(time.Now().Add(-time.Hour))
+		actualReaper.Status.LastRepairStatusCheck = &lastCheck
+		actualReaper.Status.Repairs = repairs
+
+		r.updateRepairStatus(ctx, m, actualReaper, dc, testr.New(t))
+
+		m.AssertExpectations(t)
+		assert.Equal(t, repairs, actualReaper.Status.Repairs)
+		assert.Equal(t, &lastCheck, actualReaper.Status.LastRepairStatusCheck)
+	})
+}
+
 func reaperControllerTest(ctx context.Context, env *testutils.TestEnv, test func(t *testing.T, ctx context.Context, k8sClient client.Client, testNamespace string)) func(t *testing.T) {
 	return func(t *testing.T) {
 		testNamespace := "ns-" + rand.String(6)
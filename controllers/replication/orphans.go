@@ -0,0 +1,141 @@
+package replication
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	coreapi "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/replication/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/secret"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// cleanupOrphanedSecrets deletes the secrets of the target that were replicated by rsec but whose source secret no
+// longer exists. Only the secrets carrying the hash annotation set on the sources before they are copied are
+// considered, so that secrets created by other means in the target are left alone. A secret is kept if it has the
+// orphan annotation, if it is matched by another ReplicatedSecret, or if it is still mounted or referenced by a pod.
+func (s *SecretSyncController) cleanupOrphanedSecrets(
+	ctx context.Context,
+	rsecKey types.NamespacedName,
+	selector labels.Selector,
+	sources []corev1.Secret,
+	target api.ReplicationTarget,
+	remoteClient client.Client,
+	logger logr.Logger) error {
+
+	sourceKeys := make(map[types.NamespacedName]bool, len(sources))
+	for _, sec := range sources {
+		namespace := sec.Namespace
+		if target.Namespace != "" {
+			namespace = target.Namespace
+		}
+		sourceKeys[types.NamespacedName{Namespace: namespace, Name: sec.Name}] = true
+	}
+
+	replicated := &corev1.SecretList{}
+	opts := []client.ListOption{client.MatchingLabelsSelector{Selector: selector}}
+	if target.Namespace != "" {
+		opts = append(opts, client.InNamespace(target.Namespace))
+	}
+	if err := remoteClient.List(ctx, replicated, opts...); err != nil {
+		return err
+	}
+
+	podsByNamespace := make(map[string][]corev1.Pod)
+	for i := range replicated.Items {
+		sec := &replicated.Items[i]
+		key := types.NamespacedName{Namespace: sec.Namespace, Name: sec.Name}
+		if sourceKeys[key] || !s.isOrphanCandidate(rsecKey, sec) {
+			continue
+		}
+
+		pods, found := podsByNamespace[sec.Namespace]
+		if !found {
+			podList := &corev1.PodList{}
+			if err := remoteClient.List(ctx, podList, client.InNamespace(sec.Namespace)); err != nil {
+				return err
+			}
+			pods = podList.Items
+			podsByNamespace[sec.Namespace] = pods
+		}
+		if pod := findPodUsingSecret(pods, sec.Name); pod != "" {
+			logger.Info("Keeping orphaned secret, it is used by a pod", "Secret", key, "Pod", pod, "TargetContext", target.K8sContextName)
+			continue
+		}
+
+		logger.Info("Deleting orphaned secret", "Secret", key, "TargetContext", target.K8sContextName)
+		if err := remoteClient.Delete(ctx, sec); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// isOrphanCandidate checks that sec was replicated, and that it is neither protected by the orphan annotation nor
+// matched by a ReplicatedSecret other than the one identified by rsecKey.
+func (s *SecretSyncController) isOrphanCandidate(rsecKey types.NamespacedName, sec *corev1.Secret) bool {
+	if _, found := sec.GetAnnotations()[coreapi.ResourceHashAnnotation]; !found {
+		return false
+	}
+	if val, found := sec.GetAnnotations()[secret.OrphanResourceAnnotation]; found && val == "true" {
+		return false
+	}
+
+	s.selectorMutex.RLock()
+	defer s.selectorMutex.RUnlock()
+	for k, v := range s.selectors {
+		if k != rsecKey && k.Namespace == rsecKey.Namespace && v.Matches(labels.Set(sec.GetLabels())) {
+			return false
+		}
+	}
+	return true
+}
+
+// findPodUsingSecret returns the name of the first pod that mounts the secret or references it in its environment or
+// image pull secrets, or an empty string if there is none.
+func findPodUsingSecret(pods []corev1.Pod, secretName string) string {
+	for _, pod := range pods {
+		if podUsesSecret(&pod.Spec, secretName) {
+			return pod.Name
+		}
+	}
+	return ""
+}
+
+func podUsesSecret(podSpec *corev1.PodSpec, secretName string) bool {
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == secretName {
+			return true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil && source.Secret.Name == secretName {
+					return true
+				}
+			}
+		}
+	}
+	for _, pullSecret := range podSpec.ImagePullSecrets {
+		if pullSecret.Name == secretName {
+			return true
+		}
+	}
+	containers := append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil && envFrom.SecretRef.Name == secretName {
+				return true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == secretName {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package replication
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	coreapi "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/replication/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/secret"
+	testutils "github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCleanupOrphanedSecrets(t *testing.T) {
+	ctx := context.Background()
+	rsecKey := types.NamespacedName{Namespace: "default", Name: "cluster1"}
+	selector := labels.SelectorFromSet(labels.Set{"replicated": "true"})
+
+	newSecret := func(name string, extraLabels map[string]string, annotations map[string]string) *corev1.Secret {
+		secretLabels := map[string]string{"replicated": "true"}
+		for k, v := range extraLabels {
+			secretLabels[k] = v
+		}
+		secretAnnotations := map[string]string{coreapi.ResourceHashAnnotation: "hash"}
+		for k, v := range annotations {
+			secretAnnotations[k] = v
+		}
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        name,
+			Labels:      secretLabels,
+			Annotations: secretAnnotations,
+		}}
+	}
+
+	source := newSecret("source", nil, nil)
+	orphaned := newSecret("orphaned", nil, nil)
+	protected := newSecret("protected", nil, map[string]string{secret.OrphanResourceAnnotation: "true"})
+	shared := newSecret("shared", map[string]string{"other": "true"}, nil)
+	mounted := newSecret("mounted", nil, nil)
+	referenced := newSecret("referenced", nil, nil)
+	notReplicated := newSecret("not-replicated", nil, nil)
+	delete(notReplicated.Annotations, coreapi.ResourceHashAnnotation)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod1"},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name:         "secret",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: mounted.Name}},
+			}},
+			Containers: []corev1.Container{{
+				Name: "main",
+				Env: []corev1.EnvVar{{
+					Name: "PASSWORD",
+					ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: referenced.Name},
+						Key:                  "password",
+					}},
+				}},
+			}},
+		},
+	}
+
+	remoteClient, err := testutils.NewFakeClient(source, orphaned, protected, shared, mounted, referenced, notReplicated, pod)
+	require.NoError(t, err)
+
+	s := &SecretSyncController{
+		selectors: map[types.NamespacedName]labels.Selector{
+			rsecKey:                               selector,
+			{Namespace: "default", Name: "other"}: labels.SelectorFromSet(labels.Set{"other": "true"}),
+		},
+	}
+	target := api.ReplicationTarget{K8sContextName: "remote"}
+	err = s.cleanupOrphanedSecrets(ctx, rsecKey, selector, []corev1.Secret{*source}, target, remoteClient, logr.Discard())
+	require.NoError(t, err)
+
+	for _, sec := range []*corev1.Secret{source, protected, shared, mounted, referenced, notReplicated} {
+		assert.NoError(t, remoteClient.Get(ctx, client.ObjectKeyFromObject(sec), &corev1.Secret{}), "secret %s should be kept", sec.Name)
+	}
+	err = remoteClient.Get(ctx, client.ObjectKeyFromObject(orphaned), &corev1.Secret{})
+	assert.True(t, errors.IsNotFound(err), "orphaned secret should be deleted")
+}
+
+func TestCleanupOrphanedSecretsInTargetNamespace(t *testing.T) {
+	ctx := context.Background()
+	rsecKey := types.NamespacedName{Namespace: "default", Name: "cluster1"}
+	selector := labels.SelectorFromSet(labels.Set{"replicated": "true"})
+
+	newSecret := func(namespace, name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Labels:      map[string]string{"replicated": "true"},
+			Annotations: map[string]string{coreapi.ResourceHashAnnotation: "hash"},
+		}}
+	}
+
+	source := newSecret("default", "secret1")
+	copied := newSecret("target", "secret1")
+	orphaned := newSecret("target", "secret2")
+	outsideTarget := newSecret("other", "secret2")
+
+	remoteClient, err := testutils.NewFakeClient(copied, orphaned, outsideTarget)
+	require.NoError(t, err)
+
+	s := &SecretSyncController{selectors: map[types.NamespacedName]labels.Selector{rsecKey: selector}}
+	target := api.ReplicationTarget{K8sContextName: "remote", Namespace: "target"}
+	err = s.cleanupOrphanedSecrets(ctx, rsecKey, selector, []corev1.Secret{*source}, target, remoteClient, logr.Discard())
+	require.NoError(t, err)
+
+	assert.NoError(t, remoteClient.Get(ctx, client.ObjectKeyFromObject(copied), &corev1.Secret{}))
+	assert.NoError(t, remoteClient.Get(ctx, client.ObjectKeyFromObject(outsideTarget), &corev1.Secret{}), "secrets outside of the target namespace should be ignored")
+	err = remoteClient.Get(ctx, client.ObjectKeyFromObject(orphaned), &corev1.Secret{})
+	assert.True(t, errors.IsNotFound(err))
+}
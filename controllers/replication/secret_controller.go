@@ -38,6 +38,7 @@ const (
 
 // We need rights to update the target cluster's secrets, not necessarily this cluster
 // +kubebuilder:rbac:groups=core,namespace="k8ssandra",resources=secrets,verbs=get;list;watch;update;create;delete
+// +kubebuilder:rbac:groups=core,namespace="k8ssandra",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=replication.k8ssandra.io,namespace="k8ssandra",resources=replicatedsecrets,verbs=get;list;watch;update;create;delete
 // +kubebuilder:rbac:groups=replication.k8ssandra.io,namespace="k8ssandra",resources=replicatedsecrets/finalizers,verbs=update
 // +kubebuilder:rbac:groups=replication.k8ssandra.io,namespace="k8ssandra",resources=replicatedsecrets/status,verbs=get;update;patch
@@ -258,6 +259,11 @@ func (s *SecretSyncController) Reconcile(ctx context.Context, req ctrl.Request)
 				}
 			}
 		}
+		if err == nil {
+			if err = s.cleanupOrphanedSecrets(ctx, req.NamespacedName, selector, secrets, target, remoteClient, logger); err != nil {
+				logger.Error(err, "Failed to clean up orphaned secrets in target cluster", "ReplicatedSecret", req.NamespacedName, "TargetContext", target)
+			}
+		}
 		if err != nil {
 			cond.Status = corev1.ConditionFalse
 		} else {
@@ -282,7 +288,9 @@ func (s *SecretSyncController) Reconcile(ctx context.Context, req ctrl.Request)
 			return ctrl.Result{Requeue: true}, fmt.Errorf("replication failed")
 		}
 	}
-	return ctrl.Result{}, err
+	// Requeue periodically to detect the replicated secrets whose source was removed while the controller was down,
+	// or by an event that did not reach it.
+	return ctrl.Result{RequeueAfter: s.OrphanedSecretsCleanupInterval}, err
 }
 
 func requiresUpdate(source, dest client.Object) bool {
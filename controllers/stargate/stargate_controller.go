@@ -30,6 +30,7 @@ import (
 	"github.com/k8ssandra/k8ssandra-operator/pkg/reconciliation"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/stargate"
 	stargateutil "github.com/k8ssandra/k8ssandra-operator/pkg/stargate"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
 	"gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -51,6 +52,7 @@ import (
 // +kubebuilder:rbac:groups=stargate.k8ssandra.io,namespace="k8ssandra",resources=stargates/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=stargate.k8ssandra.io,namespace="k8ssandra",resources=stargates/finalizers,verbs=update
 // +kubebuilder:rbac:groups=cassandra.datastax.com,namespace="k8ssandra",resources=cassandradatacenters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,namespace="k8ssandra",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,namespace="k8ssandra",resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,namespace="k8ssandra",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,namespace="k8ssandra",resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete;deletecollection
@@ -83,16 +85,17 @@ func (r *StargateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	if stargate.Status.Progress == "" {
 		ratio := fmt.Sprintf("0/%v", stargate.Spec.Size)
 		now := metav1.Now()
-		stargate.Status = api.StargateStatus{
-			Conditions: []api.StargateCondition{{
-				Type:               api.StargateReady,
-				Status:             corev1.ConditionFalse,
-				LastTransitionTime: &now,
-			}},
-			Progress:           api.StargateProgressPending,
-			ReadyReplicasRatio: &ratio,
-		}
-		if err := r.Status().Update(ctx, stargate); err != nil {
+		if err := utils.UpdateStatus(ctx, r.Client, stargate, func(stargate *api.Stargate) {
+			stargate.Status = api.StargateStatus{
+				Conditions: []api.StargateCondition{{
+					Type:               api.StargateReady,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: &now,
+				}},
+				Progress:           api.StargateProgressPending,
+				ReadyReplicasRatio: &ratio,
+			}
+		}); err != nil {
 			logger.Error(err, "Failed to update Stargate status", "Stargate", req.NamespacedName)
 			return ctrl.Result{}, err
 		}
@@ -106,8 +109,9 @@ func (r *StargateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		if errors.IsNotFound(err) {
 			logger.Info("Waiting for datacenter to be created", "CassandraDatacenter", dcKey)
 			if stargate.Status.Progress != api.StargateProgressPending {
-				stargate.Status.Progress = api.StargateProgressPending
-				if err := r.Status().Update(ctx, stargate); err != nil {
+				if err := utils.UpdateStatus(ctx, r.Client, stargate, func(stargate *api.Stargate) {
+					stargate.Status.Progress = api.StargateProgressPending
+				}); err != nil {
 					logger.Error(err, "Failed to update Stargate status", "Stargate", req.NamespacedName)
 					return ctrl.Result{}, err
 				}
@@ -120,11 +124,17 @@ func (r *StargateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 	actualDc = actualDc.DeepCopy()
 
-	// Wait until the DC is ready
-	if !cassandra.DatacenterReady(actualDc) {
+	// Wait until the DC is ready, according to its readiness policy
+	dcReady, err := cassandra.IsDatacenterReady(ctx, actualDc, r.Client)
+	if err != nil {
+		logger.Error(err, "Failed to check the readiness of the datacenter", "CassandraDatacenter", dcKey)
+		return ctrl.Result{}, err
+	}
+	if !dcReady {
 		if stargate.Status.Progress != api.StargateProgressPending {
-			stargate.Status.Progress = api.StargateProgressPending
-			if err := r.Status().Update(ctx, stargate); err != nil {
+			if err := utils.UpdateStatus(ctx, r.Client, stargate, func(stargate *api.Stargate) {
+				stargate.Status.Progress = api.StargateProgressPending
+			}); err != nil {
 				logger.Error(err, "Failed to update Stargate status", "Stargate", req.NamespacedName)
 				return ctrl.Result{}, err
 			}
@@ -207,12 +217,13 @@ func (r *StargateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	// Transition status from Created/Pending to Deploying
 	if stargate.Status.Progress == api.StargateProgressPending {
-		stargate.Status.Progress = api.StargateProgressDeploying
-		stargate.Status.DeploymentRefs = make([]string, 0)
-		for _, deployment := range desiredDeployments {
-			stargate.Status.DeploymentRefs = append(stargate.Status.DeploymentRefs, deployment.Name)
-		}
-		if err := r.Status().Update(ctx, stargate); err != nil {
+		if err := utils.UpdateStatus(ctx, r.Client, stargate, func(stargate *api.Stargate) {
+			stargate.Status.Progress = api.StargateProgressDeploying
+			stargate.Status.DeploymentRefs = make([]string, 0)
+			for _, deployment := range desiredDeployments {
+				stargate.Status.DeploymentRefs = append(stargate.Status.DeploymentRefs, deployment.Name)
+			}
+		}); err != nil {
 			logger.Error(err, "Failed to update Stargate status", "Stargate", req.NamespacedName)
 			return ctrl.Result{}, err
 		}
@@ -312,12 +323,13 @@ func (r *StargateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		stargate.Status.UpdatedReplicas != updatedReplicas ||
 		stargate.Status.AvailableReplicas != availableReplicas {
 		ratio := fmt.Sprintf("%v/%v", readyReplicas, stargate.Spec.Size)
-		stargate.Status.ReadyReplicasRatio = &ratio
-		stargate.Status.Replicas = replicas
-		stargate.Status.ReadyReplicas = readyReplicas
-		stargate.Status.UpdatedReplicas = updatedReplicas
-		stargate.Status.AvailableReplicas = availableReplicas
-		if err := r.Status().Update(ctx, stargate); err != nil {
+		if err := utils.UpdateStatus(ctx, r.Client, stargate, func(stargate *api.Stargate) {
+			stargate.Status.ReadyReplicasRatio = &ratio
+			stargate.Status.Replicas = replicas
+			stargate.Status.ReadyReplicas = readyReplicas
+			stargate.Status.UpdatedReplicas = updatedReplicas
+			stargate.Status.AvailableReplicas = availableReplicas
+		}); err != nil {
 			logger.Error(err, "Failed to update Stargate status", "Stargate", req.NamespacedName)
 			return ctrl.Result{}, err
 		}
@@ -327,8 +339,9 @@ func (r *StargateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	if readyReplicas != stargate.Spec.Size {
 		// Transition status back to "Deploying" if it was "Running"
 		if stargate.Status.Progress != api.StargateProgressDeploying {
-			stargate.Status.Progress = api.StargateProgressDeploying
-			if err := r.Status().Update(ctx, stargate); err != nil {
+			if err := utils.UpdateStatus(ctx, r.Client, stargate, func(stargate *api.Stargate) {
+				stargate.Status.Progress = api.StargateProgressDeploying
+			}); err != nil {
 				logger.Error(err, "Failed to update Stargate status", "Stargate", req.NamespacedName)
 				return ctrl.Result{}, err
 			}
@@ -391,15 +404,16 @@ func (r *StargateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	// Transition status to Running
 	if stargate.Status.Progress != api.StargateProgressRunning {
-		stargate.Status.Progress = api.StargateProgressRunning
-		stargate.Status.ServiceRef = &actualService.Name
 		now := metav1.Now()
-		stargate.Status.SetCondition(api.StargateCondition{
-			Type:               api.StargateReady,
-			Status:             corev1.ConditionTrue,
-			LastTransitionTime: &now,
-		})
-		if err := r.Status().Update(ctx, stargate); err != nil {
+		if err := utils.UpdateStatus(ctx, r.Client, stargate, func(stargate *api.Stargate) {
+			stargate.Status.Progress = api.StargateProgressRunning
+			stargate.Status.ServiceRef = &actualService.Name
+			stargate.Status.SetCondition(api.StargateCondition{
+				Type:               api.StargateReady,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: &now,
+			})
+		}); err != nil {
 			logger.Error(err, "Failed to update Stargate status", "Stargate", req.NamespacedName)
 			return ctrl.Result{}, err
 		}
@@ -36,6 +36,8 @@ import (
 	"github.com/k8ssandra/k8ssandra-operator/pkg/config"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/medusa"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/reaper"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/summary"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/tracing"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -92,11 +94,16 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var otlpEndpoint string
+	var otlpInsecure bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"The host:port of an OTLP/HTTP collector to export reconcile traces to. Tracing is disabled if empty.")
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", false, "Disable TLS when exporting traces to the OTLP endpoint.")
 	opts := zap.Options{
 		Development: true,
 		TimeEncoder: zapcore.ISO8601TimeEncoder,
@@ -149,13 +156,28 @@ func main() {
 	ctx, cancel := context.WithCancel(ctrl.SetupSignalHandler())
 	reconcilerConfig := config.InitConfig()
 
+	shutdownTracing, err := tracing.Setup(ctx, otlpEndpoint, otlpInsecure)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			setupLog.Error(err, "failed to flush traces")
+		}
+	}()
+
 	if isControlPlane() {
 		// Fetch ClientConfigs and create the clientCache
 		clientCache := clientcache.New(mgr.GetClient(), uncachedClient, scheme)
+		if reconcilerConfig.ReuseLocalClient {
+			clientCache.SetLocalRestConfig(mgr.GetConfig())
+		}
 
 		configCtrler := &configctrl.ClientConfigReconciler{
-			Scheme:      mgr.GetScheme(),
-			ClientCache: clientCache,
+			Scheme:                    mgr.GetScheme(),
+			ClientCache:               clientCache,
+			SecretRotationGracePeriod: reconcilerConfig.SecretRotationGracePeriod,
 		}
 
 		additionalClusters, err := configCtrler.InitClientConfigs(ctx, mgr, watchNamespace)
@@ -169,6 +191,12 @@ func main() {
 			os.Exit(1)
 		}
 
+		summaryTracker := summary.NewTracker()
+		if err = mgr.AddMetricsExtraHandler("/summary", summary.NewHandler(mgr.GetClient(), summaryTracker)); err != nil {
+			setupLog.Error(err, "unable to set up summary endpoint")
+			os.Exit(1)
+		}
+
 		if err = (&k8ssandractrl.K8ssandraClusterReconciler{
 			ReconcilerConfig: reconcilerConfig,
 			Client:           mgr.GetClient(),
@@ -176,10 +204,13 @@ func main() {
 			ClientCache:      clientCache,
 			ManagementApi:    cassandra.NewManagementApiFactory(),
 			Recorder:         mgr.GetEventRecorderFor("k8ssandracluster-controller"),
+			Summary:          summaryTracker,
 		}).SetupWithManager(mgr, additionalClusters); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "K8ssandraCluster")
 			os.Exit(1)
 		}
+		k8ssandraiov1alpha1.SetRequiredLabels(reconcilerConfig.RequiredLabels)
+		k8ssandraiov1alpha1.SetMaxClusterSize(reconcilerConfig.MaxClusterSize)
 		if err = (&k8ssandraiov1alpha1.K8ssandraCluster{}).SetupWebhookWithManager(mgr, clientCache); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "K8ssandraCluster")
 			os.Exit(1)
@@ -0,0 +1,145 @@
+package cassandra
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// AuditLoggingFilesMountPath is where the files of the audit logging ConfigMap are mounted in the cassandra
+	// container.
+	AuditLoggingFilesMountPath = "/opt/audit-logging"
+
+	auditLoggingFilesVolumeName = "audit-logging-files"
+)
+
+// auditLogCategories are the categories of requests known to the Cassandra audit log.
+var auditLogCategories = map[api.AuditLogCategory]bool{
+	api.AuditLogCategoryQuery:   true,
+	api.AuditLogCategoryDml:     true,
+	api.AuditLogCategoryDdl:     true,
+	api.AuditLogCategoryDcl:     true,
+	api.AuditLogCategoryOther:   true,
+	api.AuditLogCategoryAuth:    true,
+	api.AuditLogCategoryError:   true,
+	api.AuditLogCategoryPrepare: true,
+}
+
+// AuditLoggingConfigMapName returns the name of the copy of the audit logging files in the namespace of the DC.
+func AuditLoggingConfigMapName(kcName, dcName string) string {
+	return fmt.Sprintf("%s-%s-audit-logging", kcName, dcName)
+}
+
+// auditLoggingSettings returns the cassandra.yaml settings that correspond to the given audit logging.
+func auditLoggingSettings(auditLogging *api.AuditLogging) yamlSettings {
+	settings := make(yamlSettings)
+	if auditLogging == nil {
+		return settings
+	}
+	settings.putBool("audit_logging_options/enabled", auditLogging.Enabled)
+	if auditLogging.Logger != "" {
+		settings["audit_logging_options/logger"] = map[string]interface{}{"class_name": auditLogging.Logger}
+	}
+	if len(auditLogging.IncludedCategories) > 0 {
+		settings["audit_logging_options/included_categories"] = joinAuditLogCategories(auditLogging.IncludedCategories)
+	}
+	if len(auditLogging.ExcludedCategories) > 0 {
+		settings["audit_logging_options/excluded_categories"] = joinAuditLogCategories(auditLogging.ExcludedCategories)
+	}
+	return settings
+}
+
+func joinAuditLogCategories(categories []api.AuditLogCategory) string {
+	names := make([]string, 0, len(categories))
+	for _, category := range categories {
+		names = append(names, string(category))
+	}
+	return strings.Join(names, ",")
+}
+
+// ApplyAuditLogging adds the settings of the AuditLogging of the DC to cassandra.yaml.
+func ApplyAuditLogging(template *DatacenterConfig) {
+	auditLoggingSettings(template.AuditLogging).apply(template)
+}
+
+// validateAuditLogging checks that the AuditLogging of the DC is supported by its server version, that its categories
+// are known to Cassandra and not both included and excluded, and that its settings are not also set in cassandra.yaml.
+func validateAuditLogging(template *DatacenterConfig) error {
+	auditLogging := template.AuditLogging
+	if auditLogging == nil {
+		return nil
+	}
+	if template.ServerType != api.ServerDistributionCassandra ||
+		(template.ServerVersion != nil && template.ServerVersion.LessThan(semver.MustParse("4.0.0"))) {
+		return fmt.Errorf("auditLogging requires Cassandra 4.0.0 or later, but datacenter %s uses %s %s",
+			template.Meta.Name, template.ServerType, template.ServerVersion)
+	}
+	included := make(map[api.AuditLogCategory]bool)
+	for _, category := range auditLogging.IncludedCategories {
+		if !auditLogCategories[category] {
+			return fmt.Errorf("auditLogging of datacenter %s includes unknown category %s", template.Meta.Name, category)
+		}
+		included[category] = true
+	}
+	for _, category := range auditLogging.ExcludedCategories {
+		if !auditLogCategories[category] {
+			return fmt.Errorf("auditLogging of datacenter %s excludes unknown category %s", template.Meta.Name, category)
+		}
+		if included[category] {
+			return fmt.Errorf("auditLogging of datacenter %s both includes and excludes category %s", template.Meta.Name, category)
+		}
+	}
+	return checkNotInCassandraYaml(template, "auditLogging", auditLoggingSettings(auditLogging).names()...)
+}
+
+// NewAuditLoggingConfigMap returns the copy of source, the ConfigMap holding the audit logging files, that is replicated
+// to the namespace of the DC.
+func NewAuditLoggingConfigMap(kc *api.K8ssandraCluster, dcConfig *DatacenterConfig, source *corev1.ConfigMap) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AuditLoggingConfigMapName(kc.SanitizedName(), dcConfig.Meta.Name),
+			Namespace: utils.FirstNonEmptyString(dcConfig.Meta.Namespace, kc.Namespace),
+			Labels: map[string]string{
+				api.NameLabel:                      api.NameLabelValue,
+				api.PartOfLabel:                    api.PartOfLabelValue,
+				api.ComponentLabel:                 api.ComponentLabelValueCassandra,
+				api.CreatedByLabel:                 api.CreatedByLabelValueK8ssandraClusterController,
+				api.K8ssandraClusterNameLabel:      kc.SanitizedName(),
+				api.K8ssandraClusterNamespaceLabel: kc.Namespace,
+			},
+		},
+		Data:       source.Data,
+		BinaryData: source.BinaryData,
+	}
+}
+
+// AddAuditLoggingFiles mounts configMapName, the copy of the audit logging files in the namespace of the DC, in the
+// cassandra container. The files are executable, so that they can be used as archive_command. configHash is stored in
+// the pod template so that a change of the files results in a rolling restart.
+func AddAuditLoggingFiles(dcConfig *DatacenterConfig, configMapName, configHash string) {
+	volume := &corev1.Volume{
+		Name: auditLoggingFilesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+				DefaultMode:          pointer.Int32(0755),
+			},
+		},
+	}
+	volumeIndex, found := FindVolume(&dcConfig.PodTemplateSpec, volume.Name)
+	AddOrUpdateVolume(dcConfig, volume, volumeIndex, found)
+
+	UpdateCassandraContainer(&dcConfig.PodTemplateSpec, func(container *corev1.Container) {
+		AddOrUpdateVolumeMount(container, volume, AuditLoggingFilesMountPath)
+	})
+
+	annotations.AddAnnotation(&dcConfig.PodTemplateSpec, api.AuditLoggingConfigHashAnnotation, configHash)
+}
@@ -0,0 +1,94 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/Jeffail/gabs"
+	"github.com/Masterminds/semver/v3"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestApplyAuditLogging(t *testing.T) {
+	clusterTemplate := &api.CassandraClusterTemplate{
+		ServerType: api.ServerDistributionCassandra,
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion: "4.0.6",
+			CassandraConfig: &api.CassandraConfig{
+				CassandraYaml: unstructured.Unstructured{
+					"audit_logging_options": map[string]interface{}{"audit_logs_dir": "/var/lib/cassandra/audit"},
+				},
+			},
+			AuditLogging: &api.AuditLogging{
+				Enabled:            pointer.Bool(true),
+				IncludedCategories: []api.AuditLogCategory{api.AuditLogCategoryDdl, api.AuditLogCategoryDcl},
+			},
+		},
+	}
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{AuditLogging: &api.AuditLogging{
+			Logger:             "FileAuditLogger",
+			ExcludedCategories: []api.AuditLogCategory{api.AuditLogCategoryQuery},
+			ConfigMapRef:       &corev1.LocalObjectReference{Name: "audit-files"},
+		}},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateAuditLogging(dcConfig))
+	ApplyAuditLogging(dcConfig)
+
+	config, err := createJsonConfig(dcConfig.CassandraConfig, dcConfig.ServerVersion, dcConfig.ServerType, nil)
+	require.NoError(t, err)
+	parsed, err := gabs.ParseJSON(config)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"audit_logs_dir": "/var/lib/cassandra/audit",
+		"enabled": true,
+		"logger": {"class_name": "FileAuditLogger"},
+		"included_categories": "DDL,DCL",
+		"excluded_categories": "QUERY"
+	}`, parsed.Path("cassandra-yaml.audit_logging_options").String())
+	assert.Equal(t, "audit-files", dcConfig.AuditLogging.ConfigMapRef.Name)
+}
+
+func TestValidateAuditLogging(t *testing.T) {
+	newDcConfig := func(auditLogging *api.AuditLogging) *DatacenterConfig {
+		return &DatacenterConfig{
+			Meta:          api.EmbeddedObjectMeta{Name: "dc1"},
+			ServerType:    api.ServerDistributionCassandra,
+			ServerVersion: semver.MustParse("4.0.6"),
+			AuditLogging:  auditLogging,
+		}
+	}
+
+	assert.NoError(t, validateAuditLogging(newDcConfig(nil)))
+	assert.NoError(t, validateAuditLogging(newDcConfig(&api.AuditLogging{
+		Enabled:            pointer.Bool(true),
+		IncludedCategories: []api.AuditLogCategory{api.AuditLogCategoryAuth, api.AuditLogCategoryError},
+		ExcludedCategories: []api.AuditLogCategory{api.AuditLogCategoryPrepare},
+	})))
+
+	assert.EqualError(t, validateAuditLogging(newDcConfig(&api.AuditLogging{IncludedCategories: []api.AuditLogCategory{"DDL", "SCHEMA"}})),
+		"auditLogging of datacenter dc1 includes unknown category SCHEMA")
+	assert.EqualError(t, validateAuditLogging(newDcConfig(&api.AuditLogging{ExcludedCategories: []api.AuditLogCategory{"query"}})),
+		"auditLogging of datacenter dc1 excludes unknown category query")
+	assert.EqualError(t, validateAuditLogging(newDcConfig(&api.AuditLogging{
+		IncludedCategories: []api.AuditLogCategory{api.AuditLogCategoryDml},
+		ExcludedCategories: []api.AuditLogCategory{api.AuditLogCategoryDml},
+	})), "auditLogging of datacenter dc1 both includes and excludes category DML")
+
+	dcConfig := newDcConfig(&api.AuditLogging{Enabled: pointer.Bool(true)})
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"audit_logging_options": map[string]interface{}{"enabled": false}}
+	assert.EqualError(t, validateAuditLogging(dcConfig),
+		"cassandra.yaml setting audit_logging_options/enabled can not be set when it is also set in auditLogging")
+
+	dcConfig = newDcConfig(&api.AuditLogging{Enabled: pointer.Bool(true)})
+	dcConfig.ServerVersion = semver.MustParse("3.11.14")
+	assert.EqualError(t, validateAuditLogging(dcConfig),
+		"auditLogging requires Cassandra 4.0.0 or later, but datacenter dc1 uses cassandra 3.11.14")
+}
@@ -2,6 +2,7 @@ package cassandra
 
 import (
 	"fmt"
+	"strings"
 
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
@@ -59,6 +60,44 @@ func ApplyAuthSettings(config api.CassandraConfig, authEnabled bool, serverType
 	return config
 }
 
+// authSettings are the cassandra.yaml settings that must be identical in all the DCs of a cluster: the credentials and
+// permissions of the roles are replicated across DCs, so nodes using a different authenticator, authorizer or role
+// manager would reject the requests coordinated by the other DCs.
+var authSettings = []string{"authenticator", "authorizer", "role_manager"}
+
+// authPackagePrefix is the package of the Cassandra auth classes, which can be referenced with or without it.
+const authPackagePrefix = "org.apache.cassandra.auth."
+
+// AuthConsistencyWarnings returns a warning for each DC of a cluster that does not use the same authenticator,
+// authorizer or role manager as the first DC. This is expected while auth is changed one DC at a time, so it is not an
+// error. It must be called once the auth settings have been applied to the DCs.
+func AuthConsistencyWarnings(dcConfigs []*DatacenterConfig) []string {
+	if len(dcConfigs) < 2 {
+		return nil
+	}
+	var warnings []string
+	first := dcConfigs[0]
+	for _, setting := range authSettings {
+		expected := authSetting(first, setting)
+		for _, dcConfig := range dcConfigs[1:] {
+			if actual := authSetting(dcConfig, setting); actual != expected {
+				warnings = append(warnings, fmt.Sprintf("datacenter %s uses %s %s while datacenter %s uses %s",
+					first.Meta.Name, setting, expected, dcConfig.Meta.Name, actual))
+			}
+		}
+	}
+	return warnings
+}
+
+// authSetting returns the short class name of the given auth setting of the DC.
+func authSetting(dcConfig *DatacenterConfig, setting string) string {
+	value, found := dcConfig.CassandraConfig.CassandraYaml[setting]
+	if !found {
+		return ""
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%v", value), authPackagePrefix)
+}
+
 // If auth is enabled in this cluster, we need to allow components to access the cluster through CQL. This is done by
 // declaring a Cassandra user whose credentials are pulled from CassandraUserSecretRef.
 func AddCqlUser(cassandraUserSecretRef corev1.LocalObjectReference, dcConfig *DatacenterConfig, cassandraUserSecretName string) {
@@ -82,3 +82,31 @@ func TestApplyAuthSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthConsistencyWarnings(t *testing.T) {
+	newDcConfig := func(name, authenticator string) *DatacenterConfig {
+		dcConfig := &DatacenterConfig{Meta: k8ssandraapi.EmbeddedObjectMeta{Name: name}}
+		if authenticator != "" {
+			dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"authenticator": authenticator}
+		}
+		dcConfig.CassandraConfig = ApplyAuthSettings(dcConfig.CassandraConfig, true, k8ssandraapi.ServerDistributionCassandra)
+		return dcConfig
+	}
+
+	assert.Empty(t, AuthConsistencyWarnings([]*DatacenterConfig{newDcConfig("dc1", "AllowAllAuthenticator")}))
+	assert.Empty(t, AuthConsistencyWarnings([]*DatacenterConfig{
+		newDcConfig("dc1", ""),
+		newDcConfig("dc2", "org.apache.cassandra.auth.PasswordAuthenticator"),
+	}))
+
+	assert.Equal(t, []string{"datacenter dc1 uses authenticator PasswordAuthenticator while datacenter dc2 uses AllowAllAuthenticator"},
+		AuthConsistencyWarnings([]*DatacenterConfig{
+			newDcConfig("dc1", ""),
+			newDcConfig("dc2", "AllowAllAuthenticator"),
+		}))
+
+	dc2 := newDcConfig("dc2", "")
+	dc2.CassandraConfig.CassandraYaml["authorizer"] = "AllowAllAuthorizer"
+	assert.Equal(t, []string{"datacenter dc1 uses authorizer CassandraAuthorizer while datacenter dc2 uses AllowAllAuthorizer"},
+		AuthConsistencyWarnings([]*DatacenterConfig{newDcConfig("dc1", ""), dc2, newDcConfig("dc3", "")}))
+}
@@ -0,0 +1,129 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// cassOperatorImageName is the name of the cass-operator image, regardless of its registry and repository.
+const cassOperatorImageName = "cass-operator"
+
+// cassOperatorFeature is a CassandraDatacenter field that cass-operator only supports from minVersion on. Older
+// versions do not know the field and silently drop it.
+type cassOperatorFeature struct {
+	field      string
+	minVersion *semver.Version
+	used       func(dc *cassdcapi.CassandraDatacenter) bool
+}
+
+var cassOperatorFeatures = []cassOperatorFeature{
+	{
+		field:      "additionalServiceConfig",
+		minVersion: semver.MustParse("1.7.0"),
+		used: func(dc *cassdcapi.CassandraDatacenter) bool {
+			config := dc.Spec.AdditionalServiceConfig
+			for _, additions := range []cassdcapi.ServiceConfigAdditions{
+				config.DatacenterService,
+				config.SeedService,
+				config.AllPodsService,
+				config.AdditionalSeedService,
+				config.NodePortService,
+			} {
+				if len(additions.Labels) > 0 || len(additions.Annotations) > 0 {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		field:      "additionalLabels",
+		minVersion: semver.MustParse("1.10.0"),
+		used: func(dc *cassdcapi.CassandraDatacenter) bool {
+			return len(dc.Spec.AdditionalLabels) > 0
+		},
+	},
+	{
+		field:      "cdc",
+		minVersion: semver.MustParse("1.12.0"),
+		used: func(dc *cassdcapi.CassandraDatacenter) bool {
+			return dc.Spec.CDC != nil
+		},
+	},
+	{
+		field:      "datacenterName",
+		minVersion: semver.MustParse("1.14.0"),
+		used: func(dc *cassdcapi.CassandraDatacenter) bool {
+			return dc.Spec.DatacenterName != ""
+		},
+	},
+}
+
+// CassOperatorVersion returns the version of the cass-operator Deployment that selector selects in namespace, as found
+// in the tag of its cass-operator image. When the image is pinned by digest only, or when its tag is not a version,
+// e.g. latest, the version is taken from the app.kubernetes.io/version label of the Deployment. An error is returned
+// when the version cannot be determined, including when there is no such Deployment. A nil selector selects all the
+// Deployments of namespace.
+func CassOperatorVersion(ctx context.Context, namespace string, selector labels.Selector, remoteClient client.Client) (*semver.Version, error) {
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := remoteClient.List(ctx, deployments, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if version := cassOperatorImageVersion(container.Image); version != nil {
+				return version, nil
+			}
+		}
+	}
+	for _, deployment := range deployments.Items {
+		if version, err := semver.NewVersion(deployment.Labels[api.VersionLabel]); err == nil {
+			return version, nil
+		}
+	}
+	if len(deployments.Items) == 0 {
+		return nil, fmt.Errorf("no cass-operator Deployment matching %q in namespace %s", selector.String(), namespace)
+	}
+	return nil, fmt.Errorf("neither the image tag nor the %s label of Deployment %s is a version",
+		api.VersionLabel, deployments.Items[0].Name)
+}
+
+// cassOperatorImageVersion returns the version in the tag of image if it is a cass-operator image, and nil otherwise.
+func cassOperatorImageVersion(image string) *semver.Version {
+	image = strings.SplitN(image, "@", 2)[0]
+	name, tag := image, ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, tag = image[:i], image[i+1:]
+	}
+	if name[strings.LastIndex(name, "/")+1:] != cassOperatorImageName || tag == "" {
+		return nil
+	}
+	version, err := semver.NewVersion(tag)
+	if err != nil {
+		return nil
+	}
+	return version
+}
+
+// UnsupportedCassOperatorFields returns the fields set in dc that the given cass-operator version does not support,
+// each with the minimum version it requires, e.g. "cdc (requires 1.12.0)".
+func UnsupportedCassOperatorFields(dc *cassdcapi.CassandraDatacenter, version *semver.Version) []string {
+	unsupported := make([]string, 0)
+	for _, feature := range cassOperatorFeatures {
+		if feature.used(dc) && version.LessThan(feature.minVersion) {
+			unsupported = append(unsupported, fmt.Sprintf("%s (requires %s)", feature.field, feature.minVersion))
+		}
+	}
+	return unsupported
+}
@@ -0,0 +1,51 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCassOperatorImageVersion(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"k8ssandra/cass-operator:v1.14.0", "1.14.0"},
+		{"docker.io/k8ssandra/cass-operator:v1.10.3", "1.10.3"},
+		{"registry.example.com:5000/mirror/cass-operator:1.12.0", "1.12.0"},
+		{"k8ssandra/cass-operator:v1.13.0@sha256:0123456789abcdef", "1.13.0"},
+		{"k8ssandra/cass-operator@sha256:0123456789abcdef", ""},
+		{"k8ssandra/cass-operator:latest", ""},
+		{"k8ssandra/cass-operator", ""},
+		{"k8ssandra/k8ssandra-operator:v1.6.0", ""},
+		{"registry.example.com:5000/cass-operator", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			got := cassOperatorImageVersion(tt.image)
+			if tt.want == "" {
+				assert.Nil(t, got)
+			} else if assert.NotNil(t, got) {
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestUnsupportedCassOperatorFields(t *testing.T) {
+	dc := &cassdcapi.CassandraDatacenter{
+		Spec: cassdcapi.CassandraDatacenterSpec{
+			AdditionalLabels: map[string]string{"env": "prod"},
+			CDC:              &cassdcapi.CDCConfiguration{},
+			DatacenterName:   "DC 1",
+		},
+	}
+	assert.Equal(t,
+		[]string{"additionalLabels (requires 1.10.0)", "cdc (requires 1.12.0)", "datacenterName (requires 1.14.0)"},
+		UnsupportedCassOperatorFields(dc, semver.MustParse("1.9.0")))
+	assert.Equal(t, []string{"datacenterName (requires 1.14.0)"}, UnsupportedCassOperatorFields(dc, semver.MustParse("1.13.1")))
+	assert.Empty(t, UnsupportedCassOperatorFields(dc, semver.MustParse("1.15.0")))
+}
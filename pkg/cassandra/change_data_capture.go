@@ -0,0 +1,96 @@
+package cassandra
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+)
+
+const (
+	// serverDataDirectory is where cass-operator mounts the server data volume in the cassandra container.
+	serverDataDirectory = "/var/lib/cassandra"
+
+	cdcRawVolumeName = "cdc-raw"
+)
+
+// changeDataCaptureSettings returns the cassandra.yaml settings that correspond to the change data capture of the DC.
+// Cassandra 4.1 and later get the CDC space as cdc_total_space, the older versions as cdc_total_space_in_mb.
+func changeDataCaptureSettings(template *DatacenterConfig) yamlSettings {
+	settings := make(yamlSettings)
+	cdc := template.ChangeDataCapture
+	if cdc == nil {
+		return settings
+	}
+	settings.putBool("cdc_enabled", cdc.Enabled)
+	if cdc.TotalSpaceInMb != nil && usesCassandra41SettingNames(template) {
+		settings["cdc_total_space"] = fmt.Sprintf("%dMiB", *cdc.TotalSpaceInMb)
+	} else {
+		settings.putInt32("cdc_total_space_in_mb", cdc.TotalSpaceInMb)
+	}
+	if cdc.RawDirectory != "" {
+		settings["cdc_raw_directory"] = cdc.RawDirectory
+	}
+	return settings
+}
+
+// ApplyChangeDataCapture adds the settings of the ChangeDataCapture of the DC to cassandra.yaml, and mounts the volume
+// of the CDC raw directory when it has its own storage. The volume is a volume claim template of the StatefulSets, so
+// the webhook rejects changing it once the DC exists.
+func ApplyChangeDataCapture(template *DatacenterConfig) {
+	changeDataCaptureSettings(template).apply(template)
+	if cdc := template.ChangeDataCapture; cdc != nil && cdc.RawDirectoryStorage != nil {
+		if template.StorageConfig == nil {
+			template.StorageConfig = &cassdcapi.StorageConfig{}
+		}
+		volume := &cassdcapi.AdditionalVolumes{
+			Name:      cdcRawVolumeName,
+			MountPath: cdc.RawDirectory,
+			PVCSpec:   cdc.RawDirectoryStorage.DeepCopy(),
+		}
+		volumeIndex, found := FindAdditionalVolume(template, volume.Name)
+		AddOrUpdateAdditionalVolume(template, volume, volumeIndex, found)
+	}
+}
+
+// validateChangeDataCapture checks that the CDC settings of the DC are consistent with each other and with the cdc
+// agent, that the raw directory is kept on persistent storage, and that the settings are not also set in
+// cassandra.yaml, either under these names or under the names they were given in Cassandra 4.1.
+func validateChangeDataCapture(template *DatacenterConfig) error {
+	cdc := template.ChangeDataCapture
+	if cdc == nil {
+		return nil
+	}
+	if cdc.TotalSpaceInMb != nil && *cdc.TotalSpaceInMb < 1 {
+		return fmt.Errorf("changeDataCapture setting cdc_total_space_in_mb must be at least 1")
+	}
+	if cdc.Enabled != nil && !*cdc.Enabled && template.CDC != nil {
+		return fmt.Errorf("changeDataCapture of datacenter %s can not be disabled when the cdc agent is configured", template.Meta.Name)
+	}
+	if cdc.RawDirectory != "" {
+		if !path.IsAbs(cdc.RawDirectory) || path.Clean(cdc.RawDirectory) != cdc.RawDirectory {
+			return fmt.Errorf("changeDataCapture rawDirectory %s of datacenter %s must be a clean absolute path", cdc.RawDirectory, template.Meta.Name)
+		}
+		if cdc.RawDirectory == serverDataDirectory {
+			return fmt.Errorf("changeDataCapture rawDirectory of datacenter %s can not be the server data directory %s", template.Meta.Name, serverDataDirectory)
+		}
+		if cdc.RawDirectoryStorage == nil && !strings.HasPrefix(cdc.RawDirectory, serverDataDirectory+"/") {
+			return fmt.Errorf("changeDataCapture rawDirectory %s of datacenter %s must be under %s, unless rawDirectoryStorage is set",
+				cdc.RawDirectory, template.Meta.Name, serverDataDirectory)
+		}
+	} else if cdc.RawDirectoryStorage != nil {
+		return fmt.Errorf("changeDataCapture rawDirectoryStorage of datacenter %s requires rawDirectory", template.Meta.Name)
+	}
+	names := make([]string, 0)
+	for _, setting := range changeDataCaptureSettings(template).names() {
+		if !strings.HasPrefix(setting, "cdc_total_space") {
+			names = append(names, setting)
+		}
+	}
+	if cdc.TotalSpaceInMb != nil {
+		names = append(names, "cdc_total_space_in_mb", "cdc_total_space")
+	}
+	return checkNotInCassandraYaml(template, "changeDataCapture", names...)
+}
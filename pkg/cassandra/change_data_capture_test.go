@@ -0,0 +1,175 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/Jeffail/gabs"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+)
+
+func TestApplyChangeDataCapture(t *testing.T) {
+	rawDirectoryStorage := &corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+		},
+	}
+	clusterTemplate := &api.CassandraClusterTemplate{
+		ServerType: api.ServerDistributionCassandra,
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion:     "4.0.6",
+			ChangeDataCapture: &api.ChangeDataCapture{Enabled: pointer.Bool(true), TotalSpaceInMb: pointer.Int32(4096)},
+		},
+	}
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{ChangeDataCapture: &api.ChangeDataCapture{
+			RawDirectory:        "/var/lib/cdc-raw",
+			RawDirectoryStorage: rawDirectoryStorage,
+		}},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateChangeDataCapture(dcConfig))
+	ApplyChangeDataCapture(dcConfig)
+
+	config, err := createJsonConfig(dcConfig.CassandraConfig, dcConfig.ServerVersion, dcConfig.ServerType, nil)
+	require.NoError(t, err)
+	parsed, err := gabs.ParseJSON(config)
+	require.NoError(t, err)
+	assert.Equal(t, true, parsed.Path("cassandra-yaml.cdc_enabled").Data())
+	assert.Equal(t, float64(4096), parsed.Path("cassandra-yaml.cdc_total_space_in_mb").Data())
+	assert.Equal(t, "/var/lib/cdc-raw", parsed.Path("cassandra-yaml.cdc_raw_directory").Data())
+
+	require.NotNil(t, dcConfig.StorageConfig)
+	assert.Contains(t, dcConfig.StorageConfig.AdditionalVolumes, cassdcapi.AdditionalVolumes{
+		Name:      "cdc-raw",
+		MountPath: "/var/lib/cdc-raw",
+		PVCSpec:   rawDirectoryStorage,
+	})
+
+	// Cassandra 4.1 deprecated cdc_total_space_in_mb in favor of cdc_total_space
+	clusterTemplate.ServerVersion = "4.1.0"
+	dcConfig = Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateChangeDataCapture(dcConfig))
+	ApplyChangeDataCapture(dcConfig)
+	assert.Equal(t, "4096MiB", dcConfig.CassandraConfig.CassandraYaml["cdc_total_space"])
+	assert.NotContains(t, dcConfig.CassandraConfig.CassandraYaml, "cdc_total_space_in_mb")
+}
+
+func TestChangeDataCaptureVolumePropagates(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.ChangeDataCapture = &api.ChangeDataCapture{
+		Enabled:             pointer.Bool(true),
+		RawDirectory:        "/var/lib/cdc-raw",
+		RawDirectoryStorage: &corev1.PersistentVolumeClaimSpec{StorageClassName: pointer.String("fast")},
+	}
+	require.NoError(t, validateChangeDataCapture(&template))
+	ApplyChangeDataCapture(&template)
+	// applying the settings again, as done on each reconcile of a new DatacenterConfig, does not duplicate the volume
+	ApplyChangeDataCapture(&template)
+
+	cassDC, err := NewDatacenter(types.NamespacedName{Name: "dc1", Namespace: "k8ssandra"}, &template)
+	require.NoError(t, err)
+	volumes := make([]cassdcapi.AdditionalVolumes, 0)
+	for _, volume := range cassDC.Spec.StorageConfig.AdditionalVolumes {
+		if volume.Name == "cdc-raw" {
+			volumes = append(volumes, volume)
+		}
+	}
+	require.Len(t, volumes, 1)
+	assert.Equal(t, "/var/lib/cdc-raw", volumes[0].MountPath)
+	assert.Equal(t, "fast", *volumes[0].PVCSpec.StorageClassName)
+}
+
+func TestValidateChangeDataCapture(t *testing.T) {
+	tests := []struct {
+		name      string
+		cdc       *api.ChangeDataCapture
+		cdcAgent  bool
+		yaml      unstructured.Unstructured
+		wantError string
+	}{
+		{name: "not set"},
+		{name: "enabled with defaults", cdc: &api.ChangeDataCapture{Enabled: pointer.Bool(true)}},
+		{name: "raw directory on the data volume", cdc: &api.ChangeDataCapture{RawDirectory: "/var/lib/cassandra/cdc"}},
+		{
+			name: "raw directory with its own storage",
+			cdc:  &api.ChangeDataCapture{RawDirectory: "/cdc", RawDirectoryStorage: &corev1.PersistentVolumeClaimSpec{}},
+		},
+		{
+			name:      "invalid total space",
+			cdc:       &api.ChangeDataCapture{TotalSpaceInMb: pointer.Int32(0)},
+			wantError: "changeDataCapture setting cdc_total_space_in_mb must be at least 1",
+		},
+		{
+			name:      "disabled with the cdc agent",
+			cdc:       &api.ChangeDataCapture{Enabled: pointer.Bool(false)},
+			cdcAgent:  true,
+			wantError: "changeDataCapture of datacenter dc1 can not be disabled when the cdc agent is configured",
+		},
+		{
+			name:      "relative raw directory",
+			cdc:       &api.ChangeDataCapture{RawDirectory: "cdc_raw"},
+			wantError: "changeDataCapture rawDirectory cdc_raw of datacenter dc1 must be a clean absolute path",
+		},
+		{
+			name:      "unclean raw directory",
+			cdc:       &api.ChangeDataCapture{RawDirectory: "/var/lib/cassandra/../cdc"},
+			wantError: "changeDataCapture rawDirectory /var/lib/cassandra/../cdc of datacenter dc1 must be a clean absolute path",
+		},
+		{
+			name:      "raw directory is the data directory",
+			cdc:       &api.ChangeDataCapture{RawDirectory: "/var/lib/cassandra", RawDirectoryStorage: &corev1.PersistentVolumeClaimSpec{}},
+			wantError: "changeDataCapture rawDirectory of datacenter dc1 can not be the server data directory /var/lib/cassandra",
+		},
+		{
+			name:      "raw directory outside of the data volume",
+			cdc:       &api.ChangeDataCapture{RawDirectory: "/var/lib/cassandra-cdc"},
+			wantError: "changeDataCapture rawDirectory /var/lib/cassandra-cdc of datacenter dc1 must be under /var/lib/cassandra, unless rawDirectoryStorage is set",
+		},
+		{
+			name:      "storage without raw directory",
+			cdc:       &api.ChangeDataCapture{RawDirectoryStorage: &corev1.PersistentVolumeClaimSpec{}},
+			wantError: "changeDataCapture rawDirectoryStorage of datacenter dc1 requires rawDirectory",
+		},
+		{
+			name:      "also set in cassandra.yaml",
+			cdc:       &api.ChangeDataCapture{Enabled: pointer.Bool(true)},
+			yaml:      unstructured.Unstructured{"cdc_enabled": false},
+			wantError: "cassandra.yaml setting cdc_enabled can not be set when it is also set in changeDataCapture",
+		},
+		{
+			name:      "also set in cassandra.yaml under its Cassandra 4.1 name",
+			cdc:       &api.ChangeDataCapture{TotalSpaceInMb: pointer.Int32(4096)},
+			yaml:      unstructured.Unstructured{"cdc_total_space": "4096MiB"},
+			wantError: "cassandra.yaml setting cdc_total_space can not be set when it is also set in changeDataCapture",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dcConfig := &DatacenterConfig{
+				Meta:              api.EmbeddedObjectMeta{Name: "dc1"},
+				ChangeDataCapture: tt.cdc,
+			}
+			dcConfig.CassandraConfig.CassandraYaml = tt.yaml
+			if tt.cdcAgent {
+				dcConfig.CDC = &cassdcapi.CDCConfiguration{PulsarServiceUrl: pointer.String("pulsar://test-url")}
+			}
+			err := validateChangeDataCapture(dcConfig)
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantError)
+			}
+		})
+	}
+}
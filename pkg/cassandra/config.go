@@ -8,8 +8,10 @@ import (
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
 )
 
 const (
@@ -17,9 +19,9 @@ const (
 	allowAlterRf                    = "-Dcassandra.allow_alter_rf_during_range_movement=true"
 )
 
-// createJsonConfig parses a CassandraConfig into raw JSON bytes as required by the
-// CassandraDatacenter.Spec.Config field, which is processed by cass-config-builder.
-func createJsonConfig(config api.CassandraConfig, serverVersion *semver.Version, serverType api.ServerDistribution) ([]byte, error) {
+// createJsonConfig parses a CassandraConfig and the cassandra-rackdc.properties settings into raw JSON bytes as
+// required by the CassandraDatacenter.Spec.Config field, which is processed by cass-config-builder.
+func createJsonConfig(config api.CassandraConfig, serverVersion *semver.Version, serverType api.ServerDistribution, rackDcProperties map[string]interface{}) ([]byte, error) {
 
 	out := make(unstructured.Unstructured)
 
@@ -37,6 +39,10 @@ func createJsonConfig(config api.CassandraConfig, serverVersion *semver.Version,
 		out["dse-yaml"] = config.DseYaml
 	}
 
+	if len(rackDcProperties) > 0 {
+		out["cassandra-rackdc-properties"] = rackDcProperties
+	}
+
 	// JvmOptions is a struct, we need to convert it to a map using preMarshalConfig
 	jvmOptionsVal := reflect.ValueOf(config.JvmOptions)
 	jvmOptionsOut, err := preMarshalConfig(jvmOptionsVal, serverVersion, string(serverType))
@@ -67,6 +73,575 @@ func AddStartRpc(template *DatacenterConfig) {
 	}
 }
 
+// yamlSettings are the cassandra.yaml settings derived from one of the DatacenterOptions, by name. The name of a
+// setting nested in an options block, such as server_encryption_options, is its path in cassandra.yaml.
+type yamlSettings map[string]interface{}
+
+// putInt32 adds the setting name if value is set. Values are stored as int64, the type Unstructured.DeepCopy()
+// supports.
+func (s yamlSettings) putInt32(name string, value *int32) {
+	if value != nil {
+		s[name] = int64(*value)
+	}
+}
+
+func (s yamlSettings) putBool(name string, value *bool) {
+	if value != nil {
+		s[name] = *value
+	}
+}
+
+// putQuantity adds the setting name if value is set, as a quantity in the given unit such as "10ms" or "64MiB", the
+// format of the settings of Cassandra 4.1 and later.
+func (s yamlSettings) putQuantity(name string, value *int32, unit string) {
+	if value != nil {
+		s[name] = fmt.Sprintf("%d%s", *value, unit)
+	}
+}
+
+// names returns the names of the settings, sorted so that validation errors are stable.
+func (s yamlSettings) names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// apply adds the settings to the cassandra.yaml of the DC.
+func (s yamlSettings) apply(template *DatacenterConfig) {
+	for name, value := range s {
+		template.CassandraConfig.CassandraYaml.Put(name, value)
+	}
+}
+
+// checkNotInCassandraYaml returns an error naming the first of names, in alphabetical order, that is also set in the
+// cassandra.yaml of the DC. option is the name of the field of the DatacenterOptions the settings come from.
+func checkNotInCassandraYaml(template *DatacenterConfig, option string, names ...string) error {
+	sort.Strings(names)
+	for _, name := range names {
+		if _, found := template.CassandraConfig.CassandraYaml.Get(name); found {
+			return fmt.Errorf("cassandra.yaml setting %s can not be set when it is also set in %s", name, option)
+		}
+	}
+	return nil
+}
+
+// hintsTuningSettings returns the cassandra.yaml settings that correspond to the hints tuning of the DC. Cassandra 4.1
+// and later get the throttle and the hint window as quantities under their new names, hinted_handoff_throttle and
+// max_hint_window.
+func hintsTuningSettings(template *DatacenterConfig) yamlSettings {
+	settings := make(yamlSettings)
+	hints := template.HintsTuning
+	if hints == nil {
+		return settings
+	}
+	settings.putInt32("max_hints_delivery_threads", hints.MaxHintsDeliveryThreads)
+	if usesCassandra41SettingNames(template) {
+		settings.putQuantity("hinted_handoff_throttle", hints.HintedHandoffThrottleInKb, "KiB")
+		settings.putQuantity("max_hint_window", hints.MaxHintWindowInMs, "ms")
+	} else {
+		settings.putInt32("hinted_handoff_throttle_in_kb", hints.HintedHandoffThrottleInKb)
+		settings.putInt32("max_hint_window_in_ms", hints.MaxHintWindowInMs)
+	}
+	return settings
+}
+
+// ApplyHintsTuning adds the settings of the HintsTuning of the DC to cassandra.yaml.
+func ApplyHintsTuning(template *DatacenterConfig) {
+	hintsTuningSettings(template).apply(template)
+}
+
+// validateHintsTuning checks that the settings of the HintsTuning of the DC are not also set in cassandra.yaml, either
+// under the names they had before Cassandra 4.1 or under the names they were given in 4.1.
+func validateHintsTuning(template *DatacenterConfig) error {
+	hints := template.HintsTuning
+	if hints == nil {
+		return nil
+	}
+	names := hintsTuningSettings(template).names()
+	if hints.HintedHandoffThrottleInKb != nil {
+		names = append(names, "hinted_handoff_throttle_in_kb", "hinted_handoff_throttle")
+	}
+	if hints.MaxHintWindowInMs != nil {
+		names = append(names, "max_hint_window_in_ms", "max_hint_window")
+	}
+	return checkNotInCassandraYaml(template, "hintsTuning", names...)
+}
+
+// materializedViewsSettings returns the cassandra.yaml settings that correspond to the materialized views tuning of the
+// DC. The setting enabling materialized views was renamed in Cassandra 4.1.
+func materializedViewsSettings(template *DatacenterConfig) yamlSettings {
+	settings := make(yamlSettings)
+	views := template.MaterializedViews
+	if views == nil {
+		return settings
+	}
+	settings.putBool(materializedViewsEnabledSetting(template), views.Enabled)
+	settings.putInt32("concurrent_materialized_view_writes", views.ConcurrentWrites)
+	settings.putInt32("concurrent_materialized_view_builders", views.ConcurrentBuilders)
+	return settings
+}
+
+func materializedViewsEnabledSetting(template *DatacenterConfig) string {
+	if usesCassandra41SettingNames(template) {
+		return "materialized_views_enabled"
+	}
+	return "enable_materialized_views"
+}
+
+// usesCassandra41SettingNames returns true if the DC runs Cassandra 4.1 or later, which renamed many cassandra.yaml
+// settings and deprecated the old names.
+func usesCassandra41SettingNames(template *DatacenterConfig) bool {
+	return template.ServerType == api.ServerDistributionCassandra && template.ServerVersion != nil &&
+		!template.ServerVersion.LessThan(semver.MustParse("4.1.0"))
+}
+
+// ApplyMaterializedViews adds the settings of the MaterializedViews tuning of the DC to cassandra.yaml.
+func ApplyMaterializedViews(template *DatacenterConfig) {
+	materializedViewsSettings(template).apply(template)
+}
+
+// validateMaterializedViews checks that the settings of the MaterializedViews tuning of the DC are supported by its
+// server version, and that they are not also set in cassandra.yaml.
+func validateMaterializedViews(template *DatacenterConfig) error {
+	views := template.MaterializedViews
+	if views == nil {
+		return nil
+	}
+	if views.ConcurrentBuilders != nil && template.ServerType == api.ServerDistributionCassandra &&
+		template.ServerVersion != nil && template.ServerVersion.LessThan(semver.MustParse("4.0.0")) {
+		return fmt.Errorf("materializedViews.concurrentBuilders requires Cassandra 4.0.0 or later, but datacenter %s uses version %s",
+			template.Meta.Name, template.ServerVersion)
+	}
+	settings := materializedViewsSettings(template)
+	delete(settings, materializedViewsEnabledSetting(template))
+	names := settings.names()
+	if views.Enabled != nil {
+		// both names of the setting enabling materialized views conflict with the tuning
+		names = append(names, "enable_materialized_views", "materialized_views_enabled")
+	}
+	return checkNotInCassandraYaml(template, "materializedViews", names...)
+}
+
+// sizeSetting is the value of a numeric cassandra.yaml setting, along with the minimum value it accepts.
+type sizeSetting struct {
+	value *int32
+	min   int32
+}
+
+// memtableAndCacheSizeSettings returns the cassandra.yaml settings that correspond to the sizes set in the given
+// memtable and cache tuning, by their name before Cassandra 4.1.
+func memtableAndCacheSizeSettings(tuning *api.MemtableAndCacheTuning) map[string]sizeSetting {
+	if tuning == nil {
+		return nil
+	}
+	return map[string]sizeSetting{
+		"memtable_heap_space_in_mb":    {tuning.MemtableHeapSpaceInMb, 1},
+		"memtable_offheap_space_in_mb": {tuning.MemtableOffheapSpaceInMb, 1},
+		"memtable_flush_writers":       {tuning.MemtableFlushWriters, 1},
+		"file_cache_size_in_mb":        {tuning.FileCacheSizeInMb, 0},
+		"key_cache_size_in_mb":         {tuning.KeyCacheSizeInMb, 0},
+		"row_cache_size_in_mb":         {tuning.RowCacheSizeInMb, 0},
+		"counter_cache_size_in_mb":     {tuning.CounterCacheSizeInMb, 0},
+	}
+}
+
+// memtableAndCacheSettings returns the cassandra.yaml settings that correspond to the memtable and cache tuning of the
+// DC. Cassandra 4.1 and later get the sizes in MiB under the new names, such as key_cache_size, the older versions get
+// the megabytes under the names ending with _in_mb.
+func memtableAndCacheSettings(template *DatacenterConfig) yamlSettings {
+	settings := make(yamlSettings)
+	tuning := template.MemtableAndCacheTuning
+	if tuning == nil {
+		return settings
+	}
+	if tuning.MemtableAllocationType != "" {
+		settings["memtable_allocation_type"] = tuning.MemtableAllocationType
+	}
+	for setting, size := range memtableAndCacheSizeSettings(tuning) {
+		if usesCassandra41SettingNames(template) && strings.HasSuffix(setting, "_in_mb") {
+			settings.putQuantity(strings.TrimSuffix(setting, "_in_mb"), size.value, "MiB")
+		} else {
+			settings.putInt32(setting, size.value)
+		}
+	}
+	return settings
+}
+
+// ApplyMemtableAndCacheTuning adds the settings of the MemtableAndCacheTuning of the DC to cassandra.yaml.
+func ApplyMemtableAndCacheTuning(template *DatacenterConfig) {
+	memtableAndCacheSettings(template).apply(template)
+}
+
+// validateMemtableAndCacheTuning checks that the settings of the MemtableAndCacheTuning of the DC have valid values
+// supported by its server version, and that they are not also set in cassandra.yaml, either under the names they had
+// before Cassandra 4.1 or under the names they were given in 4.1.
+func validateMemtableAndCacheTuning(template *DatacenterConfig) error {
+	tuning := template.MemtableAndCacheTuning
+	if tuning == nil {
+		return nil
+	}
+	switch tuning.MemtableAllocationType {
+	case "", "heap_buffers", "offheap_buffers", "offheap_objects":
+	case "unslabbed_heap_buffers":
+		if template.ServerType == api.ServerDistributionCassandra && template.ServerVersion != nil &&
+			template.ServerVersion.LessThan(semver.MustParse("4.0.0")) {
+			return fmt.Errorf("memtableAndCacheTuning.memtableAllocationType unslabbed_heap_buffers requires Cassandra 4.0.0 or later, but datacenter %s uses version %s",
+				template.Meta.Name, template.ServerVersion)
+		}
+	default:
+		return fmt.Errorf("memtableAndCacheTuning.memtableAllocationType %s is not one of unslabbed_heap_buffers, heap_buffers, offheap_buffers or offheap_objects",
+			tuning.MemtableAllocationType)
+	}
+	sizes := memtableAndCacheSizeSettings(tuning)
+	values := yamlSettings{}
+	for setting, size := range sizes {
+		values.putInt32(setting, size.value)
+	}
+	names := make([]string, 0, 2*len(values)+1)
+	if tuning.MemtableAllocationType != "" {
+		names = append(names, "memtable_allocation_type")
+	}
+	for _, setting := range values.names() {
+		if values[setting].(int64) < int64(sizes[setting].min) {
+			return fmt.Errorf("memtableAndCacheTuning setting %s must be at least %d", setting, sizes[setting].min)
+		}
+		names = append(names, setting)
+		if strings.HasSuffix(setting, "_in_mb") {
+			names = append(names, strings.TrimSuffix(setting, "_in_mb"))
+		}
+	}
+	return checkNotInCassandraYaml(template, "memtableAndCacheTuning", names...)
+}
+
+const (
+	defaultEndpointSnitch = "GossipingPropertyFileSnitch"
+	snitchPackagePrefix   = "org.apache.cassandra.locator."
+)
+
+// EndpointSnitch returns the short name of the snitch used by the DC, taken from its Snitch config or from
+// cassandra.yaml, and defaulting to cass-operator's GossipingPropertyFileSnitch.
+func EndpointSnitch(template *DatacenterConfig) string {
+	snitch := defaultEndpointSnitch
+	if template.Snitch != nil && template.Snitch.EndpointSnitch != "" {
+		snitch = template.Snitch.EndpointSnitch
+	} else if value, found := template.CassandraConfig.CassandraYaml["endpoint_snitch"]; found {
+		snitch = fmt.Sprintf("%v", value)
+	}
+	return strings.TrimPrefix(snitch, snitchPackagePrefix)
+}
+
+// snitchSettings returns the cassandra.yaml settings that correspond to the given snitch config.
+func snitchSettings(snitch *api.SnitchConfig) yamlSettings {
+	settings := make(yamlSettings)
+	if snitch == nil {
+		return settings
+	}
+	if snitch.EndpointSnitch != "" {
+		settings["endpoint_snitch"] = snitch.EndpointSnitch
+	}
+	settings.putBool("dynamic_snitch", snitch.DynamicSnitch)
+	return settings
+}
+
+// ApplySnitch adds the settings of the Snitch config of the DC to cassandra.yaml.
+func ApplySnitch(template *DatacenterConfig) {
+	snitchSettings(template.Snitch).apply(template)
+}
+
+// validateSnitch checks that cassandra.yaml does not configure the snitch when the Snitch config of the DC is set,
+// even the settings the Snitch config leaves unset, since it replaces the snitch configuration as a whole.
+func validateSnitch(template *DatacenterConfig) error {
+	if template.Snitch == nil {
+		return nil
+	}
+	return checkNotInCassandraYaml(template, "snitch", "dynamic_snitch", "endpoint_snitch")
+}
+
+// ValidateSnitchConsistency checks that the snitches of the DCs of a cluster can work together. SimpleSnitch places
+// all the nodes in a single datacenter and cannot be used by multi-datacenter clusters, and PropertyFileSnitch reads
+// the topology of the whole cluster from a file, which the nodes using other snitches do not maintain.
+func ValidateSnitchConsistency(dcConfigs []*DatacenterConfig) error {
+	if len(dcConfigs) < 2 {
+		return nil
+	}
+	propertyFileDcs := make([]string, 0)
+	for _, dcConfig := range dcConfigs {
+		switch EndpointSnitch(dcConfig) {
+		case "SimpleSnitch":
+			return fmt.Errorf("datacenter %s uses SimpleSnitch, which does not support multiple datacenters", dcConfig.Meta.Name)
+		case "PropertyFileSnitch":
+			propertyFileDcs = append(propertyFileDcs, dcConfig.Meta.Name)
+		}
+	}
+	if len(propertyFileDcs) > 0 && len(propertyFileDcs) < len(dcConfigs) {
+		return fmt.Errorf("PropertyFileSnitch must be used by all the datacenters or none, but it is only used by %s",
+			strings.Join(propertyFileDcs, ", "))
+	}
+	return nil
+}
+
+// diskFailurePoliciesSettings returns the cassandra.yaml settings that correspond to the given disk failure policies.
+func diskFailurePoliciesSettings(policies *api.DiskFailurePolicies) yamlSettings {
+	settings := make(yamlSettings)
+	if policies == nil {
+		return settings
+	}
+	if policies.DiskFailurePolicy != "" {
+		settings["disk_failure_policy"] = policies.DiskFailurePolicy
+	}
+	if policies.CommitFailurePolicy != "" {
+		settings["commit_failure_policy"] = policies.CommitFailurePolicy
+	}
+	settings.putInt32("min_free_space_per_drive_in_mb", policies.MinFreeSpacePerDriveInMb)
+	return settings
+}
+
+// ApplyDiskFailurePolicies adds the settings of the DiskFailurePolicies of the DC to cassandra.yaml.
+func ApplyDiskFailurePolicies(template *DatacenterConfig) {
+	diskFailurePoliciesSettings(template.DiskFailurePolicies).apply(template)
+}
+
+// validateDiskFailurePolicies checks that the policies of the DC are supported by Cassandra, and that their settings
+// are not also set in cassandra.yaml.
+func validateDiskFailurePolicies(template *DatacenterConfig) error {
+	policies := template.DiskFailurePolicies
+	if policies == nil {
+		return nil
+	}
+	if policies.DiskFailurePolicy != "" && !utils.SliceContains(api.DiskFailurePolicyValues, policies.DiskFailurePolicy) {
+		return fmt.Errorf("invalid disk failure policy %s, must be one of %s",
+			policies.DiskFailurePolicy, strings.Join(api.DiskFailurePolicyValues, ", "))
+	}
+	if policies.CommitFailurePolicy != "" && !utils.SliceContains(api.CommitFailurePolicyValues, policies.CommitFailurePolicy) {
+		return fmt.Errorf("invalid commit failure policy %s, must be one of %s",
+			policies.CommitFailurePolicy, strings.Join(api.CommitFailurePolicyValues, ", "))
+	}
+	return checkNotInCassandraYaml(template, "diskFailurePolicies", diskFailurePoliciesSettings(policies).names()...)
+}
+
+// requestTimeoutsByName returns the request timeouts of the DC by the name of their cassandra.yaml setting before
+// Cassandra 4.1.
+func requestTimeoutsByName(timeouts *api.RequestTimeouts) map[string]*int32 {
+	return map[string]*int32{
+		"read_request_timeout_in_ms":  timeouts.ReadRequestTimeoutInMs,
+		"write_request_timeout_in_ms": timeouts.WriteRequestTimeoutInMs,
+		"range_request_timeout_in_ms": timeouts.RangeRequestTimeoutInMs,
+	}
+}
+
+// requestTimeoutsSettings returns the cassandra.yaml settings that correspond to the request timeouts of the DC.
+// Cassandra 4.1 and later get the durations under the new names, such as read_request_timeout, the older versions get
+// the milliseconds under the names ending with _in_ms.
+func requestTimeoutsSettings(template *DatacenterConfig) yamlSettings {
+	settings := make(yamlSettings)
+	if template.RequestTimeouts == nil {
+		return settings
+	}
+	for name, value := range requestTimeoutsByName(template.RequestTimeouts) {
+		if value == nil {
+			continue
+		}
+		if usesCassandra41SettingNames(template) {
+			settings[strings.TrimSuffix(name, "_in_ms")] = fmt.Sprintf("%dms", *value)
+		} else {
+			settings[name] = int64(*value)
+		}
+	}
+	return settings
+}
+
+// ApplyRequestTimeouts adds the settings of the RequestTimeouts of the DC to cassandra.yaml.
+func ApplyRequestTimeouts(template *DatacenterConfig) {
+	requestTimeoutsSettings(template).apply(template)
+}
+
+// validateRequestTimeouts checks that the timeouts of the DC are positive, and that they are not also set in
+// cassandra.yaml, either under the names they had before Cassandra 4.1 or under the names they were given in 4.1.
+func validateRequestTimeouts(template *DatacenterConfig) error {
+	if template.RequestTimeouts == nil {
+		return nil
+	}
+	timeouts := yamlSettings{}
+	for name, value := range requestTimeoutsByName(template.RequestTimeouts) {
+		timeouts.putInt32(name, value)
+	}
+	names := make([]string, 0, 2*len(timeouts))
+	for _, setting := range timeouts.names() {
+		if timeouts[setting].(int64) < 1 {
+			return fmt.Errorf("requestTimeouts setting %s must be at least 1", setting)
+		}
+		names = append(names, setting, strings.TrimSuffix(setting, "_in_ms"))
+	}
+	return checkNotInCassandraYaml(template, "requestTimeouts", names...)
+}
+
+// streamingEncryptionSettings returns the cassandra.yaml settings that correspond to the given streaming encryption.
+func streamingEncryptionSettings(streaming *api.StreamingEncryption) yamlSettings {
+	settings := make(yamlSettings)
+	if streaming == nil {
+		return settings
+	}
+	settings.putBool("server_encryption_options/optional", streaming.Optional)
+	settings.putBool("server_encryption_options/enable_legacy_ssl_storage_port", streaming.LegacySslStoragePort)
+	return settings
+}
+
+// ApplyStreamingEncryption adds the settings of the StreamingEncryption of the DC to cassandra.yaml.
+func ApplyStreamingEncryption(template *DatacenterConfig) {
+	streamingEncryptionSettings(template.StreamingEncryption).apply(template)
+}
+
+// validateStreamingEncryption checks that the StreamingEncryption of the DC is consistent with its internode
+// encryption, that its settings are supported by the server version, and that they are not also set in cassandra.yaml.
+// Streams go through the internode connections, so they are only encrypted when internode encryption is enabled.
+func validateStreamingEncryption(template *DatacenterConfig) error {
+	streaming := template.StreamingEncryption
+	if streaming == nil {
+		return nil
+	}
+	settings := streamingEncryptionSettings(streaming)
+	names := settings.names()
+	if len(names) > 0 && (template.ServerType != api.ServerDistributionCassandra ||
+		(template.ServerVersion != nil && template.ServerVersion.LessThan(semver.MustParse("4.0.0")))) {
+		return fmt.Errorf("streamingEncryption setting %s requires Cassandra 4.0.0 or later, but datacenter %s uses %s %s",
+			names[0], template.Meta.Name, template.ServerType, template.ServerVersion)
+	}
+	if err := checkNotInCassandraYaml(template, "streamingEncryption", names...); err != nil {
+		return err
+	}
+	for _, setting := range names {
+		if settings[setting].(bool) && !ServerEncryptionEnabled(template) {
+			return fmt.Errorf("streamingEncryption setting %s requires internode encryption to be enabled in datacenter %s",
+				setting, template.Meta.Name)
+		}
+	}
+	if streaming.Required != nil && *streaming.Required {
+		if streaming.Optional != nil && *streaming.Optional {
+			return fmt.Errorf("streamingEncryption of datacenter %s can not be both required and optional", template.Meta.Name)
+		}
+		internodeEncryption, found := template.CassandraConfig.CassandraYaml.Get("server_encryption_options/internode_encryption")
+		if !found {
+			internodeEncryption = "none"
+		}
+		if internodeEncryption != "all" {
+			return fmt.Errorf("streamingEncryption of datacenter %s is required, but server_encryption_options/internode_encryption is %v instead of all",
+				template.Meta.Name, internodeEncryption)
+		}
+	}
+	return nil
+}
+
+// defaultRackName is the name of the rack that cass-operator creates when a DC does not declare racks.
+const defaultRackName = "default"
+
+// rackDcPropertiesSettings returns the cassandra-rackdc.properties settings that correspond to the given properties.
+func rackDcPropertiesSettings(properties *api.RackDcProperties) map[string]interface{} {
+	settings := make(map[string]interface{})
+	if properties == nil {
+		return settings
+	}
+	if properties.Dc != "" {
+		settings["dc"] = properties.Dc
+	}
+	if properties.Rack != "" {
+		settings["rack"] = properties.Rack
+	}
+	if properties.PreferLocal != nil {
+		settings["prefer_local"] = *properties.PreferLocal
+	}
+	if properties.DcSuffix != "" {
+		settings["dc_suffix"] = properties.DcSuffix
+	}
+	return settings
+}
+
+// validateRackDcProperties checks that the dc and rack properties of the DC match its declared topology. The rack
+// property applies to all the nodes, so it can only be set for DCs that have a single rack.
+func validateRackDcProperties(template *DatacenterConfig) error {
+	properties := template.RackDcProperties
+	if properties == nil {
+		return nil
+	}
+	if properties.Dc != "" && properties.Dc != template.CassDcName() {
+		return fmt.Errorf("rackdc property dc %s does not match the datacenter name %s", properties.Dc, template.CassDcName())
+	}
+	if properties.Rack != "" {
+		switch len(template.Racks) {
+		case 0:
+			if properties.Rack != defaultRackName {
+				return fmt.Errorf("rackdc property rack %s does not match the %s rack of datacenter %s",
+					properties.Rack, defaultRackName, template.Meta.Name)
+			}
+		case 1:
+			if properties.Rack != template.Racks[0].Name {
+				return fmt.Errorf("rackdc property rack %s does not match the %s rack of datacenter %s",
+					properties.Rack, template.Racks[0].Name, template.Meta.Name)
+			}
+		default:
+			return fmt.Errorf("rackdc property rack can not be set because datacenter %s has %d racks",
+				template.Meta.Name, len(template.Racks))
+		}
+	}
+	return nil
+}
+
+// validateCassandraEnvScript performs basic safety checks of the custom cassandra-env.sh fragment of the DC. Since
+// cassandra-env.sh is sourced by the script that starts Cassandra, the fragment must not exit or replace the process,
+// must not run commands through command substitution, and must have balanced quotes so that it does not swallow the
+// rest of the script.
+func validateCassandraEnvScript(template *DatacenterConfig) error {
+	script := template.CassandraEnvScript
+	if script == "" {
+		return nil
+	}
+	for _, r := range script {
+		if r != '\n' && r != '\t' && (r < ' ' || r == 0x7f) {
+			return fmt.Errorf("cassandraEnvScript of datacenter %s contains control character %q", template.Meta.Name, r)
+		}
+	}
+	if strings.Contains(script, "`") || strings.Contains(script, "$(") {
+		return fmt.Errorf("cassandraEnvScript of datacenter %s can not use command substitution", template.Meta.Name)
+	}
+	for _, line := range strings.Split(script, "\n") {
+		for _, command := range strings.FieldsFunc(line, func(r rune) bool { return r == ';' || r == '&' || r == '|' }) {
+			fields := strings.Fields(command)
+			for len(fields) > 0 && utils.SliceContains([]string{"then", "else", "do", "{", "!"}, fields[0]) {
+				fields = fields[1:]
+			}
+			if len(fields) > 0 && (fields[0] == "exit" || fields[0] == "exec") {
+				return fmt.Errorf("cassandraEnvScript of datacenter %s can not use %s", template.Meta.Name, fields[0])
+			}
+		}
+	}
+	if !balancedQuotes(script) {
+		return fmt.Errorf("cassandraEnvScript of datacenter %s has unbalanced quotes", template.Meta.Name)
+	}
+	return nil
+}
+
+// balancedQuotes returns true if every single and double quote of script is closed, taking escaped characters into
+// account outside of single quotes.
+func balancedQuotes(script string) bool {
+	var quote rune
+	escaped := false
+	for _, r := range script {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote == 0 && (r == '\'' || r == '"'):
+			quote = r
+		case r == quote:
+			quote = 0
+		}
+	}
+	return quote == 0
+}
+
 // HandleDeprecatedJvmOptions handles the deprecated settings: HeapSize and HeapNewGenSize by
 // copying their values, if any, to the appropriate destination settings, iif these are nil.
 //
@@ -136,3 +711,100 @@ func EnableSmartTokenAllocation(template *DatacenterConfig) {
 		template.CassandraConfig.CassandraYaml.PutIfAbsent("allocate_tokens_for_local_replication_factor", int64(3))
 	}
 }
+
+const (
+	// maxEffectiveConfigSettings is the maximum number of settings reported in the effective config summary, which is
+	// stored in the status of the K8ssandraCluster along with the ones of the other DCs.
+	maxEffectiveConfigSettings = 200
+	// maxEffectiveConfigValueLength is the length beyond which the values of the effective config summary are
+	// truncated.
+	maxEffectiveConfigValueLength = 256
+	// redactedValue replaces the value of the settings that hold passwords in the effective config summary.
+	redactedValue = "<redacted>"
+)
+
+// EffectiveConfigSummary summarizes the configuration of dc, as rendered in its spec.config field. Each setting is
+// reported under a <file>.<setting> key; the values that are neither strings nor scalars are rendered as JSON. The
+// values of the settings whose name contains "password", at any level, are redacted, and the hash is computed after
+// the redaction, so that neither discloses them. The summary is capped to maxEffectiveConfigSettings settings, in
+// alphabetical order, and its values to maxEffectiveConfigValueLength bytes.
+func EffectiveConfigSummary(dc *cassdcapi.CassandraDatacenter) (*api.EffectiveConfigStatus, error) {
+	if len(dc.Spec.Config) == 0 {
+		return nil, nil
+	}
+	config, err := utils.UnmarshalToMap(dc.Spec.Config)
+	if err != nil {
+		return nil, err
+	}
+	redacted := redactPasswords(config).(map[string]interface{})
+
+	settings := make(map[string]string)
+	for file, content := range redacted {
+		section, ok := content.(map[string]interface{})
+		if !ok {
+			if settings[file], err = settingValue(content); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		for name, value := range section {
+			if settings[file+"."+name], err = settingValue(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	summary := &api.EffectiveConfigStatus{Hash: utils.DeepHashString(redacted), Settings: settings}
+	if len(settings) > maxEffectiveConfigSettings {
+		names := make([]string, 0, len(settings))
+		for name := range settings {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names[maxEffectiveConfigSettings:] {
+			delete(settings, name)
+		}
+		summary.OmittedSettings = int32(len(names) - maxEffectiveConfigSettings)
+	}
+	return summary, nil
+}
+
+// redactPasswords returns a copy of value, a configuration parsed from JSON, where the values of the keys that contain
+// "password" are replaced by redactedValue.
+func redactPasswords(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, nested := range v {
+			if strings.Contains(strings.ToLower(key), "password") {
+				redacted[key] = redactedValue
+			} else {
+				redacted[key] = redactPasswords(nested)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, nested := range v {
+			redacted[i] = redactPasswords(nested)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+func settingValue(value interface{}) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		s = string(b)
+	}
+	if len(s) > maxEffectiveConfigValueLength {
+		s = s[:maxEffectiveConfigValueLength] + "..."
+	}
+	return s, nil
+}
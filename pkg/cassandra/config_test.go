@@ -1,6 +1,9 @@
 package cassandra
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
@@ -9,6 +12,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	"github.com/Jeffail/gabs"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
 	"github.com/stretchr/testify/assert"
@@ -256,7 +260,7 @@ func TestCreateJsonConfig(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			var err error
-			tc.got, err = createJsonConfig(tc.cassandraConfig, tc.serverVersion, tc.serverType)
+			tc.got, err = createJsonConfig(tc.cassandraConfig, tc.serverVersion, tc.serverType, nil)
 			require.NoError(t, err, "failed to create json dcConfig")
 			expected, err := gabs.ParseJSON([]byte(tc.want))
 			require.NoError(t, err, "failed to parse expected value")
@@ -305,3 +309,643 @@ func TestSmartTokenAllocCassandra(t *testing.T) {
 	_, exists := dcConfig.CassandraConfig.CassandraYaml["allocate_tokens_for_local_replication_factor"]
 	assert.False(t, exists, "allocate_tokens_for_local_replication_factor should not be set for Cassandra")
 }
+
+func TestApplyHintsTuning(t *testing.T) {
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{HintsTuning: &api.HintsTuning{
+			MaxHintWindowInMs: pointer.Int32(3600000),
+		}},
+	}
+	clusterTemplate := &api.CassandraClusterTemplate{
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion: "4.0.6",
+			HintsTuning: &api.HintsTuning{
+				HintedHandoffThrottleInKb: pointer.Int32(2048),
+				MaxHintWindowInMs:         pointer.Int32(10800000),
+			},
+			CassandraConfig: &api.CassandraConfig{
+				CassandraYaml: unstructured.Unstructured{"concurrent_reads": int64(32)},
+			},
+		},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateHintsTuning(dcConfig))
+	ApplyHintsTuning(dcConfig)
+
+	assert.Equal(t, unstructured.Unstructured{
+		"concurrent_reads":              int64(32),
+		"hinted_handoff_throttle_in_kb": int64(2048),
+		"max_hint_window_in_ms":         int64(3600000),
+	}, dcConfig.CassandraConfig.CassandraYaml)
+
+	clusterTemplate.ServerType = api.ServerDistributionCassandra
+	clusterTemplate.ServerVersion = "4.1.0"
+	dcConfig = Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateHintsTuning(dcConfig))
+	ApplyHintsTuning(dcConfig)
+
+	assert.Equal(t, unstructured.Unstructured{
+		"concurrent_reads":        int64(32),
+		"hinted_handoff_throttle": "2048KiB",
+		"max_hint_window":         "3600000ms",
+	}, dcConfig.CassandraConfig.CassandraYaml)
+}
+
+func TestValidateHintsTuning(t *testing.T) {
+	dcConfig := &DatacenterConfig{
+		HintsTuning: &api.HintsTuning{MaxHintsDeliveryThreads: pointer.Int32(4)},
+		CassandraConfig: api.CassandraConfig{
+			CassandraYaml: unstructured.Unstructured{"max_hints_delivery_threads": int64(2)},
+		},
+	}
+	assert.EqualError(t, validateHintsTuning(dcConfig), "cassandra.yaml setting max_hints_delivery_threads can not be set when it is also set in hintsTuning")
+
+	dcConfig.HintsTuning = &api.HintsTuning{HintedHandoffThrottleInKb: pointer.Int32(1024)}
+	assert.NoError(t, validateHintsTuning(dcConfig))
+
+	// the new name of a setting conflicts with the tuning before Cassandra 4.1 too, and the old name after
+	dcConfig.HintsTuning = &api.HintsTuning{MaxHintWindowInMs: pointer.Int32(3600000)}
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"max_hint_window": "1h"}
+	assert.EqualError(t, validateHintsTuning(dcConfig), "cassandra.yaml setting max_hint_window can not be set when it is also set in hintsTuning")
+
+	dcConfig.ServerType = api.ServerDistributionCassandra
+	dcConfig.ServerVersion = semver.MustParse("4.1.0")
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"max_hint_window_in_ms": int64(60000)}
+	assert.EqualError(t, validateHintsTuning(dcConfig), "cassandra.yaml setting max_hint_window_in_ms can not be set when it is also set in hintsTuning")
+}
+
+func TestApplyMaterializedViews(t *testing.T) {
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{MaterializedViews: &api.MaterializedViewsTuning{
+			Enabled:            pointer.Bool(true),
+			ConcurrentWrites:   pointer.Int32(64),
+			ConcurrentBuilders: pointer.Int32(2),
+		}},
+	}
+	clusterTemplate := &api.CassandraClusterTemplate{
+		ServerType: api.ServerDistributionCassandra,
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion:     "4.0.6",
+			MaterializedViews: &api.MaterializedViewsTuning{ConcurrentWrites: pointer.Int32(32)},
+			CassandraConfig: &api.CassandraConfig{
+				CassandraYaml: unstructured.Unstructured{"concurrent_reads": int64(32)},
+			},
+		},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateMaterializedViews(dcConfig))
+	ApplyMaterializedViews(dcConfig)
+
+	assert.Equal(t, unstructured.Unstructured{
+		"concurrent_reads":                      int64(32),
+		"enable_materialized_views":             true,
+		"concurrent_materialized_view_writes":   int64(64),
+		"concurrent_materialized_view_builders": int64(2),
+	}, dcConfig.CassandraConfig.CassandraYaml)
+
+	clusterTemplate.ServerVersion = "4.1.0"
+	dcConfig = Coalesce("cluster1", clusterTemplate, dcTemplate)
+	ApplyMaterializedViews(dcConfig)
+	assert.Equal(t, true, dcConfig.CassandraConfig.CassandraYaml["materialized_views_enabled"])
+	assert.NotContains(t, dcConfig.CassandraConfig.CassandraYaml, "enable_materialized_views")
+}
+
+func TestValidateMaterializedViews(t *testing.T) {
+	dcConfig := &DatacenterConfig{
+		Meta:              api.EmbeddedObjectMeta{Name: "dc1"},
+		ServerType:        api.ServerDistributionCassandra,
+		ServerVersion:     semver.MustParse("4.1.0"),
+		MaterializedViews: &api.MaterializedViewsTuning{Enabled: pointer.Bool(true)},
+		CassandraConfig: api.CassandraConfig{
+			CassandraYaml: unstructured.Unstructured{"enable_materialized_views": false},
+		},
+	}
+	assert.EqualError(t, validateMaterializedViews(dcConfig), "cassandra.yaml setting enable_materialized_views can not be set when it is also set in materializedViews")
+
+	dcConfig.MaterializedViews = &api.MaterializedViewsTuning{ConcurrentWrites: pointer.Int32(16)}
+	assert.NoError(t, validateMaterializedViews(dcConfig))
+
+	dcConfig.ServerVersion = semver.MustParse("3.11.14")
+	dcConfig.MaterializedViews = &api.MaterializedViewsTuning{ConcurrentBuilders: pointer.Int32(2)}
+	assert.EqualError(t, validateMaterializedViews(dcConfig), "materializedViews.concurrentBuilders requires Cassandra 4.0.0 or later, but datacenter dc1 uses version 3.11.14")
+}
+
+func TestApplyMemtableAndCacheTuning(t *testing.T) {
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{MemtableAndCacheTuning: &api.MemtableAndCacheTuning{
+			MemtableAllocationType:   "offheap_objects",
+			MemtableOffheapSpaceInMb: pointer.Int32(4096),
+			RowCacheSizeInMb:         pointer.Int32(0),
+		}},
+	}
+	clusterTemplate := &api.CassandraClusterTemplate{
+		ServerType: api.ServerDistributionCassandra,
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion: "4.0.6",
+			MemtableAndCacheTuning: &api.MemtableAndCacheTuning{
+				MemtableAllocationType: "heap_buffers",
+				KeyCacheSizeInMb:       pointer.Int32(256),
+				FileCacheSizeInMb:      pointer.Int32(1024),
+			},
+			CassandraConfig: &api.CassandraConfig{
+				CassandraYaml: unstructured.Unstructured{"concurrent_reads": int64(32)},
+			},
+		},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateMemtableAndCacheTuning(dcConfig))
+	ApplyMemtableAndCacheTuning(dcConfig)
+
+	assert.Equal(t, unstructured.Unstructured{
+		"concurrent_reads":             int64(32),
+		"memtable_allocation_type":     "offheap_objects",
+		"memtable_offheap_space_in_mb": int64(4096),
+		"key_cache_size_in_mb":         int64(256),
+		"file_cache_size_in_mb":        int64(1024),
+		"row_cache_size_in_mb":         int64(0),
+	}, dcConfig.CassandraConfig.CassandraYaml)
+
+	clusterTemplate.ServerVersion = "4.1.0"
+	dcConfig = Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateMemtableAndCacheTuning(dcConfig))
+	ApplyMemtableAndCacheTuning(dcConfig)
+
+	assert.Equal(t, unstructured.Unstructured{
+		"concurrent_reads":         int64(32),
+		"memtable_allocation_type": "offheap_objects",
+		"memtable_offheap_space":   "4096MiB",
+		"key_cache_size":           "256MiB",
+		"file_cache_size":          "1024MiB",
+		"row_cache_size":           "0MiB",
+	}, dcConfig.CassandraConfig.CassandraYaml)
+}
+
+func TestValidateMemtableAndCacheTuning(t *testing.T) {
+	dcConfig := &DatacenterConfig{
+		Meta:                   api.EmbeddedObjectMeta{Name: "dc1"},
+		ServerType:             api.ServerDistributionCassandra,
+		ServerVersion:          semver.MustParse("4.0.6"),
+		MemtableAndCacheTuning: &api.MemtableAndCacheTuning{KeyCacheSizeInMb: pointer.Int32(128)},
+		CassandraConfig: api.CassandraConfig{
+			CassandraYaml: unstructured.Unstructured{"key_cache_size_in_mb": int64(64)},
+		},
+	}
+	assert.EqualError(t, validateMemtableAndCacheTuning(dcConfig), "cassandra.yaml setting key_cache_size_in_mb can not be set when it is also set in memtableAndCacheTuning")
+
+	// the new name of a setting conflicts with the tuning before Cassandra 4.1 too, and the old name after
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"key_cache_size": "64MiB"}
+	assert.EqualError(t, validateMemtableAndCacheTuning(dcConfig), "cassandra.yaml setting key_cache_size can not be set when it is also set in memtableAndCacheTuning")
+	dcConfig.ServerVersion = semver.MustParse("4.1.0")
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"key_cache_size_in_mb": int64(64)}
+	assert.EqualError(t, validateMemtableAndCacheTuning(dcConfig), "cassandra.yaml setting key_cache_size_in_mb can not be set when it is also set in memtableAndCacheTuning")
+	dcConfig.ServerVersion = semver.MustParse("4.0.6")
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"key_cache_size_in_mb": int64(64)}
+
+	dcConfig.MemtableAndCacheTuning = &api.MemtableAndCacheTuning{MemtableAllocationType: "unslabbed_heap_buffers", RowCacheSizeInMb: pointer.Int32(512)}
+	assert.NoError(t, validateMemtableAndCacheTuning(dcConfig))
+
+	dcConfig.MemtableAndCacheTuning = &api.MemtableAndCacheTuning{MemtableAllocationType: "offheap"}
+	assert.EqualError(t, validateMemtableAndCacheTuning(dcConfig), "memtableAndCacheTuning.memtableAllocationType offheap is not one of unslabbed_heap_buffers, heap_buffers, offheap_buffers or offheap_objects")
+
+	dcConfig.MemtableAndCacheTuning = &api.MemtableAndCacheTuning{MemtableFlushWriters: pointer.Int32(0)}
+	assert.EqualError(t, validateMemtableAndCacheTuning(dcConfig), "memtableAndCacheTuning setting memtable_flush_writers must be at least 1")
+
+	dcConfig.MemtableAndCacheTuning = &api.MemtableAndCacheTuning{FileCacheSizeInMb: pointer.Int32(-1)}
+	assert.EqualError(t, validateMemtableAndCacheTuning(dcConfig), "memtableAndCacheTuning setting file_cache_size_in_mb must be at least 0")
+
+	dcConfig.ServerVersion = semver.MustParse("3.11.14")
+	dcConfig.MemtableAndCacheTuning = &api.MemtableAndCacheTuning{MemtableAllocationType: "unslabbed_heap_buffers"}
+	assert.EqualError(t, validateMemtableAndCacheTuning(dcConfig), "memtableAndCacheTuning.memtableAllocationType unslabbed_heap_buffers requires Cassandra 4.0.0 or later, but datacenter dc1 uses version 3.11.14")
+}
+
+func TestApplySnitch(t *testing.T) {
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{Snitch: &api.SnitchConfig{
+			EndpointSnitch: "Ec2MultiRegionSnitch",
+		}},
+	}
+	clusterTemplate := &api.CassandraClusterTemplate{
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion: "4.0.6",
+			Snitch: &api.SnitchConfig{
+				EndpointSnitch: "GossipingPropertyFileSnitch",
+				DynamicSnitch:  pointer.Bool(false),
+			},
+		},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateSnitch(dcConfig))
+	ApplySnitch(dcConfig)
+
+	assert.Equal(t, unstructured.Unstructured{
+		"endpoint_snitch": "Ec2MultiRegionSnitch",
+		"dynamic_snitch":  false,
+	}, dcConfig.CassandraConfig.CassandraYaml)
+	assert.Equal(t, "Ec2MultiRegionSnitch", EndpointSnitch(dcConfig))
+}
+
+func TestValidateSnitch(t *testing.T) {
+	dcConfig := &DatacenterConfig{
+		Snitch: &api.SnitchConfig{EndpointSnitch: "GossipingPropertyFileSnitch"},
+		CassandraConfig: api.CassandraConfig{
+			CassandraYaml: unstructured.Unstructured{"endpoint_snitch": "SimpleSnitch"},
+		},
+	}
+	assert.EqualError(t, validateSnitch(dcConfig), "cassandra.yaml setting endpoint_snitch can not be set when it is also set in snitch")
+
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"concurrent_reads": int64(32)}
+	assert.NoError(t, validateSnitch(dcConfig))
+}
+
+func TestApplyDiskFailurePolicies(t *testing.T) {
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{DiskFailurePolicies: &api.DiskFailurePolicies{
+			DiskFailurePolicy: "best_effort",
+		}},
+	}
+	clusterTemplate := &api.CassandraClusterTemplate{
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion: "4.0.6",
+			DiskFailurePolicies: &api.DiskFailurePolicies{
+				DiskFailurePolicy:        "stop",
+				CommitFailurePolicy:      "stop_commit",
+				MinFreeSpacePerDriveInMb: pointer.Int32(100),
+			},
+		},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateDiskFailurePolicies(dcConfig))
+	ApplyDiskFailurePolicies(dcConfig)
+
+	assert.Equal(t, unstructured.Unstructured{
+		"disk_failure_policy":            "best_effort",
+		"commit_failure_policy":          "stop_commit",
+		"min_free_space_per_drive_in_mb": int64(100),
+	}, dcConfig.CassandraConfig.CassandraYaml)
+}
+
+func TestValidateDiskFailurePolicies(t *testing.T) {
+	dcConfig := &DatacenterConfig{
+		DiskFailurePolicies: &api.DiskFailurePolicies{DiskFailurePolicy: "halt"},
+	}
+	assert.EqualError(t, validateDiskFailurePolicies(dcConfig),
+		"invalid disk failure policy halt, must be one of die, stop_paranoid, stop, best_effort, ignore")
+
+	dcConfig.DiskFailurePolicies = &api.DiskFailurePolicies{CommitFailurePolicy: "best_effort"}
+	assert.EqualError(t, validateDiskFailurePolicies(dcConfig),
+		"invalid commit failure policy best_effort, must be one of die, stop, stop_commit, ignore")
+
+	dcConfig.DiskFailurePolicies = &api.DiskFailurePolicies{CommitFailurePolicy: "die"}
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"commit_failure_policy": "stop"}
+	assert.EqualError(t, validateDiskFailurePolicies(dcConfig),
+		"cassandra.yaml setting commit_failure_policy can not be set when it is also set in diskFailurePolicies")
+
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"disk_failure_policy": "stop"}
+	assert.NoError(t, validateDiskFailurePolicies(dcConfig))
+}
+
+func TestApplyRequestTimeouts(t *testing.T) {
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{RequestTimeouts: &api.RequestTimeouts{
+			ReadRequestTimeoutInMs: pointer.Int32(10000),
+		}},
+	}
+	clusterTemplate := &api.CassandraClusterTemplate{
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion: "4.0.6",
+			RequestTimeouts: &api.RequestTimeouts{
+				ReadRequestTimeoutInMs:  pointer.Int32(5000),
+				WriteRequestTimeoutInMs: pointer.Int32(2000),
+			},
+			CassandraConfig: &api.CassandraConfig{
+				CassandraYaml: unstructured.Unstructured{"concurrent_reads": int64(32)},
+			},
+		},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateRequestTimeouts(dcConfig))
+	ApplyRequestTimeouts(dcConfig)
+
+	assert.Equal(t, unstructured.Unstructured{
+		"concurrent_reads":            int64(32),
+		"read_request_timeout_in_ms":  int64(10000),
+		"write_request_timeout_in_ms": int64(2000),
+	}, dcConfig.CassandraConfig.CassandraYaml)
+
+	config, err := createJsonConfig(dcConfig.CassandraConfig, dcConfig.ServerVersion, dcConfig.ServerType, nil)
+	require.NoError(t, err)
+	parsed, err := gabs.ParseJSON(config)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"concurrent_reads":32,"read_request_timeout_in_ms":10000,"write_request_timeout_in_ms":2000}`,
+		parsed.Path("cassandra-yaml").String())
+
+	// Cassandra 4.1 deprecated the settings ending with _in_ms in favor of durations
+	clusterTemplate.ServerVersion = "4.1.0"
+	clusterTemplate.CassandraConfig = nil
+	dcConfig = Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateRequestTimeouts(dcConfig))
+	ApplyRequestTimeouts(dcConfig)
+
+	assert.Equal(t, unstructured.Unstructured{
+		"read_request_timeout":  "10000ms",
+		"write_request_timeout": "2000ms",
+	}, dcConfig.CassandraConfig.CassandraYaml)
+}
+
+func TestValidateRequestTimeouts(t *testing.T) {
+	dcConfig := &DatacenterConfig{
+		RequestTimeouts: &api.RequestTimeouts{RangeRequestTimeoutInMs: pointer.Int32(0)},
+	}
+	assert.EqualError(t, validateRequestTimeouts(dcConfig), "requestTimeouts setting range_request_timeout_in_ms must be at least 1")
+
+	dcConfig.RequestTimeouts = &api.RequestTimeouts{WriteRequestTimeoutInMs: pointer.Int32(5000)}
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"write_request_timeout_in_ms": int64(2000)}
+	assert.EqualError(t, validateRequestTimeouts(dcConfig),
+		"cassandra.yaml setting write_request_timeout_in_ms can not be set when it is also set in requestTimeouts")
+
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"write_request_timeout": "2000ms"}
+	assert.EqualError(t, validateRequestTimeouts(dcConfig),
+		"cassandra.yaml setting write_request_timeout can not be set when it is also set in requestTimeouts")
+
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"read_request_timeout_in_ms": int64(2000)}
+	assert.NoError(t, validateRequestTimeouts(dcConfig))
+}
+
+func TestApplyStreamingEncryption(t *testing.T) {
+	clusterTemplate := &api.CassandraClusterTemplate{
+		ServerType: api.ServerDistributionCassandra,
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion: "4.0.6",
+			CassandraConfig: &api.CassandraConfig{
+				CassandraYaml: unstructured.Unstructured{
+					"server_encryption_options": map[string]interface{}{"internode_encryption": "all"},
+				},
+			},
+			StreamingEncryption: &api.StreamingEncryption{Required: pointer.Bool(true)},
+		},
+	}
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{StreamingEncryption: &api.StreamingEncryption{
+			Required:             pointer.Bool(false),
+			Optional:             pointer.Bool(true),
+			LegacySslStoragePort: pointer.Bool(true),
+		}},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, validateStreamingEncryption(dcConfig))
+	ApplyStreamingEncryption(dcConfig)
+
+	config, err := createJsonConfig(dcConfig.CassandraConfig, dcConfig.ServerVersion, dcConfig.ServerType, nil)
+	require.NoError(t, err)
+	parsed, err := gabs.ParseJSON(config)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"internode_encryption":"all","optional":true,"enable_legacy_ssl_storage_port":true}`,
+		parsed.Path("cassandra-yaml.server_encryption_options").String())
+}
+
+func TestValidateStreamingEncryption(t *testing.T) {
+	newDcConfig := func(internodeEncryption string, streaming *api.StreamingEncryption) *DatacenterConfig {
+		dcConfig := &DatacenterConfig{
+			Meta:                api.EmbeddedObjectMeta{Name: "dc1"},
+			ServerType:          api.ServerDistributionCassandra,
+			ServerVersion:       semver.MustParse("4.0.6"),
+			StreamingEncryption: streaming,
+		}
+		if internodeEncryption != "" {
+			dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{
+				"server_encryption_options": map[string]interface{}{"internode_encryption": internodeEncryption},
+			}
+		}
+		return dcConfig
+	}
+
+	assert.NoError(t, validateStreamingEncryption(newDcConfig("", nil)))
+	assert.NoError(t, validateStreamingEncryption(newDcConfig("all", &api.StreamingEncryption{Required: pointer.Bool(true)})))
+	assert.NoError(t, validateStreamingEncryption(newDcConfig("", &api.StreamingEncryption{Optional: pointer.Bool(false)})))
+
+	assert.EqualError(t, validateStreamingEncryption(newDcConfig("dc", &api.StreamingEncryption{Required: pointer.Bool(true)})),
+		"streamingEncryption of datacenter dc1 is required, but server_encryption_options/internode_encryption is dc instead of all")
+	assert.EqualError(t, validateStreamingEncryption(newDcConfig("", &api.StreamingEncryption{Required: pointer.Bool(true)})),
+		"streamingEncryption of datacenter dc1 is required, but server_encryption_options/internode_encryption is none instead of all")
+	assert.EqualError(t, validateStreamingEncryption(newDcConfig("all", &api.StreamingEncryption{Required: pointer.Bool(true), Optional: pointer.Bool(true)})),
+		"streamingEncryption of datacenter dc1 can not be both required and optional")
+	assert.EqualError(t, validateStreamingEncryption(newDcConfig("none", &api.StreamingEncryption{Optional: pointer.Bool(true)})),
+		"streamingEncryption setting server_encryption_options/optional requires internode encryption to be enabled in datacenter dc1")
+
+	dcConfig := newDcConfig("all", &api.StreamingEncryption{LegacySslStoragePort: pointer.Bool(true)})
+	dcConfig.CassandraConfig.CassandraYaml.Put("server_encryption_options/enable_legacy_ssl_storage_port", false)
+	assert.EqualError(t, validateStreamingEncryption(dcConfig),
+		"cassandra.yaml setting server_encryption_options/enable_legacy_ssl_storage_port can not be set when it is also set in streamingEncryption")
+
+	dcConfig = newDcConfig("all", &api.StreamingEncryption{Optional: pointer.Bool(true)})
+	dcConfig.ServerVersion = semver.MustParse("3.11.14")
+	assert.EqualError(t, validateStreamingEncryption(dcConfig),
+		"streamingEncryption setting server_encryption_options/optional requires Cassandra 4.0.0 or later, but datacenter dc1 uses cassandra 3.11.14")
+}
+
+func TestCreateJsonConfigRackDcProperties(t *testing.T) {
+	dcConfig := &DatacenterConfig{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		RackDcProperties: &api.RackDcProperties{
+			Dc:          "dc1",
+			Rack:        "rack1",
+			PreferLocal: pointer.Bool(true),
+		},
+		Racks: []cassdcapi.Rack{{Name: "rack1"}},
+	}
+	require.NoError(t, validateRackDcProperties(dcConfig))
+
+	rawConfig, err := createJsonConfig(api.CassandraConfig{}, semver.MustParse("4.0.6"), api.ServerDistributionCassandra,
+		rackDcPropertiesSettings(dcConfig.RackDcProperties))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"cassandra-rackdc-properties": {"dc": "dc1", "rack": "rack1", "prefer_local": true}}`, string(rawConfig))
+}
+
+func TestValidateCassandraEnvScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{"empty", "", ""},
+		{"agent", "if [ -f /opt/agent/agent.jar ]; then\n  JVM_OPTS=\"$JVM_OPTS -javaagent:/opt/agent/agent.jar\"\nfi\n", ""},
+		{"escaped quote", "MESSAGE=\"it\\\"s\"\nNAME='a \"b'", ""},
+		{"control character", "JVM_OPTS=\"$JVM_OPTS\"\r\n", `cassandraEnvScript of datacenter dc1 contains control character '\r'`},
+		{"backticks", "HOST=`hostname`", "cassandraEnvScript of datacenter dc1 can not use command substitution"},
+		{"command substitution", "HOST=$(hostname)", "cassandraEnvScript of datacenter dc1 can not use command substitution"},
+		{"exit", "JVM_OPTS=\"$JVM_OPTS\"; exit 0", "cassandraEnvScript of datacenter dc1 can not use exit"},
+		{"conditional exit", "if [ -z \"$AGENT\" ]; then exit 1; fi", "cassandraEnvScript of datacenter dc1 can not use exit"},
+		{"exec", "exec /bin/sh", "cassandraEnvScript of datacenter dc1 can not use exec"},
+		{"unbalanced quotes", "JVM_OPTS=\"$JVM_OPTS -Dfoo=bar", "cassandraEnvScript of datacenter dc1 has unbalanced quotes"},
+		{"executor variable", "EXECUTOR_THREADS=4", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dcConfig := &DatacenterConfig{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, CassandraEnvScript: tt.script}
+			err := validateCassandraEnvScript(dcConfig)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRackDcProperties(t *testing.T) {
+	dcConfig := &DatacenterConfig{
+		Meta:             api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterName:   "DC 1",
+		RackDcProperties: &api.RackDcProperties{Dc: "dc1"},
+	}
+	assert.EqualError(t, validateRackDcProperties(dcConfig), "rackdc property dc dc1 does not match the datacenter name DC 1")
+
+	dcConfig.RackDcProperties = &api.RackDcProperties{Dc: "DC 1", Rack: "default", DcSuffix: "_east"}
+	assert.NoError(t, validateRackDcProperties(dcConfig))
+
+	dcConfig.RackDcProperties = &api.RackDcProperties{Rack: "rack1"}
+	assert.EqualError(t, validateRackDcProperties(dcConfig), "rackdc property rack rack1 does not match the default rack of datacenter dc1")
+
+	dcConfig.Racks = []cassdcapi.Rack{{Name: "rack1"}, {Name: "rack2"}}
+	assert.EqualError(t, validateRackDcProperties(dcConfig), "rackdc property rack can not be set because datacenter dc1 has 2 racks")
+
+	dcConfig.RackDcProperties = &api.RackDcProperties{PreferLocal: pointer.Bool(true)}
+	assert.NoError(t, validateRackDcProperties(dcConfig))
+}
+
+func TestValidateSnitchConsistency(t *testing.T) {
+	newDcConfig := func(name, snitch string) *DatacenterConfig {
+		dcConfig := &DatacenterConfig{Meta: api.EmbeddedObjectMeta{Name: name}}
+		if snitch != "" {
+			dcConfig.Snitch = &api.SnitchConfig{EndpointSnitch: snitch}
+		}
+		return dcConfig
+	}
+
+	assert.NoError(t, ValidateSnitchConsistency([]*DatacenterConfig{newDcConfig("dc1", "SimpleSnitch")}))
+	assert.NoError(t, ValidateSnitchConsistency([]*DatacenterConfig{
+		newDcConfig("dc1", ""),
+		newDcConfig("dc2", "org.apache.cassandra.locator.Ec2MultiRegionSnitch"),
+	}))
+	assert.NoError(t, ValidateSnitchConsistency([]*DatacenterConfig{
+		newDcConfig("dc1", "PropertyFileSnitch"),
+		newDcConfig("dc2", "org.apache.cassandra.locator.PropertyFileSnitch"),
+	}))
+
+	assert.EqualError(t, ValidateSnitchConsistency([]*DatacenterConfig{
+		newDcConfig("dc1", ""),
+		newDcConfig("dc2", "SimpleSnitch"),
+	}), "datacenter dc2 uses SimpleSnitch, which does not support multiple datacenters")
+	assert.EqualError(t, ValidateSnitchConsistency([]*DatacenterConfig{
+		newDcConfig("dc1", "PropertyFileSnitch"),
+		newDcConfig("dc2", "GossipingPropertyFileSnitch"),
+	}), "PropertyFileSnitch must be used by all the datacenters or none, but it is only used by dc1")
+}
+
+func TestEffectiveConfigSummary(t *testing.T) {
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{
+			CassandraConfig: &api.CassandraConfig{
+				CassandraYaml: unstructured.Unstructured{"concurrent_reads": int64(64)},
+			},
+			DiskFailurePolicies: &api.DiskFailurePolicies{DiskFailurePolicy: "best_effort"},
+		},
+	}
+	clusterTemplate := &api.CassandraClusterTemplate{
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion: "4.0.6",
+			CassandraConfig: &api.CassandraConfig{
+				CassandraYaml: unstructured.Unstructured{
+					"concurrent_reads":             int64(32),
+					"concurrent_writes":            int64(16),
+					"client_encryption_options":    map[string]interface{}{"enabled": false},
+					"auto_snapshot":                true,
+					"allocate_tokens_for_keyspace": "ks1",
+				},
+			},
+		},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	ApplyDiskFailurePolicies(dcConfig)
+	config, err := createJsonConfig(dcConfig.CassandraConfig, dcConfig.ServerVersion, dcConfig.ServerType, nil)
+	require.NoError(t, err)
+	dc := &cassdcapi.CassandraDatacenter{Spec: cassdcapi.CassandraDatacenterSpec{Config: config}}
+
+	summary, err := EffectiveConfigSummary(dc)
+	require.NoError(t, err)
+	require.NotNil(t, summary)
+	assert.NotEmpty(t, summary.Hash)
+	assert.Equal(t, map[string]string{
+		"cassandra-yaml.concurrent_reads":             "64",
+		"cassandra-yaml.concurrent_writes":            "16",
+		"cassandra-yaml.client_encryption_options":    `{"enabled":false}`,
+		"cassandra-yaml.auto_snapshot":                "true",
+		"cassandra-yaml.allocate_tokens_for_keyspace": "ks1",
+		"cassandra-yaml.disk_failure_policy":          "best_effort",
+	}, summary.Settings)
+
+	// the hash follows the settings
+	dcConfig.CassandraConfig.CassandraYaml["concurrent_writes"] = int64(32)
+	config, err = createJsonConfig(dcConfig.CassandraConfig, dcConfig.ServerVersion, dcConfig.ServerType, nil)
+	require.NoError(t, err)
+	dc.Spec.Config = config
+	updated, err := EffectiveConfigSummary(dc)
+	require.NoError(t, err)
+	assert.NotEqual(t, summary.Hash, updated.Hash)
+	assert.Equal(t, "32", updated.Settings["cassandra-yaml.concurrent_writes"])
+
+	summary, err = EffectiveConfigSummary(&cassdcapi.CassandraDatacenter{})
+	require.NoError(t, err)
+	assert.Nil(t, summary)
+}
+
+func TestEffectiveConfigSummaryRedactsPasswords(t *testing.T) {
+	newDc := func(password string) *cassdcapi.CassandraDatacenter {
+		config := fmt.Sprintf(`{"cassandra-yaml":{"server_encryption_options":{"internode_encryption":"all","keystore_password":%q},"ldap_password":%q}}`, password, password)
+		return &cassdcapi.CassandraDatacenter{Spec: cassdcapi.CassandraDatacenterSpec{Config: []byte(config)}}
+	}
+	summary, err := EffectiveConfigSummary(newDc("secret1"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"cassandra-yaml.server_encryption_options": `{"internode_encryption":"all","keystore_password":"<redacted>"}`,
+		"cassandra-yaml.ldap_password":             "<redacted>",
+	}, summary.Settings)
+
+	rotated, err := EffectiveConfigSummary(newDc("secret2"))
+	require.NoError(t, err)
+	assert.Equal(t, summary.Hash, rotated.Hash, "the hash does not depend on the passwords")
+}
+
+func TestEffectiveConfigSummaryIsCapped(t *testing.T) {
+	yaml := make(map[string]interface{})
+	for i := 0; i < maxEffectiveConfigSettings+10; i++ {
+		yaml[fmt.Sprintf("setting_%03d", i)] = i
+	}
+	yaml["setting_000"] = strings.Repeat("x", maxEffectiveConfigValueLength+1)
+	config, err := json.Marshal(map[string]interface{}{"cassandra-yaml": yaml})
+	require.NoError(t, err)
+
+	summary, err := EffectiveConfigSummary(&cassdcapi.CassandraDatacenter{Spec: cassdcapi.CassandraDatacenterSpec{Config: config}})
+	require.NoError(t, err)
+	assert.Len(t, summary.Settings, maxEffectiveConfigSettings)
+	assert.Equal(t, int32(10), summary.OmittedSettings)
+	assert.Contains(t, summary.Settings, fmt.Sprintf("cassandra-yaml.setting_%03d", maxEffectiveConfigSettings-1))
+	assert.NotContains(t, summary.Settings, fmt.Sprintf("cassandra-yaml.setting_%03d", maxEffectiveConfigSettings))
+	assert.Equal(t, strings.Repeat("x", maxEffectiveConfigValueLength)+"...", summary.Settings["cassandra-yaml.setting_000"])
+}
@@ -1,12 +1,20 @@
 package cassandra
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 
 	"github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
 	"github.com/k8ssandra/cass-operator/pkg/reconciliation"
 	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/encryption"
@@ -18,6 +26,9 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/pointer"
 )
 
 // SystemReplication represents the replication factor of the system_auth, system_traces,
@@ -82,6 +93,7 @@ type DatacenterConfig struct {
 	Cluster                   string
 	SuperuserSecretRef        corev1.LocalObjectReference
 	ServerImage               string
+	ConfigBuilderImage        string
 	ServerVersion             *semver.Version
 	ServerType                api.ServerDistribution
 	JmxInitContainerImage     *images.Image
@@ -92,6 +104,7 @@ type DatacenterConfig struct {
 	Racks                     []cassdcapi.Rack
 	CassandraConfig           api.CassandraConfig
 	AdditionalSeeds           []string
+	SeedServiceRef            *corev1.LocalObjectReference
 	Networking                *cassdcapi.NetworkingConfig
 	Users                     []cassdcapi.CassandraUser
 	PodTemplateSpec           corev1.PodTemplateSpec
@@ -113,6 +126,31 @@ type DatacenterConfig struct {
 	ExternalSecrets           bool
 	McacEnabled               bool
 	DatacenterName            string
+	CanaryUpgrade             *api.CanaryUpgradeConfig
+	StartupTimeouts           *api.StartupTimeouts
+	HintsTuning               *api.HintsTuning
+	ExtraEnvVars              []corev1.EnvVar
+	PvcRetentionPolicy        api.PvcRetentionPolicy
+	Snitch                    *api.SnitchConfig
+	DiskFailurePolicies       *api.DiskFailurePolicies
+	RackDcProperties          *api.RackDcProperties
+	AddressStrategy           api.AddressStrategy
+	ClientAddressStrategy     api.ClientAddressStrategy
+	ClientAddress             string
+	ManagementApiTimeouts     *api.ManagementApiTimeouts
+	NetworkPolicy             *api.NetworkPolicyConfig
+	DnsConfig                 *corev1.PodDNSConfig
+	PodHostname               *api.PodHostname
+	ReadinessPolicy           *api.ReadinessPolicy
+	MaterializedViews         *api.MaterializedViewsTuning
+	MemtableAndCacheTuning    *api.MemtableAndCacheTuning
+	CassandraEnvScript        string
+	StartupProbe              *api.StartupProbe
+	DedicatedNodes            *api.DedicatedNodes
+	RequestTimeouts           *api.RequestTimeouts
+	StreamingEncryption       *api.StreamingEncryption
+	AuditLogging              *api.AuditLogging
+	ChangeDataCapture         *api.ChangeDataCapture
 
 	// InitialTokensByPodName is a list of initial tokens for the RF first pods in the cluster. It
 	// is only populated when num_tokens < 16 in the whole cluster. Used for generating default
@@ -133,12 +171,30 @@ func (in *DatacenterConfig) CassDcName() string {
 const (
 	mgmtApiHeapSizeEnvVar = "MANAGEMENT_API_HEAP_SIZE"
 	mcacDisabledEnvVar    = "MGMT_API_DISABLE_MCAC"
+	metricFiltersEnvVar   = "METRIC_FILTERS"
 )
 
+// managedEnvVars are the environment variables of the cassandra container that are set by k8ssandra-operator or
+// cass-operator, and that cannot be overridden with ExtraEnvVars.
+var managedEnvVars = []string{
+	mgmtApiHeapSizeEnvVar,
+	mcacDisabledEnvVar,
+	metricFiltersEnvVar,
+	"POD_NAME",
+	"NODE_NAME",
+	"USE_MGMT_API",
+	"MGMT_API_EXPLICIT_START",
+	"DSE_MGMT_EXPLICIT_START",
+}
+
+// cassandraLabelPrefix is the prefix of the labels that cass-operator sets on the Cassandra pods, some of which, such as
+// the seed and node state labels, it updates on the running pods.
+const cassandraLabelPrefix = "cassandra.datastax.com/"
+
 func NewDatacenter(klusterKey types.NamespacedName, template *DatacenterConfig) (*cassdcapi.CassandraDatacenter, error) {
 	namespace := utils.FirstNonEmptyString(template.Meta.Namespace, klusterKey.Namespace)
 
-	rawConfig, err := createJsonConfig(template.CassandraConfig, template.ServerVersion, template.ServerType)
+	rawConfig, err := createJsonConfig(template.CassandraConfig, template.ServerVersion, template.ServerType, rackDcPropertiesSettings(template.RackDcProperties))
 	if err != nil {
 		return nil, err
 	}
@@ -168,6 +224,7 @@ func NewDatacenter(klusterKey types.NamespacedName, template *DatacenterConfig)
 			Stopped:             template.Stopped,
 			ServerVersion:       template.ServerVersion.String(),
 			ServerImage:         template.ServerImage,
+			ConfigBuilderImage:  template.ConfigBuilderImage,
 			ServerType:          string(template.ServerType),
 			Config:              rawConfig,
 			Racks:               template.Racks,
@@ -191,8 +248,37 @@ func NewDatacenter(klusterKey types.NamespacedName, template *DatacenterConfig)
 		setMgmtAPIHeap(dc, template.MgmtAPIHeap)
 	}
 
-	if template.SoftPodAntiAffinity != nil {
-		dc.Spec.AllowMultipleNodesPerWorker = *template.SoftPodAntiAffinity
+	if template.SoftPodAntiAffinity != nil && *template.SoftPodAntiAffinity {
+		dc.Spec.AllowMultipleNodesPerWorker = true
+		setSoftPodAntiAffinity(dc)
+	}
+
+	if template.StartupTimeouts != nil {
+		setStartupTimeouts(dc, template.StartupTimeouts)
+	}
+
+	if template.StartupProbe != nil {
+		setStartupProbe(dc, template.StartupProbe)
+	}
+
+	if template.AddressStrategy != "" {
+		setAddressStrategy(dc, template.AddressStrategy)
+	}
+
+	if template.ClientAddressStrategy != "" {
+		setClientAddressStrategy(dc, template.ClientAddressStrategy, template.ClientAddress)
+	}
+
+	if template.ManagementApiTimeouts != nil && template.ManagementApiTimeouts.RequestTimeout != nil {
+		dc.Annotations[api.ManagementApiRequestTimeoutAnnotation] = template.ManagementApiTimeouts.RequestTimeout.Duration.String()
+	}
+
+	if policy := template.ReadinessPolicy; policy != nil && policy.Mode != "" && policy.Mode != api.ReadinessModeAll {
+		value, err := json.Marshal(policy)
+		if err != nil {
+			return nil, err
+		}
+		dc.Annotations[api.ReadinessPolicyAnnotation] = string(value)
 	}
 
 	if position, found := FindInitContainer(&template.PodTemplateSpec, reconciliation.ServerConfigContainerName); found {
@@ -218,6 +304,14 @@ func NewDatacenter(klusterKey types.NamespacedName, template *DatacenterConfig)
 
 	dc.Spec.Tolerations = template.Tolerations
 
+	if template.DedicatedNodes != nil {
+		setDedicatedNodes(dc, template.DedicatedNodes)
+	}
+
+	if template.DnsConfig != nil {
+		dc.Spec.PodTemplateSpec.Spec.DNSConfig = template.DnsConfig.DeepCopy()
+	}
+
 	if !template.McacEnabled {
 		// MCAC needs to be disabled
 		setMcacDisabled(dc, template)
@@ -225,6 +319,16 @@ func NewDatacenter(klusterKey types.NamespacedName, template *DatacenterConfig)
 
 	dc.Spec.DatacenterName = template.DatacenterName
 
+	if template.PodHostname != nil {
+		if err := setPodHostname(dc, template.PodHostname); err != nil {
+			return nil, err
+		}
+	}
+
+	if template.CassandraEnvScript != "" {
+		mountCassandraEnvScript(dc, template.CassandraEnvScript, template.PerNodeInitContainerImage)
+	}
+
 	return dc, nil
 }
 
@@ -236,6 +340,334 @@ func setMgmtAPIHeap(dc *cassdcapi.CassandraDatacenter, heapSize *resource.Quanti
 	})
 }
 
+// setSoftPodAntiAffinity adds a preferred anti-affinity term to the pod template of dc, so that the scheduler tries
+// to spread Cassandra pods across worker nodes. cass-operator only sets a required anti-affinity term, and only when
+// AllowMultipleNodesPerWorker is false.
+func setSoftPodAntiAffinity(dc *cassdcapi.CassandraDatacenter) {
+	spec := &dc.Spec.PodTemplateSpec.Spec
+	if spec.Affinity == nil {
+		spec.Affinity = &corev1.Affinity{}
+	}
+	if spec.Affinity.PodAntiAffinity == nil {
+		spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.WeightedPodAffinityTerm{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: cassdcapi.ClusterLabel, Operator: metav1.LabelSelectorOpExists},
+						{Key: cassdcapi.DatacenterLabel, Operator: metav1.LabelSelectorOpExists},
+						{Key: cassdcapi.RackLabel, Operator: metav1.LabelSelectorOpExists},
+					},
+				},
+				TopologyKey: corev1.LabelHostname,
+			},
+		})
+}
+
+// defaultCassandraUserId is the user and group ID that cass-operator runs Cassandra pods with when no pod security
+// context is defined.
+const defaultCassandraUserId int64 = 999
+
+// podSecurityContext returns the security context of the Cassandra pods, with fsGroup set as FSGroup when not nil.
+// When securityContext is nil, the other settings are the defaults cass-operator would have applied.
+func podSecurityContext(securityContext *corev1.PodSecurityContext, fsGroup *int64) *corev1.PodSecurityContext {
+	if fsGroup == nil {
+		return securityContext
+	}
+	if securityContext == nil {
+		userId := defaultCassandraUserId
+		groupId := defaultCassandraUserId
+		securityContext = &corev1.PodSecurityContext{RunAsUser: &userId, RunAsGroup: &groupId}
+	} else {
+		securityContext = securityContext.DeepCopy()
+	}
+	group := *fsGroup
+	securityContext.FSGroup = &group
+	return securityContext
+}
+
+// setStartupTimeouts sets the readiness and liveness probes of the cassandra container with the initial delays from
+// timeouts. The probes are otherwise identical to the ones cass-operator creates by default. Probes that are already
+// defined in the pod template are left untouched.
+func setStartupTimeouts(dc *cassdcapi.CassandraDatacenter, timeouts *api.StartupTimeouts) {
+	UpdateCassandraContainer(dc.Spec.PodTemplateSpec, func(c *corev1.Container) {
+		if c.ReadinessProbe == nil && timeouts.ReadinessDelay != nil {
+			c.ReadinessProbe = mgmtApiProbe(httphelper.ReadinessEndpoint, timeouts.ReadinessDelay.Duration, 10)
+		}
+		if c.LivenessProbe == nil && timeouts.BootstrapTimeout != nil {
+			c.LivenessProbe = mgmtApiProbe(httphelper.LivenessEndpoint, timeouts.BootstrapTimeout.Duration, 15)
+		}
+	})
+}
+
+const (
+	defaultStartupProbePeriod = 10 * time.Second
+	minStartupTime            = 10 * time.Second
+	maxStartupTime            = 24 * time.Hour
+)
+
+// setStartupProbe sets the startup probe of the cassandra container, probing the liveness endpoint of the management
+// API until the node started or MaxStartupTime elapsed. A startup probe already defined in the pod template is left
+// untouched.
+func setStartupProbe(dc *cassdcapi.CassandraDatacenter, startupProbe *api.StartupProbe) {
+	period := defaultStartupProbePeriod
+	if startupProbe.Period != nil {
+		period = startupProbe.Period.Duration
+	}
+	UpdateCassandraContainer(dc.Spec.PodTemplateSpec, func(c *corev1.Container) {
+		if c.StartupProbe == nil {
+			c.StartupProbe = mgmtApiProbe(httphelper.LivenessEndpoint, 0, int32(period.Seconds()))
+			c.StartupProbe.FailureThreshold = int32(math.Ceil(float64(startupProbe.MaxStartupTime.Duration) / float64(period)))
+		}
+	})
+}
+
+// validateStartupProbe checks that the startup probe of the DC gives nodes a reasonable time to start, and that its
+// period is consistent with it.
+func validateStartupProbe(dcConfig *DatacenterConfig) error {
+	startupProbe := dcConfig.StartupProbe
+	if startupProbe == nil {
+		return nil
+	}
+	if startupProbe.MaxStartupTime.Duration < minStartupTime || startupProbe.MaxStartupTime.Duration > maxStartupTime {
+		return fmt.Errorf("startupProbe.maxStartupTime must be between %v and %v", minStartupTime, maxStartupTime)
+	}
+	if startupProbe.Period != nil && (startupProbe.Period.Duration < time.Second || startupProbe.Period.Duration > startupProbe.MaxStartupTime.Duration) {
+		return fmt.Errorf("startupProbe.period must be between 1s and startupProbe.maxStartupTime")
+	}
+	return nil
+}
+
+// imageReferenceRegexp matches container image references: an optional registry host and port, a repository made of
+// lowercase path components, an optional tag and an optional digest.
+var imageReferenceRegexp = regexp.MustCompile(`^` +
+	`(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?/)?` +
+	`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+	`(?::[\w][\w.-]{0,127})?` +
+	`(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?` +
+	`$`)
+
+// validateConfigBuilderImage checks that the server-config-builder image override is a valid image reference, so
+// that an invalid one fails the reconcile instead of leaving the pods unable to start.
+func validateConfigBuilderImage(dcConfig *DatacenterConfig) error {
+	if dcConfig.ConfigBuilderImage == "" {
+		return nil
+	}
+	if !imageReferenceRegexp.MatchString(dcConfig.ConfigBuilderImage) {
+		return fmt.Errorf("configBuilderImage %q is not a valid image reference", dcConfig.ConfigBuilderImage)
+	}
+	return nil
+}
+
+// setDedicatedNodes adds the toleration of the taint of the dedicated nodes to dc, unless an identical toleration is
+// already set, and pins dc to the dedicated nodes through its node affinity labels if requested. cass-operator merges
+// these labels with the node affinity labels of each rack.
+func setDedicatedNodes(dc *cassdcapi.CassandraDatacenter, dedicatedNodes *api.DedicatedNodes) {
+	toleration := corev1.Toleration{
+		Key:      dedicatedNodes.TaintKey,
+		Operator: corev1.TolerationOpExists,
+		Effect:   dedicatedNodes.TaintEffect,
+	}
+	if dedicatedNodes.TaintValue != "" {
+		toleration.Operator = corev1.TolerationOpEqual
+		toleration.Value = dedicatedNodes.TaintValue
+	}
+	found := false
+	for _, t := range dc.Spec.Tolerations {
+		if t.MatchToleration(&toleration) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		// copied so that the tolerations of the template are not modified
+		tolerations := make([]corev1.Toleration, 0, len(dc.Spec.Tolerations)+1)
+		dc.Spec.Tolerations = append(append(tolerations, dc.Spec.Tolerations...), toleration)
+	}
+
+	if dedicatedNodes.NodeAffinity {
+		dc.Spec.NodeAffinityLabels = utils.MergeMap(dc.Spec.NodeAffinityLabels, map[string]string{dedicatedNodes.TaintKey: dedicatedNodes.TaintValue})
+	}
+}
+
+// validateDedicatedNodes checks that the node affinity to the dedicated nodes can be expressed with a node label.
+func validateDedicatedNodes(dcConfig *DatacenterConfig) error {
+	dedicatedNodes := dcConfig.DedicatedNodes
+	if dedicatedNodes == nil {
+		return nil
+	}
+	if dedicatedNodes.TaintKey == "" {
+		return fmt.Errorf("dedicatedNodes.taintKey is required")
+	}
+	if dedicatedNodes.NodeAffinity && dedicatedNodes.TaintValue == "" {
+		return fmt.Errorf("dedicatedNodes.nodeAffinity requires dedicatedNodes.taintValue")
+	}
+	return nil
+}
+
+const (
+	useHostIpForBroadcastEnvVar = "USE_HOST_IP_FOR_BROADCAST"
+	hostIpEnvVar                = "HOST_IP"
+	broadcastRpcAddressEnvVar   = "CASSANDRA_BROADCAST_RPC_ADDRESS"
+)
+
+// setAddressStrategy overrides the environment variables from which the config builder derives the broadcast address
+// of the nodes. cass-operator lets the variables of the server-config-init container in the PodTemplateSpec take
+// precedence over its own.
+func setAddressStrategy(dc *cassdcapi.CassandraDatacenter, strategy api.AddressStrategy) {
+	useHostIp := corev1.EnvVar{Name: useHostIpForBroadcastEnvVar, Value: "true"}
+	var hostIp *corev1.EnvVar
+	switch strategy {
+	case api.AddressStrategyPodIP:
+		useHostIp.Value = "false"
+	case api.AddressStrategyNodeName:
+		hostIp = &corev1.EnvVar{
+			Name:      hostIpEnvVar,
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}},
+		}
+	}
+	UpdateInitContainer(dc.Spec.PodTemplateSpec, reconciliation.ServerConfigContainerName, func(c *corev1.Container) {
+		c.Env = setEnvVar(c.Env, useHostIp)
+		if hostIp != nil {
+			c.Env = setEnvVar(c.Env, *hostIp)
+		}
+	})
+}
+
+// setClientAddressStrategy sets the environment variable from which the entrypoint of the cassandra container sets
+// broadcast_rpc_address in cassandra.yaml, before starting the node.
+func setClientAddressStrategy(dc *cassdcapi.CassandraDatacenter, strategy api.ClientAddressStrategy, address string) {
+	broadcastRpcAddress := corev1.EnvVar{Name: broadcastRpcAddressEnvVar}
+	switch strategy {
+	case api.ClientAddressStrategyPodIP:
+		broadcastRpcAddress.ValueFrom = &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}}
+	case api.ClientAddressStrategyHostIP:
+		broadcastRpcAddress.ValueFrom = &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.hostIP"}}
+	case api.ClientAddressStrategyService:
+		broadcastRpcAddress.Value = address
+	}
+	UpdateCassandraContainer(dc.Spec.PodTemplateSpec, func(c *corev1.Container) {
+		c.Env = setEnvVar(c.Env, broadcastRpcAddress)
+	})
+}
+
+// setPodHostname sets the subdomain of the Cassandra pods to the all-pods Service of dc, which governs its
+// StatefulSets, and makes their hostname their FQDN if requested. It must be called once the name of dc is final.
+func setPodHostname(dc *cassdcapi.CassandraDatacenter, podHostname *api.PodHostname) error {
+	dc.Spec.PodTemplateSpec.Spec.Subdomain = dc.GetAllPodsServiceName()
+	if !podHostname.SetHostnameAsFQDN {
+		return nil
+	}
+	// The kubelet refuses to start pods whose FQDN does not fit in a Linux hostname.
+	if fqdn := longestPodFQDN(dc, podHostname.ClusterDomain); len(fqdn) > maxHostnameLength {
+		return fmt.Errorf("setHostnameAsFQDN requires pod FQDNs of at most %d characters, but datacenter %s has pods such as %s (%d characters)",
+			maxHostnameLength, dc.Name, fqdn, len(fqdn))
+	}
+	dc.Spec.PodTemplateSpec.Spec.SetHostnameAsFQDN = pointer.Bool(true)
+	return nil
+}
+
+// setEnvVar replaces the variable with the same name as envVar, or appends envVar if there is none.
+func setEnvVar(envVars []corev1.EnvVar, envVar corev1.EnvVar) []corev1.EnvVar {
+	if i := utils.GetEnvVarIndex(envVar.Name, envVars); i >= 0 {
+		envVars[i] = envVar
+		return envVars
+	}
+	return append(envVars, envVar)
+}
+
+// validateAddressStrategy checks that the address strategy of the DC is compatible with its networking. Host IPs and
+// node names can only be broadcast when Cassandra is reachable on the worker nodes, and with NodePort networking,
+// pod IPs are not reachable through the node ports.
+func validateAddressStrategy(dcConfig *DatacenterConfig) error {
+	if dcConfig.AddressStrategy == "" {
+		return nil
+	}
+	hostNetwork := dcConfig.Networking != nil && dcConfig.Networking.HostNetwork
+	nodePort := dcConfig.Networking != nil && dcConfig.Networking.NodePort != nil
+	switch dcConfig.AddressStrategy {
+	case api.AddressStrategyPodIP:
+		if nodePort {
+			return fmt.Errorf("address strategy %s can not be used with NodePort networking", dcConfig.AddressStrategy)
+		}
+	case api.AddressStrategyHostIP, api.AddressStrategyNodeName:
+		if !hostNetwork && !nodePort {
+			return fmt.Errorf("address strategy %s requires host networking or NodePort networking", dcConfig.AddressStrategy)
+		}
+	default:
+		return fmt.Errorf("invalid address strategy %s", dcConfig.AddressStrategy)
+	}
+	return nil
+}
+
+// validatePodHostname checks that the cluster domain of the DC pods is a valid DNS domain.
+func validatePodHostname(dcConfig *DatacenterConfig) error {
+	if dcConfig.PodHostname == nil || dcConfig.PodHostname.ClusterDomain == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(dcConfig.PodHostname.ClusterDomain); len(errs) > 0 {
+		return fmt.Errorf("invalid cluster domain %s: %s", dcConfig.PodHostname.ClusterDomain, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// validateClientAddressStrategy checks that the client address strategy of the DC is compatible with its networking,
+// following the same rules as validateAddressStrategy, and that ClientAddress is set exactly when it is used.
+func validateClientAddressStrategy(dcConfig *DatacenterConfig) error {
+	if dcConfig.ClientAddressStrategy == "" {
+		if dcConfig.ClientAddress != "" {
+			return fmt.Errorf("clientAddress requires the %s client address strategy", api.ClientAddressStrategyService)
+		}
+		return nil
+	}
+	if _, found := dcConfig.CassandraConfig.CassandraYaml["broadcast_rpc_address"]; found {
+		return fmt.Errorf("cassandra.yaml setting broadcast_rpc_address can not be set when clientAddressStrategy is set")
+	}
+	hostNetwork := dcConfig.Networking != nil && dcConfig.Networking.HostNetwork
+	nodePort := dcConfig.Networking != nil && dcConfig.Networking.NodePort != nil
+	switch dcConfig.ClientAddressStrategy {
+	case api.ClientAddressStrategyPodIP:
+		if nodePort {
+			return fmt.Errorf("client address strategy %s can not be used with NodePort networking", dcConfig.ClientAddressStrategy)
+		}
+	case api.ClientAddressStrategyHostIP:
+		if !hostNetwork && !nodePort {
+			return fmt.Errorf("client address strategy %s requires host networking or NodePort networking", dcConfig.ClientAddressStrategy)
+		}
+	case api.ClientAddressStrategyService:
+		if dcConfig.ClientAddress == "" {
+			return fmt.Errorf("client address strategy %s requires clientAddress", dcConfig.ClientAddressStrategy)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid client address strategy %s", dcConfig.ClientAddressStrategy)
+	}
+	if dcConfig.ClientAddress != "" {
+		return fmt.Errorf("clientAddress requires the %s client address strategy", api.ClientAddressStrategyService)
+	}
+	return nil
+}
+
+// mgmtApiProbe returns a probe calling the given management API endpoint, with the same period and timeout as the
+// probes created by cass-operator.
+func mgmtApiProbe(path string, initialDelay time.Duration, periodSeconds int32) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Port: intstr.FromInt(8080),
+				Path: path,
+			},
+		},
+		InitialDelaySeconds: int32(initialDelay.Seconds()),
+		PeriodSeconds:       periodSeconds,
+		TimeoutSeconds:      10,
+	}
+}
+
 func setMcacDisabled(dc *cassdcapi.CassandraDatacenter, template *DatacenterConfig) {
 	UpdateCassandraContainer(dc.Spec.PodTemplateSpec, func(c *corev1.Container) {
 		c.Env = append(
@@ -323,6 +755,7 @@ func Coalesce(clusterName string, clusterTemplate *api.CassandraClusterTemplate,
 	dcConfig.Size = dcTemplate.Size
 	dcConfig.Stopped = dcTemplate.Stopped
 	dcConfig.PerNodeConfigMapRef = dcTemplate.PerNodeConfigMapRef
+	dcConfig.SeedServiceRef = dcTemplate.SeedServiceRef
 	dcConfig.CDC = dcTemplate.CDC
 	dcConfig.DatacenterName = dcTemplate.DatacenterName
 
@@ -332,6 +765,7 @@ func Coalesce(clusterName string, clusterTemplate *api.CassandraClusterTemplate,
 		dcConfig.ServerVersion = semver.MustParse(mergedOptions.ServerVersion)
 	}
 	dcConfig.ServerImage = mergedOptions.ServerImage
+	dcConfig.ConfigBuilderImage = mergedOptions.ConfigBuilderImage
 	dcConfig.JmxInitContainerImage = mergedOptions.JmxInitContainerImage
 	dcConfig.Racks = mergedOptions.Racks
 	dcConfig.Resources = mergedOptions.Resources
@@ -340,14 +774,43 @@ func Coalesce(clusterName string, clusterTemplate *api.CassandraClusterTemplate,
 	if mergedOptions.CassandraConfig != nil {
 		dcConfig.CassandraConfig = *mergedOptions.CassandraConfig
 	}
+	if clusterTemplate.JvmOptions != nil {
+		// the cluster-wide JVM options are defaults, overridden by the options set in config.jvmOptions
+		dcConfig.CassandraConfig.JvmOptions = goalesceutils.MergeCRs(*clusterTemplate.JvmOptions, dcConfig.CassandraConfig.JvmOptions)
+	}
 	dcConfig.MgmtAPIHeap = mergedOptions.MgmtAPIHeap
 	dcConfig.SoftPodAntiAffinity = mergedOptions.SoftPodAntiAffinity
 	dcConfig.Tolerations = mergedOptions.Tolerations
 	dcConfig.DseWorkloads = mergedOptions.DseWorkloads
 	dcConfig.ManagementApiAuth = mergedOptions.ManagementApiAuth
-	dcConfig.PodTemplateSpec.Spec.SecurityContext = mergedOptions.PodSecurityContext
+	dcConfig.PodTemplateSpec.Spec.SecurityContext = podSecurityContext(mergedOptions.PodSecurityContext, mergedOptions.FsGroup)
 	dcConfig.PerNodeInitContainerImage = mergedOptions.PerNodeConfigInitContainerImage
 	dcConfig.ServiceAccount = mergedOptions.ServiceAccount
+	dcConfig.CanaryUpgrade = mergedOptions.CanaryUpgrade
+	dcConfig.StartupTimeouts = mergedOptions.StartupTimeouts
+	dcConfig.HintsTuning = mergedOptions.HintsTuning
+	dcConfig.ExtraEnvVars = mergedOptions.ExtraEnvVars
+	dcConfig.PvcRetentionPolicy = mergedOptions.PvcRetentionPolicy
+	dcConfig.Snitch = mergedOptions.Snitch
+	dcConfig.DiskFailurePolicies = mergedOptions.DiskFailurePolicies
+	dcConfig.RackDcProperties = mergedOptions.RackDcProperties
+	dcConfig.AddressStrategy = mergedOptions.AddressStrategy
+	dcConfig.ClientAddressStrategy = mergedOptions.ClientAddressStrategy
+	dcConfig.ClientAddress = mergedOptions.ClientAddress
+	dcConfig.ManagementApiTimeouts = mergedOptions.ManagementApiTimeouts
+	dcConfig.NetworkPolicy = mergedOptions.NetworkPolicy
+	dcConfig.DnsConfig = mergedOptions.DnsConfig
+	dcConfig.PodHostname = mergedOptions.PodHostname
+	dcConfig.ReadinessPolicy = mergedOptions.ReadinessPolicy
+	dcConfig.MaterializedViews = mergedOptions.MaterializedViews
+	dcConfig.MemtableAndCacheTuning = mergedOptions.MemtableAndCacheTuning
+	dcConfig.CassandraEnvScript = mergedOptions.CassandraEnvScript
+	dcConfig.StartupProbe = mergedOptions.StartupProbe
+	dcConfig.DedicatedNodes = mergedOptions.DedicatedNodes
+	dcConfig.RequestTimeouts = mergedOptions.RequestTimeouts
+	dcConfig.StreamingEncryption = mergedOptions.StreamingEncryption
+	dcConfig.AuditLogging = mergedOptions.AuditLogging
+	dcConfig.ChangeDataCapture = mergedOptions.ChangeDataCapture
 
 	dcConfig.Meta.Metadata = goalesceutils.MergeCRs(clusterTemplate.Meta, dcTemplate.Meta.Metadata)
 
@@ -366,28 +829,264 @@ func Coalesce(clusterName string, clusterTemplate *api.CassandraClusterTemplate,
 	}
 
 	// we need to declare at least one container, otherwise the PodTemplateSpec struct will be invalid
-	UpdateCassandraContainer(&dcConfig.PodTemplateSpec, func(c *corev1.Container) {})
+	UpdateCassandraContainer(&dcConfig.PodTemplateSpec, func(c *corev1.Container) {
+		c.Env = append(c.Env, dcConfig.ExtraEnvVars...)
+	})
 
 	dcConfig.McacEnabled = mergedOptions.Telemetry.IsMcacEnabled()
 
 	return dcConfig
 }
 
-// ValidateDatacenterConfig checks the coalesced DC config for missing fields and mandatory options,
-// and then validates the cassandra.yaml file.
-func ValidateDatacenterConfig(dcConfig *DatacenterConfig) error {
+// ValidateRequiredFields checks that the coalesced DC config has the fields without which no valid CassandraDatacenter
+// can be built. They are normally enforced by the K8ssandraCluster webhook, but may be missing when it is disabled. A
+// zero size is treated as missing, since it is indistinguishable from an omitted one.
+func ValidateRequiredFields(dcConfig *DatacenterConfig) error {
 	if dcConfig.ServerVersion == nil {
-		return DCConfigIncomplete{"template.ServerVersion"}
+		return DCConfigIncomplete{dcConfig.Meta.Name, "template.ServerVersion"}
 	}
 	if dcConfig.ServerType == "" {
-		return DCConfigIncomplete{"template.ServerType"}
+		return DCConfigIncomplete{dcConfig.Meta.Name, "template.ServerType"}
 	}
 	if dcConfig.StorageConfig == nil {
-		return DCConfigIncomplete{"template.StorageConfig"}
+		return DCConfigIncomplete{dcConfig.Meta.Name, "template.StorageConfig"}
+	}
+	if dcConfig.Size < 1 {
+		return DCConfigIncomplete{dcConfig.Meta.Name, "template.Size"}
+	}
+	return nil
+}
+
+// ValidateDatacenterConfig checks the coalesced DC config for missing fields and mandatory options,
+// and then validates the cassandra.yaml file.
+func ValidateDatacenterConfig(dcConfig *DatacenterConfig) error {
+	if err := ValidateRequiredFields(dcConfig); err != nil {
+		return err
 	}
 	if err := validateCassandraYaml(dcConfig.CassandraConfig.CassandraYaml); err != nil {
 		return err
 	}
+	if err := validateConfigBuilderImage(dcConfig); err != nil {
+		return err
+	}
+	if err := validateFeatureVersions(dcConfig); err != nil {
+		return err
+	}
+	if err := validateRemovedSettings(dcConfig); err != nil {
+		return err
+	}
+	if err := validateServerTypeSettings(dcConfig); err != nil {
+		return err
+	}
+	if err := validateHintsTuning(dcConfig); err != nil {
+		return err
+	}
+	if err := validateExtraEnvVars(dcConfig); err != nil {
+		return err
+	}
+	if err := validateResources(dcConfig); err != nil {
+		return err
+	}
+	if err := validateSnitch(dcConfig); err != nil {
+		return err
+	}
+	if err := validateDiskFailurePolicies(dcConfig); err != nil {
+		return err
+	}
+	if err := validateRackDcProperties(dcConfig); err != nil {
+		return err
+	}
+	if err := validateAddressStrategy(dcConfig); err != nil {
+		return err
+	}
+	if err := validateClientAddressStrategy(dcConfig); err != nil {
+		return err
+	}
+	if err := validatePodHostname(dcConfig); err != nil {
+		return err
+	}
+	if err := validateRackZones(dcConfig); err != nil {
+		return err
+	}
+	if err := validateManagementApiTimeouts(dcConfig); err != nil {
+		return err
+	}
+	if err := validatePodLabels(dcConfig); err != nil {
+		return err
+	}
+	if err := validateNetworkPolicy(dcConfig); err != nil {
+		return err
+	}
+	if err := validateDnsConfig(dcConfig); err != nil {
+		return err
+	}
+	if err := validateReadinessPolicy(dcConfig); err != nil {
+		return err
+	}
+	if err := validateMaterializedViews(dcConfig); err != nil {
+		return err
+	}
+	if err := validateMemtableAndCacheTuning(dcConfig); err != nil {
+		return err
+	}
+	if err := validateCassandraEnvScript(dcConfig); err != nil {
+		return err
+	}
+	if err := validateStartupProbe(dcConfig); err != nil {
+		return err
+	}
+	if err := validateDedicatedNodes(dcConfig); err != nil {
+		return err
+	}
+	if err := validateRequestTimeouts(dcConfig); err != nil {
+		return err
+	}
+	if err := validateStreamingEncryption(dcConfig); err != nil {
+		return err
+	}
+	if err := validateAuditLogging(dcConfig); err != nil {
+		return err
+	}
+	if err := validateChangeDataCapture(dcConfig); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateReadinessPolicy checks that the Threshold readiness mode comes with a percentage of nodes.
+func validateReadinessPolicy(dcConfig *DatacenterConfig) error {
+	policy := dcConfig.ReadinessPolicy
+	if policy == nil || policy.Mode != api.ReadinessModeThreshold {
+		return nil
+	}
+	if policy.MinReadyPercent == nil || *policy.MinReadyPercent < 1 || *policy.MinReadyPercent > 100 {
+		return fmt.Errorf("the Threshold readiness mode of datacenter %s requires minReadyPercent between 1 and 100", dcConfig.Meta.Name)
+	}
+	return nil
+}
+
+const (
+	maxDnsNameservers   = 3
+	maxDnsSearchDomains = 32
+)
+
+// validateDnsConfig checks that the DNS config of the DC is well-formed: the nameservers must be IP addresses, the
+// search domains DNS subdomains, and the options must have a name.
+func validateDnsConfig(dcConfig *DatacenterConfig) error {
+	dnsConfig := dcConfig.DnsConfig
+	if dnsConfig == nil {
+		return nil
+	}
+	if len(dnsConfig.Nameservers) > maxDnsNameservers {
+		return fmt.Errorf("the DNS config of datacenter %s has %d nameservers, at most %d are allowed", dcConfig.Meta.Name, len(dnsConfig.Nameservers), maxDnsNameservers)
+	}
+	for _, nameserver := range dnsConfig.Nameservers {
+		if net.ParseIP(nameserver) == nil {
+			return fmt.Errorf("invalid nameserver %s in the DNS config of datacenter %s, must be an IP address", nameserver, dcConfig.Meta.Name)
+		}
+	}
+	if len(dnsConfig.Searches) > maxDnsSearchDomains {
+		return fmt.Errorf("the DNS config of datacenter %s has %d search domains, at most %d are allowed", dcConfig.Meta.Name, len(dnsConfig.Searches), maxDnsSearchDomains)
+	}
+	for _, search := range dnsConfig.Searches {
+		// a trailing dot denotes a fully qualified domain
+		if errs := validation.IsDNS1123Subdomain(strings.TrimSuffix(search, ".")); len(errs) > 0 {
+			return fmt.Errorf("invalid search domain %s in the DNS config of datacenter %s: %s", search, dcConfig.Meta.Name, strings.Join(errs, ", "))
+		}
+	}
+	for _, option := range dnsConfig.Options {
+		if option.Name == "" {
+			return fmt.Errorf("the DNS config of datacenter %s has an option without a name", dcConfig.Meta.Name)
+		}
+	}
+	return nil
+}
+
+// validateNetworkPolicy checks that the peer CIDRs of the network policy of the DC are valid.
+func validateNetworkPolicy(dcConfig *DatacenterConfig) error {
+	if dcConfig.NetworkPolicy == nil {
+		return nil
+	}
+	for _, cidr := range dcConfig.NetworkPolicy.PeerCidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid peer CIDR %s in the network policy of datacenter %s: %v", cidr, dcConfig.Meta.Name, err)
+		}
+	}
+	return nil
+}
+
+// validatePodLabels rejects the metadata.pods labels prefixed with cassandraLabelPrefix, which would make cass-operator
+// misidentify the pods, e.g. as seeds. The other labels managed by cass-operator are only rejected by the webhook when
+// they are added, so that the datacenters that already set them keep being reconciled.
+func validatePodLabels(dcConfig *DatacenterConfig) error {
+	for key := range dcConfig.Meta.Metadata.Pods.Labels {
+		if strings.HasPrefix(key, cassandraLabelPrefix) {
+			return fmt.Errorf("label %s in metadata.pods of datacenter %s is managed by cass-operator", key, dcConfig.Meta.Name)
+		}
+	}
+	return nil
+}
+
+// validateManagementApiTimeouts checks that the management API timeouts of the DC are within the allowed range.
+func validateManagementApiTimeouts(dcConfig *DatacenterConfig) error {
+	if dcConfig.ManagementApiTimeouts == nil || dcConfig.ManagementApiTimeouts.RequestTimeout == nil {
+		return nil
+	}
+	timeout := dcConfig.ManagementApiTimeouts.RequestTimeout.Duration
+	if timeout < api.MinManagementApiTimeout || timeout > api.MaxManagementApiTimeout {
+		return fmt.Errorf("management API request timeout %v of datacenter %s must be between %v and %v",
+			timeout, dcConfig.Meta.Name, api.MinManagementApiTimeout, api.MaxManagementApiTimeout)
+	}
+	return nil
+}
+
+// validateResources rejects resource requests that exceed their limit, and a max heap size that leaves no room for
+// off-heap memory within the memory limit of the cassandra container.
+func validateResources(dcConfig *DatacenterConfig) error {
+	if dcConfig.Resources == nil {
+		return nil
+	}
+	names := make([]string, 0, len(dcConfig.Resources.Requests))
+	for name := range dcConfig.Resources.Requests {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		request := dcConfig.Resources.Requests[corev1.ResourceName(name)]
+		if limit, found := dcConfig.Resources.Limits[corev1.ResourceName(name)]; found && request.Cmp(limit) > 0 {
+			return fmt.Errorf("resources: %s request %s is greater than its limit %s", name, request.String(), limit.String())
+		}
+	}
+	heap := dcConfig.CassandraConfig.JvmOptions.MaxHeapSize
+	if limit, found := dcConfig.Resources.Limits[corev1.ResourceMemory]; found && heap != nil && heap.Cmp(limit) >= 0 {
+		return fmt.Errorf("resources: max heap size %s must be lower than the memory limit %s", heap.String(), limit.String())
+	}
+	return nil
+}
+
+// ResourceWarnings returns the problems of the resources of the DC that are not severe enough to reject it, such as
+// a missing memory limit, which exposes the node to be OOM killed when its host runs out of memory.
+func ResourceWarnings(dcConfig *DatacenterConfig) []string {
+	if dcConfig.Resources == nil {
+		return nil
+	}
+	var warnings []string
+	memoryLimit, hasMemoryLimit := dcConfig.Resources.Limits[corev1.ResourceMemory]
+	if !hasMemoryLimit {
+		warnings = append(warnings, "resources: no memory limit is set for the cassandra container")
+	} else if heap := dcConfig.CassandraConfig.JvmOptions.MaxHeapSize; heap != nil && heap.Cmp(memoryLimit) < 0 && heap.Value()*2 > memoryLimit.Value() {
+		warnings = append(warnings, fmt.Sprintf("resources: max heap size %s is more than half of the memory limit %s, which leaves little room for off-heap memory", heap.String(), memoryLimit.String()))
+	}
+	return warnings
+}
+
+// validateExtraEnvVars checks that the extra env vars of the DC do not override a managed env var.
+func validateExtraEnvVars(dcConfig *DatacenterConfig) error {
+	for _, envVar := range dcConfig.ExtraEnvVars {
+		if utils.SliceContains(managedEnvVars, envVar.Name) {
+			return fmt.Errorf("env var %s is managed by the operator and can not be set in extraEnvVars", envVar.Name)
+		}
+	}
 	return nil
 }
 
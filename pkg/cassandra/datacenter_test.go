@@ -1,7 +1,9 @@
 package cassandra
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/k8ssandra/cass-operator/pkg/reconciliation"
 
@@ -108,6 +110,28 @@ func TestCoalesce(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Override ConfigBuilderImage",
+			clusterTemplate: &api.CassandraClusterTemplate{
+				DatacenterOptions: api.DatacenterOptions{
+					ConfigBuilderImage: "registry.example.com/k8ssandra/cass-config-builder:1.0-ubi7",
+				},
+			},
+			dcTemplate: &api.CassandraDatacenterTemplate{
+				DatacenterOptions: api.DatacenterOptions{
+					ConfigBuilderImage: "registry.example.com:5000/k8ssandra/cass-config-builder:1.0.5-ubi8",
+				},
+			},
+			want: &DatacenterConfig{
+				ConfigBuilderImage: "registry.example.com:5000/k8ssandra/cass-config-builder:1.0.5-ubi8",
+				McacEnabled:        true,
+				PodTemplateSpec: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "cassandra"}},
+					},
+				},
+			},
+		},
 		{
 			name: "Override ServerImage",
 			clusterTemplate: &api.CassandraClusterTemplate{
@@ -1394,6 +1418,758 @@ func TestNewDatacenter_AllowMultipleCassPerNodeSet(t *testing.T) {
 	assert.Equal(t, true, dc.Spec.AllowMultipleNodesPerWorker)
 }
 
+func TestNewDatacenter_AllowMultipleCassPerNodeUnset(t *testing.T) {
+	template := GetDatacenterConfig()
+	dc, err := NewDatacenter(
+		types.NamespacedName{Name: "testdc", Namespace: "test-namespace"},
+		&template,
+	)
+	assert.NoError(t, err)
+	assert.False(t, dc.Spec.AllowMultipleNodesPerWorker)
+}
+
+func TestCoalesce_SoftPodAntiAffinity(t *testing.T) {
+	clusterTemplate := &api.CassandraClusterTemplate{
+		DatacenterOptions: api.DatacenterOptions{SoftPodAntiAffinity: pointer.Bool(true)},
+	}
+	inherited := &api.CassandraDatacenterTemplate{Meta: api.EmbeddedObjectMeta{Name: "dc1"}}
+	overridden := &api.CassandraDatacenterTemplate{
+		Meta:              api.EmbeddedObjectMeta{Name: "dc2"},
+		DatacenterOptions: api.DatacenterOptions{SoftPodAntiAffinity: pointer.Bool(false)},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, inherited)
+	assert.Equal(t, pointer.Bool(true), dcConfig.SoftPodAntiAffinity)
+	dcConfig = Coalesce("cluster1", clusterTemplate, overridden)
+	assert.Equal(t, pointer.Bool(false), dcConfig.SoftPodAntiAffinity)
+}
+
+func TestNewDatacenter_PodAntiAffinity(t *testing.T) {
+	t.Run("hard", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		template.SoftPodAntiAffinity = pointer.Bool(false)
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+		assert.False(t, dc.Spec.AllowMultipleNodesPerWorker)
+		assert.Nil(t, dc.Spec.PodTemplateSpec.Spec.Affinity)
+	})
+	t.Run("soft", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		template.SoftPodAntiAffinity = pointer.Bool(true)
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+		assert.True(t, dc.Spec.AllowMultipleNodesPerWorker)
+		require.NotNil(t, dc.Spec.PodTemplateSpec.Spec.Affinity)
+		require.NotNil(t, dc.Spec.PodTemplateSpec.Spec.Affinity.PodAntiAffinity)
+		antiAffinity := dc.Spec.PodTemplateSpec.Spec.Affinity.PodAntiAffinity
+		assert.Empty(t, antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+		require.Len(t, antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+		assert.Equal(t, corev1.LabelHostname, antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm.TopologyKey)
+	})
+}
+
+func TestNewDatacenter_StartupTimeouts(t *testing.T) {
+	t.Run("timeouts set", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		template.StartupTimeouts = &api.StartupTimeouts{
+			ReadinessDelay:   &metav1.Duration{Duration: 2 * time.Minute},
+			BootstrapTimeout: &metav1.Duration{Duration: 6 * time.Hour},
+		}
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+
+		idx, found := FindContainer(dc.Spec.PodTemplateSpec, reconciliation.CassandraContainerName)
+		require.True(t, found)
+		container := dc.Spec.PodTemplateSpec.Spec.Containers[idx]
+		require.NotNil(t, container.ReadinessProbe)
+		assert.Equal(t, int32(120), container.ReadinessProbe.InitialDelaySeconds)
+		assert.Equal(t, "/api/v0/probes/readiness", container.ReadinessProbe.HTTPGet.Path)
+		require.NotNil(t, container.LivenessProbe)
+		assert.Equal(t, int32(6*60*60), container.LivenessProbe.InitialDelaySeconds)
+		assert.Equal(t, "/api/v0/probes/liveness", container.LivenessProbe.HTTPGet.Path)
+	})
+	t.Run("explicit probe takes precedence", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		explicitProbe := &corev1.Probe{InitialDelaySeconds: 42}
+		template.PodTemplateSpec.Spec.Containers = []corev1.Container{{
+			Name:          reconciliation.CassandraContainerName,
+			LivenessProbe: explicitProbe,
+		}}
+		template.StartupTimeouts = &api.StartupTimeouts{BootstrapTimeout: &metav1.Duration{Duration: time.Hour}}
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+
+		idx, found := FindContainer(dc.Spec.PodTemplateSpec, reconciliation.CassandraContainerName)
+		require.True(t, found)
+		container := dc.Spec.PodTemplateSpec.Spec.Containers[idx]
+		assert.Equal(t, explicitProbe, container.LivenessProbe)
+		assert.Nil(t, container.ReadinessProbe)
+	})
+}
+
+func TestNewDatacenter_StartupProbe(t *testing.T) {
+	t.Run("startup probe set", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		template.StartupProbe = &api.StartupProbe{
+			MaxStartupTime: metav1.Duration{Duration: time.Hour},
+			Period:         &metav1.Duration{Duration: 30 * time.Second},
+		}
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+
+		idx, found := FindContainer(dc.Spec.PodTemplateSpec, reconciliation.CassandraContainerName)
+		require.True(t, found)
+		probe := dc.Spec.PodTemplateSpec.Spec.Containers[idx].StartupProbe
+		require.NotNil(t, probe)
+		assert.Equal(t, "/api/v0/probes/liveness", probe.HTTPGet.Path)
+		assert.Equal(t, int32(30), probe.PeriodSeconds)
+		assert.Equal(t, int32(120), probe.FailureThreshold)
+	})
+	t.Run("explicit probe takes precedence", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		explicitProbe := &corev1.Probe{FailureThreshold: 42}
+		template.PodTemplateSpec.Spec.Containers = []corev1.Container{{
+			Name:         reconciliation.CassandraContainerName,
+			StartupProbe: explicitProbe,
+		}}
+		template.StartupProbe = &api.StartupProbe{MaxStartupTime: metav1.Duration{Duration: time.Hour}}
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+
+		idx, found := FindContainer(dc.Spec.PodTemplateSpec, reconciliation.CassandraContainerName)
+		require.True(t, found)
+		assert.Equal(t, explicitProbe, dc.Spec.PodTemplateSpec.Spec.Containers[idx].StartupProbe)
+	})
+	t.Run("no startup probe by default", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+
+		if idx, found := FindContainer(dc.Spec.PodTemplateSpec, reconciliation.CassandraContainerName); found {
+			assert.Nil(t, dc.Spec.PodTemplateSpec.Spec.Containers[idx].StartupProbe)
+		}
+	})
+}
+
+func TestValidateDatacenterConfig_StartupProbe(t *testing.T) {
+	dcConfig := GetDatacenterConfig()
+	dcConfig.StartupProbe = &api.StartupProbe{MaxStartupTime: metav1.Duration{Duration: 2 * time.Hour}}
+	assert.NoError(t, ValidateDatacenterConfig(&dcConfig))
+
+	dcConfig.StartupProbe.MaxStartupTime.Duration = time.Second
+	assert.EqualError(t, ValidateDatacenterConfig(&dcConfig), "startupProbe.maxStartupTime must be between 10s and 24h0m0s")
+
+	dcConfig.StartupProbe.MaxStartupTime.Duration = time.Minute
+	dcConfig.StartupProbe.Period = &metav1.Duration{Duration: 2 * time.Minute}
+	assert.EqualError(t, ValidateDatacenterConfig(&dcConfig), "startupProbe.period must be between 1s and startupProbe.maxStartupTime")
+}
+
+func TestNewDatacenter_ConfigBuilderImage(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.ConfigBuilderImage = "registry.example.com/k8ssandra/cass-config-builder:1.0-ubi7"
+	dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.Equal(t, "registry.example.com/k8ssandra/cass-config-builder:1.0-ubi7", dc.Spec.ConfigBuilderImage)
+
+	template.ConfigBuilderImage = ""
+	dc, err = NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.Empty(t, dc.Spec.ConfigBuilderImage, "cass-operator picks the default image")
+}
+
+func TestValidateDatacenterConfig_ConfigBuilderImage(t *testing.T) {
+	for _, image := range []string{
+		"cass-config-builder",
+		"k8ssandra/cass-config-builder:1.0-ubi7",
+		"localhost:5000/k8ssandra/cass-config-builder:1.0-ubi7",
+		"registry.example.com/k8ssandra/cass-config-builder@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	} {
+		dcConfig := GetDatacenterConfig()
+		dcConfig.ConfigBuilderImage = image
+		assert.NoError(t, ValidateDatacenterConfig(&dcConfig), image)
+	}
+	for _, image := range []string{
+		"K8ssandra/Cass-Config-Builder",
+		"k8ssandra/cass-config-builder:",
+		"k8ssandra/cass-config-builder:1.0 ubi7",
+		"https://registry.example.com/k8ssandra/cass-config-builder",
+	} {
+		dcConfig := GetDatacenterConfig()
+		dcConfig.ConfigBuilderImage = image
+		assert.EqualError(t, ValidateDatacenterConfig(&dcConfig), fmt.Sprintf("configBuilderImage %q is not a valid image reference", image))
+	}
+}
+
+func TestNewDatacenter_DedicatedNodes(t *testing.T) {
+	t.Run("toleration and node affinity", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		userToleration := corev1.Toleration{Key: "other", Operator: corev1.TolerationOpExists}
+		template.Tolerations = []corev1.Toleration{userToleration}
+		template.DedicatedNodes = &api.DedicatedNodes{
+			TaintKey:     "dedicated",
+			TaintValue:   "cassandra",
+			TaintEffect:  corev1.TaintEffectNoSchedule,
+			NodeAffinity: true,
+		}
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+
+		assert.Equal(t, []corev1.Toleration{
+			userToleration,
+			{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "cassandra", Effect: corev1.TaintEffectNoSchedule},
+		}, dc.Spec.Tolerations)
+		assert.Equal(t, []corev1.Toleration{userToleration}, template.Tolerations, "the template is not modified")
+		assert.Equal(t, map[string]string{"dedicated": "cassandra"}, dc.Spec.NodeAffinityLabels)
+	})
+	t.Run("any value and effect", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		template.DedicatedNodes = &api.DedicatedNodes{TaintKey: "dedicated"}
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+
+		assert.Equal(t, []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}, dc.Spec.Tolerations)
+		assert.Empty(t, dc.Spec.NodeAffinityLabels)
+	})
+	t.Run("toleration already set", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		template.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "cassandra"}}
+		template.DedicatedNodes = &api.DedicatedNodes{TaintKey: "dedicated", TaintValue: "cassandra"}
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+
+		assert.Equal(t, template.Tolerations, dc.Spec.Tolerations)
+	})
+}
+
+func TestValidateDatacenterConfig_DedicatedNodes(t *testing.T) {
+	dcConfig := GetDatacenterConfig()
+	dcConfig.DedicatedNodes = &api.DedicatedNodes{TaintKey: "dedicated", NodeAffinity: true}
+	assert.EqualError(t, ValidateDatacenterConfig(&dcConfig), "dedicatedNodes.nodeAffinity requires dedicatedNodes.taintValue")
+
+	dcConfig.DedicatedNodes.TaintValue = "cassandra"
+	assert.NoError(t, ValidateDatacenterConfig(&dcConfig))
+}
+
+func TestCoalesce_DedicatedNodes(t *testing.T) {
+	clusterTemplate := &api.CassandraClusterTemplate{
+		DatacenterOptions: api.DatacenterOptions{DedicatedNodes: &api.DedicatedNodes{TaintKey: "dedicated", TaintValue: "cassandra"}},
+	}
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta:              api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{DedicatedNodes: &api.DedicatedNodes{TaintKey: "dedicated", NodeAffinity: true}},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	assert.Equal(t, &api.DedicatedNodes{TaintKey: "dedicated", TaintValue: "cassandra", NodeAffinity: true}, dcConfig.DedicatedNodes)
+}
+
+func TestCoalesce_JvmOptions(t *testing.T) {
+	heap := resource.MustParse("8Gi")
+	clusterTemplate := &api.CassandraClusterTemplate{
+		JvmOptions: &api.JvmOptions{
+			InitialHeapSize:  &heap,
+			MaxHeapSize:      &heap,
+			GarbageCollector: pointer.String("G1GC"),
+			G1MaxGcPauseMs:   pointer.Int(300),
+		},
+		DatacenterOptions: api.DatacenterOptions{
+			CassandraConfig: &api.CassandraConfig{JvmOptions: api.JvmOptions{G1MaxGcPauseMs: pointer.Int(500)}},
+		},
+	}
+
+	t.Run("defaults apply", func(t *testing.T) {
+		dcTemplate := &api.CassandraDatacenterTemplate{Meta: api.EmbeddedObjectMeta{Name: "dc1"}}
+
+		dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+		assert.Equal(t, api.JvmOptions{
+			InitialHeapSize:  &heap,
+			MaxHeapSize:      &heap,
+			GarbageCollector: pointer.String("G1GC"),
+			G1MaxGcPauseMs:   pointer.Int(500),
+		}, dcConfig.CassandraConfig.JvmOptions)
+		// the defaults must not leak into the cluster template
+		assert.Equal(t, pointer.Int(300), clusterTemplate.JvmOptions.G1MaxGcPauseMs)
+	})
+
+	t.Run("datacenter overrides", func(t *testing.T) {
+		dcHeap := resource.MustParse("16Gi")
+		dcTemplate := &api.CassandraDatacenterTemplate{
+			Meta: api.EmbeddedObjectMeta{Name: "dc2"},
+			DatacenterOptions: api.DatacenterOptions{
+				CassandraConfig: &api.CassandraConfig{JvmOptions: api.JvmOptions{
+					MaxHeapSize:    &dcHeap,
+					G1MaxGcPauseMs: pointer.Int(200),
+				}},
+			},
+		}
+
+		dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+		assert.Equal(t, api.JvmOptions{
+			InitialHeapSize:  &heap,
+			MaxHeapSize:      &dcHeap,
+			GarbageCollector: pointer.String("G1GC"),
+			G1MaxGcPauseMs:   pointer.Int(200),
+		}, dcConfig.CassandraConfig.JvmOptions)
+	})
+}
+
+func TestCoalesce_StartupTimeouts(t *testing.T) {
+	clusterTemplate := &api.CassandraClusterTemplate{
+		DatacenterOptions: api.DatacenterOptions{StartupTimeouts: &api.StartupTimeouts{
+			ReadinessDelay:   &metav1.Duration{Duration: time.Minute},
+			BootstrapTimeout: &metav1.Duration{Duration: time.Hour},
+		}},
+	}
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{StartupTimeouts: &api.StartupTimeouts{
+			BootstrapTimeout: &metav1.Duration{Duration: 3 * time.Hour},
+		}},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NotNil(t, dcConfig.StartupTimeouts)
+	assert.Equal(t, time.Minute, dcConfig.StartupTimeouts.ReadinessDelay.Duration)
+	assert.Equal(t, 3*time.Hour, dcConfig.StartupTimeouts.BootstrapTimeout.Duration)
+}
+
+func TestCoalesce_FsGroup(t *testing.T) {
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta:              api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{FsGroup: pointer.Int64(2000)},
+	}
+
+	t.Run("without pod security context", func(t *testing.T) {
+		dcConfig := Coalesce("cluster1", &api.CassandraClusterTemplate{}, dcTemplate)
+		assert.Equal(t, &corev1.PodSecurityContext{
+			RunAsUser:  pointer.Int64(999),
+			RunAsGroup: pointer.Int64(999),
+			FSGroup:    pointer.Int64(2000),
+		}, dcConfig.PodTemplateSpec.Spec.SecurityContext)
+	})
+
+	t.Run("with pod security context", func(t *testing.T) {
+		clusterTemplate := &api.CassandraClusterTemplate{
+			DatacenterOptions: api.DatacenterOptions{PodSecurityContext: &corev1.PodSecurityContext{
+				RunAsUser: pointer.Int64(1000),
+				FSGroup:   pointer.Int64(1000),
+			}},
+		}
+		dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+		assert.Equal(t, &corev1.PodSecurityContext{
+			RunAsUser: pointer.Int64(1000),
+			FSGroup:   pointer.Int64(2000),
+		}, dcConfig.PodTemplateSpec.Spec.SecurityContext)
+		assert.Equal(t, int64(1000), *clusterTemplate.PodSecurityContext.FSGroup, "the template should not be modified")
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		dcConfig := Coalesce("cluster1", &api.CassandraClusterTemplate{}, &api.CassandraDatacenterTemplate{Meta: api.EmbeddedObjectMeta{Name: "dc1"}})
+		assert.Nil(t, dcConfig.PodTemplateSpec.Spec.SecurityContext, "cass-operator should apply its default security context")
+	})
+}
+
+func TestCoalesce_ExtraEnvVars(t *testing.T) {
+	clusterTemplate := &api.CassandraClusterTemplate{
+		DatacenterOptions: api.DatacenterOptions{ExtraEnvVars: []corev1.EnvVar{
+			{Name: "JVM_EXTRA_OPTS", Value: "-Dcassandra.ring_delay_ms=30000"},
+			{Name: "LOCAL_JMX", Value: "no"},
+		}},
+	}
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{ExtraEnvVars: []corev1.EnvVar{
+			{Name: "LOCAL_JMX", Value: "yes"},
+		}},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+
+	idx, found := FindContainer(&dcConfig.PodTemplateSpec, reconciliation.CassandraContainerName)
+	require.True(t, found)
+	assert.ElementsMatch(t, []corev1.EnvVar{
+		{Name: "JVM_EXTRA_OPTS", Value: "-Dcassandra.ring_delay_ms=30000"},
+		{Name: "LOCAL_JMX", Value: "yes"},
+	}, dcConfig.PodTemplateSpec.Spec.Containers[idx].Env)
+}
+
+func TestValidateDatacenterConfig_ExtraEnvVars(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.ExtraEnvVars = []corev1.EnvVar{{Name: "JVM_EXTRA_OPTS", Value: "-Dcassandra.ring_delay_ms=30000"}}
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+
+	for _, name := range []string{mgmtApiHeapSizeEnvVar, mcacDisabledEnvVar, metricFiltersEnvVar, "USE_MGMT_API"} {
+		template.ExtraEnvVars = []corev1.EnvVar{{Name: name, Value: "foo"}}
+		err := ValidateDatacenterConfig(&template)
+		assert.EqualError(t, err, fmt.Sprintf("env var %s is managed by the operator and can not be set in extraEnvVars", name))
+	}
+}
+
+func TestValidateDatacenterConfig_Resources(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.Resources = &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+	}
+	heap := resource.MustParse("4Gi")
+	template.CassandraConfig.JvmOptions.MaxHeapSize = &heap
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+	assert.Empty(t, ResourceWarnings(&template))
+
+	template.Resources.Limits[corev1.ResourceCPU] = resource.MustParse("1")
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "resources: cpu request 2 is greater than its limit 1")
+	template.Resources.Limits[corev1.ResourceCPU] = resource.MustParse("2")
+
+	heap = resource.MustParse("8Gi")
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "resources: max heap size 8Gi must be lower than the memory limit 8Gi")
+
+	heap = resource.MustParse("6Gi")
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+	assert.Equal(t, []string{"resources: max heap size 6Gi is more than half of the memory limit 8Gi, which leaves little room for off-heap memory"}, ResourceWarnings(&template))
+
+	delete(template.Resources.Limits, corev1.ResourceMemory)
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+	assert.Equal(t, []string{"resources: no memory limit is set for the cassandra container"}, ResourceWarnings(&template))
+}
+
+func TestNewDatacenter_AddressStrategy(t *testing.T) {
+	nodeNameRef := &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}
+	tests := []struct {
+		strategy api.AddressStrategy
+		want     []corev1.EnvVar
+	}{
+		{api.AddressStrategyPodIP, []corev1.EnvVar{{Name: "USE_HOST_IP_FOR_BROADCAST", Value: "false"}}},
+		{api.AddressStrategyHostIP, []corev1.EnvVar{{Name: "USE_HOST_IP_FOR_BROADCAST", Value: "true"}}},
+		{api.AddressStrategyNodeName, []corev1.EnvVar{
+			{Name: "USE_HOST_IP_FOR_BROADCAST", Value: "true"},
+			{Name: "HOST_IP", ValueFrom: nodeNameRef},
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.strategy), func(t *testing.T) {
+			template := GetDatacenterConfig()
+			template.PodTemplateSpec.Spec.InitContainers = []corev1.Container{{
+				Name: reconciliation.ServerConfigContainerName,
+				Env:  []corev1.EnvVar{{Name: "USE_HOST_IP_FOR_BROADCAST", Value: "ignored"}},
+			}}
+			template.AddressStrategy = tc.strategy
+			dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+			require.NoError(t, err)
+
+			idx, found := FindInitContainer(dc.Spec.PodTemplateSpec, reconciliation.ServerConfigContainerName)
+			require.True(t, found)
+			assert.Equal(t, tc.want, dc.Spec.PodTemplateSpec.Spec.InitContainers[idx].Env)
+		})
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+
+		_, found := FindInitContainer(dc.Spec.PodTemplateSpec, reconciliation.ServerConfigContainerName)
+		assert.False(t, found)
+	})
+}
+
+func TestValidateDatacenterConfig_AddressStrategy(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.AddressStrategy = api.AddressStrategyPodIP
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+
+	template.AddressStrategy = api.AddressStrategyHostIP
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "address strategy HostIP requires host networking or NodePort networking")
+	template.Networking = &cassdcapi.NetworkingConfig{HostNetwork: true}
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+
+	template.AddressStrategy = api.AddressStrategyNodeName
+	template.Networking = &cassdcapi.NetworkingConfig{NodePort: &cassdcapi.NodePortConfig{Internode: 30001}}
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+
+	template.AddressStrategy = api.AddressStrategyPodIP
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "address strategy PodIP can not be used with NodePort networking")
+}
+
+func TestNewDatacenter_ClientAddressStrategy(t *testing.T) {
+	tests := []struct {
+		strategy api.ClientAddressStrategy
+		address  string
+		want     corev1.EnvVar
+	}{
+		{api.ClientAddressStrategyPodIP, "", corev1.EnvVar{
+			Name:      "CASSANDRA_BROADCAST_RPC_ADDRESS",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}},
+		}},
+		{api.ClientAddressStrategyHostIP, "", corev1.EnvVar{
+			Name:      "CASSANDRA_BROADCAST_RPC_ADDRESS",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.hostIP"}},
+		}},
+		{api.ClientAddressStrategyService, "cassandra.example.com", corev1.EnvVar{
+			Name:  "CASSANDRA_BROADCAST_RPC_ADDRESS",
+			Value: "cassandra.example.com",
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.strategy), func(t *testing.T) {
+			template := GetDatacenterConfig()
+			template.ClientAddressStrategy = tc.strategy
+			template.ClientAddress = tc.address
+			dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+			require.NoError(t, err)
+
+			idx, found := FindContainer(dc.Spec.PodTemplateSpec, reconciliation.CassandraContainerName)
+			require.True(t, found)
+			assert.Contains(t, dc.Spec.PodTemplateSpec.Spec.Containers[idx].Env, tc.want)
+		})
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		template := GetDatacenterConfig()
+		dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+		require.NoError(t, err)
+
+		if idx, found := FindContainer(dc.Spec.PodTemplateSpec, reconciliation.CassandraContainerName); found {
+			for _, envVar := range dc.Spec.PodTemplateSpec.Spec.Containers[idx].Env {
+				assert.NotEqual(t, "CASSANDRA_BROADCAST_RPC_ADDRESS", envVar.Name)
+			}
+		}
+	})
+}
+
+func TestValidateDatacenterConfig_ClientAddressStrategy(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.ClientAddress = "10.0.0.1"
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "clientAddress requires the Service client address strategy")
+
+	template.ClientAddressStrategy = api.ClientAddressStrategyService
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+	template.ClientAddress = ""
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "client address strategy Service requires clientAddress")
+
+	template.ClientAddressStrategy = api.ClientAddressStrategyPodIP
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+	template.ClientAddress = "10.0.0.1"
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "clientAddress requires the Service client address strategy")
+	template.ClientAddress = ""
+
+	template.ClientAddressStrategy = api.ClientAddressStrategyHostIP
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "client address strategy HostIP requires host networking or NodePort networking")
+	template.Networking = &cassdcapi.NetworkingConfig{NodePort: &cassdcapi.NodePortConfig{Native: 30002}}
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+
+	template.ClientAddressStrategy = api.ClientAddressStrategyPodIP
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "client address strategy PodIP can not be used with NodePort networking")
+
+	template.ClientAddressStrategy = api.ClientAddressStrategyHostIP
+	template.CassandraConfig.CassandraYaml = unstructured.Unstructured{"broadcast_rpc_address": "10.0.0.1"}
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "cassandra.yaml setting broadcast_rpc_address can not be set when clientAddressStrategy is set")
+}
+
+func TestNewDatacenter_PodHostname(t *testing.T) {
+	template := GetDatacenterConfig()
+	dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.Empty(t, dc.Spec.PodTemplateSpec.Spec.Subdomain)
+	assert.Nil(t, dc.Spec.PodTemplateSpec.Spec.SetHostnameAsFQDN)
+
+	template = GetDatacenterConfig()
+	template.PodHostname = &api.PodHostname{ClusterDomain: "east.example.com"}
+	dc, err = NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.Equal(t, "k8ssandra-dc1-all-pods-service", dc.Spec.PodTemplateSpec.Spec.Subdomain)
+	assert.Nil(t, dc.Spec.PodTemplateSpec.Spec.SetHostnameAsFQDN)
+	assert.Equal(t, "k8ssandra-dc1-all-pods-service.k8ssandra.svc.east.example.com", PodDomain(dc, template.PodHostname.ClusterDomain))
+	assert.Equal(t, "k8ssandra-dc1-default-sts-2.k8ssandra-dc1-all-pods-service.k8ssandra.svc.east.example.com", longestPodFQDN(dc, template.PodHostname.ClusterDomain))
+
+	template = GetDatacenterConfig()
+	template.DatacenterName = "Real DC1"
+	template.Racks = []cassdcapi.Rack{{Name: "r1"}, {Name: "r2"}}
+	template.Size = 12
+	template.PodHostname = &api.PodHostname{}
+	dc, err = NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.Equal(t, "k8ssandra-realdc1-all-pods-service", dc.Spec.PodTemplateSpec.Spec.Subdomain)
+	assert.Equal(t, "k8ssandra-realdc1-r1-sts-5.k8ssandra-realdc1-all-pods-service.k8ssandra.svc.cluster.local", longestPodFQDN(dc, ""))
+
+	template.PodHostname.SetHostnameAsFQDN = true
+	_, err = NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	assert.EqualError(t, err, "setHostnameAsFQDN requires pod FQDNs of at most 64 characters, but datacenter dc1 has pods such as "+
+		"k8ssandra-realdc1-r1-sts-5.k8ssandra-realdc1-all-pods-service.k8ssandra.svc.cluster.local (89 characters)")
+
+	template = GetDatacenterConfig()
+	template.Cluster = "c"
+	template.Meta.Name = "d"
+	template.Meta.Namespace = "n"
+	template.PodHostname = &api.PodHostname{SetHostnameAsFQDN: true}
+	dc, err = NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.Equal(t, "c-d-all-pods-service", dc.Spec.PodTemplateSpec.Spec.Subdomain)
+	assert.Equal(t, pointer.Bool(true), dc.Spec.PodTemplateSpec.Spec.SetHostnameAsFQDN)
+	assert.Equal(t, "c-d-default-sts-2.c-d-all-pods-service.n.svc.cluster.local", longestPodFQDN(dc, ""))
+}
+
+func TestValidateDatacenterConfig_PodHostname(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.PodHostname = &api.PodHostname{ClusterDomain: "east.example.com"}
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+
+	template.PodHostname.ClusterDomain = "East_Example"
+	assert.ErrorContains(t, ValidateDatacenterConfig(&template), "invalid cluster domain East_Example: ")
+}
+
+func TestNewDatacenter_ManagementApiTimeouts(t *testing.T) {
+	template := GetDatacenterConfig()
+	dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.NotContains(t, dc.Annotations, api.ManagementApiRequestTimeoutAnnotation)
+
+	template.ManagementApiTimeouts = &api.ManagementApiTimeouts{RequestTimeout: &metav1.Duration{Duration: 5 * time.Minute}}
+	dc, err = NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.Equal(t, "5m0s", dc.Annotations[api.ManagementApiRequestTimeoutAnnotation])
+
+	timeout, err := ManagementApiRequestTimeout(dc)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, timeout)
+}
+
+func TestNewDatacenter_ReadinessPolicy(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.ReadinessPolicy = &api.ReadinessPolicy{Mode: api.ReadinessModeAll}
+	dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.NotContains(t, dc.Annotations, api.ReadinessPolicyAnnotation)
+
+	template.ReadinessPolicy = &api.ReadinessPolicy{Mode: api.ReadinessModeThreshold, MinReadyPercent: pointer.Int32(75)}
+	dc, err = NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.Equal(t, `{"mode":"Threshold","minReadyPercent":75}`, dc.Annotations[api.ReadinessPolicyAnnotation])
+
+	policy, err := DatacenterReadinessPolicy(dc)
+	require.NoError(t, err)
+	assert.Equal(t, template.ReadinessPolicy, policy)
+}
+
+func TestValidateDatacenterConfig_ManagementApiTimeouts(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.ManagementApiTimeouts = &api.ManagementApiTimeouts{}
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+
+	template.ManagementApiTimeouts.RequestTimeout = &metav1.Duration{Duration: time.Hour}
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+
+	template.ManagementApiTimeouts.RequestTimeout.Duration = 500 * time.Millisecond
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "management API request timeout 500ms of datacenter dc1 must be between 1s and 1h0m0s")
+
+	template.ManagementApiTimeouts.RequestTimeout.Duration = 2 * time.Hour
+	assert.Error(t, ValidateDatacenterConfig(&template))
+}
+
+func TestNewDatacenter_PodLabels(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.Meta.Metadata = meta.CassandraDatacenterMeta{
+		Tags:         meta.Tags{Labels: map[string]string{"dc-label": "dc"}},
+		CommonLabels: map[string]string{"team": "storage"},
+		Pods:         meta.Tags{Labels: map[string]string{"mesh": "enabled", "cost-center": "1234"}},
+	}
+	AddPodTemplateSpecMeta(&template, template.Meta.Metadata)
+	require.NoError(t, ValidateDatacenterConfig(&template))
+
+	dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"mesh": "enabled", "cost-center": "1234"}, dc.Spec.PodTemplateSpec.Labels)
+	assert.Equal(t, map[string]string{"team": "storage"}, dc.Spec.AdditionalLabels)
+	assert.NotContains(t, dc.Labels, "mesh", "pod labels should only be set on the pods")
+	assert.Equal(t, "dc", dc.Labels["dc-label"])
+	assert.Equal(t, api.NameLabelValue, dc.Labels[api.NameLabel])
+}
+
+func TestValidateDatacenterConfig_PodLabels(t *testing.T) {
+	for _, key := range []string{cassdcapi.SeedNodeLabel, cassdcapi.CassNodeState} {
+		template := GetDatacenterConfig()
+		template.Meta.Metadata.Pods.Labels = map[string]string{"mesh": "enabled", key: "value"}
+		assert.EqualError(t, ValidateDatacenterConfig(&template), fmt.Sprintf("label %s in metadata.pods of datacenter dc1 is managed by cass-operator", key))
+	}
+
+	template := GetDatacenterConfig()
+	template.Meta.Metadata.Pods.Labels = map[string]string{"app.kubernetes.io/managed-by": "value"}
+	assert.NoError(t, ValidateDatacenterConfig(&template), "the other managed labels are only rejected by the webhook")
+}
+
+func TestNewDatacenter_DnsConfig(t *testing.T) {
+	ndots := "2"
+	clusterTemplate := &api.CassandraClusterTemplate{
+		DatacenterOptions: api.DatacenterOptions{
+			ServerVersion: "4.0.6",
+			DnsConfig: &corev1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.10"},
+				Searches:    []string{"east.svc.cluster.local"},
+			},
+		},
+	}
+	dcTemplate := &api.CassandraDatacenterTemplate{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		DatacenterOptions: api.DatacenterOptions{
+			DnsConfig: &corev1.PodDNSConfig{
+				Nameservers: []string{"10.1.0.10", "fd00::10"},
+				Searches:    []string{"west.svc.cluster.local", "example.com."},
+				Options:     []corev1.PodDNSConfigOption{{Name: "ndots", Value: &ndots}},
+			},
+		},
+	}
+
+	dcConfig := Coalesce("cluster1", clusterTemplate, dcTemplate)
+	require.NoError(t, ValidateDatacenterConfig(dcConfig))
+	dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, dcConfig)
+	require.NoError(t, err)
+	assert.Equal(t, dcTemplate.DnsConfig, dc.Spec.PodTemplateSpec.Spec.DNSConfig)
+
+	// the cluster-wide DNS config applies to the DCs without one
+	dcTemplate.DnsConfig = nil
+	dcConfig = Coalesce("cluster1", clusterTemplate, dcTemplate)
+	dc, err = NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, dcConfig)
+	require.NoError(t, err)
+	assert.Equal(t, clusterTemplate.DnsConfig, dc.Spec.PodTemplateSpec.Spec.DNSConfig)
+}
+
+func TestValidateDatacenterConfig_DnsConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		dnsConfig *corev1.PodDNSConfig
+		err       string
+	}{
+		{
+			name:      "too many nameservers",
+			dnsConfig: &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}},
+			err:       "the DNS config of datacenter dc1 has 4 nameservers, at most 3 are allowed",
+		},
+		{
+			name:      "nameserver not an IP",
+			dnsConfig: &corev1.PodDNSConfig{Nameservers: []string{"dns.example.com"}},
+			err:       "invalid nameserver dns.example.com in the DNS config of datacenter dc1, must be an IP address",
+		},
+		{
+			name:      "malformed search domain",
+			dnsConfig: &corev1.PodDNSConfig{Searches: []string{"Example_Domain"}},
+			err:       "invalid search domain Example_Domain in the DNS config of datacenter dc1",
+		},
+		{
+			name:      "option without name",
+			dnsConfig: &corev1.PodDNSConfig{Options: []corev1.PodDNSConfigOption{{}}},
+			err:       "the DNS config of datacenter dc1 has an option without a name",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			template := GetDatacenterConfig()
+			template.DnsConfig = tc.dnsConfig
+			err := ValidateDatacenterConfig(&template)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
 func TestNewDatacenter_Tolerations(t *testing.T) {
 	template := GetDatacenterConfig()
 	template.Tolerations = []corev1.Toleration{{
@@ -1410,6 +2186,35 @@ func TestNewDatacenter_Tolerations(t *testing.T) {
 	assert.Equal(t, template.Tolerations, dc.Spec.Tolerations)
 }
 
+func TestNewDatacenter_AdditionalServiceConfig(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.Meta.Metadata.ServiceConfig = meta.CassandraDatacenterServicesMeta{
+		DatacenterService: meta.Tags{Labels: map[string]string{"dc-label": "dc"}},
+		SeedService:       meta.Tags{Annotations: map[string]string{"seed-annotation": "seed"}},
+		AllPodsService: meta.Tags{
+			Labels:      map[string]string{"all-pods-label": "all-pods"},
+			Annotations: map[string]string{"all-pods-annotation": "all-pods"},
+		},
+		AdditionalSeedService: meta.Tags{Labels: map[string]string{"additional-seed-label": "additional-seed"}},
+		NodePortService:       meta.Tags{Annotations: map[string]string{"node-port-annotation": "node-port"}},
+	}
+	dc, err := NewDatacenter(
+		types.NamespacedName{Name: "testdc", Namespace: "test-namespace"},
+		&template,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, cassdcapi.ServiceConfig{
+		DatacenterService: cassdcapi.ServiceConfigAdditions{Labels: map[string]string{"dc-label": "dc"}},
+		SeedService:       cassdcapi.ServiceConfigAdditions{Annotations: map[string]string{"seed-annotation": "seed"}},
+		AllPodsService: cassdcapi.ServiceConfigAdditions{
+			Labels:      map[string]string{"all-pods-label": "all-pods"},
+			Annotations: map[string]string{"all-pods-annotation": "all-pods"},
+		},
+		AdditionalSeedService: cassdcapi.ServiceConfigAdditions{Labels: map[string]string{"additional-seed-label": "additional-seed"}},
+		NodePortService:       cassdcapi.ServiceConfigAdditions{Annotations: map[string]string{"node-port-annotation": "node-port"}},
+	}, dc.Spec.AdditionalServiceConfig)
+}
+
 func TestNewDatacenter_ServiceAccount(t *testing.T) {
 	template := GetDatacenterConfig()
 	template.ServiceAccount = "svc"
@@ -1437,6 +2242,16 @@ func TestValidateDatacenterConfig_Fail_NoServerVersion(t *testing.T) {
 	assert.IsType(t, DCConfigIncomplete{}, err)
 }
 
+// TestValidateDatacenterConfig_Fail_ZeroSize tests that a zero size, which is indistinguishable from an omitted one, is
+// reported as a missing field.
+func TestValidateDatacenterConfig_Fail_ZeroSize(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.Size = 0
+	err := ValidateDatacenterConfig(&template)
+	assert.IsType(t, DCConfigIncomplete{}, err)
+	assert.EqualError(t, err, "DatacenterConfig of datacenter dc1 did not contain required fields to process into a CassandraDatacenter, missing field template.Size")
+}
+
 func TestCDC(t *testing.T) {
 	template := GetDatacenterConfig()
 	template.CDC = &cassdcapi.CDCConfiguration{
@@ -1539,3 +2354,15 @@ func GetDatacenterConfig() DatacenterConfig {
 		McacEnabled: true,
 	}
 }
+
+func TestValidateDatacenterConfig_ReadinessPolicy(t *testing.T) {
+	template := GetDatacenterConfig()
+	template.ReadinessPolicy = &api.ReadinessPolicy{Mode: api.ReadinessModeQuorum}
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+
+	template.ReadinessPolicy = &api.ReadinessPolicy{Mode: api.ReadinessModeThreshold}
+	assert.EqualError(t, ValidateDatacenterConfig(&template), "the Threshold readiness mode of datacenter dc1 requires minReadyPercent between 1 and 100")
+
+	template.ReadinessPolicy.MinReadyPercent = pointer.Int32(60)
+	assert.NoError(t, ValidateDatacenterConfig(&template))
+}
@@ -3,6 +3,7 @@ package cassandra
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/go-logr/logr"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/encryption"
@@ -217,6 +218,36 @@ func ReadEncryptionStoresSecrets(ctx context.Context, klusterKey types.Namespace
 	return nil
 }
 
+// EncryptionStoresSecretNames returns the sorted, deduplicated names of the secrets referenced by
+// the encryption stores that are in use in the given datacenter config. It returns nil when
+// ExternalSecrets is enabled, since the operator doesn't mount the stores in that case.
+func EncryptionStoresSecretNames(template *DatacenterConfig) []string {
+	if template.ExternalSecrets {
+		return nil
+	}
+	var stores []*encryption.Stores
+	if ClientEncryptionEnabled(template) && template.ClientEncryptionStores != nil {
+		stores = append(stores, template.ClientEncryptionStores)
+	}
+	if ServerEncryptionEnabled(template) && template.ServerEncryptionStores != nil {
+		stores = append(stores, template.ServerEncryptionStores)
+	}
+	names := make(map[string]bool)
+	for _, s := range stores {
+		for _, ref := range []*encryption.SecretKeySelector{s.KeystoreSecretRef, s.TruststoreSecretRef, s.KeystorePasswordRef, s.TruststorePasswordSecretRef} {
+			if ref != nil && ref.Name != "" {
+				names[ref.Name] = true
+			}
+		}
+	}
+	var result []string
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
 func ReadEncryptionStorePassword(ctx context.Context, namespace string, remoteClient client.Client, stores *encryption.Stores, storeName encryption.StoreName) (string, error) {
 	var storeSecretRef, storePasswordSecretRef *encryption.SecretKeySelector
 	var secretName, secretKey string
@@ -0,0 +1,101 @@
+package cassandra
+
+import (
+	"fmt"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/reconciliation"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	CassandraEnvScriptInitContainerName = "cassandra-env-script"
+	CassandraEnvScriptVolumeName        = "cassandra-env-script"
+
+	// cassandraEnvScriptFile is the name of the file holding the custom cassandra-env.sh fragment, both in the volume
+	// it is mounted from and next to cassandra-env.sh.
+	cassandraEnvScriptFile = "cassandra-env-custom.sh"
+	// cassandraEnvScriptMountPath is where the volume holding the fragment is mounted in the init container.
+	cassandraEnvScriptMountPath = "/cassandra-env-script"
+	// serverConfigMountPath is where the config rendered by cass-config-builder is mounted, the entrypoint of the
+	// cassandra container copying it to the config directory of Cassandra.
+	serverConfigMountPath = "/config"
+
+	// defaultCassandraEnvScriptInitContainerImage is the image of the init container installing the fragment when
+	// the image of the per-node config init container is not set. It only needs a shell.
+	defaultCassandraEnvScriptInitContainerImage = "mikefarah/yq:4"
+)
+
+// mountCassandraEnvScript makes the Cassandra nodes of dc source the custom cassandra-env.sh fragment script, since
+// cass-config-builder has no setting for it. The fragment is set in an annotation of the pods, which mount it as a file
+// through the downward API, so that changing it rolls the pods. An init container running after cass-config-builder,
+// with the given image, copies the file next to the rendered cassandra-env.sh and appends a line sourcing it.
+func mountCassandraEnvScript(dc *cassdcapi.CassandraDatacenter, script, image string) {
+	podTemplate := dc.Spec.PodTemplateSpec
+	// The annotations may be shared with the pod metadata of the K8ssandraCluster, they are copied before being changed
+	podTemplate.Annotations = utils.MergeMap(podTemplate.Annotations, map[string]string{api.CassandraEnvScriptAnnotation: script})
+
+	// if the config-builder init container isn't found, declare a placeholder now to guarantee order of execution
+	UpdateInitContainer(podTemplate, reconciliation.ServerConfigContainerName, func(container *corev1.Container) {})
+	if image == "" {
+		image = defaultCassandraEnvScriptInitContainerImage
+	}
+	UpdateInitContainer(podTemplate, CassandraEnvScriptInitContainerName, func(container *corev1.Container) {
+		container.Image = image
+		container.Command = []string{"sh", "-c", cassandraEnvScriptCommand(serverConfigMountPath, cassandraEnvScriptMountPath)}
+		container.Resources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("16Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+		}
+		container.VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      "server-config", // volume will be created by cass-operator
+				MountPath: serverConfigMountPath,
+			},
+			{
+				Name:      CassandraEnvScriptVolumeName,
+				MountPath: cassandraEnvScriptMountPath,
+			},
+		}
+	})
+
+	volume := corev1.Volume{
+		Name: CassandraEnvScriptVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path: cassandraEnvScriptFile,
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: fmt.Sprintf("metadata.annotations['%s']", api.CassandraEnvScriptAnnotation),
+					},
+				}},
+			},
+		},
+	}
+	for i, v := range podTemplate.Spec.Volumes {
+		if v.Name == CassandraEnvScriptVolumeName {
+			podTemplate.Spec.Volumes[i] = volume
+			return
+		}
+	}
+	AddVolumesToPodTemplateSpec(podTemplate, volume)
+}
+
+// cassandraEnvScriptCommand returns the shell command that copies the fragment from scriptDir next to the
+// cassandra-env.sh rendered in configDir, and makes cassandra-env.sh source it from the config directory of Cassandra.
+// Running it again leaves cassandra-env.sh unchanged.
+func cassandraEnvScriptCommand(configDir, scriptDir string) string {
+	return fmt.Sprintf(`cp %[2]s/%[3]s %[1]s/%[3]s && `+
+		`(grep -qF '/%[3]s"' %[1]s/cassandra-env.sh || printf '\n. "$CASSANDRA_CONF/%[3]s"\n' >> %[1]s/cassandra-env.sh) && `+
+		`echo installed %[3]s`,
+		configDir, scriptDir, cassandraEnvScriptFile)
+}
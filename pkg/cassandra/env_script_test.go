@@ -0,0 +1,77 @@
+package cassandra
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/k8ssandra/cass-operator/pkg/reconciliation"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNewDatacenter_CassandraEnvScript(t *testing.T) {
+	script := "JVM_OPTS=\"$JVM_OPTS -javaagent:/opt/agent/agent.jar\"\n"
+	template := GetDatacenterConfig()
+	template.CassandraEnvScript = script
+	podAnnotations := map[string]string{"team": "db"}
+	template.PodTemplateSpec.Annotations = podAnnotations
+	require.NoError(t, validateCassandraEnvScript(&template))
+
+	dc, err := NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(dc.Spec.Config), "cassandra-env-sh", "cass-config-builder has no setting for the fragment")
+	assert.Equal(t, script, dc.Spec.PodTemplateSpec.Annotations[api.CassandraEnvScriptAnnotation])
+	assert.Equal(t, "db", dc.Spec.PodTemplateSpec.Annotations["team"])
+	assert.NotContains(t, podAnnotations, api.CassandraEnvScriptAnnotation, "the annotations of the template are not modified")
+
+	initContainers := dc.Spec.PodTemplateSpec.Spec.InitContainers
+	configBuilder, found := FindInitContainer(dc.Spec.PodTemplateSpec, reconciliation.ServerConfigContainerName)
+	require.True(t, found)
+	envScript, found := FindInitContainer(dc.Spec.PodTemplateSpec, CassandraEnvScriptInitContainerName)
+	require.True(t, found)
+	assert.Less(t, configBuilder, envScript, "the fragment is installed once the config is rendered")
+	assert.Equal(t, defaultCassandraEnvScriptInitContainerImage, initContainers[envScript].Image)
+
+	volumes := dc.Spec.PodTemplateSpec.Spec.Volumes
+	require.Len(t, volumes, 1)
+	assert.Equal(t, CassandraEnvScriptVolumeName, volumes[0].Name)
+	require.NotNil(t, volumes[0].DownwardAPI)
+	assert.Equal(t, "metadata.annotations['k8ssandra.io/cassandra-env-script']", volumes[0].DownwardAPI.Items[0].FieldRef.FieldPath)
+
+	// Building the datacenter again does not duplicate the init container nor the volume
+	dc, err = NewDatacenter(types.NamespacedName{Name: "testdc", Namespace: "test-namespace"}, &template)
+	require.NoError(t, err)
+	assert.Len(t, dc.Spec.PodTemplateSpec.Spec.InitContainers, len(initContainers))
+	assert.Len(t, dc.Spec.PodTemplateSpec.Spec.Volumes, 1)
+}
+
+func TestCassandraEnvScriptCommand(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+	configDir, scriptDir := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "cassandra-env.sh"), []byte("JVM_OPTS=\"$JVM_OPTS -Xss256k\"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(scriptDir, cassandraEnvScriptFile),
+		[]byte("JVM_OPTS=\"$JVM_OPTS -javaagent:/opt/agent/agent.jar\"\n"), 0644))
+
+	// The init container runs again when the pod restarts
+	for i := 0; i < 2; i++ {
+		output, err := exec.Command(sh, "-c", cassandraEnvScriptCommand(configDir, scriptDir)).CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	rendered, err := os.ReadFile(filepath.Join(configDir, "cassandra-env.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, "JVM_OPTS=\"$JVM_OPTS -Xss256k\"\n\n. \"$CASSANDRA_CONF/cassandra-env-custom.sh\"\n", string(rendered))
+
+	// cassandra-env.sh is sourced with CASSANDRA_CONF set to the config directory the config was copied to
+	output, err := exec.Command(sh, "-c", `CASSANDRA_CONF="$1"; . "$1/cassandra-env.sh"; echo "$JVM_OPTS"`, sh, configDir).Output()
+	require.NoError(t, err)
+	assert.Equal(t, " -Xss256k -javaagent:/opt/agent/agent.jar\n", string(output))
+}
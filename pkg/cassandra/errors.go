@@ -1,7 +1,15 @@
 package cassandra
 
-type DCConfigIncomplete struct{ missingfield string }
+type DCConfigIncomplete struct {
+	datacenter   string
+	missingfield string
+}
 
 func (detail DCConfigIncomplete) Error() string {
-	return "DatacenterConfig did not contain required fields to process into a CassandraDatacenter, missing field " + detail.missingfield
+	return "DatacenterConfig of datacenter " + detail.datacenter + " did not contain required fields to process into a CassandraDatacenter, missing field " + detail.missingfield
+}
+
+// Datacenter returns the name of the datacenter whose config is incomplete.
+func (detail DCConfigIncomplete) Datacenter() string {
+	return detail.datacenter
 }
@@ -0,0 +1,136 @@
+package cassandra
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+)
+
+// featureMinVersions maps cassandra.yaml settings to the minimum Cassandra version that understands them. Older
+// versions either refuse to start or silently ignore such settings, so using them with an older ServerVersion is
+// rejected.
+var featureMinVersions = map[string]*semver.Version{
+	"audit_logging_options":        semver.MustParse("4.0.0"),
+	"full_query_logging_options":   semver.MustParse("4.0.0"),
+	"enable_transient_replication": semver.MustParse("4.0.0"),
+	"network_authorizer":           semver.MustParse("4.0.0"),
+	"auto_hints_cleanup_enabled":   semver.MustParse("4.1.0"),
+	"materialized_views_enabled":   semver.MustParse("4.1.0"),
+	"sasi_indexes_enabled":         semver.MustParse("4.1.0"),
+	"drop_compact_storage_enabled": semver.MustParse("4.1.0"),
+}
+
+// validateFeatureVersions checks that every version-dependent setting enabled in cassandra.yaml is supported by the
+// datacenter's ServerVersion. It only applies to Cassandra; DSE versions follow a different numbering scheme.
+func validateFeatureVersions(dcConfig *DatacenterConfig) error {
+	if dcConfig.ServerType != api.ServerDistributionCassandra || dcConfig.ServerVersion == nil {
+		return nil
+	}
+	features := make([]string, 0, len(featureMinVersions))
+	for feature := range featureMinVersions {
+		if _, found := dcConfig.CassandraConfig.CassandraYaml[feature]; found {
+			features = append(features, feature)
+		}
+	}
+	sort.Strings(features)
+	for _, feature := range features {
+		if minVersion := featureMinVersions[feature]; dcConfig.ServerVersion.LessThan(minVersion) {
+			return fmt.Errorf("cassandra.yaml setting %s requires Cassandra %s or later, but datacenter %s uses version %s",
+				feature, minVersion, dcConfig.Meta.Name, dcConfig.ServerVersion)
+		}
+	}
+	return nil
+}
+
+// settingChange describes a cassandra.yaml setting that was removed or deprecated in version, along with how to
+// replace it.
+type settingChange struct {
+	version *semver.Version
+	hint    string
+}
+
+var thriftRemoval = settingChange{version: semver.MustParse("4.0.0"), hint: "Thrift was removed, clients must use the native protocol"}
+
+// removedSettings maps cassandra.yaml settings to the Cassandra version that removed them. Cassandra refuses to start
+// with unknown settings, so using them with a later ServerVersion is rejected.
+var removedSettings = map[string]settingChange{
+	"start_rpc":                                thriftRemoval,
+	"rpc_port":                                 thriftRemoval,
+	"rpc_server_type":                          thriftRemoval,
+	"rpc_min_threads":                          thriftRemoval,
+	"rpc_max_threads":                          thriftRemoval,
+	"rpc_send_buff_size_in_bytes":              thriftRemoval,
+	"rpc_recv_buff_size_in_bytes":              thriftRemoval,
+	"thrift_framed_transport_size_in_mb":       thriftRemoval,
+	"thrift_prepared_statements_cache_size_mb": thriftRemoval,
+	"request_scheduler":                        thriftRemoval,
+	"request_scheduler_id":                     thriftRemoval,
+	"request_scheduler_options":                thriftRemoval,
+}
+
+func renamedIn41(replacement string) settingChange {
+	return settingChange{version: semver.MustParse("4.1.0"), hint: "use " + replacement + " instead"}
+}
+
+// deprecatedSettings maps cassandra.yaml settings to the Cassandra version that deprecated them. They are still
+// accepted, so using them only produces a warning.
+var deprecatedSettings = map[string]settingChange{
+	"enable_user_defined_functions":          renamedIn41("user_defined_functions_enabled"),
+	"enable_scripted_user_defined_functions": renamedIn41("scripted_user_defined_functions_enabled"),
+	"enable_materialized_views":              renamedIn41("materialized_views_enabled"),
+	"enable_sasi_indexes":                    renamedIn41("sasi_indexes_enabled"),
+	"enable_transient_replication":           renamedIn41("transient_replication_enabled"),
+	"enable_drop_compact_storage":            renamedIn41("drop_compact_storage_enabled"),
+	"cross_node_timeout":                     renamedIn41("internode_timeout"),
+	"max_hint_window_in_ms":                  renamedIn41("max_hint_window"),
+	"read_request_timeout_in_ms":             renamedIn41("read_request_timeout"),
+	"write_request_timeout_in_ms":            renamedIn41("write_request_timeout"),
+	"hinted_handoff_throttle_in_kb":          renamedIn41("hinted_handoff_throttle"),
+	"key_cache_size_in_mb":                   renamedIn41("key_cache_size"),
+	"row_cache_size_in_mb":                   renamedIn41("row_cache_size"),
+	"counter_cache_size_in_mb":               renamedIn41("counter_cache_size"),
+	"file_cache_size_in_mb":                  renamedIn41("file_cache_size"),
+	"memtable_heap_space_in_mb":              renamedIn41("memtable_heap_space"),
+	"memtable_offheap_space_in_mb":           renamedIn41("memtable_offheap_space"),
+}
+
+// changedSettings returns the settings of cassandra.yaml that are in changes and were changed in the datacenter's
+// ServerVersion or earlier, sorted by name. It only applies to Cassandra; DSE versions follow a different numbering
+// scheme.
+func changedSettings(dcConfig *DatacenterConfig, changes map[string]settingChange) []string {
+	if dcConfig.ServerType != api.ServerDistributionCassandra || dcConfig.ServerVersion == nil {
+		return nil
+	}
+	settings := make([]string, 0)
+	for setting, change := range changes {
+		if _, found := dcConfig.CassandraConfig.CassandraYaml[setting]; found && !dcConfig.ServerVersion.LessThan(change.version) {
+			settings = append(settings, setting)
+		}
+	}
+	sort.Strings(settings)
+	return settings
+}
+
+// validateRemovedSettings checks that cassandra.yaml does not contain settings that were removed in the datacenter's
+// ServerVersion or earlier.
+func validateRemovedSettings(dcConfig *DatacenterConfig) error {
+	if settings := changedSettings(dcConfig, removedSettings); len(settings) > 0 {
+		change := removedSettings[settings[0]]
+		return fmt.Errorf("cassandra.yaml setting %s was removed in Cassandra %s, but datacenter %s uses version %s: %s",
+			settings[0], change.version, dcConfig.Meta.Name, dcConfig.ServerVersion, change.hint)
+	}
+	return nil
+}
+
+// DeprecatedSettingWarnings returns a warning for each cassandra.yaml setting that is deprecated in the datacenter's
+// ServerVersion, along with its replacement.
+func DeprecatedSettingWarnings(dcConfig *DatacenterConfig) []string {
+	var warnings []string
+	for _, setting := range changedSettings(dcConfig, deprecatedSettings) {
+		change := deprecatedSettings[setting]
+		warnings = append(warnings, fmt.Sprintf("cassandra.yaml setting %s is deprecated since Cassandra %s, %s", setting, change.version, change.hint))
+	}
+	return warnings
+}
@@ -0,0 +1,160 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFeatureVersions(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverType    api.ServerDistribution
+		serverVersion string
+		cassandraYaml unstructured.Unstructured
+		wantErr       string
+	}{
+		{
+			name:          "no versioned feature",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "3.11.14",
+			cassandraYaml: unstructured.Unstructured{"concurrent_reads": 32},
+		},
+		{
+			name:          "feature supported by version",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.0.6",
+			cassandraYaml: unstructured.Unstructured{"audit_logging_options": map[string]interface{}{"enabled": true}},
+		},
+		{
+			name:          "feature supported by later version",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.1.0",
+			cassandraYaml: unstructured.Unstructured{"audit_logging_options": map[string]interface{}{"enabled": true}, "materialized_views_enabled": true},
+		},
+		{
+			name:          "feature too recent for 3.11",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "3.11.14",
+			cassandraYaml: unstructured.Unstructured{"audit_logging_options": map[string]interface{}{"enabled": true}},
+			wantErr:       "cassandra.yaml setting audit_logging_options requires Cassandra 4.0.0 or later, but datacenter dc1 uses version 3.11.14",
+		},
+		{
+			name:          "feature too recent for 4.0",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.0.6",
+			cassandraYaml: unstructured.Unstructured{"materialized_views_enabled": true},
+			wantErr:       "cassandra.yaml setting materialized_views_enabled requires Cassandra 4.1.0 or later, but datacenter dc1 uses version 4.0.6",
+		},
+		{
+			name:          "dse is not checked",
+			serverType:    api.ServerDistributionDse,
+			serverVersion: "6.8.25",
+			cassandraYaml: unstructured.Unstructured{"materialized_views_enabled": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dcConfig := GetDatacenterConfig()
+			dcConfig.ServerType = tt.serverType
+			dcConfig.ServerVersion = semver.MustParse(tt.serverVersion)
+			dcConfig.CassandraConfig.CassandraYaml = tt.cassandraYaml
+			err := ValidateDatacenterConfig(&dcConfig)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRemovedSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverType    api.ServerDistribution
+		serverVersion string
+		cassandraYaml unstructured.Unstructured
+		wantErr       string
+	}{
+		{
+			name:          "thrift settings before removal",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "3.11.14",
+			cassandraYaml: unstructured.Unstructured{"start_rpc": false, "rpc_port": 9160},
+		},
+		{
+			name:          "thrift settings after removal",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.0.6",
+			cassandraYaml: unstructured.Unstructured{"start_rpc": false, "rpc_port": 9160},
+			wantErr:       "cassandra.yaml setting rpc_port was removed in Cassandra 4.0.0, but datacenter dc1 uses version 4.0.6: Thrift was removed, clients must use the native protocol",
+		},
+		{
+			name:          "dse is not checked",
+			serverType:    api.ServerDistributionDse,
+			serverVersion: "6.8.25",
+			cassandraYaml: unstructured.Unstructured{"start_rpc": false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dcConfig := GetDatacenterConfig()
+			dcConfig.ServerType = tt.serverType
+			dcConfig.ServerVersion = semver.MustParse(tt.serverVersion)
+			dcConfig.CassandraConfig.CassandraYaml = tt.cassandraYaml
+			err := ValidateDatacenterConfig(&dcConfig)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeprecatedSettingWarnings(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverType    api.ServerDistribution
+		serverVersion string
+		cassandraYaml unstructured.Unstructured
+		want          []string
+	}{
+		{
+			name:          "not yet deprecated",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.0.6",
+			cassandraYaml: unstructured.Unstructured{"enable_user_defined_functions": true, "read_request_timeout_in_ms": 5000},
+		},
+		{
+			name:          "deprecated",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.1.0",
+			cassandraYaml: unstructured.Unstructured{"read_request_timeout_in_ms": 5000, "enable_user_defined_functions": true, "concurrent_reads": 32},
+			want: []string{
+				"cassandra.yaml setting enable_user_defined_functions is deprecated since Cassandra 4.1.0, use user_defined_functions_enabled instead",
+				"cassandra.yaml setting read_request_timeout_in_ms is deprecated since Cassandra 4.1.0, use read_request_timeout instead",
+			},
+		},
+		{
+			name:          "dse is not checked",
+			serverType:    api.ServerDistributionDse,
+			serverVersion: "6.8.25",
+			cassandraYaml: unstructured.Unstructured{"enable_user_defined_functions": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dcConfig := GetDatacenterConfig()
+			dcConfig.ServerType = tt.serverType
+			dcConfig.ServerVersion = semver.MustParse(tt.serverVersion)
+			dcConfig.CassandraConfig.CassandraYaml = tt.cassandraYaml
+			assert.NoError(t, ValidateDatacenterConfig(&dcConfig))
+			assert.Equal(t, tt.want, DeprecatedSettingWarnings(&dcConfig))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package cassandra
+
+import (
+	"fmt"
+
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LogShippingContainerName is the name of the log shipping sidecar in the Cassandra pods.
+	LogShippingContainerName = "log-shipper"
+
+	// DefaultLogShippingConfigMountPath is where the configuration of the log shipping sidecar is mounted when the
+	// LogShipping spec does not say otherwise.
+	DefaultLogShippingConfigMountPath = "/fluent-bit/etc"
+
+	logShippingConfigVolumeName = "log-shipping-config"
+
+	// serverLogsVolumeName is the volume that cass-operator creates for the Cassandra logs, and mounts at
+	// serverLogsMountPath in the cassandra and server-system-logger containers.
+	serverLogsVolumeName = "server-logs"
+	serverLogsMountPath  = "/var/log/cassandra"
+)
+
+// LogShippingConfigMapName returns the name of the copy of the log shipping configuration in the namespace of the DC.
+func LogShippingConfigMapName(kcName, dcName string) string {
+	return fmt.Sprintf("%s-%s-log-shipping", kcName, dcName)
+}
+
+// NewLogShippingConfigMap returns the copy of source, the ConfigMap holding the log shipping configuration, that is
+// replicated to the namespace of the DC.
+func NewLogShippingConfigMap(kc *api.K8ssandraCluster, dcConfig *DatacenterConfig, source *corev1.ConfigMap) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LogShippingConfigMapName(kc.SanitizedName(), dcConfig.Meta.Name),
+			Namespace: utils.FirstNonEmptyString(dcConfig.Meta.Namespace, kc.Namespace),
+			Labels: map[string]string{
+				api.NameLabel:                      api.NameLabelValue,
+				api.PartOfLabel:                    api.PartOfLabelValue,
+				api.ComponentLabel:                 api.ComponentLabelValueCassandra,
+				api.CreatedByLabel:                 api.CreatedByLabelValueK8ssandraClusterController,
+				api.K8ssandraClusterNameLabel:      kc.SanitizedName(),
+				api.K8ssandraClusterNamespaceLabel: kc.Namespace,
+			},
+		},
+		Data:       source.Data,
+		BinaryData: source.BinaryData,
+	}
+}
+
+// AddLogShippingSidecar adds the log shipping sidecar to the Cassandra pods of the DC. The sidecar mounts the Cassandra
+// logs and configMapName, the copy of the log shipping configuration in the namespace of the DC. configHash is stored
+// in the pod template so that a change of the configuration results in a rolling restart.
+func AddLogShippingSidecar(dcConfig *DatacenterConfig, logShipping *api.LogShipping, configMapName, configHash string) {
+	configVolume := &corev1.Volume{
+		Name: logShippingConfigVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+			},
+		},
+	}
+	volumeIndex, found := FindVolume(&dcConfig.PodTemplateSpec, configVolume.Name)
+	AddOrUpdateVolume(dcConfig, configVolume, volumeIndex, found)
+
+	UpdateContainer(&dcConfig.PodTemplateSpec, LogShippingContainerName, func(container *corev1.Container) {
+		container.Image = logShipping.Image
+		if logShipping.Resources != nil {
+			container.Resources = *logShipping.Resources
+		}
+		AddOrUpdateVolumeMount(container, configVolume, utils.FirstNonEmptyString(logShipping.ConfigMountPath, DefaultLogShippingConfigMountPath))
+		AddOrUpdateVolumeMount(container, &corev1.Volume{Name: serverLogsVolumeName}, serverLogsMountPath)
+	})
+
+	annotations.AddAnnotation(&dcConfig.PodTemplateSpec, api.LogShippingConfigHashAnnotation, configHash)
+}
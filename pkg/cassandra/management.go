@@ -2,20 +2,31 @@ package cassandra
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/errors"
 	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ManagementApiFactory creates request-scoped instances of ManagementApiFacade. This component exists
-// mostly to allow tests to provide mocks for the Management API client.
+// mostly to allow tests to provide mocks for the Management API client. The default factory also remembers the
+// coordinator of each datacenter, so that the facades it creates keep using the same node across requests.
 type ManagementApiFactory interface {
 
 	// NewManagementApiFacade returns a new ManagementApiFacade that will connect to the Management API of nodes in
@@ -33,9 +44,10 @@ func NewManagementApiFactory() ManagementApiFactory {
 }
 
 type defaultManagementApiFactory struct {
+	coordinators coordinators
 }
 
-func (d defaultManagementApiFactory) NewManagementApiFacade(
+func (d *defaultManagementApiFactory) NewManagementApiFacade(
 	ctx context.Context,
 	dc *cassdcapi.CassandraDatacenter,
 	k8sClient client.Client,
@@ -45,7 +57,14 @@ func (d defaultManagementApiFactory) NewManagementApiFacade(
 		return nil, err
 	} else if protocol, err := httphelper.GetManagementApiProtocol(dc); err != nil {
 		return nil, err
+	} else if requestTimeout, err := ManagementApiRequestTimeout(dc); err != nil {
+		return nil, err
 	} else {
+		if requestTimeout > 0 {
+			httpClient = &timeoutHttpClient{HttpClient: httpClient, ctx: ctx, timeout: requestTimeout}
+		} else {
+			requestTimeout = defaultRequestTimeout
+		}
 		nodeMgmtClient := &httphelper.NodeMgmtClient{
 			Client:   httpClient,
 			Log:      logger,
@@ -57,10 +76,77 @@ func (d defaultManagementApiFactory) NewManagementApiFacade(
 			nodeMgmtClient: nodeMgmtClient,
 			k8sClient:      k8sClient,
 			logger:         logger,
+			requestTimeout: requestTimeout,
+			coordinators:   &d.coordinators,
 		}, nil
 	}
 }
 
+// defaultRequestTimeout is the timeout of the requests built by defaultManagementApiFacade when the DC doesn't override
+// it.
+const defaultRequestTimeout = 60 * time.Second
+
+const (
+	// managementApiPort is the port of the management API endpoints.
+	managementApiPort = 8080
+
+	// metricsPort is the port of the Prometheus metrics endpoint of the management API.
+	metricsPort = 9000
+
+	// metricsProtocol is the protocol of the Prometheus metrics endpoint, which is served over plain HTTP even when
+	// the management API endpoints require TLS.
+	metricsProtocol = "http"
+)
+
+// metricsHttpClient sends the requests to the Prometheus metrics endpoints. The management API client can't be used,
+// since it is set up for the protocol and the certificates of the management API endpoints.
+var metricsHttpClient httphelper.HttpClient = &http.Client{}
+
+// ManagementApiRequestTimeout returns the management API request timeout configured for dc through the
+// ManagementApiRequestTimeoutAnnotation, or zero if the timeout is not overridden.
+func ManagementApiRequestTimeout(dc *cassdcapi.CassandraDatacenter) (time.Duration, error) {
+	value, found := dc.Annotations[api.ManagementApiRequestTimeoutAnnotation]
+	if !found {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q on CassandraDatacenter %s", api.ManagementApiRequestTimeoutAnnotation, value, dc.Name)
+	}
+	return timeout, nil
+}
+
+// timeoutHttpClient replaces the deadline of the requests sent through HttpClient. httphelper.NodeMgmtClient sets a
+// fixed timeout on each request, which is too short for some operations on busy clusters. The new deadline is derived
+// from ctx rather than from the request context, so that it can be longer than the original one.
+type timeoutHttpClient struct {
+	httphelper.HttpClient
+	ctx     context.Context
+	timeout time.Duration
+}
+
+func (c *timeoutHttpClient) Do(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	res, err := c.HttpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The body is read after Do returns, so the context must only be released when it is closed.
+	res.Body = &cancelOnCloseBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
 // ManagementApiFacade is a component mirroring methods available on httphelper.NodeMgmtClient.
 type ManagementApiFacade interface {
 
@@ -97,6 +183,28 @@ type ManagementApiFacade interface {
 	// GetSchemaVersions list all of the schema versions know to this node. The map keys are schema version UUIDs.
 	// The values are list of node IPs.
 	GetSchemaVersions() (map[string][]string, error)
+
+	// GetEndpointStates calls the management API "GET /api/v0/metadata/endpoints" endpoint to retrieve the gossip state
+	// of all the nodes known to the coordinator, across all datacenters.
+	GetEndpointStates() ([]httphelper.EndpointState, error)
+
+	// GetPendingCompactions calls the Prometheus metrics endpoint of the management API on each ready node of the datacenter, and
+	// returns the number of pending compaction tasks reported by each of them, keyed by pod name.
+	GetPendingCompactions() (map[string]int32, error)
+
+	// GetDroppedMutations calls the Prometheus metrics endpoint of the management API on each ready node of the datacenter, and
+	// returns the number of mutations dropped by each of them since it started, keyed by pod name.
+	GetDroppedMutations() (map[string]int64, error)
+
+	// MeasureManagementApiLatency calls the management API "GET /api/v0/probes/liveness" endpoint on each ready node of
+	// the datacenter, and returns the lowest round-trip time observed. The time to establish the connection is not
+	// included.
+	MeasureManagementApiLatency() (time.Duration, error)
+
+	// GetTokenOwnership calls the management API "GET /api/v1/ops/tokens/rangetoendpoint" endpoint to retrieve the
+	// token ranges of the system_auth keyspace, and returns the fraction of the token ring for which each ready node
+	// of the datacenter is the first replica within the datacenter, keyed by pod name.
+	GetTokenOwnership() (map[string]float64, error)
 }
 
 type defaultManagementApiFacade struct {
@@ -105,6 +213,39 @@ type defaultManagementApiFacade struct {
 	nodeMgmtClient *httphelper.NodeMgmtClient
 	k8sClient      client.Client
 	logger         logr.Logger
+	requestTimeout time.Duration
+
+	// coordinators records the coordinator of the datacenter, shared by all the facades of the same factory.
+	coordinators *coordinators
+}
+
+// coordinators records, for each datacenter, the name of the pod that last served a request successfully. It is safe
+// for concurrent use, and its zero value is ready to use.
+type coordinators struct {
+	mu    sync.Mutex
+	names map[string]string
+}
+
+// coordinatorKey identifies dc across clusters and namespaces.
+func coordinatorKey(dc *cassdcapi.CassandraDatacenter) string {
+	return fmt.Sprintf("%s/%s/%s", dc.Spec.ClusterName, dc.Namespace, dc.Name)
+}
+
+// get returns the coordinator recorded for dc, or an empty string if there is none.
+func (c *coordinators) get(dc *cassdcapi.CassandraDatacenter) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.names[coordinatorKey(dc)]
+}
+
+// set records podName as the coordinator of dc.
+func (c *coordinators) set(dc *cassdcapi.CassandraDatacenter, podName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.names == nil {
+		c.names = make(map[string]string)
+	}
+	c.names[coordinatorKey(dc)] = podName
 }
 
 func (r *defaultManagementApiFacade) CreateKeyspaceIfNotExists(
@@ -117,19 +258,56 @@ func (r *defaultManagementApiFacade) CreateKeyspaceIfNotExists(
 		return errors.NewSchemaDisagreementError(fmt.Sprintf("cannot create keyspace %s", keyspaceName))
 	}
 
-	if pods, err := r.fetchDatacenterPods(); err != nil {
+	if err := r.callCoordinator(func(pod *corev1.Pod) error {
+		if err := r.nodeMgmtClient.CreateKeyspace(pod, keyspaceName, r.createReplicationConfig(replication)); err != nil {
+			r.logger.Error(err, fmt.Sprintf("Failed to CALL create keyspace %s on pod %v", keyspaceName, pod.Name))
+			return err
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("CALL create keyspace %s failed: %w", keyspaceName, err)
+	}
+	return nil
+}
+
+// callCoordinator runs call against a single coordinator node of the datacenter. The coordinator recorded for the
+// datacenter is tried first; if there is none, or if it is not ready anymore or the call fails, the other ready pods
+// are tried in turn, sorted by name, and the first one to succeed becomes the new coordinator. The coordinator is
+// recorded by the factory that created this facade, so all the operations made through the facades of the same
+// factory are sent to the same node for as long as it is healthy, which avoids conflicting concurrent schema changes.
+// This does not hold across operator instances or restarts, where the pod with the lowest name is preferred again.
+func (r *defaultManagementApiFacade) callCoordinator(call func(pod *corev1.Pod) error) error {
+	pods, err := r.fetchDatacenterPods()
+	if err != nil {
 		r.logger.Error(err, "Failed to fetch datacenter pods")
 		return err
-	} else {
-		for _, pod := range pods {
-			if err := r.nodeMgmtClient.CreateKeyspace(&pod, keyspaceName, r.createReplicationConfig(replication)); err != nil {
-				r.logger.Error(err, fmt.Sprintf("Failed to CALL create keyspace %s on pod %v", keyspaceName, pod.Name))
-			} else {
-				return nil
+	}
+	coordinator := r.coordinators.get(r.dc)
+	for _, pod := range coordinatorCandidates(pods, coordinator) {
+		pod := pod
+		if err := call(&pod); err == nil {
+			if coordinator != pod.Name {
+				r.logger.Info("Selected new coordinator for management operations", "Pod", pod.Name, "PreviousCoordinator", coordinator)
+				r.coordinators.set(r.dc, pod.Name)
 			}
+			return nil
 		}
-		return fmt.Errorf("CALL create keyspace %s failed on all datacenter %v pods", keyspaceName, r.dc.Name)
 	}
+	return errors.NewManagementApiUnreachableError(fmt.Sprintf("no healthy coordinator found among datacenter %v pods", r.dc.Name))
+}
+
+// coordinatorCandidates returns the pods that can act as coordinator, in the order in which they should be tried: the
+// current coordinator first, if it's still among the given pods, then the remaining pods sorted by name.
+func coordinatorCandidates(pods []corev1.Pod, coordinator string) []corev1.Pod {
+	candidates := make([]corev1.Pod, len(pods))
+	copy(candidates, pods)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Name == coordinator || candidates[j].Name == coordinator {
+			return candidates[i].Name == coordinator
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates
 }
 
 func (r *defaultManagementApiFacade) fetchDatacenterPods() ([]corev1.Pod, error) {
@@ -143,7 +321,7 @@ func (r *defaultManagementApiFacade) fetchDatacenterPods() ([]corev1.Pod, error)
 			return status != nil && status.Ready
 		})
 		if len(pods) == 0 {
-			err = fmt.Errorf("no pods in READY state found in datacenter %v", r.dc.Name)
+			err = errors.NewManagementApiUnreachableError(fmt.Sprintf("no pods in READY state found in datacenter %v", r.dc.Name))
 			return nil, err
 		}
 		return pods, nil
@@ -157,7 +335,7 @@ func (r *defaultManagementApiFacade) filterPods(pods []corev1.Pod, filter func(c
 	filtered := make([]corev1.Pod, 0)
 	for _, pod := range pods {
 		if filter(pod) {
-			filtered = append(pods, pod)
+			filtered = append(filtered, pod)
 		}
 	}
 	return filtered
@@ -186,19 +364,16 @@ func (r *defaultManagementApiFacade) getCassandraContainerStatus(pod corev1.Pod)
 func (r *defaultManagementApiFacade) ListKeyspaces(
 	keyspaceName string,
 ) ([]string, error) {
-	if pods, err := r.fetchDatacenterPods(); err != nil {
-		r.logger.Error(err, "Failed to fetch datacenter pods")
-		return []string{}, err
-	} else {
-		for _, pod := range pods {
-			if keyspaces, err := r.nodeMgmtClient.GetKeyspace(&pod, keyspaceName); err != nil {
-				r.logger.Error(err, fmt.Sprintf("Failed to CALL list keyspaces %s on pod %v", keyspaceName, pod.Name))
-			} else {
-				return keyspaces, nil
-			}
+	var keyspaces []string
+	if err := r.callCoordinator(func(pod *corev1.Pod) (err error) {
+		if keyspaces, err = r.nodeMgmtClient.GetKeyspace(pod, keyspaceName); err != nil {
+			r.logger.Error(err, fmt.Sprintf("Failed to CALL list keyspaces %s on pod %v", keyspaceName, pod.Name))
 		}
-		return []string{}, fmt.Errorf("CALL list keyspaces %s failed on all datacenter %v pods", keyspaceName, r.dc.Name)
+		return err
+	}); err != nil {
+		return []string{}, fmt.Errorf("CALL list keyspaces %s failed: %w", keyspaceName, err)
 	}
+	return keyspaces, nil
 }
 
 func (r *defaultManagementApiFacade) AlterKeyspace(
@@ -211,54 +386,45 @@ func (r *defaultManagementApiFacade) AlterKeyspace(
 		return errors.NewSchemaDisagreementError(fmt.Sprintf("cannot alter keyspace %s", keyspaceName))
 	}
 
-	if pods, err := r.fetchDatacenterPods(); err != nil {
-		r.logger.Error(err, "Failed to fetch datacenter pods")
-		return err
-	} else {
-		for _, pod := range pods {
-			if err := r.nodeMgmtClient.AlterKeyspace(&pod, keyspaceName, r.createReplicationConfig(replicationSettings)); err != nil {
-				r.logger.Error(err, fmt.Sprintf("Failed to CALL alter keyspace %s on pod %v", keyspaceName, pod.Name))
-			} else {
-				r.logger.Info(fmt.Sprintf("Successfully altered keyspace %s replication", keyspaceName))
-				return nil
-			}
+	if err := r.callCoordinator(func(pod *corev1.Pod) error {
+		if err := r.nodeMgmtClient.AlterKeyspace(pod, keyspaceName, r.createReplicationConfig(replicationSettings)); err != nil {
+			r.logger.Error(err, fmt.Sprintf("Failed to CALL alter keyspace %s on pod %v", keyspaceName, pod.Name))
+			return err
 		}
-		return fmt.Errorf("CALL alter keyspaces %s failed on all datacenter %v pods", keyspaceName, r.dc.Name)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("CALL alter keyspaces %s failed: %w", keyspaceName, err)
 	}
+	r.logger.Info(fmt.Sprintf("Successfully altered keyspace %s replication", keyspaceName))
+	return nil
 }
 
 func (r *defaultManagementApiFacade) GetKeyspaceReplication(keyspaceName string) (map[string]string, error) {
-	if pods, err := r.fetchDatacenterPods(); err != nil {
-		r.logger.Error(err, "Failed to fetch datacenter pods")
-		return nil, err
-	} else {
-		for _, pod := range pods {
-			if replication, err := r.nodeMgmtClient.GetKeyspaceReplication(&pod, keyspaceName); err != nil {
-				r.logger.Error(err, fmt.Sprintf("Failed to CALL get keyspace %s replication on pod %v", keyspaceName, pod.Name))
-			} else {
-				r.logger.Info(fmt.Sprintf("Successfully got keyspace %s replication", keyspaceName))
-				return replication, nil
-			}
+	var replication map[string]string
+	if err := r.callCoordinator(func(pod *corev1.Pod) (err error) {
+		if replication, err = r.nodeMgmtClient.GetKeyspaceReplication(pod, keyspaceName); err != nil {
+			r.logger.Error(err, fmt.Sprintf("Failed to CALL get keyspace %s replication on pod %v", keyspaceName, pod.Name))
 		}
-		return nil, fmt.Errorf("CALL get keyspace %s replication failed on all datacenter %v pods", keyspaceName, r.dc.Name)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("CALL get keyspace %s replication failed: %w", keyspaceName, err)
 	}
+	r.logger.Info(fmt.Sprintf("Successfully got keyspace %s replication", keyspaceName))
+	return replication, nil
 }
 
 func (r *defaultManagementApiFacade) ListTables(keyspaceName string) ([]string, error) {
-	if pods, err := r.fetchDatacenterPods(); err != nil {
-		r.logger.Error(err, "Failed to fetch datacenter pods")
-		return nil, err
-	} else {
-		for _, pod := range pods {
-			if tables, err := r.nodeMgmtClient.ListTables(&pod, keyspaceName); err != nil {
-				r.logger.Error(err, fmt.Sprintf("Failed to CALL get keyspace %s tables on pod %v", keyspaceName, pod.Name))
-			} else {
-				r.logger.Info(fmt.Sprintf("Successfully got keyspace %s tables", keyspaceName))
-				return tables, nil
-			}
+	var tables []string
+	if err := r.callCoordinator(func(pod *corev1.Pod) (err error) {
+		if tables, err = r.nodeMgmtClient.ListTables(pod, keyspaceName); err != nil {
+			r.logger.Error(err, fmt.Sprintf("Failed to CALL get keyspace %s tables on pod %v", keyspaceName, pod.Name))
 		}
-		return nil, fmt.Errorf("CALL get keyspace %s tables failed on all datacenter %v pods", keyspaceName, r.dc.Name)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("CALL get keyspace %s tables failed: %w", keyspaceName, err)
 	}
+	r.logger.Info(fmt.Sprintf("Successfully got keyspace %s tables", keyspaceName))
+	return tables, nil
 }
 
 func (r *defaultManagementApiFacade) CreateTable(table *httphelper.TableDefinition) error {
@@ -268,20 +434,17 @@ func (r *defaultManagementApiFacade) CreateTable(table *httphelper.TableDefiniti
 		return errors.NewSchemaDisagreementError(fmt.Sprintf("cannot create table %s.%s", table.KeyspaceName, table.KeyspaceName))
 	}
 
-	if pods, err := r.fetchDatacenterPods(); err != nil {
-		r.logger.Error(err, "Failed to fetch datacenter pods")
-		return err
-	} else {
-		for _, pod := range pods {
-			if err := r.nodeMgmtClient.CreateTable(&pod, table); err != nil {
-				r.logger.Error(err, fmt.Sprintf("Failed to CALL create table on pod %v", pod.Name))
-			} else {
-				r.logger.Info(fmt.Sprintf("Successfully created table %s.%s", table.KeyspaceName, table.TableName))
-				return nil
-			}
+	if err := r.callCoordinator(func(pod *corev1.Pod) error {
+		if err := r.nodeMgmtClient.CreateTable(pod, table); err != nil {
+			r.logger.Error(err, fmt.Sprintf("Failed to CALL create table on pod %v", pod.Name))
+			return err
 		}
-		return fmt.Errorf("CALL create table failed on all datacenter %v pods", r.dc.Name)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("CALL create table failed: %w", err)
 	}
+	r.logger.Info(fmt.Sprintf("Successfully created table %s.%s", table.KeyspaceName, table.TableName))
+	return nil
 }
 
 func (r *defaultManagementApiFacade) EnsureKeyspaceReplication(keyspaceName string, replication map[string]int) error {
@@ -316,20 +479,285 @@ func (r *defaultManagementApiFacade) EnsureKeyspaceReplication(keyspaceName stri
 }
 
 func (r *defaultManagementApiFacade) GetSchemaVersions() (map[string][]string, error) {
+	var schemaVersions map[string][]string
+	if err := r.callCoordinator(func(pod *corev1.Pod) (err error) {
+		if schemaVersions, err = r.nodeMgmtClient.CallSchemaVersionsEndpoint(pod); err != nil {
+			r.logger.V(4).Error(err, "failed to list schema versions", "Pod", pod.Name)
+		}
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get schema version in CassandraDatacenter %v: %w", utils.GetKey(r.dc), err)
+	}
+	return schemaVersions, nil
+}
+
+func (r *defaultManagementApiFacade) GetEndpointStates() ([]httphelper.EndpointState, error) {
+	var endpoints httphelper.CassMetadataEndpoints
+	if err := r.callCoordinator(func(pod *corev1.Pod) (err error) {
+		if endpoints, err = r.nodeMgmtClient.CallMetadataEndpointsEndpoint(pod); err != nil {
+			r.logger.V(4).Error(err, "failed to get endpoint states", "Pod", pod.Name)
+		}
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get endpoint states in CassandraDatacenter %v: %w", utils.GetKey(r.dc), err)
+	}
+	return endpoints.Entity, nil
+}
+
+func (r *defaultManagementApiFacade) GetPendingCompactions() (map[string]int32, error) {
+	pods, err := r.fetchDatacenterPods()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending compactions in CassandraDatacenter %v: %w", utils.GetKey(r.dc), err)
+	}
+	pendingCompactions := make(map[string]int32, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		body, err := r.callMetricsEndpoint(pod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pending compactions of pod %v: %w", pod.Name, err)
+		}
+		if pendingCompactions[pod.Name], err = parsePendingCompactions(body); err != nil {
+			return nil, fmt.Errorf("failed to get pending compactions of pod %v: %w", pod.Name, err)
+		}
+	}
+	return pendingCompactions, nil
+}
+
+const compactionPendingTasksMetric = "org_apache_cassandra_metrics_compaction_pending_tasks"
+
+// parsePendingCompactions returns the number of pending compaction tasks reported in the given Prometheus text
+// exposition. These are the compactions the node estimates it still has to run, not the ones in progress.
+func parsePendingCompactions(metrics []byte) (int32, error) {
+	pending, err := sumMetric(metrics, compactionPendingTasksMetric)
+	return int32(pending), err
+}
+
+func (r *defaultManagementApiFacade) GetDroppedMutations() (map[string]int64, error) {
+	pods, err := r.fetchDatacenterPods()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dropped mutations in CassandraDatacenter %v: %w", utils.GetKey(r.dc), err)
+	}
+	droppedMutations := make(map[string]int64, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		body, err := r.callMetricsEndpoint(pod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dropped mutations of pod %v: %w", pod.Name, err)
+		}
+		if droppedMutations[pod.Name], err = parseDroppedMutations(body); err != nil {
+			return nil, fmt.Errorf("failed to get dropped mutations of pod %v: %w", pod.Name, err)
+		}
+	}
+	return droppedMutations, nil
+}
+
+const droppedMessagesMetric = "org_apache_cassandra_metrics_dropped_message_dropped_total"
+
+// parseDroppedMutations returns the number of dropped mutations reported in the given Prometheus text exposition.
+// Internal mutations, such as hints or batch log replays, are not counted.
+func parseDroppedMutations(metrics []byte) (int64, error) {
+	dropped, err := sumMetric(metrics, droppedMessagesMetric, `message_type="MUTATION"`)
+	return int64(dropped), err
+}
+
+// sumMetric returns the sum of the samples of the metric name in the given Prometheus text exposition, only counting
+// the samples that have all the given labels, formatted as name="value".
+func sumMetric(metrics []byte, name string, labels ...string) (float64, error) {
+	var sum float64
+	for _, line := range strings.Split(string(metrics), "\n") {
+		if !strings.HasPrefix(line, name+"{") && !strings.HasPrefix(line, name+" ") {
+			continue
+		}
+		matches := true
+		for _, label := range labels {
+			matches = matches && strings.Contains(line, label)
+		}
+		if !matches {
+			continue
+		}
+		fields := strings.Fields(line[strings.LastIndex(line, "}")+1:])
+		if strings.HasPrefix(line, name+" ") {
+			fields = strings.Fields(line[len(name):])
+		}
+		if len(fields) == 0 {
+			return 0, fmt.Errorf("malformed metric: %s", line)
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, fmt.Errorf("malformed metric: %s", line)
+		}
+		sum += value
+	}
+	return sum, nil
+}
+
+func (r *defaultManagementApiFacade) MeasureManagementApiLatency() (time.Duration, error) {
 	pods, err := r.fetchDatacenterPods()
 	if err != nil {
+		return 0, fmt.Errorf("failed to measure latency in CassandraDatacenter %v: %w", utils.GetKey(r.dc), err)
+	}
+	var latency time.Duration
+	for i := range pods {
+		pod := &pods[i]
+		// the first request establishes the connection, and leaves it open for the measured one to reuse it
+		if _, err := r.sendGetRequest(pod, managementApiPort, httphelper.LivenessEndpoint, true); err != nil {
+			r.logger.V(4).Error(err, "failed to measure latency", "Pod", pod.Name)
+			continue
+		}
+		start := time.Now()
+		if _, err := r.callGetEndpoint(pod, httphelper.LivenessEndpoint); err != nil {
+			r.logger.V(4).Error(err, "failed to measure latency", "Pod", pod.Name)
+			continue
+		}
+		if roundTripTime := time.Since(start); latency == 0 || roundTripTime < latency {
+			latency = roundTripTime
+		}
+	}
+	if latency == 0 {
+		return 0, fmt.Errorf("failed to measure latency in CassandraDatacenter %v: no node responded", utils.GetKey(r.dc))
+	}
+	return latency, nil
+}
+
+// tokenRangeToEndpoints is the response of the management API rangetoendpoint endpoint.
+type tokenRangeToEndpoints struct {
+	TokenRangeToEndpoints []tokenRange `json:"token_range_to_endpoints"`
+}
+
+// tokenRange is a token range and its replicas.
+type tokenRange struct {
+	Tokens    []int64  `json:"tokens"`
+	Endpoints []string `json:"endpoints"`
+}
+
+// parseTokenRanges parses the response of the management API rangetoendpoint endpoint.
+func parseTokenRanges(body []byte) ([]tokenRange, error) {
+	var response tokenRangeToEndpoints
+	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, err
 	}
+	return response.TokenRangeToEndpoints, nil
+}
 
+func (r *defaultManagementApiFacade) GetTokenOwnership() (map[string]float64, error) {
+	pods, err := r.fetchDatacenterPods()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token ownership in CassandraDatacenter %v: %w", utils.GetKey(r.dc), err)
+	}
+	podsByAddress := make(map[string]string, len(pods))
 	for _, pod := range pods {
-		if schemaVersions, err := r.nodeMgmtClient.CallSchemaVersionsEndpoint(&pod); err != nil {
-			r.logger.V(4).Error(err, "failed to list schema versions", "Pod", pod.Name)
-		} else {
-			return schemaVersions, nil
+		if pod.Status.PodIP != "" {
+			podsByAddress[pod.Status.PodIP] = pod.Name
 		}
 	}
+	var ranges []tokenRange
+	if err := r.callCoordinator(func(pod *corev1.Pod) error {
+		body, err := r.callGetEndpoint(pod, "/api/v1/ops/tokens/rangetoendpoint?keyspaceName=system_auth")
+		if err != nil {
+			r.logger.V(4).Error(err, "failed to get token ranges", "Pod", pod.Name)
+			return err
+		}
+		ranges, err = parseTokenRanges(body)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get token ownership in CassandraDatacenter %v: %w", utils.GetKey(r.dc), err)
+	}
+	return primaryOwnership(ranges, podsByAddress)
+}
 
-	return nil, fmt.Errorf("failed to get schema version on all pods in CassandraDatacenter %v", utils.GetKey(r.dc))
+// primaryOwnership returns the fraction of the token ring for which each pod of podsByAddress is the first replica
+// among the pods of podsByAddress. Pods that own no range are included with an ownership of 0.
+func primaryOwnership(ranges []tokenRange, podsByAddress map[string]string) (map[string]float64, error) {
+	ownership := make(map[string]float64, len(podsByAddress))
+	for _, pod := range podsByAddress {
+		ownership[pod] = 0
+	}
+	for _, replicas := range ranges {
+		if len(replicas.Tokens) != 2 {
+			return nil, fmt.Errorf("invalid token range %v", replicas.Tokens)
+		}
+		start, end := replicas.Tokens[0], replicas.Tokens[1]
+		// Murmur3 tokens cover the whole int64 range, so the size of a range is computed modulo 2^64, which handles the
+		// range wrapping around the ring. A range starting and ending on the same token is the whole ring.
+		fraction := 1.0
+		if size := uint64(end) - uint64(start); size != 0 {
+			fraction = float64(size) / math.Pow(2, 64)
+		}
+		for _, endpoint := range replicas.Endpoints {
+			address := strings.TrimPrefix(endpoint, "/")
+			if host, _, err := net.SplitHostPort(address); err == nil {
+				address = host
+			}
+			if pod, found := podsByAddress[address]; found {
+				ownership[pod] += fraction
+				break
+			}
+		}
+	}
+	return ownership, nil
+}
+
+// callGetEndpoint sends a GET request to the given management API path of the node running in the given pod, and
+// returns the response body. httphelper doesn't expose all the endpoints, so the request is built here, in the same
+// way as httphelper does.
+func (r *defaultManagementApiFacade) callGetEndpoint(pod *corev1.Pod, path string) ([]byte, error) {
+	return r.callEndpoint(pod, managementApiPort, path)
+}
+
+// callEndpoint sends a GET request to the given port of the management API container of pod.
+func (r *defaultManagementApiFacade) callEndpoint(pod *corev1.Pod, port int, path string) ([]byte, error) {
+	return r.sendGetRequest(pod, port, path, false)
+}
+
+// callMetricsEndpoint returns the Prometheus text exposition of the metrics of the node running in pod.
+func (r *defaultManagementApiFacade) callMetricsEndpoint(pod *corev1.Pod) ([]byte, error) {
+	return r.sendRequest(metricsHttpClient, metricsProtocol, pod, metricsPort, "/metrics", false)
+}
+
+// sendGetRequest sends a GET request to the given port of the management API container of pod. The connection is
+// closed once the response is read, unless keepAlive is true.
+func (r *defaultManagementApiFacade) sendGetRequest(pod *corev1.Pod, port int, path string, keepAlive bool) ([]byte, error) {
+	return r.sendRequest(r.nodeMgmtClient.Client, r.nodeMgmtClient.Protocol, pod, port, path, keepAlive)
+}
+
+// sendRequest sends a GET request with httpClient to the given port of the management API container of pod, using
+// protocol. The connection is closed once the response is read, unless keepAlive is true.
+func (r *defaultManagementApiFacade) sendRequest(
+	httpClient httphelper.HttpClient,
+	protocol string,
+	pod *corev1.Pod,
+	port int,
+	path string,
+	keepAlive bool,
+) ([]byte, error) {
+	podHost, err := httphelper.BuildPodHostFromPod(pod)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(r.ctx, r.requestTimeout)
+	defer cancel()
+	url := fmt.Sprintf("%s://%s:%d%s", protocol, podHost, port, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Close = !keepAlive
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			r.logger.Error(err, "unable to close response body")
+		}
+	}()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, &httphelper.RequestError{
+			StatusCode: res.StatusCode,
+			Err:        fmt.Errorf("incorrect status code of %d when calling endpoint", res.StatusCode),
+		}
+	}
+	return io.ReadAll(res.Body)
 }
 
 func (r *defaultManagementApiFacade) HasSchemaAgreement() (bool, error) {
@@ -0,0 +1,264 @@
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	kerrors "github.com/k8ssandra/k8ssandra-operator/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCoordinatorCandidates(t *testing.T) {
+	pods := []corev1.Pod{newPod("pod-c", true), newPod("pod-a", true), newPod("pod-b", true)}
+
+	assert.Equal(t, []string{"pod-a", "pod-b", "pod-c"}, podNames(coordinatorCandidates(pods, "")))
+	assert.Equal(t, []string{"pod-b", "pod-a", "pod-c"}, podNames(coordinatorCandidates(pods, "pod-b")))
+	assert.Equal(t, []string{"pod-a", "pod-b", "pod-c"}, podNames(coordinatorCandidates(pods, "pod-gone")))
+	assert.Equal(t, []string{"pod-c", "pod-a", "pod-b"}, podNames(pods), "input slice should not be modified")
+}
+
+func TestCallCoordinator(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().WithObjects(
+		podObject(newPod("pod-a", true)),
+		podObject(newPod("pod-b", true)),
+		podObject(newPod("pod-c", false)),
+	).Build()
+	dc := &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"}}
+	newFacade := func(coordinators *coordinators) *defaultManagementApiFacade {
+		return &defaultManagementApiFacade{
+			ctx:          context.Background(),
+			dc:           dc,
+			k8sClient:    k8sClient,
+			logger:       testr.New(t),
+			coordinators: coordinators,
+		}
+	}
+	facade := newFacade(&coordinators{})
+
+	var calls []string
+	healthy := map[string]bool{"pod-a": true, "pod-b": true, "pod-c": true}
+	call := func(pod *corev1.Pod) error {
+		calls = append(calls, pod.Name)
+		if !healthy[pod.Name] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	t.Run("single coordinator", func(t *testing.T) {
+		calls = nil
+		require.NoError(t, facade.callCoordinator(call))
+		require.NoError(t, facade.callCoordinator(call))
+		require.NoError(t, facade.callCoordinator(call))
+		assert.Equal(t, []string{"pod-a", "pod-a", "pod-a"}, calls)
+		assert.Equal(t, "pod-a", facade.coordinators.get(dc))
+	})
+
+	t.Run("failover", func(t *testing.T) {
+		calls = nil
+		healthy["pod-a"] = false
+		require.NoError(t, facade.callCoordinator(call))
+		require.NoError(t, facade.callCoordinator(call))
+		assert.Equal(t, []string{"pod-a", "pod-b", "pod-b"}, calls, "not ready pod-c should never be called")
+		assert.Equal(t, "pod-b", facade.coordinators.get(dc))
+	})
+
+	t.Run("shared across facades", func(t *testing.T) {
+		calls = nil
+		require.NoError(t, newFacade(facade.coordinators).callCoordinator(call))
+		assert.Equal(t, []string{"pod-b"}, calls)
+
+		calls = nil
+		require.NoError(t, newFacade(&coordinators{}).callCoordinator(call))
+		assert.Equal(t, []string{"pod-a", "pod-b"}, calls, "a facade of another factory should start from the lowest pod name")
+	})
+
+	t.Run("no healthy coordinator", func(t *testing.T) {
+		calls = nil
+		healthy["pod-b"] = false
+		err := facade.callCoordinator(call)
+		assert.True(t, kerrors.IsManagementApiUnreachable(err), "expected a ManagementApiUnreachable error, got %v", err)
+		assert.Equal(t, []string{"pod-b", "pod-a"}, calls)
+		assert.Equal(t, "pod-b", facade.coordinators.get(dc))
+	})
+}
+
+type deadlineRecordingClient struct {
+	deadline time.Time
+	ctx      context.Context
+}
+
+func (c *deadlineRecordingClient) Do(req *http.Request) (*http.Response, error) {
+	c.ctx = req.Context()
+	c.deadline, _ = req.Context().Deadline()
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+}
+
+func TestManagementApiRequestTimeout(t *testing.T) {
+	ctx := context.Background()
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "dc1",
+			Annotations: map[string]string{api.ManagementApiRequestTimeoutAnnotation: "5m"},
+		},
+		Spec: cassdcapi.CassandraDatacenterSpec{
+			ManagementApiAuth: cassdcapi.ManagementApiAuthConfig{Insecure: &cassdcapi.ManagementApiAuthInsecureConfig{}},
+		},
+	}
+
+	facade, err := NewManagementApiFactory().NewManagementApiFacade(ctx, dc, fake.NewClientBuilder().Build(), testr.New(t))
+	require.NoError(t, err)
+	defaultFacade := facade.(*defaultManagementApiFacade)
+	assert.Equal(t, 5*time.Minute, defaultFacade.requestTimeout)
+	timeoutClient, ok := defaultFacade.nodeMgmtClient.Client.(*timeoutHttpClient)
+	require.True(t, ok, "the http client should apply the configured timeout")
+
+	// The request timeout set by httphelper is replaced, even when it is shorter than the configured one.
+	recorder := &deadlineRecordingClient{}
+	timeoutClient.HttpClient = recorder
+	reqCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, "http://localhost:8080/api/v0/ops/keyspace/create", nil)
+	require.NoError(t, err)
+	start := time.Now()
+	res, err := timeoutClient.Do(req)
+	require.NoError(t, err)
+	assert.WithinDuration(t, start.Add(5*time.Minute), recorder.deadline, 10*time.Second)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	assert.NoError(t, recorder.ctx.Err(), "the request should not be cancelled before its body is closed")
+	require.NoError(t, res.Body.Close())
+	assert.Error(t, recorder.ctx.Err())
+
+	delete(dc.Annotations, api.ManagementApiRequestTimeoutAnnotation)
+	facade, err = NewManagementApiFactory().NewManagementApiFacade(ctx, dc, fake.NewClientBuilder().Build(), testr.New(t))
+	require.NoError(t, err)
+	defaultFacade = facade.(*defaultManagementApiFacade)
+	assert.Equal(t, defaultRequestTimeout, defaultFacade.requestTimeout)
+	_, ok = defaultFacade.nodeMgmtClient.Client.(*timeoutHttpClient)
+	assert.False(t, ok)
+
+	dc.Annotations[api.ManagementApiRequestTimeoutAnnotation] = "soon"
+	_, err = NewManagementApiFactory().NewManagementApiFacade(ctx, dc, fake.NewClientBuilder().Build(), testr.New(t))
+	assert.Error(t, err)
+}
+
+func newPod(name string, ready bool) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    map[string]string{cassdcapi.DatacenterLabel: "dc1"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "cassandra", Ready: ready}},
+		},
+	}
+}
+
+func podObject(pod corev1.Pod) client.Object {
+	return &pod
+}
+
+func podNames(pods []corev1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestPrimaryOwnership(t *testing.T) {
+	podsByAddress := map[string]string{"10.0.0.1": "pod-a", "10.0.0.2": "pod-b", "10.0.0.3": "pod-c"}
+	ranges := []tokenRange{
+		// A quarter of the ring, for which a node of another datacenter is the first replica.
+		{Tokens: []int64{math.MinInt64, -4611686018427387904}, Endpoints: []string{"10.0.1.1", "10.0.0.1", "10.0.0.2"}},
+		// A quarter of the ring.
+		{Tokens: []int64{-4611686018427387904, 0}, Endpoints: []string{"/10.0.0.1:7000", "10.0.0.2"}},
+		// Half of the ring, wrapping around.
+		{Tokens: []int64{0, math.MinInt64}, Endpoints: []string{"10.0.0.2", "10.0.0.1"}},
+	}
+
+	ownership, err := primaryOwnership(ranges, podsByAddress)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, ownership["pod-a"], 0.0001)
+	assert.InDelta(t, 0.5, ownership["pod-b"], 0.0001)
+	assert.Contains(t, ownership, "pod-c")
+	assert.Equal(t, 0.0, ownership["pod-c"])
+
+	_, err = primaryOwnership([]tokenRange{{Tokens: []int64{0}}}, podsByAddress)
+	assert.Error(t, err)
+}
+
+func TestParseTokenRanges(t *testing.T) {
+	// response of the management API to GET /api/v1/ops/tokens/rangetoendpoint?keyspaceName=system_auth for a
+	// datacenter of 3 nodes with a single token each
+	body := `{"token_range_to_endpoints":[
+{"tokens":[-9223372036854775808,-3074457345618258603],"endpoints":["10.244.1.5","10.244.2.7","10.244.3.4"]},
+{"tokens":[-3074457345618258603,3074457345618258602],"endpoints":["10.244.2.7","10.244.3.4","10.244.1.5"]},
+{"tokens":[3074457345618258602,-9223372036854775808],"endpoints":["10.244.3.4","10.244.1.5","10.244.2.7"]}]}`
+
+	ranges, err := parseTokenRanges([]byte(body))
+	require.NoError(t, err)
+	require.Len(t, ranges, 3)
+	assert.Equal(t, []int64{math.MinInt64, -3074457345618258603}, ranges[0].Tokens)
+	assert.Equal(t, []string{"10.244.1.5", "10.244.2.7", "10.244.3.4"}, ranges[0].Endpoints)
+
+	podsByAddress := map[string]string{"10.244.1.5": "pod-a", "10.244.2.7": "pod-b", "10.244.3.4": "pod-c"}
+	ownership, err := primaryOwnership(ranges, podsByAddress)
+	require.NoError(t, err)
+	for _, pod := range podsByAddress {
+		assert.InDelta(t, 1.0/3, ownership[pod], 0.0001)
+	}
+}
+
+func TestParseDroppedMutations(t *testing.T) {
+	metrics := `# HELP org_apache_cassandra_metrics_dropped_message_dropped_total Dropped messages
+# TYPE org_apache_cassandra_metrics_dropped_message_dropped_total counter
+org_apache_cassandra_metrics_dropped_message_dropped_total{host="a",message_type="MUTATION",} 12.0
+org_apache_cassandra_metrics_dropped_message_dropped_total{host="a",message_type="COUNTER_MUTATION",} 3.0
+org_apache_cassandra_metrics_dropped_message_dropped_total{host="a",message_type="READ",} 7.0
+org_apache_cassandra_metrics_dropped_message_dropped_latency_count{host="a",message_type="MUTATION",} 40.0
+`
+	dropped, err := parseDroppedMutations([]byte(metrics))
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), dropped)
+
+	dropped, err = parseDroppedMutations([]byte("jvm_threads_current 42.0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), dropped)
+
+	_, err = parseDroppedMutations([]byte(`org_apache_cassandra_metrics_dropped_message_dropped_total{message_type="MUTATION",} many`))
+	assert.Error(t, err)
+}
+
+func TestParsePendingCompactions(t *testing.T) {
+	metrics := `# HELP org_apache_cassandra_metrics_compaction_pending_tasks Pending compaction tasks
+# TYPE org_apache_cassandra_metrics_compaction_pending_tasks gauge
+org_apache_cassandra_metrics_compaction_pending_tasks{host="a",cluster="test",datacenter="dc1",} 37.0
+org_apache_cassandra_metrics_compaction_completed_tasks{host="a",cluster="test",datacenter="dc1",} 1200.0
+`
+	pending, err := parsePendingCompactions([]byte(metrics))
+	require.NoError(t, err)
+	assert.Equal(t, int32(37), pending)
+
+	pending, err = parsePendingCompactions([]byte("org_apache_cassandra_metrics_compaction_pending_tasks 4.0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), pending, "samples without labels are counted")
+}
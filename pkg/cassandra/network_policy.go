@@ -0,0 +1,176 @@
+package cassandra
+
+import (
+	"sort"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/shared"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ManagementPorts are the ports of the management API, of the metrics endpoints and of the Medusa gRPC server, which
+// are called by the operators.
+var ManagementPorts = []int{8080, 9000, 9103, shared.BackupSidecarPort}
+
+const dnsPort = 53
+
+// internodePorts returns the ports used for gossip and streaming between the nodes of the DC, without and with
+// encryption.
+func internodePorts(dcConfig *DatacenterConfig) []int {
+	return distinctPorts(StoragePort(dcConfig), SslStoragePort(dcConfig))
+}
+
+// cqlPorts returns the ports of the native protocol of the DC, without and with a dedicated encrypted port.
+func cqlPorts(dcConfig *DatacenterConfig) []int {
+	return distinctPorts(NativeTransportPort(dcConfig), NativeTransportSslPort(dcConfig))
+}
+
+func distinctPorts(ports ...int) []int {
+	distinct := make([]int, 0, len(ports))
+	for _, port := range ports {
+		if !utils.SliceContains(distinct, port) {
+			distinct = append(distinct, port)
+		}
+	}
+	return distinct
+}
+
+// NetworkPolicyKey returns the key of the NetworkPolicy of the DC described by dcConfig.
+func NetworkPolicyKey(dcConfig *DatacenterConfig, namespace string) types.NamespacedName {
+	return types.NamespacedName{Namespace: namespace, Name: cassdcapi.CleanupForKubernetes(dcConfig.Cluster + "-" + dcConfig.Meta.Name + "-network-policy")}
+}
+
+// NewNetworkPolicy returns the NetworkPolicy selecting the Cassandra pods of the DC described by dcConfig, or nil if
+// the DC has no network policy. Internode traffic is allowed with the Cassandra and Stargate pods of the cluster in the
+// same k8s context, with the peer CIDRs, and with remoteSeeds, which are the addresses of the seeds of the DCs deployed
+// in other contexts. CQL and management traffic is allowed from the configured clients, CQL and JMX traffic from the
+// Reaper pods of the cluster, and any other ingress is denied. The ports are the ones configured for the DC.
+func NewNetworkPolicy(kcKey types.NamespacedName, dcConfig *DatacenterConfig, namespace string, remoteSeeds []string) *networkingv1.NetworkPolicy {
+	config := dcConfig.NetworkPolicy
+	if config == nil {
+		return nil
+	}
+
+	policyKey := NetworkPolicyKey(dcConfig, namespace)
+	internodePeers := internodePeers(kcKey, dcConfig, remoteSeeds)
+	reaperPeer := componentPeer(kcKey, api.ComponentLabelValueReaper)
+
+	cqlClients := config.AllowedClients
+	if len(cqlClients) > 0 {
+		// an empty list allows all the clients, Reaper must only be added to a restricted one
+		cqlClients = append(append([]networkingv1.NetworkPolicyPeer{}, cqlClients...), reaperPeer)
+	}
+
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{Ports: tcpPorts(internodePorts(dcConfig)), From: internodePeers},
+		{Ports: tcpPorts(cqlPorts(dcConfig)), From: cqlClients},
+		{Ports: tcpPorts([]int{JmxPort(dcConfig)}), From: []networkingv1.NetworkPolicyPeer{reaperPeer}},
+		{Ports: tcpPorts(ManagementPorts), From: config.ManagementClients},
+	}
+	ingress = append(ingress, config.AdditionalIngress...)
+
+	policyTypes := []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	var egress []networkingv1.NetworkPolicyEgressRule
+	if config.RestrictEgress {
+		policyTypes = append(policyTypes, networkingv1.PolicyTypeEgress)
+		udp := corev1.ProtocolUDP
+		tcp := corev1.ProtocolTCP
+		dns := intstr.FromInt(dnsPort)
+		egress = []networkingv1.NetworkPolicyEgressRule{
+			{Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &dns}, {Protocol: &tcp, Port: &dns}}},
+			{Ports: tcpPorts(internodePorts(dcConfig)), To: internodePeers},
+		}
+		egress = append(egress, config.AdditionalEgress...)
+	}
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      policyKey.Name,
+			Namespace: policyKey.Namespace,
+			Labels: map[string]string{
+				api.NameLabel:                      api.NameLabelValue,
+				api.PartOfLabel:                    api.PartOfLabelValue,
+				api.ComponentLabel:                 api.ComponentLabelValueCassandra,
+				api.CreatedByLabel:                 api.CreatedByLabelValueK8ssandraClusterController,
+				api.K8ssandraClusterNameLabel:      kcKey.Name,
+				api.K8ssandraClusterNamespaceLabel: kcKey.Namespace,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					cassdcapi.ClusterLabel:    cassdcapi.CleanLabelValue(dcConfig.Cluster),
+					cassdcapi.DatacenterLabel: dcConfig.Meta.Name,
+				},
+			},
+			Ingress:     ingress,
+			Egress:      egress,
+			PolicyTypes: policyTypes,
+		},
+	}
+	annotations.AddHashAnnotation(policy)
+	return policy
+}
+
+// internodePeers returns the peers allowed to exchange internode traffic with the DC: the Cassandra and Stargate pods
+// of the cluster in any namespace of the same k8s context, the peer CIDRs and the remote seeds.
+func internodePeers(kcKey types.NamespacedName, dcConfig *DatacenterConfig, remoteSeeds []string) []networkingv1.NetworkPolicyPeer {
+	peers := []networkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: &metav1.LabelSelector{},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{cassdcapi.ClusterLabel: cassdcapi.CleanLabelValue(dcConfig.Cluster)},
+			},
+		},
+		componentPeer(kcKey, api.ComponentLabelValueStargate),
+	}
+	for _, cidr := range dcConfig.NetworkPolicy.PeerCidrs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+	seeds := append([]string{}, remoteSeeds...)
+	sort.Strings(seeds)
+	for _, seed := range seeds {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: hostCidr(seed)}})
+	}
+	return peers
+}
+
+// componentPeer selects the pods of the given component, such as Stargate or Reaper, deployed for the K8ssandraCluster
+// of kcKey in any namespace of the k8s context.
+func componentPeer(kcKey types.NamespacedName, component string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				api.ComponentLabel:                 component,
+				api.K8ssandraClusterNameLabel:      kcKey.Name,
+				api.K8ssandraClusterNamespaceLabel: kcKey.Namespace,
+			},
+		},
+	}
+}
+
+func hostCidr(ip string) string {
+	if strings.Contains(ip, ":") {
+		return ip + "/128"
+	}
+	return ip + "/32"
+}
+
+func tcpPorts(ports []int) []networkingv1.NetworkPolicyPort {
+	tcp := corev1.ProtocolTCP
+	policyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		p := intstr.FromInt(port)
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &p})
+	}
+	return policyPorts
+}
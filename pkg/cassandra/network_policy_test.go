@@ -0,0 +1,190 @@
+package cassandra
+
+import (
+	"net"
+	"testing"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+)
+
+// trafficSource is either a pod with labels, or an address outside of the k8s context.
+type trafficSource struct {
+	podLabels map[string]string
+	ip        string
+}
+
+func TestNewNetworkPolicy(t *testing.T) {
+	kcKey := types.NamespacedName{Namespace: "k8ssandra", Name: "test"}
+	dcConfig := GetDatacenterConfig()
+	assert.Nil(t, NewNetworkPolicy(kcKey, &dcConfig, "k8ssandra", nil))
+
+	dcConfig.NetworkPolicy = &api.NetworkPolicyConfig{
+		AllowedClients: []networkingv1.NetworkPolicyPeer{{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}},
+		}},
+		PeerCidrs: []string{"10.200.0.0/16"},
+	}
+	policy := NewNetworkPolicy(kcKey, &dcConfig, "k8ssandra", []string{"10.100.0.5"})
+	require.NotNil(t, policy)
+	assert.Equal(t, NetworkPolicyKey(&dcConfig, "k8ssandra").Name, policy.Name)
+	assert.Equal(t, "test", policy.Labels[api.K8ssandraClusterNameLabel])
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, policy.Spec.PolicyTypes)
+	assert.Empty(t, policy.Spec.Egress)
+
+	selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+	require.NoError(t, err)
+	assert.True(t, selector.Matches(labels.Set{cassdcapi.ClusterLabel: "k8ssandra", cassdcapi.DatacenterLabel: "dc1"}))
+	assert.False(t, selector.Matches(labels.Set{cassdcapi.ClusterLabel: "k8ssandra", cassdcapi.DatacenterLabel: "dc2"}))
+
+	localDc := trafficSource{podLabels: map[string]string{cassdcapi.ClusterLabel: "k8ssandra", cassdcapi.DatacenterLabel: "dc2"}}
+	otherCluster := trafficSource{podLabels: map[string]string{cassdcapi.ClusterLabel: "other", cassdcapi.DatacenterLabel: "dc1"}}
+	client := trafficSource{podLabels: map[string]string{"app": "client"}}
+	stargate := trafficSource{podLabels: map[string]string{
+		api.ComponentLabel: api.ComponentLabelValueStargate, api.K8ssandraClusterNameLabel: "test", api.K8ssandraClusterNamespaceLabel: "k8ssandra",
+	}}
+	reaper := trafficSource{podLabels: map[string]string{
+		api.ComponentLabel: api.ComponentLabelValueReaper, api.K8ssandraClusterNameLabel: "test", api.K8ssandraClusterNamespaceLabel: "k8ssandra",
+	}}
+	otherReaper := trafficSource{podLabels: map[string]string{
+		api.ComponentLabel: api.ComponentLabelValueReaper, api.K8ssandraClusterNameLabel: "other", api.K8ssandraClusterNamespaceLabel: "k8ssandra",
+	}}
+	remoteSeed := trafficSource{ip: "10.100.0.5"}
+	remoteNode := trafficSource{ip: "10.200.3.4"}
+	unknown := trafficSource{ip: "10.100.0.6"}
+
+	for _, port := range []int{7000, 7001} {
+		assert.True(t, allowsIngress(t, policy, localDc, port), "internode port %d from a DC of the same context", port)
+		assert.True(t, allowsIngress(t, policy, stargate, port), "internode port %d from Stargate", port)
+		assert.True(t, allowsIngress(t, policy, remoteSeed, port), "internode port %d from a remote seed", port)
+		assert.True(t, allowsIngress(t, policy, remoteNode, port), "internode port %d from a peer CIDR", port)
+		assert.False(t, allowsIngress(t, policy, otherCluster, port), "internode port %d from another cluster", port)
+		assert.False(t, allowsIngress(t, policy, unknown, port), "internode port %d from an unknown address", port)
+		assert.False(t, allowsIngress(t, policy, client, port), "internode port %d from a client", port)
+	}
+	for _, port := range []int{9042, 9142} {
+		assert.True(t, allowsIngress(t, policy, client, port), "CQL port %d from a client", port)
+		assert.True(t, allowsIngress(t, policy, reaper, port), "CQL port %d from Reaper", port)
+		assert.False(t, allowsIngress(t, policy, unknown, port), "CQL port %d from an unknown address", port)
+	}
+	assert.True(t, allowsIngress(t, policy, reaper, 7199), "JMX port from Reaper")
+	assert.False(t, allowsIngress(t, policy, otherReaper, 7199), "JMX port from the Reaper of another cluster")
+	for _, port := range []int{8080, 9000, 9103, 50051} {
+		assert.True(t, allowsIngress(t, policy, unknown, port), "management port %d is open by default", port)
+	}
+	for _, port := range []int{7199, 9160, 22} {
+		assert.False(t, allowsIngress(t, policy, localDc, port), "port %d from a DC of the same context", port)
+		assert.False(t, allowsIngress(t, policy, remoteSeed, port), "port %d from a remote seed", port)
+		assert.False(t, allowsIngress(t, policy, client, port), "port %d from a client", port)
+	}
+}
+
+func TestNewNetworkPolicyConfiguredPorts(t *testing.T) {
+	kcKey := types.NamespacedName{Namespace: "k8ssandra", Name: "test"}
+	dcConfig := GetDatacenterConfig()
+	dcConfig.NetworkPolicy = &api.NetworkPolicyConfig{}
+	dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{
+		"storage_port":              7010,
+		"ssl_storage_port":          7011,
+		"native_transport_port":     9052,
+		"native_transport_port_ssl": 9052,
+	}
+	dcConfig.CassandraConfig.JvmOptions.JmxPort = pointer.Int(7299)
+	policy := NewNetworkPolicy(kcKey, &dcConfig, "k8ssandra", nil)
+	require.NotNil(t, policy)
+
+	localDc := trafficSource{podLabels: map[string]string{cassdcapi.ClusterLabel: "k8ssandra", cassdcapi.DatacenterLabel: "dc2"}}
+	reaper := trafficSource{podLabels: map[string]string{
+		api.ComponentLabel: api.ComponentLabelValueReaper, api.K8ssandraClusterNameLabel: "test", api.K8ssandraClusterNamespaceLabel: "k8ssandra",
+	}}
+	for _, port := range []int{7010, 7011} {
+		assert.True(t, allowsIngress(t, policy, localDc, port), "internode port %d", port)
+	}
+	for _, port := range []int{7000, 7001} {
+		assert.False(t, allowsIngress(t, policy, localDc, port), "default internode port %d", port)
+	}
+	assert.Len(t, policy.Spec.Ingress[1].Ports, 1, "the CQL ports are deduplicated")
+	assert.Equal(t, 9052, policy.Spec.Ingress[1].Ports[0].Port.IntValue())
+	assert.True(t, allowsIngress(t, policy, reaper, 7299), "configured JMX port from Reaper")
+	assert.False(t, allowsIngress(t, policy, reaper, 7199), "default JMX port from Reaper")
+}
+
+func TestNewNetworkPolicyRestrictEgress(t *testing.T) {
+	kcKey := types.NamespacedName{Namespace: "k8ssandra", Name: "test"}
+	dcConfig := GetDatacenterConfig()
+	dcConfig.NetworkPolicy = &api.NetworkPolicyConfig{
+		RestrictEgress: true,
+		AdditionalEgress: []networkingv1.NetworkPolicyEgressRule{{
+			To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.0/24"}}},
+		}},
+	}
+	policy := NewNetworkPolicy(kcKey, &dcConfig, "k8ssandra", []string{"10.100.0.5"})
+	require.NotNil(t, policy)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, policy.Spec.PolicyTypes)
+	require.Len(t, policy.Spec.Egress, 3)
+	assert.Equal(t, int32(dnsPort), policy.Spec.Egress[0].Ports[0].Port.IntVal)
+	assert.Equal(t, policy.Spec.Ingress[0].From, policy.Spec.Egress[1].To, "internode egress goes to the same peers as internode ingress")
+	assert.Equal(t, "192.168.0.0/24", policy.Spec.Egress[2].To[0].IPBlock.CIDR)
+}
+
+func TestValidateDatacenterConfig_NetworkPolicy(t *testing.T) {
+	dcConfig := GetDatacenterConfig()
+	dcConfig.NetworkPolicy = &api.NetworkPolicyConfig{PeerCidrs: []string{"10.0.0.0/8", "fd00::/8"}}
+	assert.NoError(t, ValidateDatacenterConfig(&dcConfig))
+
+	dcConfig.NetworkPolicy.PeerCidrs = []string{"10.0.0.1"}
+	assert.Error(t, ValidateDatacenterConfig(&dcConfig))
+}
+
+// allowsIngress evaluates the ingress rules of policy for traffic from source to port. Namespace selectors are
+// ignored, as all the pods are assumed to live in namespaces selected by the rules.
+func allowsIngress(t *testing.T, policy *networkingv1.NetworkPolicy, source trafficSource, port int) bool {
+	for _, rule := range policy.Spec.Ingress {
+		if matchesPort(rule.Ports, port) && matchesPeer(t, rule.From, source) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesPort(ports []networkingv1.NetworkPolicyPort, port int) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		if p.Port == nil || p.Port.IntValue() == port {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesPeer(t *testing.T, peers []networkingv1.NetworkPolicyPeer, source trafficSource) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			_, cidr, err := net.ParseCIDR(peer.IPBlock.CIDR)
+			require.NoError(t, err)
+			if source.ip != "" && cidr.Contains(net.ParseIP(source.ip)) {
+				return true
+			}
+		} else if peer.PodSelector != nil && source.podLabels != nil {
+			selector, err := metav1.LabelSelectorAsSelector(peer.PodSelector)
+			require.NoError(t, err)
+			if selector.Matches(labels.Set(source.podLabels)) {
+				return true
+			}
+		}
+	}
+	return false
+}
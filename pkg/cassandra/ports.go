@@ -0,0 +1,77 @@
+package cassandra
+
+import (
+	"github.com/Masterminds/semver/v3"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+)
+
+const (
+	// DefaultStoragePort is the internode port of the Cassandra nodes, unless storage_port is set in cassandra.yaml.
+	DefaultStoragePort = 7000
+	// DefaultSslStoragePort is the encrypted internode port of the Cassandra nodes, unless ssl_storage_port is set in
+	// cassandra.yaml.
+	DefaultSslStoragePort = 7001
+	// DefaultNativeTransportSslPort is the dedicated encrypted CQL port of the Cassandra nodes, unless
+	// native_transport_port_ssl is set in cassandra.yaml.
+	DefaultNativeTransportSslPort = 9142
+	// DefaultJmxPort is the JMX port of the Cassandra nodes, unless jmx_port is set in the JVM options.
+	DefaultJmxPort = 7199
+)
+
+// StoragePort returns the internode port of the nodes of the DC described by template.
+func StoragePort(template *DatacenterConfig) int {
+	return cassandraYamlPort(template, "storage_port", DefaultStoragePort)
+}
+
+// SslStoragePort returns the encrypted internode port of the nodes of the DC described by template.
+func SslStoragePort(template *DatacenterConfig) int {
+	return cassandraYamlPort(template, "ssl_storage_port", DefaultSslStoragePort)
+}
+
+// NativeTransportPort returns the CQL native transport port of the nodes of the DC described by template.
+func NativeTransportPort(template *DatacenterConfig) int {
+	return cassandraYamlPort(template, "native_transport_port", NativePort)
+}
+
+// NativeTransportSslPort returns the dedicated encrypted CQL port of the nodes of the DC described by template.
+func NativeTransportSslPort(template *DatacenterConfig) int {
+	return cassandraYamlPort(template, "native_transport_port_ssl", DefaultNativeTransportSslPort)
+}
+
+// JmxPort returns the JMX port of the nodes of the DC described by template.
+func JmxPort(template *DatacenterConfig) int {
+	if port := template.CassandraConfig.JvmOptions.JmxPort; port != nil {
+		return *port
+	}
+	return DefaultJmxPort
+}
+
+// InternodePort returns the port the nodes of the DC described by template actually listen on for internode traffic.
+// With internode encryption, DSE and Cassandra before 4.0 use the ssl_storage_port, while Cassandra 4.0 and later
+// serve encrypted traffic on the storage_port.
+func InternodePort(template *DatacenterConfig) int {
+	if ServerEncryptionEnabled(template) && (template.ServerType == api.ServerDistributionDse ||
+		(template.ServerVersion != nil && template.ServerVersion.LessThan(semver.MustParse("4.0.0")))) {
+		return SslStoragePort(template)
+	}
+	return StoragePort(template)
+}
+
+// cassandraYamlPort returns the port set in cassandra.yaml under name, or defaultPort if it is not set.
+func cassandraYamlPort(template *DatacenterConfig, name string, defaultPort int) int {
+	value, found := template.CassandraConfig.CassandraYaml.Get(name)
+	if !found {
+		return defaultPort
+	}
+	switch port := value.(type) {
+	case int:
+		return port
+	case int32:
+		return int(port)
+	case int64:
+		return int(port)
+	case float64:
+		return int(port)
+	}
+	return defaultPort
+}
@@ -0,0 +1,64 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPorts(t *testing.T) {
+	encrypted := map[string]interface{}{"internode_encryption": "all"}
+	tests := []struct {
+		name          string
+		serverType    api.ServerDistribution
+		serverVersion string
+		yaml          unstructured.Unstructured
+		internode     int
+		native        int
+	}{
+		{name: "defaults", serverType: api.ServerDistributionCassandra, serverVersion: "4.0.6", internode: 7000, native: 9042},
+		{
+			name:          "configured ports",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.0.6",
+			yaml:          unstructured.Unstructured{"storage_port": float64(7010), "native_transport_port": int64(9052)},
+			internode:     7010,
+			native:        9052,
+		},
+		{
+			name:          "encryption with Cassandra 4.0",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.0.6",
+			yaml:          unstructured.Unstructured{"server_encryption_options": encrypted, "ssl_storage_port": 7011},
+			internode:     7000,
+			native:        9042,
+		},
+		{
+			name:          "encryption with Cassandra 3.11",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "3.11.14",
+			yaml:          unstructured.Unstructured{"server_encryption_options": encrypted},
+			internode:     7001,
+			native:        9042,
+		},
+		{
+			name:          "encryption with DSE",
+			serverType:    api.ServerDistributionDse,
+			serverVersion: "6.8.25",
+			yaml:          unstructured.Unstructured{"server_encryption_options": encrypted, "ssl_storage_port": 7011},
+			internode:     7011,
+			native:        9042,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &DatacenterConfig{ServerType: tt.serverType, ServerVersion: semver.MustParse(tt.serverVersion)}
+			template.CassandraConfig.CassandraYaml = tt.yaml
+			assert.Equal(t, tt.internode, InternodePort(template))
+			assert.Equal(t, tt.native, NativeTransportPort(template))
+		})
+	}
+}
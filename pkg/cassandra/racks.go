@@ -0,0 +1,81 @@
+package cassandra
+
+import (
+	"fmt"
+	"sort"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+)
+
+const (
+	zoneLabel           = "topology.kubernetes.io/zone"
+	deprecatedZoneLabel = "failure-domain.beta.kubernetes.io/zone"
+)
+
+// rackZones returns the distinct zones that rack is pinned to through its deprecated Zone field and its zone node
+// affinity labels. A coherent rack has at most one zone.
+func rackZones(rack cassdcapi.Rack) []string {
+	zones := make([]string, 0, 1)
+	for _, zone := range []string{rack.Zone, rack.NodeAffinityLabels[deprecatedZoneLabel], rack.NodeAffinityLabels[zoneLabel]} {
+		if zone != "" && !utils.SliceContains(zones, zone) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
+
+// validateRackZones checks that each rack of the DC is pinned to a single zone, and that a rack name is not declared
+// twice, which would map it to two sets of nodes.
+func validateRackZones(dcConfig *DatacenterConfig) error {
+	seen := make(map[string]bool)
+	for _, rack := range dcConfig.Racks {
+		if seen[rack.Name] {
+			return fmt.Errorf("rack %s is declared more than once in datacenter %s", rack.Name, dcConfig.Meta.Name)
+		}
+		seen[rack.Name] = true
+		if zones := rackZones(rack); len(zones) > 1 {
+			return fmt.Errorf("rack %s of datacenter %s is mapped to contradictory zones %v", rack.Name, dcConfig.Meta.Name, zones)
+		}
+	}
+	return nil
+}
+
+// RackZoneWarnings flags the rack names that are mapped to different zones by DCs deployed in the same context. This
+// is allowed, but usually means that the racks of the DCs don't share the same availability zone semantics.
+func RackZoneWarnings(dcConfigs []*DatacenterConfig) []string {
+	type rackKey struct{ k8sContext, rack string }
+	zonesByRack := make(map[rackKey]map[string][]string)
+	for _, dcConfig := range dcConfigs {
+		for _, rack := range dcConfig.Racks {
+			zones := rackZones(rack)
+			if len(zones) != 1 {
+				continue
+			}
+			key := rackKey{dcConfig.K8sContext, rack.Name}
+			if zonesByRack[key] == nil {
+				zonesByRack[key] = make(map[string][]string)
+			}
+			zonesByRack[key][zones[0]] = append(zonesByRack[key][zones[0]], dcConfig.Meta.Name)
+		}
+	}
+
+	warnings := make([]string, 0)
+	for key, dcsByZone := range zonesByRack {
+		if len(dcsByZone) < 2 {
+			continue
+		}
+		zones := make([]string, 0, len(dcsByZone))
+		for zone := range dcsByZone {
+			zones = append(zones, zone)
+		}
+		sort.Strings(zones)
+		mappings := make([]string, 0, len(zones))
+		for _, zone := range zones {
+			mappings = append(mappings, fmt.Sprintf("%s in %v", zone, dcsByZone[zone]))
+		}
+		warnings = append(warnings, fmt.Sprintf("rack %s is mapped to different zones: %v", key.rack, mappings))
+	}
+	sort.Strings(warnings)
+	return warnings
+}
@@ -0,0 +1,49 @@
+package cassandra
+
+import (
+	"testing"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRackZones(t *testing.T) {
+	dcConfig := &DatacenterConfig{
+		Meta: api.EmbeddedObjectMeta{Name: "dc1"},
+		Racks: []cassdcapi.Rack{
+			{Name: "rack1", NodeAffinityLabels: map[string]string{zoneLabel: "us-east-1a"}},
+			{Name: "rack2", Zone: "us-east-1b", NodeAffinityLabels: map[string]string{deprecatedZoneLabel: "us-east-1b"}},
+			{Name: "rack3"},
+		},
+	}
+	assert.NoError(t, validateRackZones(dcConfig))
+
+	dcConfig.Racks[1].NodeAffinityLabels[zoneLabel] = "us-east-1c"
+	assert.EqualError(t, validateRackZones(dcConfig), "rack rack2 of datacenter dc1 is mapped to contradictory zones [us-east-1b us-east-1c]")
+
+	dcConfig.Racks[1] = cassdcapi.Rack{Name: "rack1", NodeAffinityLabels: map[string]string{zoneLabel: "us-east-1b"}}
+	assert.EqualError(t, validateRackZones(dcConfig), "rack rack1 is declared more than once in datacenter dc1")
+}
+
+func TestRackZoneWarnings(t *testing.T) {
+	newDcConfig := func(name, k8sContext string, zonesByRack map[string]string) *DatacenterConfig {
+		dcConfig := &DatacenterConfig{Meta: api.EmbeddedObjectMeta{Name: name}, K8sContext: k8sContext}
+		for rack, zone := range zonesByRack {
+			dcConfig.Racks = append(dcConfig.Racks, cassdcapi.Rack{Name: rack, NodeAffinityLabels: map[string]string{zoneLabel: zone}})
+		}
+		return dcConfig
+	}
+
+	assert.Empty(t, RackZoneWarnings([]*DatacenterConfig{
+		newDcConfig("dc1", "cluster-0", map[string]string{"rack1": "us-east-1a"}),
+		newDcConfig("dc2", "cluster-0", map[string]string{"rack1": "us-east-1a"}),
+		newDcConfig("dc3", "cluster-1", map[string]string{"rack1": "eu-west-1a"}),
+	}), "racks in different contexts can map to different zones")
+
+	assert.Equal(t, []string{"rack rack1 is mapped to different zones: [us-east-1a in [dc1] us-east-1b in [dc2 dc3]]"}, RackZoneWarnings([]*DatacenterConfig{
+		newDcConfig("dc1", "cluster-0", map[string]string{"rack1": "us-east-1a"}),
+		newDcConfig("dc2", "cluster-0", map[string]string{"rack1": "us-east-1b"}),
+		newDcConfig("dc3", "cluster-0", map[string]string{"rack1": "us-east-1b", "rack2": "us-east-1c"}),
+	}))
+}
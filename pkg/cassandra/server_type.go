@@ -0,0 +1,50 @@
+package cassandra
+
+import (
+	"fmt"
+	"sort"
+
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+)
+
+// serverTypeSettings maps cassandra.yaml settings that only one server distribution understands to that distribution.
+// The other distribution refuses to start when such a setting is present.
+var serverTypeSettings = map[string]api.ServerDistribution{
+	// Cassandra-only settings
+	"enable_transient_replication":                       api.ServerDistributionCassandra,
+	"full_query_logging_options":                         api.ServerDistributionCassandra,
+	"repaired_data_tracking_for_range_reads_enabled":     api.ServerDistributionCassandra,
+	"repaired_data_tracking_for_partition_reads_enabled": api.ServerDistributionCassandra,
+	"auto_hints_cleanup_enabled":                         api.ServerDistributionCassandra,
+	"drop_compact_storage_enabled":                       api.ServerDistributionCassandra,
+
+	// DSE-only settings
+	"tpc_cores":              api.ServerDistributionDse,
+	"tpc_io_cores":           api.ServerDistributionDse,
+	"io_global_queue_depth":  api.ServerDistributionDse,
+	"system_key_directory":   api.ServerDistributionDse,
+	"system_info_encryption": api.ServerDistributionDse,
+}
+
+// validateServerTypeSettings checks that the cassandra.yaml settings of the datacenter are understood by its
+// ServerType, and that dse.yaml settings are only used with DSE.
+func validateServerTypeSettings(dcConfig *DatacenterConfig) error {
+	if dcConfig.ServerType != api.ServerDistributionDse && len(dcConfig.CassandraConfig.DseYaml) > 0 {
+		return fmt.Errorf("dse.yaml settings can only be used with DSE, but datacenter %s uses server type %s",
+			dcConfig.Meta.Name, dcConfig.ServerType)
+	}
+	settings := make([]string, 0, len(serverTypeSettings))
+	for setting := range serverTypeSettings {
+		if _, found := dcConfig.CassandraConfig.CassandraYaml[setting]; found {
+			settings = append(settings, setting)
+		}
+	}
+	sort.Strings(settings)
+	for _, setting := range settings {
+		if serverType := serverTypeSettings[setting]; serverType != dcConfig.ServerType {
+			return fmt.Errorf("cassandra.yaml setting %s is only supported by server type %s, but datacenter %s uses server type %s",
+				setting, serverType, dcConfig.Meta.Name, dcConfig.ServerType)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateServerTypeSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverType    api.ServerDistribution
+		serverVersion string
+		cassandraYaml unstructured.Unstructured
+		dseYaml       unstructured.Unstructured
+		wantErr       string
+	}{
+		{
+			name:          "cassandra with common settings",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.0.6",
+			cassandraYaml: unstructured.Unstructured{"concurrent_reads": 32},
+		},
+		{
+			name:          "cassandra with cassandra-only setting",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.0.6",
+			cassandraYaml: unstructured.Unstructured{"full_query_logging_options": map[string]interface{}{"log_dir": "/tmp"}},
+		},
+		{
+			name:          "cassandra with dse-only setting",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.0.6",
+			cassandraYaml: unstructured.Unstructured{"tpc_cores": int64(4)},
+			wantErr:       "cassandra.yaml setting tpc_cores is only supported by server type dse, but datacenter dc1 uses server type cassandra",
+		},
+		{
+			name:          "cassandra with dse.yaml settings",
+			serverType:    api.ServerDistributionCassandra,
+			serverVersion: "4.0.6",
+			dseYaml:       unstructured.Unstructured{"server_id": "dse1"},
+			wantErr:       "dse.yaml settings can only be used with DSE, but datacenter dc1 uses server type cassandra",
+		},
+		{
+			name:          "dse with dse-only setting",
+			serverType:    api.ServerDistributionDse,
+			serverVersion: "6.8.25",
+			cassandraYaml: unstructured.Unstructured{"tpc_cores": int64(4)},
+			dseYaml:       unstructured.Unstructured{"server_id": "dse1"},
+		},
+		{
+			name:          "dse with cassandra-only setting",
+			serverType:    api.ServerDistributionDse,
+			serverVersion: "6.8.25",
+			cassandraYaml: unstructured.Unstructured{"enable_transient_replication": true},
+			wantErr:       "cassandra.yaml setting enable_transient_replication is only supported by server type cassandra, but datacenter dc1 uses server type dse",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dcConfig := GetDatacenterConfig()
+			dcConfig.ServerType = tt.serverType
+			dcConfig.ServerVersion = semver.MustParse(tt.serverVersion)
+			dcConfig.CassandraConfig.CassandraYaml = tt.cassandraYaml
+			dcConfig.CassandraConfig.DseYaml = tt.dseYaml
+			err := ValidateDatacenterConfig(&dcConfig)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
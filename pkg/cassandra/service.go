@@ -0,0 +1,94 @@
+package cassandra
+
+import (
+	"fmt"
+	"math"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/annotations"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NativePort is the CQL native transport port of the Cassandra nodes.
+const NativePort = 9042
+
+// DefaultClusterDomain is the DNS domain of a k8s cluster, unless configured otherwise.
+const DefaultClusterDomain = "cluster.local"
+
+// maxHostnameLength is the maximum length of a Linux hostname, and thus of the FQDN of a pod that uses it as hostname.
+const maxHostnameLength = 64
+
+// PodDomain returns the domain of the Cassandra pods of dc, that is the DNS name of the all-pods Service, which governs
+// the StatefulSets of dc. Each pod resolves to <pod-name>.<pod-domain>. An empty clusterDomain stands for
+// DefaultClusterDomain.
+func PodDomain(dc *cassdcapi.CassandraDatacenter, clusterDomain string) string {
+	if clusterDomain == "" {
+		clusterDomain = DefaultClusterDomain
+	}
+	return fmt.Sprintf("%s.%s.svc.%s", dc.GetAllPodsServiceName(), dc.Namespace, clusterDomain)
+}
+
+// longestPodFQDN returns the longest FQDN among the Cassandra pods of dc. cass-operator creates a StatefulSet per rack,
+// named <cluster>-<dc>-<rack>-sts, and spreads the nodes evenly across them.
+func longestPodFQDN(dc *cassdcapi.CassandraDatacenter, clusterDomain string) string {
+	racks := dc.GetRacks()
+	maxOrdinal := int(math.Ceil(float64(dc.Spec.Size)/float64(len(racks)))) - 1
+	if maxOrdinal < 0 {
+		maxOrdinal = 0
+	}
+	longest := ""
+	for _, rack := range racks {
+		podName := fmt.Sprintf("%s-%s-%s-sts-%d",
+			cassdcapi.CleanupForKubernetes(dc.Spec.ClusterName), dc.SanitizedName(), cassdcapi.CleanupSubdomain(rack.Name), maxOrdinal)
+		if fqdn := podName + "." + PodDomain(dc, clusterDomain); len(fqdn) > len(longest) {
+			longest = fqdn
+		}
+	}
+	return longest
+}
+
+// ClusterCqlServiceKey returns the key of the headless Service selecting the Cassandra pods of all the DCs of kc that
+// live in the given namespace.
+func ClusterCqlServiceKey(kc *api.K8ssandraCluster, namespace string) types.NamespacedName {
+	return types.NamespacedName{Namespace: namespace, Name: kc.SanitizedName() + "-all-dcs-service"}
+}
+
+// NewClusterCqlService returns a headless Service selecting the Cassandra pods of all the DCs of kc that live in the
+// given namespace, regardless of the DC they belong to. Only ready pods are selected.
+func NewClusterCqlService(kc *api.K8ssandraCluster, namespace string) *corev1.Service {
+	kcKey := utils.GetKey(kc)
+	serviceKey := ClusterCqlServiceKey(kc, namespace)
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceKey.Name,
+			Namespace: serviceKey.Namespace,
+			Labels: map[string]string{
+				api.NameLabel:                      api.NameLabelValue,
+				api.PartOfLabel:                    api.PartOfLabelValue,
+				api.ComponentLabel:                 api.ComponentLabelValueCassandra,
+				api.CreatedByLabel:                 api.CreatedByLabelValueK8ssandraClusterController,
+				api.K8ssandraClusterNameLabel:      kcKey.Name,
+				api.K8ssandraClusterNamespaceLabel: kcKey.Namespace,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Ports: []corev1.ServicePort{{
+				Name:       "native",
+				Port:       NativePort,
+				Protocol:   corev1.ProtocolTCP,
+				TargetPort: intstr.FromInt(NativePort),
+			}},
+			Selector: map[string]string{
+				cassdcapi.ClusterLabel: cassdcapi.CleanLabelValue(kc.CassClusterName()),
+			},
+		},
+	}
+	annotations.AddHashAnnotation(service)
+	return service
+}
@@ -142,7 +142,7 @@ func assignInitialTokens(dcConfigs []*DatacenterConfig, infos []*tokenAllocation
 
 		racks := dcConfig.Racks
 		if len(racks) == 0 {
-			racks = []cassdcapi.Rack{{Name: "default"}}
+			racks = []cassdcapi.Rack{{Name: defaultRackName}}
 		}
 
 		// First, generate RF pod names since we need to assign tokens to the RF first nodes
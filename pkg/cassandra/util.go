@@ -1,6 +1,7 @@
 package cassandra
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -11,6 +12,7 @@ import (
 
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func DatacenterUpdatedAfter(t time.Time, dc *cassdcapi.CassandraDatacenter) bool {
@@ -25,6 +27,134 @@ func DatacenterReady(dc *cassdcapi.CassandraDatacenter) bool {
 	return dc.GetConditionStatus(cassdcapi.DatacenterReady) == corev1.ConditionTrue && dc.Status.CassandraOperatorProgress == cassdcapi.ProgressReady
 }
 
+// DatacenterReadyWithPolicy returns true if dc is ready according to policy, given its Cassandra pods. With the All
+// mode, which is used when policy is nil, this is the same as DatacenterReady. With the other modes, the datacenter is
+// also considered ready when enough of its pods are ready, even if cass-operator does not report it ready.
+func DatacenterReadyWithPolicy(dc *cassdcapi.CassandraDatacenter, pods []corev1.Pod, policy *api.ReadinessPolicy) bool {
+	if DatacenterReady(dc) {
+		return true
+	}
+	if policy == nil || policy.Mode == "" || policy.Mode == api.ReadinessModeAll || dc.Spec.Stopped || dc.Spec.Size == 0 {
+		return false
+	}
+	required := MinReadyNodes(dc.Spec.Size, policy)
+	ready := int32(0)
+	for _, pod := range pods {
+		if podReady(&pod) {
+			ready++
+		}
+	}
+	return ready >= required
+}
+
+// CheckDatacenterReady checks whether dc is ready according to policy. The Cassandra pods of the datacenter are only
+// listed when the policy is not the default one, which relies on the Ready condition set by cass-operator.
+func CheckDatacenterReady(ctx context.Context, dc *cassdcapi.CassandraDatacenter, policy *api.ReadinessPolicy, remoteClient client.Client) (bool, error) {
+	if policy == nil || policy.Mode == "" || policy.Mode == api.ReadinessModeAll || DatacenterReady(dc) {
+		return DatacenterReady(dc), nil
+	}
+
+	pods, err := datacenterPods(ctx, dc, remoteClient)
+	if err != nil {
+		return false, err
+	}
+	return DatacenterReadyWithPolicy(dc, pods, policy), nil
+}
+
+// ReadyNodeCount returns the number of Cassandra pods of dc that are ready.
+func ReadyNodeCount(ctx context.Context, dc *cassdcapi.CassandraDatacenter, remoteClient client.Client) (int32, error) {
+	pods, err := datacenterPods(ctx, dc, remoteClient)
+	if err != nil {
+		return 0, err
+	}
+	var ready int32
+	for i := range pods {
+		if podReady(&pods[i]) {
+			ready++
+		}
+	}
+	return ready, nil
+}
+
+// ReadyNodeCountByRack returns the number of Cassandra pods of dc that are ready in each of its racks.
+func ReadyNodeCountByRack(ctx context.Context, dc *cassdcapi.CassandraDatacenter, remoteClient client.Client) (map[string]int, error) {
+	pods, err := datacenterPods(ctx, dc, remoteClient)
+	if err != nil {
+		return nil, err
+	}
+	ready := make(map[string]int)
+	for i := range pods {
+		if podReady(&pods[i]) {
+			ready[pods[i].Labels[cassdcapi.RackLabel]]++
+		}
+	}
+	return ready, nil
+}
+
+// datacenterPods returns the Cassandra pods of dc.
+func datacenterPods(ctx context.Context, dc *cassdcapi.CassandraDatacenter, remoteClient client.Client) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	selector := map[string]string{
+		cassdcapi.ClusterLabel:    cassdcapi.CleanLabelValue(dc.Spec.ClusterName),
+		cassdcapi.DatacenterLabel: dc.Name,
+	}
+	if err := remoteClient.List(ctx, pods, client.InNamespace(dc.Namespace), client.MatchingLabels(selector)); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// IsDatacenterReady checks whether dc is ready according to the readiness policy recorded on it through the
+// ReadinessPolicyAnnotation. This is what the components that depend on a datacenter use to wait for it.
+func IsDatacenterReady(ctx context.Context, dc *cassdcapi.CassandraDatacenter, remoteClient client.Client) (bool, error) {
+	policy, err := DatacenterReadinessPolicy(dc)
+	if err != nil {
+		return false, err
+	}
+	return CheckDatacenterReady(ctx, dc, policy, remoteClient)
+}
+
+// DatacenterReadinessPolicy returns the readiness policy recorded on dc through the ReadinessPolicyAnnotation, or nil
+// if the default policy applies.
+func DatacenterReadinessPolicy(dc *cassdcapi.CassandraDatacenter) (*api.ReadinessPolicy, error) {
+	value, found := dc.Annotations[api.ReadinessPolicyAnnotation]
+	if !found {
+		return nil, nil
+	}
+	policy := &api.ReadinessPolicy{}
+	if err := json.Unmarshal([]byte(value), policy); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation %q on CassandraDatacenter %s", api.ReadinessPolicyAnnotation, value, dc.Name)
+	}
+	return policy, nil
+}
+
+// MinReadyNodes returns the minimum number of ready nodes required by policy for a datacenter of the given size.
+func MinReadyNodes(size int32, policy *api.ReadinessPolicy) int32 {
+	if policy == nil {
+		return size
+	}
+	switch policy.Mode {
+	case api.ReadinessModeQuorum:
+		return size/2 + 1
+	case api.ReadinessModeThreshold:
+		if policy.MinReadyPercent == nil {
+			return size
+		}
+		return int32(math.Ceil(float64(size) * float64(*policy.MinReadyPercent) / 100))
+	default:
+		return size
+	}
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func DatacenterStopped(dc *cassdcapi.CassandraDatacenter) bool {
 	return dc.GetConditionStatus(cassdcapi.DatacenterStopped) == corev1.ConditionTrue && dc.Status.CassandraOperatorProgress == cassdcapi.ProgressReady
 }
@@ -33,13 +163,40 @@ func DatacenterStopping(dc *cassdcapi.CassandraDatacenter) bool {
 	return dc.GetConditionStatus(cassdcapi.DatacenterStopped) == corev1.ConditionTrue && dc.Status.CassandraOperatorProgress == cassdcapi.ProgressUpdating
 }
 
+// operationConditions are the conditions that cass-operator sets to true while it performs an operation on a
+// datacenter.
+var operationConditions = []cassdcapi.DatacenterConditionType{
+	cassdcapi.DatacenterScalingUp,
+	cassdcapi.DatacenterScalingDown,
+	cassdcapi.DatacenterUpdating,
+	cassdcapi.DatacenterRollingRestart,
+	cassdcapi.DatacenterReplacingNodes,
+	cassdcapi.DatacenterResuming,
+	cassdcapi.DatacenterDecommission,
+}
+
+// DatacenterOperationInProgress returns true if cass-operator is performing an operation on dc, such as scaling, a
+// rolling restart or a node replacement.
+func DatacenterOperationInProgress(dc *cassdcapi.CassandraDatacenter) bool {
+	if dc.Status.CassandraOperatorProgress == cassdcapi.ProgressUpdating {
+		return true
+	}
+	for _, condition := range operationConditions {
+		if dc.GetConditionStatus(condition) == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 // ComputeReplication computes the desired replication for each dc, taking into account the desired maximum replication
-// per dc.
+// per dc. The replication is keyed by the Cassandra name of each dc, which differs from its object name when
+// DatacenterName is set.
 func ComputeReplication(maxReplicationPerDc int, datacenters ...*cassdcapi.CassandraDatacenter) map[string]int {
 	desiredReplication := make(map[string]int, len(datacenters))
 	for _, dcTemplate := range datacenters {
 		replicationFactor := int(math.Min(float64(maxReplicationPerDc), float64(dcTemplate.Spec.Size)))
-		desiredReplication[dcTemplate.Name] = replicationFactor
+		desiredReplication[dcTemplate.DatacenterName()] = replicationFactor
 	}
 	return desiredReplication
 }
@@ -49,7 +206,7 @@ func ComputeReplicationFromDatacenters(maxReplicationPerDc int, externalDatacent
 	desiredReplication := make(map[string]int, len(datacenters))
 	for _, dcTemplate := range datacenters {
 		replicationFactor := int(math.Min(float64(maxReplicationPerDc), float64(dcTemplate.Size)))
-		desiredReplication[dcTemplate.Meta.Name] = replicationFactor
+		desiredReplication[dcTemplate.CassDcName()] = replicationFactor
 	}
 	for _, dcName := range externalDatacenters {
 		desiredReplication[dcName] = maxReplicationPerDc
@@ -58,6 +215,19 @@ func ComputeReplicationFromDatacenters(maxReplicationPerDc int, externalDatacent
 	return desiredReplication
 }
 
+// ComputeSystemReplication computes the replication of the system keyspaces for the given datacenters. The
+// replication factor of each DC is its SystemReplicationFactor if set, and the smallest of 3 and its size otherwise.
+// External datacenters get a replication factor of 3.
+func ComputeSystemReplication(externalDatacenters []string, datacenters ...api.CassandraDatacenterTemplate) map[string]int {
+	desiredReplication := ComputeReplicationFromDatacenters(3, externalDatacenters, datacenters...)
+	for _, dcTemplate := range datacenters {
+		if dcTemplate.SystemReplicationFactor != nil {
+			desiredReplication[dcTemplate.CassDcName()] = int(*dcTemplate.SystemReplicationFactor)
+		}
+	}
+	return desiredReplication
+}
+
 const NetworkTopology = "org.apache.cassandra.locator.NetworkTopologyStrategy"
 
 func CompareReplications(actualReplication map[string]string, desiredReplication map[string]int) bool {
@@ -111,3 +281,35 @@ func ParseReplication(val []byte) (*Replication, error) {
 
 	return &dcsReplication, nil
 }
+
+// RackCount returns the number of racks of the datacenter. cass-operator creates a single default rack when none is
+// configured.
+func RackCount(dcConfig *DatacenterConfig) int {
+	if len(dcConfig.Racks) == 0 {
+		return 1
+	}
+	return len(dcConfig.Racks)
+}
+
+// ValidateReplicationFactor checks that replicationFactor does not exceed the number of racks of the datacenter. With a
+// rack-aware snitch, NetworkTopologyStrategy places at most one replica per rack until every rack holds one; beyond
+// that, some racks hold several replicas of the same data and losing one of them loses more than one replica. The
+// check is skipped for datacenters with a single rack, where all replicas are necessarily in the same rack, and for
+// snitches that are not rack-aware.
+func ValidateReplicationFactor(dcConfig *DatacenterConfig, replicationFactor int) error {
+	racks := RackCount(dcConfig)
+	if racks < 2 || !rackAwareSnitch(dcConfig) {
+		return nil
+	}
+	if replicationFactor > racks {
+		return fmt.Errorf("replication factor %d for datacenter %s exceeds its number of racks (%d)",
+			replicationFactor, dcConfig.Meta.Name, racks)
+	}
+	return nil
+}
+
+// rackAwareSnitch returns false if the DC uses SimpleSnitch, which ignores racks. All the other snitches, including
+// cass-operator's default GossipingPropertyFileSnitch, take racks into account.
+func rackAwareSnitch(dcConfig *DatacenterConfig) bool {
+	return EndpointSnitch(dcConfig) != "SimpleSnitch"
+}
@@ -1,14 +1,19 @@
 package cassandra
 
 import (
+	"context"
 	"testing"
 
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/unstructured"
 	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestComputeReplication(t *testing.T) {
@@ -65,6 +70,20 @@ func TestComputeReplication(t *testing.T) {
 			},
 			map[string]int{"dc1": 3, "dc2": 1, "dc3": 3},
 		},
+		{
+			"datacenter name override",
+			[]*cassdcapi.CassandraDatacenter{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "dc1"},
+					Spec:       cassdcapi.CassandraDatacenterSpec{Size: 3, DatacenterName: "Real DC1"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "dc2"},
+					Spec:       cassdcapi.CassandraDatacenterSpec{Size: 1},
+				},
+			},
+			map[string]int{"Real DC1": 3, "dc2": 1},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -94,6 +113,10 @@ func TestComputeReplicationFromDatacenters(t *testing.T) {
 			{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, Size: 1},
 			{Meta: api.EmbeddedObjectMeta{Name: "dc3"}, Size: 10},
 		}, map[string]int{"dc1": 3, "dc2": 1, "dc3": 3}},
+		{"datacenter name override", []api.CassandraDatacenterTemplate{
+			{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, Size: 3, DatacenterOptions: api.DatacenterOptions{DatacenterName: "Real DC1"}},
+			{Meta: api.EmbeddedObjectMeta{Name: "dc2"}, Size: 1},
+		}, map[string]int{"Real DC1": 3, "dc2": 1}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -132,6 +155,17 @@ func TestComputeReplicationWithExternalDc(t *testing.T) {
 	}
 }
 
+func TestComputeSystemReplication(t *testing.T) {
+	dcs := []api.CassandraDatacenterTemplate{
+		{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, Size: 10, SystemReplicationFactor: pointer.Int32(5)},
+		{Meta: api.EmbeddedObjectMeta{Name: "analytics"}, Size: 3, SystemReplicationFactor: pointer.Int32(1)},
+		{Meta: api.EmbeddedObjectMeta{Name: "dc3"}, Size: 10},
+		{Meta: api.EmbeddedObjectMeta{Name: "dc4"}, Size: 10, SystemReplicationFactor: pointer.Int32(2), DatacenterOptions: api.DatacenterOptions{DatacenterName: "Real DC4"}},
+	}
+	actual := ComputeSystemReplication([]string{"external"}, dcs...)
+	assert.Equal(t, map[string]int{"dc1": 5, "analytics": 1, "dc3": 3, "Real DC4": 2, "external": 3}, actual)
+}
+
 func TestCompareReplications(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -227,3 +261,140 @@ func TestReplicationFactor(t *testing.T) {
 	assert.Equal(t, 0, replication.ReplicationFactor("dc2", "ks3"))
 	assert.Equal(t, 0, replication.ReplicationFactor("dc3", "ks1"))
 }
+
+func TestValidateReplicationFactor(t *testing.T) {
+	threeRacks := []cassdcapi.Rack{{Name: "rack1"}, {Name: "rack2"}, {Name: "rack3"}}
+	tests := []struct {
+		name              string
+		racks             []cassdcapi.Rack
+		snitch            string
+		replicationFactor int
+		wantErr           bool
+	}{
+		{name: "rf equals rack count", racks: threeRacks, replicationFactor: 3},
+		{name: "rf below rack count", racks: threeRacks, replicationFactor: 2},
+		{name: "rf exceeds rack count", racks: threeRacks[:2], replicationFactor: 3, wantErr: true},
+		{name: "default single rack", replicationFactor: 3},
+		{name: "rack-aware snitch", racks: threeRacks[:2], snitch: "GossipingPropertyFileSnitch", replicationFactor: 3, wantErr: true},
+		{name: "simple snitch", racks: threeRacks[:2], snitch: "SimpleSnitch", replicationFactor: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dcConfig := &DatacenterConfig{Meta: api.EmbeddedObjectMeta{Name: "dc1"}, Racks: tt.racks}
+			if tt.snitch != "" {
+				dcConfig.CassandraConfig.CassandraYaml = unstructured.Unstructured{"endpoint_snitch": tt.snitch}
+			}
+			err := ValidateReplicationFactor(dcConfig, tt.replicationFactor)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDatacenterReadyWithPolicy(t *testing.T) {
+	newPods := func(ready, notReady int) []corev1.Pod {
+		var pods []corev1.Pod
+		for i := 0; i < ready+notReady; i++ {
+			status := corev1.ConditionTrue
+			if i >= ready {
+				status = corev1.ConditionFalse
+			}
+			pods = append(pods, corev1.Pod{Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			}})
+		}
+		return pods
+	}
+	newDc := func(size int32, ready bool) *cassdcapi.CassandraDatacenter {
+		dc := &cassdcapi.CassandraDatacenter{Spec: cassdcapi.CassandraDatacenterSpec{Size: size}}
+		if ready {
+			dc.Status.CassandraOperatorProgress = cassdcapi.ProgressReady
+			dc.SetCondition(cassdcapi.DatacenterCondition{Type: cassdcapi.DatacenterReady, Status: corev1.ConditionTrue})
+		}
+		return dc
+	}
+	quorum := &api.ReadinessPolicy{Mode: api.ReadinessModeQuorum}
+	threshold := &api.ReadinessPolicy{Mode: api.ReadinessModeThreshold, MinReadyPercent: pointer.Int32(75)}
+
+	tests := []struct {
+		name   string
+		dc     *cassdcapi.CassandraDatacenter
+		pods   []corev1.Pod
+		policy *api.ReadinessPolicy
+		want   bool
+	}{
+		{"all, reported ready", newDc(3, true), newPods(3, 0), nil, true},
+		{"all, one node down", newDc(3, false), newPods(2, 1), nil, false},
+		{"all, not reported ready", newDc(3, false), newPods(3, 0), nil, false},
+		{"explicit all, one node down", newDc(3, false), newPods(2, 1), &api.ReadinessPolicy{Mode: api.ReadinessModeAll}, false},
+		{"quorum, one node down", newDc(3, false), newPods(2, 1), quorum, true},
+		{"quorum, two nodes down", newDc(3, false), newPods(1, 2), quorum, false},
+		{"quorum, even size", newDc(4, false), newPods(2, 2), quorum, false},
+		{"quorum, pods not created yet", newDc(3, false), newPods(2, 0), quorum, true},
+		{"quorum, no pods", newDc(3, false), nil, quorum, false},
+		{"threshold, enough nodes", newDc(4, false), newPods(3, 1), threshold, true},
+		{"threshold, rounded up", newDc(5, false), newPods(3, 2), threshold, false},
+		{"threshold, reported ready", newDc(5, true), newPods(3, 2), threshold, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, DatacenterReadyWithPolicy(tc.dc, tc.pods, tc.policy))
+		})
+	}
+
+	stopped := newDc(3, false)
+	stopped.Spec.Stopped = true
+	assert.False(t, DatacenterReadyWithPolicy(stopped, newPods(3, 0), quorum))
+}
+
+func TestIsDatacenterReady(t *testing.T) {
+	ctx := context.Background()
+	dc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dc1"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "Test Cluster", Size: 3},
+	}
+	newPod := func(name, dcName string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{
+				cassdcapi.ClusterLabel:    cassdcapi.CleanLabelValue("Test Cluster"),
+				cassdcapi.DatacenterLabel: dcName,
+			}},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		newPod("dc1-0", "dc1", corev1.ConditionTrue),
+		newPod("dc1-1", "dc1", corev1.ConditionFalse),
+		newPod("dc1-2", "dc1", corev1.ConditionFalse),
+		newPod("dc2-0", "dc2", corev1.ConditionTrue),
+		newPod("dc2-1", "dc2", corev1.ConditionTrue),
+	).Build()
+
+	ready, err := IsDatacenterReady(ctx, dc, fakeClient)
+	require.NoError(t, err)
+	assert.False(t, ready, "no policy recorded on the datacenter")
+
+	// the pods of the other datacenters are not counted
+	dc.Annotations = map[string]string{api.ReadinessPolicyAnnotation: `{"mode":"Quorum"}`}
+	ready, err = IsDatacenterReady(ctx, dc, fakeClient)
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	// a majority of the nodes is ready once a second pod is
+	require.NoError(t, fakeClient.Update(ctx, newPod("dc1-1", "dc1", corev1.ConditionTrue)))
+	ready, err = IsDatacenterReady(ctx, dc, fakeClient)
+	require.NoError(t, err)
+	assert.True(t, ready)
+
+	// the given policy is applied rather than the recorded one
+	ready, err = CheckDatacenterReady(ctx, dc, nil, fakeClient)
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	dc.Annotations[api.ReadinessPolicyAnnotation] = "Quorum"
+	_, err = IsDatacenterReady(ctx, dc, fakeClient)
+	assert.Error(t, err)
+}
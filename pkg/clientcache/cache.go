@@ -2,7 +2,11 @@ package clientcache
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -26,19 +30,75 @@ type ClientCache struct {
 	// RemoteClients to other clusters. The string is the name of the KubeConfig item targeting
 	// another cluster.
 	remoteClients map[string]client.Client
+
+	// localHost is the API server address of the cluster the operator runs in. When set, contexts resolving to the
+	// same address reuse localClient instead of opening a separate connection.
+	localHost string
+
+	// localContexts tracks the context names that were resolved to the local cluster.
+	localContexts map[string]bool
+
+	// certificateExpiries are the expiry times of the client certificates used by the remote clients, keyed by context
+	// name. Contexts that do not authenticate with a client certificate are absent.
+	certificateExpiries map[string]time.Time
+
+	// secretRotations are the times at which a change of the kubeconfig secret of the remote clients was detected,
+	// keyed by context name.
+	secretRotations map[string]time.Time
 }
 
 func New(localClient client.Client, noCacheClient client.Client, scheme *runtime.Scheme) *ClientCache {
 
 	// Call to create new RemoteClients here?
 	return &ClientCache{
-		localClient:   localClient,
-		noCacheClient: noCacheClient,
-		scheme:        scheme,
-		remoteClients: make(map[string]client.Client),
+		localClient:         localClient,
+		noCacheClient:       noCacheClient,
+		scheme:              scheme,
+		remoteClients:       make(map[string]client.Client),
+		localContexts:       make(map[string]bool),
+		certificateExpiries: make(map[string]time.Time),
+		secretRotations:     make(map[string]time.Time),
 	}
 }
 
+// SetLocalRestConfig records the rest.Config of the cluster the operator runs in, so that contexts pointing at the
+// same API server are served by the local client.
+func (c *ClientCache) SetLocalRestConfig(restConfig *rest.Config) {
+	if restConfig == nil {
+		c.localHost = ""
+		return
+	}
+	c.localHost = normalizeHost(restConfig.Host)
+}
+
+// IsLocalCluster returns true if restConfig targets the API server of the cluster the operator runs in. It always
+// returns false when no local rest.Config was set.
+func (c *ClientCache) IsLocalCluster(restConfig *rest.Config) bool {
+	if c.localHost == "" || restConfig == nil {
+		return false
+	}
+	return normalizeHost(restConfig.Host) == c.localHost
+}
+
+// AddLocalClient registers k8sContextName as an alias of the local cluster, served by the local client.
+func (c *ClientCache) AddLocalClient(k8sContextName string) {
+	c.remoteClients[k8sContextName] = c.localClient
+	c.localContexts[k8sContextName] = true
+}
+
+// IsLocalContext returns true if k8sContextName was registered as an alias of the local cluster.
+func (c *ClientCache) IsLocalContext(k8sContextName string) bool {
+	return c.localContexts[k8sContextName]
+}
+
+func normalizeHost(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), "/")
+	if host != "" && !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	return host
+}
+
 // GetRemoteClient returns the client to remote cluster with name k8sContextName or error if no such client is cached
 func (c *ClientCache) GetRemoteClient(k8sContextName string) (client.Client, error) {
 	if k8sContextName == "" {
@@ -61,10 +121,14 @@ func (c *ClientCache) GetRemoteClients() map[string]client.Client {
 	return c.remoteClients
 }
 
-// GetAllClients returns all the remote clients, plus the local one.
+// GetAllClients returns all the remote clients, plus the local one. Contexts resolving to the local cluster are only
+// returned once.
 func (c *ClientCache) GetAllClients() []client.Client {
 	clients := make([]client.Client, 0, len(c.remoteClients)+1)
-	for _, remoteClient := range c.remoteClients {
+	for contextName, remoteClient := range c.remoteClients {
+		if c.localContexts[contextName] {
+			continue
+		}
 		clients = append(clients, remoteClient)
 	}
 	clients = append(clients, c.localClient)
@@ -74,6 +138,56 @@ func (c *ClientCache) GetAllClients() []client.Client {
 // AddClient adds a new remoteClient with the name k8sContextName
 func (c *ClientCache) AddClient(k8sContextName string, cli client.Client) {
 	c.remoteClients[k8sContextName] = cli
+	delete(c.localContexts, k8sContextName)
+}
+
+// SetCertificateExpiry records the expiry time of the client certificate used by the client of k8sContextName.
+func (c *ClientCache) SetCertificateExpiry(k8sContextName string, expiry time.Time) {
+	c.certificateExpiries[k8sContextName] = expiry
+}
+
+// GetCertificateExpiry returns the expiry time of the client certificate used by the client of k8sContextName, if it
+// authenticates with a client certificate.
+func (c *ClientCache) GetCertificateExpiry(k8sContextName string) (time.Time, bool) {
+	expiry, found := c.certificateExpiries[k8sContextName]
+	return expiry, found
+}
+
+// SetSecretRotated records that a change of the kubeconfig secret used by the client of k8sContextName was detected at
+// the given time.
+func (c *ClientCache) SetSecretRotated(k8sContextName string, rotatedAt time.Time) {
+	c.secretRotations[k8sContextName] = rotatedAt
+}
+
+// SecretRotatedWithin returns true if a change of the kubeconfig secret used by the client of k8sContextName was
+// detected less than period ago.
+func (c *ClientCache) SecretRotatedWithin(k8sContextName string, period time.Duration) bool {
+	rotatedAt, found := c.secretRotations[k8sContextName]
+	return found && time.Since(rotatedAt) < period
+}
+
+// ClientCertificateExpiry returns the expiry time of the client certificate embedded in restConfig, if any. When the
+// certificate data holds a chain, the first certificate is the client's.
+func ClientCertificateExpiry(restConfig *rest.Config) (time.Time, bool) {
+	if restConfig == nil {
+		return time.Time{}, false
+	}
+	data := restConfig.TLSClientConfig.CertData
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return time.Time{}, false
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return cert.NotAfter, true
+	}
 }
 
 // createClient creates a remoteClient and stores it in the cache. If already stored, returns the existing client
@@ -83,6 +197,11 @@ func (c *ClientCache) createClient(contextName string, restConfig *rest.Config)
 		return cli, nil
 	}
 
+	if c.IsLocalCluster(restConfig) {
+		c.AddLocalClient(contextName)
+		return c.localClient, nil
+	}
+
 	remoteClient, err := client.New(restConfig, client.Options{Scheme: c.scheme})
 	if err != nil {
 		return nil, err
@@ -90,6 +209,9 @@ func (c *ClientCache) createClient(contextName string, restConfig *rest.Config)
 
 	// Store for later use and return to the caller
 	c.remoteClients[contextName] = remoteClient
+	if expiry, found := ClientCertificateExpiry(restConfig); found {
+		c.SetCertificateExpiry(contextName, expiry)
+	}
 	return remoteClient, nil
 
 }
@@ -141,6 +263,16 @@ func (c *ClientCache) GetLocalNonCacheClient() client.Client {
 	return c.noCacheClient
 }
 
+// GetRemoteNonCacheClient returns a client to the cluster with name k8sContextName that reads directly from its API
+// server. The clients of remote clusters never use a cache, while contexts resolving to the local cluster are served
+// by the local non-cache client.
+func (c *ClientCache) GetRemoteNonCacheClient(k8sContextName string) (client.Client, error) {
+	if k8sContextName == "" || c.localContexts[k8sContextName] {
+		return c.noCacheClient, nil
+	}
+	return c.GetRemoteClient(k8sContextName)
+}
+
 func (c *ClientCache) extractClientCmdApiConfigFromSecret(secretKey types.NamespacedName) (*clientcmdapi.Config, error) {
 	// Fetch the secret containing the details
 	secret := &corev1.Secret{}
@@ -149,7 +281,7 @@ func (c *ClientCache) extractClientCmdApiConfigFromSecret(secretKey types.Namesp
 		return nil, err
 	}
 
-	b, found := secret.Data["kubeconfig"]
+	b, found := secret.Data[KubeConfigSecretKey]
 	if !found {
 		return nil, errors.New("secret is missing required kubeconfig property")
 	}
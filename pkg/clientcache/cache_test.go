@@ -0,0 +1,103 @@
+package clientcache
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestIsLocalCluster(t *testing.T) {
+	cache := New(nil, nil, scheme.Scheme)
+	assert.False(t, cache.IsLocalCluster(&rest.Config{Host: "https://10.0.0.1:6443"}), "no local config set")
+
+	cache.SetLocalRestConfig(&rest.Config{Host: "https://10.0.0.1:6443"})
+	assert.True(t, cache.IsLocalCluster(&rest.Config{Host: "https://10.0.0.1:6443"}))
+	assert.True(t, cache.IsLocalCluster(&rest.Config{Host: "https://10.0.0.1:6443/"}))
+	assert.True(t, cache.IsLocalCluster(&rest.Config{Host: "10.0.0.1:6443"}))
+	assert.False(t, cache.IsLocalCluster(&rest.Config{Host: "https://10.0.0.2:6443"}))
+	assert.False(t, cache.IsLocalCluster(nil))
+}
+
+func TestCreateClientReusesLocalClient(t *testing.T) {
+	localClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	cache := New(localClient, localClient, scheme.Scheme)
+	cache.SetLocalRestConfig(&rest.Config{Host: "https://10.0.0.1:6443"})
+
+	cli, err := cache.createClient("local-context", &rest.Config{Host: "https://10.0.0.1:6443"})
+	require.NoError(t, err)
+	assert.Same(t, localClient, cli)
+
+	remoteClient, err := cache.GetRemoteClient("local-context")
+	require.NoError(t, err)
+	assert.Same(t, localClient, remoteClient)
+
+	assert.Len(t, cache.GetAllClients(), 1, "the local client must only be returned once")
+	assert.True(t, cache.IsLocalContext("local-context"))
+	assert.False(t, cache.IsLocalContext("remote-context"))
+}
+
+func TestGetRemoteNonCacheClient(t *testing.T) {
+	localClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	noCacheClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	remoteClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	cache := New(localClient, noCacheClient, scheme.Scheme)
+	cache.AddLocalClient("local-context")
+	cache.AddClient("remote-context", remoteClient)
+
+	for _, k8sContext := range []string{"", "local-context"} {
+		cli, err := cache.GetRemoteNonCacheClient(k8sContext)
+		require.NoError(t, err)
+		assert.Same(t, noCacheClient, cli, "the local cluster must be read through the non-cache client")
+	}
+
+	cli, err := cache.GetRemoteNonCacheClient("remote-context")
+	require.NoError(t, err)
+	assert.Same(t, remoteClient, cli)
+
+	_, err = cache.GetRemoteNonCacheClient("unknown-context")
+	assert.Error(t, err)
+}
+
+func TestClientCertificateExpiry(t *testing.T) {
+	_, found := ClientCertificateExpiry(&rest.Config{Host: "https://10.0.0.1:6443", BearerToken: "token"})
+	assert.False(t, found, "no client certificate")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "k8ssandra-operator"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	expiry, found := ClientCertificateExpiry(&rest.Config{TLSClientConfig: rest.TLSClientConfig{CertData: certData}})
+	require.True(t, found)
+	assert.True(t, notAfter.Equal(expiry))
+}
+
+func TestSecretRotatedWithin(t *testing.T) {
+	cache := New(nil, nil, scheme.Scheme)
+	assert.False(t, cache.SecretRotatedWithin("east", time.Minute), "no rotation detected")
+
+	cache.SetSecretRotated("east", time.Now().Add(-30*time.Second))
+	assert.True(t, cache.SecretRotatedWithin("east", time.Minute))
+	assert.False(t, cache.SecretRotatedWithin("east", 10*time.Second))
+	assert.False(t, cache.SecretRotatedWithin("west", time.Minute))
+}
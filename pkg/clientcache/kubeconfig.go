@@ -0,0 +1,92 @@
+package clientcache
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// KubeConfigSecretKey is the key of the secrets referenced by ClientConfigs that holds the kubeconfig.
+const KubeConfigSecretKey = "kubeconfig"
+
+// ContextVerifier checks that the rest config of a context can be used to access its cluster.
+type ContextVerifier func(contextName string, restConfig *rest.Config) error
+
+// VerifyAuthentication is a ContextVerifier that checks that the credentials of a context are accepted by its API
+// server, by fetching the API groups.
+func VerifyAuthentication(contextName string, restConfig *rest.Config) error {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	_, err = discoveryClient.ServerGroups()
+	return err
+}
+
+// NewKubeConfigSecret assembles the secret that ClientConfigs reference from the given contexts of apiConfig. Only
+// the clusters and users of these contexts are kept, and the certificates and keys they reference through files are
+// embedded, since the files are not available to the operator. Each context is resolved the way ClientCache
+// resolves it, then checked with verify, if not nil.
+func NewKubeConfigSecret(
+	key types.NamespacedName,
+	apiConfig *clientcmdapi.Config,
+	contextNames []string,
+	verify ContextVerifier) (*corev1.Secret, error) {
+
+	if len(contextNames) == 0 {
+		return nil, fmt.Errorf("at least one context is required")
+	}
+
+	config := clientcmdapi.NewConfig()
+	for _, contextName := range contextNames {
+		context, found := apiConfig.Contexts[contextName]
+		if !found {
+			return nil, fmt.Errorf("context %s not found", contextName)
+		}
+		cluster, found := apiConfig.Clusters[context.Cluster]
+		if !found {
+			return nil, fmt.Errorf("cluster %s of context %s not found", context.Cluster, contextName)
+		}
+		authInfo, found := apiConfig.AuthInfos[context.AuthInfo]
+		if !found {
+			return nil, fmt.Errorf("user %s of context %s not found", context.AuthInfo, contextName)
+		}
+		config.Contexts[contextName] = context.DeepCopy()
+		config.Clusters[context.Cluster] = cluster.DeepCopy()
+		config.AuthInfos[context.AuthInfo] = authInfo.DeepCopy()
+	}
+	config.CurrentContext = contextNames[0]
+
+	if err := clientcmdapi.FlattenConfig(config); err != nil {
+		return nil, fmt.Errorf("failed to embed the files referenced by the kubeconfig: %v", err)
+	}
+
+	for _, contextName := range contextNames {
+		clientCmdCfg := clientcmd.NewNonInteractiveClientConfig(*config, contextName, &clientcmd.ConfigOverrides{}, nil)
+		restConfig, err := clientCmdCfg.ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid context %s: %v", contextName, err)
+		}
+		if verify != nil {
+			if err = verify(contextName, restConfig); err != nil {
+				return nil, fmt.Errorf("failed to authenticate with context %s: %v", contextName, err)
+			}
+		}
+	}
+
+	b, err := clientcmd.Write(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Data:       map[string][]byte{KubeConfigSecretKey: b},
+	}, nil
+}
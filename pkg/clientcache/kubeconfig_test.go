@@ -0,0 +1,99 @@
+package clientcache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/k8ssandra/k8ssandra-operator/apis/config/v1beta1"
+)
+
+func newTestApiConfig(t *testing.T) *clientcmdapi.Config {
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, []byte("test-ca"), 0600))
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters["kind-0"] = &clientcmdapi.Cluster{Server: "https://10.0.0.1:6443", CertificateAuthority: caFile}
+	config.Clusters["kind-1"] = &clientcmdapi.Cluster{Server: "https://10.0.0.2:6443", CertificateAuthority: caFile}
+	config.Clusters["unused"] = &clientcmdapi.Cluster{Server: "https://10.0.0.3:6443"}
+	config.AuthInfos["operator-0"] = &clientcmdapi.AuthInfo{Token: "token-0"}
+	config.AuthInfos["operator-1"] = &clientcmdapi.AuthInfo{Token: "token-1"}
+	config.Contexts["cluster-0"] = &clientcmdapi.Context{Cluster: "kind-0", AuthInfo: "operator-0"}
+	config.Contexts["cluster-1"] = &clientcmdapi.Context{Cluster: "kind-1", AuthInfo: "operator-1"}
+	config.Contexts["cluster-2"] = &clientcmdapi.Context{Cluster: "unused", AuthInfo: "operator-0"}
+	return config
+}
+
+func TestNewKubeConfigSecret(t *testing.T) {
+	secretKey := types.NamespacedName{Namespace: "k8ssandra-operator", Name: "k8s-contexts"}
+	verified := make([]string, 0)
+	verify := func(contextName string, restConfig *rest.Config) error {
+		verified = append(verified, contextName)
+		return nil
+	}
+
+	secret, err := NewKubeConfigSecret(secretKey, newTestApiConfig(t), []string{"cluster-0", "cluster-1"}, verify)
+	require.NoError(t, err)
+	assert.Equal(t, secretKey.Name, secret.Name)
+	assert.Equal(t, secretKey.Namespace, secret.Namespace)
+	assert.Equal(t, []string{"cluster-0", "cluster-1"}, verified)
+
+	localClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret).Build()
+	cache := New(localClient, localClient, scheme.Scheme)
+
+	apiConfig, err := cache.extractClientCmdApiConfigFromSecret(secretKey)
+	require.NoError(t, err)
+	assert.Len(t, apiConfig.Contexts, 2, "only the requested contexts should be kept")
+	assert.NotContains(t, apiConfig.Clusters, "unused")
+	assert.Equal(t, []byte("test-ca"), apiConfig.Clusters["kind-1"].CertificateAuthorityData, "the CA file should be embedded")
+
+	clientConfig := &api.ClientConfig{}
+	clientConfig.Namespace = secretKey.Namespace
+	clientConfig.Name = "cluster-1"
+	clientConfig.Spec.KubeConfigSecret.Name = secretKey.Name
+	restConfig, err := cache.GetRestConfig(clientConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.2:6443", restConfig.Host)
+	assert.Equal(t, "token-1", restConfig.BearerToken)
+
+	// The context of the local cluster reuses the local client, which avoids connecting to a real API server.
+	secret, err = NewKubeConfigSecret(secretKey, newTestApiConfig(t), []string{"cluster-0"}, nil)
+	require.NoError(t, err)
+	localClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret).Build()
+	cache = New(localClient, localClient, scheme.Scheme)
+	cache.SetLocalRestConfig(&rest.Config{Host: "https://10.0.0.1:6443"})
+	require.NoError(t, cache.CreateRemoteClientsFromSecret(secretKey))
+	remoteClient, err := cache.GetRemoteClient("cluster-0")
+	require.NoError(t, err)
+	assert.Same(t, localClient, remoteClient)
+}
+
+func TestNewKubeConfigSecretInvalid(t *testing.T) {
+	secretKey := types.NamespacedName{Namespace: "k8ssandra-operator", Name: "k8s-contexts"}
+
+	_, err := NewKubeConfigSecret(secretKey, newTestApiConfig(t), nil, nil)
+	assert.EqualError(t, err, "at least one context is required")
+
+	_, err = NewKubeConfigSecret(secretKey, newTestApiConfig(t), []string{"cluster-3"}, nil)
+	assert.EqualError(t, err, "context cluster-3 not found")
+
+	apiConfig := newTestApiConfig(t)
+	delete(apiConfig.AuthInfos, "operator-1")
+	_, err = NewKubeConfigSecret(secretKey, apiConfig, []string{"cluster-1"}, nil)
+	assert.EqualError(t, err, "user operator-1 of context cluster-1 not found")
+
+	verify := func(contextName string, restConfig *rest.Config) error {
+		return errors.New("Unauthorized")
+	}
+	_, err = NewKubeConfigSecret(secretKey, newTestApiConfig(t), []string{"cluster-0"}, verify)
+	assert.EqualError(t, err, "failed to authenticate with context cluster-0: Unauthorized")
+}
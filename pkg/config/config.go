@@ -3,17 +3,109 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 type ReconcilerConfig struct {
 	DefaultDelay time.Duration
 	LongDelay    time.Duration
+
+	// WebhookUnavailableDelay is the delay before retrying a request that failed because a remote admission webhook,
+	// such as cass-operator's, was unavailable.
+	WebhookUnavailableDelay time.Duration
+
+	// SpecRejectedDelay is the delay before retrying to create or update a CassandraDatacenter whose spec was rejected
+	// by the API server or by cass-operator's validating webhook.
+	SpecRejectedDelay time.Duration
+
+	// ResyncPeriod is the interval at which all the K8ssandraClusters are enqueued for reconciliation, regardless of
+	// events and requeues. Periodic resyncs are disabled when it is zero.
+	ResyncPeriod time.Duration
+
+	// CreateDcNamespaces makes the operator create the namespace of a datacenter in its target context when it does not
+	// exist. When false, a missing namespace is reported as a reconcile error.
+	CreateDcNamespaces bool
+
+	// ReuseLocalClient makes the operator use the manager client for the ClientConfigs whose context resolves to the
+	// cluster it runs in, instead of creating a separate remote client. Enabled by default.
+	ReuseLocalClient bool
+
+	// RequiredLabels are the label keys that the K8ssandraCluster webhook requires on K8ssandraClusters and on the
+	// CassandraDatacenters derived from them.
+	RequiredLabels []string
+
+	// MaxClusterSize is the maximum number of nodes of a K8ssandraCluster, across all its datacenters. Larger clusters
+	// are rejected by the K8ssandraCluster webhook, and are not reconciled, unless they already had more nodes and do
+	// not grow. Disabled when it is zero.
+	MaxClusterSize int
+
+	// SeedReachabilityTimeout enables probing the seeds of multi-context clusters from the operator before they are
+	// propagated, and is the timeout of each probe. Probing is disabled when it is zero.
+	SeedReachabilityTimeout time.Duration
+
+	// RestampOnUpgrade makes the operator re-stamp the hash annotation and labels of the CassandraDatacenters whose
+	// spec is unchanged the first time it reconciles a K8ssandraCluster after an upgrade, instead of updating them.
+	// Enabled by default.
+	RestampOnUpgrade bool
+
+	// OrphanedSecretsCleanupInterval is the interval at which the secret replication controller looks for replicated
+	// secrets whose source secret no longer exists, and deletes them. Periodic cleanups are disabled when it is zero,
+	// orphaned secrets are then only detected when a ReplicatedSecret or one of its sources changes.
+	OrphanedSecretsCleanupInterval time.Duration
+
+	// InstanceId identifies this operator instance. When set, a K8ssandraCluster is only reconciled by the instance
+	// whose id it is annotated with, the first instance to reconcile it claiming it. Ownership guarding is disabled
+	// when it is empty.
+	InstanceId string
+
+	// OwnershipLeaseDuration is how long the claim of an operator instance on a K8ssandraCluster remains valid without
+	// being renewed. The owner renews its claim while it reconciles the cluster; once the claim expired, another
+	// instance takes the cluster over. Claims never expire when it is zero.
+	OwnershipLeaseDuration time.Duration
+
+	// SecretRotationGracePeriod is how long after a change of the kubeconfig secret of a ClientConfig is detected the
+	// operator treats failures caused by the secret as transient: a secret missing the context or holding an invalid
+	// kubeconfig is waited for instead of restarting the operator, and authentication failures of the remote requests
+	// are retried with a backoff instead of being reported as reconcile errors. Disabled when it is zero.
+	SecretRotationGracePeriod time.Duration
+
+	// RequireStatusSubresource makes the operator fail to start when the status subresource of the K8ssandraCluster
+	// CRD is not enabled, as with CRDs installed by old versions of the operator. By default, this is logged once at
+	// startup and the status updates are skipped.
+	RequireStatusSubresource bool
+
+	// CassOperatorNamespace is the namespace cass-operator is deployed in, in every context. When empty, cass-operator
+	// is looked up in the namespace of each datacenter.
+	CassOperatorNamespace string
+
+	// CassOperatorSelector selects the cass-operator Deployment, whose version is checked against the
+	// CassandraDatacenter fields that each datacenter uses.
+	CassOperatorSelector labels.Selector
 }
 
 const (
-	RequeueDefaultDelayEnvVar = "REQUEUE_DEFAULT_DELAY"
-	RequeueLongDelayEnvVar    = "REQUEUE_LONG_DELAY"
+	RequeueDefaultDelayEnvVar            = "REQUEUE_DEFAULT_DELAY"
+	RequeueLongDelayEnvVar               = "REQUEUE_LONG_DELAY"
+	RequeueWebhookUnavailableDelayEnvVar = "REQUEUE_WEBHOOK_UNAVAILABLE_DELAY"
+	RequeueSpecRejectedDelayEnvVar       = "REQUEUE_SPEC_REJECTED_DELAY"
+	ResyncPeriodEnvVar                   = "RESYNC_PERIOD"
+	CreateDcNamespacesEnvVar             = "CREATE_DC_NAMESPACES"
+	ReuseLocalClientEnvVar               = "REUSE_LOCAL_CLIENT"
+	RequiredLabelsEnvVar                 = "REQUIRED_LABELS"
+	MaxClusterSizeEnvVar                 = "MAX_CLUSTER_SIZE"
+	SeedReachabilityTimeoutEnvVar        = "SEED_REACHABILITY_TIMEOUT"
+	RestampOnUpgradeEnvVar               = "RESTAMP_ON_UPGRADE"
+	OrphanedSecretsCleanupIntervalEnvVar = "ORPHANED_SECRETS_CLEANUP_INTERVAL"
+	InstanceIdEnvVar                     = "OPERATOR_INSTANCE_ID"
+	OwnershipLeaseDurationEnvVar         = "OWNERSHIP_LEASE_DURATION"
+	SecretRotationGracePeriodEnvVar      = "SECRET_ROTATION_GRACE_PERIOD"
+	RequireStatusSubresourceEnvVar       = "REQUIRE_STATUS_SUBRESOURCE"
+	CassOperatorNamespaceEnvVar          = "CASS_OPERATOR_NAMESPACE"
+	CassOperatorSelectorEnvVar           = "CASS_OPERATOR_SELECTOR"
 )
 
 // InitConfig is primarily a hook for integration tests. It provides a way to use shorter
@@ -21,34 +113,69 @@ const (
 // likely be changed when we tackle
 // https://github.com/k8ssandra/k8ssandra-operator/issues/63.
 func InitConfig() *ReconcilerConfig {
-	var (
-		defaultDelay time.Duration
-		longDelay    time.Duration
-		err          error
-	)
-
-	val, found := os.LookupEnv(RequeueDefaultDelayEnvVar)
-	if found {
-		defaultDelay, err = time.ParseDuration(val)
-		if err != nil {
-			log.Fatalf("failed to parse value for %s %s: %s", RequeueDefaultDelayEnvVar, val, err)
+	var requiredLabels []string
+	if val, found := os.LookupEnv(RequiredLabelsEnvVar); found {
+		for _, label := range strings.Split(val, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				requiredLabels = append(requiredLabels, label)
+			}
 		}
-	} else {
-		defaultDelay = 15 * time.Second
 	}
 
-	val, found = os.LookupEnv(RequeueLongDelayEnvVar)
-	if found {
-		longDelay, err = time.ParseDuration(val)
-		if err != nil {
-			log.Fatalf("failed to parse value for %s %s: %s", RequeueLongDelayEnvVar, val, err)
-		}
-	} else {
-		longDelay = 1 * time.Minute
+	return &ReconcilerConfig{
+		DefaultDelay:                   envDuration(RequeueDefaultDelayEnvVar, 15*time.Second),
+		LongDelay:                      envDuration(RequeueLongDelayEnvVar, 1*time.Minute),
+		WebhookUnavailableDelay:        envDuration(RequeueWebhookUnavailableDelayEnvVar, 30*time.Second),
+		SpecRejectedDelay:              envDuration(RequeueSpecRejectedDelayEnvVar, 5*time.Minute),
+		ResyncPeriod:                   envDuration(ResyncPeriodEnvVar, 0),
+		CreateDcNamespaces:             envBool(CreateDcNamespacesEnvVar, false),
+		ReuseLocalClient:               envBool(ReuseLocalClientEnvVar, true),
+		RequiredLabels:                 requiredLabels,
+		MaxClusterSize:                 envInt(MaxClusterSizeEnvVar, 0),
+		SeedReachabilityTimeout:        envDuration(SeedReachabilityTimeoutEnvVar, 0),
+		RestampOnUpgrade:               envBool(RestampOnUpgradeEnvVar, true),
+		OrphanedSecretsCleanupInterval: envDuration(OrphanedSecretsCleanupIntervalEnvVar, 10*time.Minute),
+		InstanceId:                     strings.TrimSpace(os.Getenv(InstanceIdEnvVar)),
+		OwnershipLeaseDuration:         envDuration(OwnershipLeaseDurationEnvVar, 5*time.Minute),
+		SecretRotationGracePeriod:      envDuration(SecretRotationGracePeriodEnvVar, 2*time.Minute),
+		RequireStatusSubresource:       envBool(RequireStatusSubresourceEnvVar, false),
+		CassOperatorNamespace:          strings.TrimSpace(os.Getenv(CassOperatorNamespaceEnvVar)),
+		CassOperatorSelector:           envSelector(CassOperatorSelectorEnvVar, labels.SelectorFromSet(labels.Set{"app.kubernetes.io/name": "cass-operator"})),
 	}
+}
 
-	return &ReconcilerConfig{
-		DefaultDelay: defaultDelay,
-		LongDelay:    longDelay,
+// envDuration returns the duration set in the environment variable name, or defaultValue if it is not set. The
+// operator exits if the variable does not hold a valid duration.
+func envDuration(name string, defaultValue time.Duration) time.Duration {
+	return parseEnv(name, defaultValue, time.ParseDuration)
+}
+
+// envInt returns the integer set in the environment variable name, or defaultValue if it is not set. The operator
+// exits if the variable does not hold a valid integer.
+func envInt(name string, defaultValue int) int {
+	return parseEnv(name, defaultValue, strconv.Atoi)
+}
+
+// envBool returns the boolean set in the environment variable name, or defaultValue if it is not set. The operator
+// exits if the variable does not hold a valid boolean.
+func envBool(name string, defaultValue bool) bool {
+	return parseEnv(name, defaultValue, strconv.ParseBool)
+}
+
+// envSelector returns the label selector set in the environment variable name, or defaultValue if it is not set. The
+// operator exits if the variable does not hold a valid label selector.
+func envSelector(name string, defaultValue labels.Selector) labels.Selector {
+	return parseEnv(name, defaultValue, labels.Parse)
+}
+
+func parseEnv[T any](name string, defaultValue T, parse func(string) (T, error)) T {
+	val, found := os.LookupEnv(name)
+	if !found {
+		return defaultValue
+	}
+	parsed, err := parse(val)
+	if err != nil {
+		log.Fatalf("failed to parse value for %s %s: %s", name, val, err)
 	}
+	return parsed
 }
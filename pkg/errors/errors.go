@@ -1,8 +1,15 @@
 package errors
 
 import (
+	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 type Reason string
@@ -15,6 +22,10 @@ const (
 	// cluster so schema changes should not be applied until that disagreement is
 	// resolved.
 	ReasonSchemaDisagreement = "SchemaDisagreement"
+
+	// ReasonManagementApiUnreachable means that the management API could not be reached on any node of the
+	// datacenter, so operations that depend on it cannot be performed until the nodes are reachable again.
+	ReasonManagementApiUnreachable = "ManagementApiUnreachable"
 )
 
 type K8ssandraError struct {
@@ -37,6 +48,17 @@ func IsSchemaDisagreement(err error) bool {
 	return ReasonForError(err) == ReasonSchemaDisagreement
 }
 
+func NewManagementApiUnreachableError(message string) *K8ssandraError {
+	return &K8ssandraError{
+		Message: message,
+		Reason:  ReasonManagementApiUnreachable,
+	}
+}
+
+func IsManagementApiUnreachable(err error) bool {
+	return ReasonForError(err) == ReasonManagementApiUnreachable
+}
+
 func ReasonForError(err error) Reason {
 	var k8ssandraError *K8ssandraError
 	if errors.As(err, &k8ssandraError) {
@@ -44,3 +66,73 @@ func ReasonForError(err error) Reason {
 	}
 	return ReasonUnknown
 }
+
+// IsTransient returns true if err is likely to go away on its own, such as a timeout, a throttled request or a
+// connection failure, as opposed to errors caused by invalid requests or missing permissions.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err) {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// RateLimitDelay returns true if err is the rejection of a request by an API server that is rate limiting its clients,
+// along with the delay that the server asked to wait before retrying, from its Retry-After header. The delay is zero
+// when the server did not suggest any.
+func RateLimitDelay(err error) (time.Duration, bool) {
+	if err == nil || !apierrors.IsTooManyRequests(err) {
+		return 0, false
+	}
+	if seconds, found := apierrors.SuggestsClientDelay(err); found && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	return 0, true
+}
+
+// IsWebhookUnavailable returns true if err was returned by the API server because it could not reach an admission
+// webhook, which typically happens while the operator serving the webhook is being restarted or upgraded.
+func IsWebhookUnavailable(err error) bool {
+	return apierrors.IsInternalError(err) && strings.Contains(err.Error(), "failed calling webhook")
+}
+
+// IsSpecRejected returns true if err is the rejection of an object by the validation of the API server, or by a
+// validating admission webhook. Retrying the same request is expected to fail the same way.
+func IsSpecRejected(err error) bool {
+	return apierrors.IsInvalid(err) || (apierrors.IsForbidden(err) && strings.Contains(err.Error(), "denied the request"))
+}
+
+// RejectedFields returns the fields reported as invalid in the details of err, if any.
+func RejectedFields(err error) []string {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return nil
+	}
+	fields := make([]string, 0)
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Field != "" {
+			fields = append(fields, cause.Field)
+		}
+	}
+	return fields
+}
+
+// IsCertificateExpired returns true if err was caused by an expired certificate, either the certificate of the server,
+// which the client rejects, or the client certificate, which the server rejects during the TLS handshake.
+func IsCertificateExpired(err error) bool {
+	if err == nil {
+		return false
+	}
+	var certErr x509.CertificateInvalidError
+	if errors.As(err, &certErr) && certErr.Reason == x509.Expired {
+		return true
+	}
+	return strings.Contains(err.Error(), "tls: expired certificate") || strings.Contains(err.Error(), "certificate has expired")
+}
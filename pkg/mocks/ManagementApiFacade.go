@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	time "time"
+
 	httphelper "github.com/k8ssandra/cass-operator/pkg/httphelper"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -91,6 +93,75 @@ func (_m *ManagementApiFacade) GetKeyspaceReplication(keyspaceName string) (map[
 	return r0, r1
 }
 
+// GetDroppedMutations provides a mock function with given fields:
+func (_m *ManagementApiFacade) GetDroppedMutations() (map[string]int64, error) {
+	ret := _m.Called()
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func() map[string]int64); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEndpointStates provides a mock function with given fields:
+func (_m *ManagementApiFacade) GetEndpointStates() ([]httphelper.EndpointState, error) {
+	ret := _m.Called()
+
+	var r0 []httphelper.EndpointState
+	if rf, ok := ret.Get(0).(func() []httphelper.EndpointState); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]httphelper.EndpointState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingCompactions provides a mock function with given fields:
+func (_m *ManagementApiFacade) GetPendingCompactions() (map[string]int32, error) {
+	ret := _m.Called()
+
+	var r0 map[string]int32
+	if rf, ok := ret.Get(0).(func() map[string]int32); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int32)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSchemaVersions provides a mock function with given fields:
 func (_m *ManagementApiFacade) GetSchemaVersions() (map[string][]string, error) {
 	ret := _m.Called()
@@ -114,6 +185,50 @@ func (_m *ManagementApiFacade) GetSchemaVersions() (map[string][]string, error)
 	return r0, r1
 }
 
+// GetTokenOwnership provides a mock function with given fields:
+func (_m *ManagementApiFacade) GetTokenOwnership() (map[string]float64, error) {
+	ret := _m.Called()
+
+	var r0 map[string]float64
+	if rf, ok := ret.Get(0).(func() map[string]float64); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]float64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MeasureManagementApiLatency provides a mock function with given fields:
+func (_m *ManagementApiFacade) MeasureManagementApiLatency() (time.Duration, error) {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListKeyspaces provides a mock function with given fields: keyspaceName
 func (_m *ManagementApiFacade) ListKeyspaces(keyspaceName string) ([]string, error) {
 	ret := _m.Called(keyspaceName)
@@ -45,6 +45,29 @@ func (_m *ReaperManager) Connect(ctx context.Context, _a1 *v1alpha1.Reaper, user
 	return r0
 }
 
+// GetRepairStatus provides a mock function with given fields: ctx, cassdc
+func (_m *ReaperManager) GetRepairStatus(ctx context.Context, cassdc *v1beta1.CassandraDatacenter) ([]v1alpha1.KeyspaceRepairStatus, error) {
+	ret := _m.Called(ctx, cassdc)
+
+	var r0 []v1alpha1.KeyspaceRepairStatus
+	if rf, ok := ret.Get(0).(func(context.Context, *v1beta1.CassandraDatacenter) []v1alpha1.KeyspaceRepairStatus); ok {
+		r0 = rf(ctx, cassdc)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1alpha1.KeyspaceRepairStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *v1beta1.CassandraDatacenter) error); ok {
+		r1 = rf(ctx, cassdc)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifyClusterIsConfigured provides a mock function with given fields: ctx, cassdc
 func (_m *ReaperManager) VerifyClusterIsConfigured(ctx context.Context, cassdc *v1beta1.CassandraDatacenter) (bool, error) {
 	ret := _m.Called(ctx, cassdc)
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
+	"time"
 
 	"github.com/k8ssandra/k8ssandra-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	api "github.com/k8ssandra/k8ssandra-operator/apis/reaper/v1alpha1"
@@ -16,6 +19,7 @@ type Manager interface {
 	Connect(ctx context.Context, reaper *api.Reaper, username, password string) error
 	AddClusterToReaper(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) error
 	VerifyClusterIsConfigured(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) (bool, error)
+	GetRepairStatus(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) ([]api.KeyspaceRepairStatus, error)
 }
 
 func NewManager() Manager {
@@ -55,3 +59,63 @@ func (r *restReaperManager) VerifyClusterIsConfigured(ctx context.Context, cassd
 	}
 	return utils.SliceContains(clusters, cassdcapi.CleanupForKubernetes(cassdc.Spec.ClusterName)), nil
 }
+
+func (r *restReaperManager) GetRepairStatus(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) ([]api.KeyspaceRepairStatus, error) {
+	runs, err := r.reaperClient.RepairRuns(ctx, &reaperclient.RepairRunSearchOptions{
+		Cluster: cassdcapi.CleanupForKubernetes(cassdc.Spec.ClusterName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	repairRuns := make([]*reaperclient.RepairRun, 0, len(runs))
+	for _, run := range runs {
+		repairRuns = append(repairRuns, run)
+	}
+	return summarizeRepairRuns(repairRuns), nil
+}
+
+// summarizeRepairRuns computes, for each keyspace, the time of the last successful repair and the progress of the most
+// recent repair run. The result is sorted by keyspace name.
+func summarizeRepairRuns(runs []*reaperclient.RepairRun) []api.KeyspaceRepairStatus {
+	latestRuns := make(map[string]*reaperclient.RepairRun)
+	statuses := make(map[string]*api.KeyspaceRepairStatus)
+	for _, run := range runs {
+		if run == nil || run.Keyspace == "" {
+			continue
+		}
+		status, found := statuses[run.Keyspace]
+		if !found {
+			status = &api.KeyspaceRepairStatus{Keyspace: run.Keyspace}
+			statuses[run.Keyspace] = status
+		}
+		if run.State == reaperclient.RepairRunStateDone && run.EndTime != nil {
+			if status.LastRepairTime == nil || run.EndTime.After(status.LastRepairTime.Time) {
+				endTime := metav1.NewTime(*run.EndTime)
+				status.LastRepairTime = &endTime
+			}
+		}
+		if latest, found := latestRuns[run.Keyspace]; !found || repairRunStart(run).After(repairRunStart(latest)) {
+			latestRuns[run.Keyspace] = run
+		}
+	}
+	result := make([]api.KeyspaceRepairStatus, 0, len(statuses))
+	for keyspace, status := range statuses {
+		if latest := latestRuns[keyspace]; latest.TotalSegments > 0 {
+			status.PercentRepaired = latest.SegmentsRepaired * 100 / latest.TotalSegments
+		}
+		result = append(result, *status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Keyspace < result[j].Keyspace
+	})
+	return result
+}
+
+func repairRunStart(run *reaperclient.RepairRun) time.Time {
+	if run.StartTime != nil {
+		return *run.StartTime
+	} else if run.CreationTime != nil {
+		return *run.CreationTime
+	}
+	return time.Time{}
+}
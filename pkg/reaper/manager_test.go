@@ -0,0 +1,39 @@
+package reaper
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/k8ssandra/k8ssandra-operator/apis/reaper/v1alpha1"
+	reaperclient "github.com/k8ssandra/reaper-client-go/reaper"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSummarizeRepairRuns(t *testing.T) {
+	t0 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+
+	runs := []*reaperclient.RepairRun{
+		// ks1: an older completed run, then a newer completed run
+		{Keyspace: "ks1", State: reaperclient.RepairRunStateDone, StartTime: &t0, EndTime: &t1, SegmentsRepaired: 10, TotalSegments: 10},
+		{Keyspace: "ks1", State: reaperclient.RepairRunStateDone, StartTime: &t2, EndTime: &t3, SegmentsRepaired: 20, TotalSegments: 20},
+		// ks2: a completed run, then a running one which is 25% done
+		{Keyspace: "ks2", State: reaperclient.RepairRunStateDone, StartTime: &t0, EndTime: &t1, SegmentsRepaired: 8, TotalSegments: 8},
+		{Keyspace: "ks2", State: reaperclient.RepairRunStateRunning, StartTime: &t2, SegmentsRepaired: 2, TotalSegments: 8},
+		// ks3: a single run that never completed
+		{Keyspace: "ks3", State: reaperclient.RepairRunStateError, StartTime: &t1, SegmentsRepaired: 1, TotalSegments: 3},
+	}
+
+	lastRepairKs1 := metav1.NewTime(t3)
+	lastRepairKs2 := metav1.NewTime(t1)
+	expected := []api.KeyspaceRepairStatus{
+		{Keyspace: "ks1", LastRepairTime: &lastRepairKs1, PercentRepaired: 100},
+		{Keyspace: "ks2", LastRepairTime: &lastRepairKs2, PercentRepaired: 25},
+		{Keyspace: "ks3", PercentRepaired: 33},
+	}
+	assert.Equal(t, expected, summarizeRepairRuns(runs))
+	assert.Empty(t, summarizeRepairRuns(nil))
+}
@@ -27,6 +27,10 @@ const (
 
 	// OrphanResourceAnnotation when set to true prevents the deletion of secret from target clusters even if matching ReplicatedSecret is removed
 	OrphanResourceAnnotation = "replicatedresource.k8ssandra.io/orphan"
+
+	// UsernameKey and PasswordKey are the keys of the secrets holding the credentials of a CQL user.
+	UsernameKey = "username"
+	PasswordKey = "password"
 )
 
 func generateRandomString(charset string, length int) ([]byte, error) {
@@ -75,8 +79,8 @@ func ReconcileSecret(ctx context.Context, c client.Client, secretName string, kc
 				// Immutable feature is only available from 1.21 and up (beta in 1.19 and up)
 				// Immutable:  true,
 				Data: map[string][]byte{
-					"username": []byte(secretName),
-					"password": password,
+					UsernameKey: []byte(secretName),
+					PasswordKey: password,
 				},
 			}
 
@@ -95,6 +99,21 @@ func ReconcileSecret(ctx context.Context, c client.Client, secretName string, kc
 	return nil
 }
 
+// ValidateCredentials checks that sec holds a non-empty username and password. cass-operator only reads the secret
+// when creating the superuser, so a malformed secret otherwise surfaces as authentication failures much later.
+func ValidateCredentials(sec *corev1.Secret) error {
+	missing := make([]string, 0, 2)
+	for _, key := range []string{UsernameKey, PasswordKey} {
+		if len(sec.Data[key]) == 0 {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("secret %s/%s is missing non-empty keys %v", sec.Namespace, sec.Name, missing)
+	}
+	return nil
+}
+
 // ReconcileReplicatedSecret ensures that the correct replicatedSecret for all managed secrets is created
 func ReconcileReplicatedSecret(ctx context.Context, c client.Client, scheme *runtime.Scheme, kc *api.K8ssandraCluster, logger logr.Logger) error {
 	replicationTargets := make([]replicationapi.ReplicationTarget, 0, len(kc.Spec.Cassandra.Datacenters))
@@ -0,0 +1,143 @@
+// Package summary serves a JSON summary of the K8ssandraClusters managed by the operator, meant for dashboards and
+// quick operational checks.
+package summary
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	PhaseReady       = "Ready"
+	PhaseProgressing = "Progressing"
+	PhaseError       = "Error"
+	PhaseDeleting    = "Deleting"
+)
+
+// ClusterSummary is the summary of a single K8ssandraCluster.
+type ClusterSummary struct {
+	Namespace         string     `json:"namespace"`
+	Name              string     `json:"name"`
+	Phase             string     `json:"phase"`
+	Error             string     `json:"error,omitempty"`
+	Datacenters       int        `json:"datacenters"`
+	ReadyDatacenters  int        `json:"readyDatacenters"`
+	LastReconcileTime *time.Time `json:"lastReconcileTime,omitempty"`
+}
+
+// Tracker records the last time each K8ssandraCluster was reconciled. It is safe for concurrent use. Reconcile times
+// are only kept in memory, and are therefore lost when the operator restarts.
+type Tracker struct {
+	mu            sync.RWMutex
+	lastReconcile map[types.NamespacedName]time.Time
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{lastReconcile: make(map[types.NamespacedName]time.Time)}
+}
+
+// Record records that the K8ssandraCluster key was reconciled at time t.
+func (t *Tracker) Record(key types.NamespacedName, at time.Time) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastReconcile[key] = at
+}
+
+// Forget removes the K8ssandraCluster key, typically once it has been deleted.
+func (t *Tracker) Forget(key types.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.lastReconcile, key)
+}
+
+// LastReconcile returns the last time the K8ssandraCluster key was reconciled, if any.
+func (t *Tracker) LastReconcile(key types.NamespacedName) (time.Time, bool) {
+	if t == nil {
+		return time.Time{}, false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	at, found := t.lastReconcile[key]
+	return at, found
+}
+
+// Summarize returns the summaries of the given K8ssandraClusters, sorted by namespace and name.
+func Summarize(clusters []api.K8ssandraCluster, tracker *Tracker) []ClusterSummary {
+	summaries := make([]ClusterSummary, 0, len(clusters))
+	for i := range clusters {
+		summaries = append(summaries, summarizeCluster(&clusters[i], tracker))
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+	return summaries
+}
+
+func summarizeCluster(kc *api.K8ssandraCluster, tracker *Tracker) ClusterSummary {
+	summary := ClusterSummary{Namespace: kc.Namespace, Name: kc.Name}
+	if kc.Spec.Cassandra != nil {
+		summary.Datacenters = len(kc.Spec.Cassandra.Datacenters)
+		for _, dcTemplate := range kc.Spec.Cassandra.Datacenters {
+			if dcStatus, found := kc.Status.Datacenters[dcTemplate.Meta.Name]; found && datacenterReady(dcStatus.Cassandra) {
+				summary.ReadyDatacenters++
+			}
+		}
+	}
+	if at, found := tracker.LastReconcile(types.NamespacedName{Namespace: kc.Namespace, Name: kc.Name}); found {
+		summary.LastReconcileTime = &at
+	}
+
+	switch {
+	case kc.DeletionTimestamp != nil:
+		summary.Phase = PhaseDeleting
+	case kc.Status.Error != "" && kc.Status.Error != "None":
+		summary.Phase = PhaseError
+		summary.Error = kc.Status.Error
+	case summary.ReadyDatacenters == summary.Datacenters && kc.Status.GetConditionStatus(api.CassandraInitialized) == corev1.ConditionTrue:
+		summary.Phase = PhaseReady
+	default:
+		summary.Phase = PhaseProgressing
+	}
+	return summary
+}
+
+func datacenterReady(status *cassdcapi.CassandraDatacenterStatus) bool {
+	return status != nil &&
+		status.GetConditionStatus(cassdcapi.DatacenterReady) == corev1.ConditionTrue &&
+		status.CassandraOperatorProgress == cassdcapi.ProgressReady
+}
+
+// NewHandler returns an http.Handler serving the summaries of the K8ssandraClusters read with reader as JSON.
+func NewHandler(reader client.Reader, tracker *Tracker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		clusters := &api.K8ssandraClusterList{}
+		if err := reader.List(req.Context(), clusters); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Summarize(clusters.Items, tracker))
+	})
+}
@@ -0,0 +1,95 @@
+package summary
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	api "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-operator/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newCluster(name string, dcNames ...string) *api.K8ssandraCluster {
+	kc := &api.K8ssandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       api.K8ssandraClusterSpec{Cassandra: &api.CassandraClusterTemplate{}},
+	}
+	for _, dcName := range dcNames {
+		kc.Spec.Cassandra.Datacenters = append(kc.Spec.Cassandra.Datacenters, api.CassandraDatacenterTemplate{
+			Meta: api.EmbeddedObjectMeta{Name: dcName},
+		})
+	}
+	return kc
+}
+
+func readyStatus() api.K8ssandraStatus {
+	return api.K8ssandraStatus{Cassandra: &cassdcapi.CassandraDatacenterStatus{
+		CassandraOperatorProgress: cassdcapi.ProgressReady,
+		Conditions: []cassdcapi.DatacenterCondition{
+			{Type: cassdcapi.DatacenterReady, Status: corev1.ConditionTrue},
+		},
+	}}
+}
+
+func TestSummaryHandler(t *testing.T) {
+	ready := newCluster("ready", "dc1", "dc2")
+	ready.Status.Error = "None"
+	ready.Status.Datacenters = map[string]api.K8ssandraStatus{"dc1": readyStatus(), "dc2": readyStatus()}
+	ready.Status.SetCondition(api.K8ssandraClusterCondition{Type: api.CassandraInitialized, Status: corev1.ConditionTrue})
+
+	progressing := newCluster("progressing", "dc1", "dc2")
+	progressing.Status.Error = "None"
+	progressing.Status.Datacenters = map[string]api.K8ssandraStatus{"dc1": readyStatus()}
+
+	failing := newCluster("failing", "dc1")
+	failing.Status.Error = "invalid Cassandra config"
+
+	fakeClient, err := test.NewFakeClient(ready, progressing, failing)
+	require.NoError(t, err)
+
+	tracker := NewTracker()
+	reconciledAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	tracker.Record(types.NamespacedName{Namespace: "default", Name: "ready"}, reconciledAt)
+
+	recorder := httptest.NewRecorder()
+	NewHandler(fakeClient, tracker).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/summary", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var summaries []ClusterSummary
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &summaries))
+	require.Len(t, summaries, 3)
+
+	assert.Equal(t, ClusterSummary{
+		Namespace: "default", Name: "failing", Phase: PhaseError, Error: "invalid Cassandra config", Datacenters: 1,
+	}, summaries[0])
+	assert.Equal(t, ClusterSummary{
+		Namespace: "default", Name: "progressing", Phase: PhaseProgressing, Datacenters: 2, ReadyDatacenters: 1,
+	}, summaries[1])
+	assert.Equal(t, "ready", summaries[2].Name)
+	assert.Equal(t, PhaseReady, summaries[2].Phase)
+	assert.Equal(t, 2, summaries[2].ReadyDatacenters)
+	require.NotNil(t, summaries[2].LastReconcileTime)
+	assert.True(t, reconciledAt.Equal(*summaries[2].LastReconcileTime))
+
+	tracker.Forget(types.NamespacedName{Namespace: "default", Name: "ready"})
+	_, found := tracker.LastReconcile(types.NamespacedName{Namespace: "default", Name: "ready"})
+	assert.False(t, found)
+}
+
+func TestSummaryHandlerRejectsOtherMethods(t *testing.T) {
+	fakeClient, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	NewHandler(fakeClient, NewTracker()).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/summary", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -5,12 +5,14 @@ import (
 	"errors"
 
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	cassctlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
 	k8ssandraapi "github.com/k8ssandra/k8ssandra-operator/apis/k8ssandra/v1alpha1"
 	reaperapi "github.com/k8ssandra/k8ssandra-operator/apis/reaper/v1alpha1"
 	stargateapi "github.com/k8ssandra/k8ssandra-operator/apis/stargate/v1alpha1"
 	promapi "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -29,11 +31,13 @@ func NewFakeClient(initRuntimeObjs ...runtime.Object) (client.Client, error) {
 	}
 	utilruntime.Must(promapi.AddToScheme(testScheme))
 	utilruntime.Must(cassdcapi.AddToScheme(testScheme))
+	utilruntime.Must(cassctlapi.AddToScheme(testScheme))
 	utilruntime.Must(k8ssandraapi.AddToScheme(testScheme))
 	utilruntime.Must(reaperapi.AddToScheme(testScheme))
 	utilruntime.Must(stargateapi.AddToScheme(testScheme))
 	utilruntime.Must(corev1.AddToScheme(testScheme))
 	utilruntime.Must(appsv1.AddToScheme(testScheme))
+	utilruntime.Must(networkingv1.AddToScheme(testScheme))
 	fakeClient := fake.NewClientBuilder().
 		WithScheme(testScheme).
 		WithRuntimeObjects(initRuntimeObjs...).
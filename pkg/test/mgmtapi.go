@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"testing"
+	"time"
 )
 
 type ManagementApiFactoryAdapter func(
@@ -34,6 +35,11 @@ var defaultAdapter ManagementApiFactoryAdapter = func(
 	})).Return(nil)
 	m.On(ListKeyspaces, "").Return([]string{}, nil)
 	m.On(GetSchemaVersions).Return(map[string][]string{"fake": {"test"}}, nil)
+	m.On(GetEndpointStates).Return([]httphelper.EndpointState{}, nil)
+	m.On(GetPendingCompactions).Return(map[string]int32{}, nil)
+	m.On(GetDroppedMutations).Return(map[string]int64{}, nil)
+	m.On(MeasureManagementApiLatency).Return(time.Millisecond, nil)
+	m.On(GetTokenOwnership).Return(map[string]float64{}, nil)
 	return m, nil
 }
 
@@ -87,14 +93,19 @@ func (f *FakeManagementApiFactory) NewManagementApiFacade(
 type ManagementApiMethod string
 
 const (
-	EnsureKeyspaceReplication = "EnsureKeyspaceReplication"
-	GetKeyspaceReplication    = "GetKeyspaceReplication"
-	CreateKeyspaceIfNotExists = "CreateKeyspaceIfNotExists"
-	AlterKeyspace             = "AlterKeyspace"
-	ListKeyspaces             = "ListKeyspaces"
-	CreateTable               = "CreateTable"
-	ListTables                = "ListTables"
-	GetSchemaVersions         = "GetSchemaVersions"
+	EnsureKeyspaceReplication   = "EnsureKeyspaceReplication"
+	GetKeyspaceReplication      = "GetKeyspaceReplication"
+	CreateKeyspaceIfNotExists   = "CreateKeyspaceIfNotExists"
+	AlterKeyspace               = "AlterKeyspace"
+	ListKeyspaces               = "ListKeyspaces"
+	CreateTable                 = "CreateTable"
+	ListTables                  = "ListTables"
+	GetSchemaVersions           = "GetSchemaVersions"
+	GetEndpointStates           = "GetEndpointStates"
+	GetPendingCompactions       = "GetPendingCompactions"
+	GetDroppedMutations         = "GetDroppedMutations"
+	MeasureManagementApiLatency = "MeasureManagementApiLatency"
+	GetTokenOwnership           = "GetTokenOwnership"
 )
 
 type FakeManagementApiFacade struct {
@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"context"
+	"reflect"
+
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewClient wraps c so that every call it makes to the API server is recorded as a span, annotated with the given
+// Kubernetes context. Status and sub-resource writers are not instrumented.
+func NewClient(c client.Client, k8sContext string) client.Client {
+	return &tracingClient{Client: c, k8sContext: k8sContext}
+}
+
+type tracingClient struct {
+	client.Client
+	k8sContext string
+}
+
+func (c *tracingClient) start(ctx context.Context, verb string, obj runtime.Object) (context.Context, trace.Span) {
+	kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	return Start(ctx, "Client."+verb, K8sContextKey.String(c.k8sContext), KindKey.String(kind))
+}
+
+func (c *tracingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	ctx, span := c.start(ctx, "Get", obj)
+	err := c.Client.Get(ctx, key, obj, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	ctx, span := c.start(ctx, "List", list)
+	err := c.Client.List(ctx, list, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, span := c.start(ctx, "Create", obj)
+	err := c.Client.Create(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := c.start(ctx, "Update", obj)
+	err := c.Client.Update(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := c.start(ctx, "Patch", obj)
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, span := c.start(ctx, "Delete", obj)
+	err := c.Client.Delete(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
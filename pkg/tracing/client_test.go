@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClient(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	ctx, parent := Start(context.Background(), "parent")
+	c := NewClient(fake.NewClientBuilder().Build(), "cluster-1")
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	require.NoError(t, c.Create(ctx, cm))
+	require.NoError(t, c.List(ctx, &corev1.ConfigMapList{}))
+	require.Error(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "missing"}, &corev1.ConfigMap{}))
+	End(parent, nil)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+
+	assert.Equal(t, "Client.Create", spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, K8sContextKey.String("cluster-1"))
+	assert.Contains(t, spans[0].Attributes, KindKey.String("ConfigMap"))
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+
+	assert.Equal(t, "Client.List", spans[1].Name)
+	assert.Contains(t, spans[1].Attributes, KindKey.String("ConfigMapList"))
+
+	assert.Equal(t, "Client.Get", spans[2].Name)
+	assert.Equal(t, codes.Error, spans[2].Status.Code)
+
+	assert.Equal(t, "parent", spans[3].Name)
+	assert.Equal(t, codes.Unset, spans[3].Status.Code)
+}
+
+func TestSetupDisabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), "", false)
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	_, isSdkProvider := otel.GetTracerProvider().(*sdktrace.TracerProvider)
+	assert.False(t, isSdkProvider)
+}
+
+func TestEndNilSpan(t *testing.T) {
+	assert.NotPanics(t, func() { End(nil, nil) })
+}
@@ -0,0 +1,69 @@
+// Package tracing provides OpenTelemetry instrumentation of the operator's reconcile loops. Tracing is disabled unless
+// an OTLP endpoint is configured, in which case spans are exported to it using OTLP over HTTP.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName  = "github.com/k8ssandra/k8ssandra-operator"
+	serviceName = "k8ssandra-operator"
+)
+
+// Attribute keys used to annotate spans.
+const (
+	NamespaceKey  = attribute.Key("k8ssandra.namespace")
+	ClusterKey    = attribute.Key("k8ssandra.cluster")
+	DatacenterKey = attribute.Key("k8ssandra.datacenter")
+	K8sContextKey = attribute.Key("k8ssandra.k8s_context")
+	KindKey       = attribute.Key("k8s.kind")
+)
+
+// Start starts a new span, as a child of the span found in ctx if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on span, if not nil, then ends span. It is a no-op if span is nil.
+func End(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Setup installs a global tracer provider that exports spans to the given OTLP/HTTP endpoint, in host:port form. If
+// endpoint is empty, tracing stays disabled. The returned function flushes pending spans and stops the exporter; it
+// should be called when the operator shuts down.
+func Setup(ctx context.Context, endpoint string, insecure bool) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
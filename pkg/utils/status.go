@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UpdateStatus applies mutate to the status of obj and updates the status subresource. When the update conflicts with
+// a concurrent write, the latest version of obj is fetched and mutate is applied to it again before retrying, so that
+// the status written is never based on a stale copy. On success, obj holds the updated object. Status merge patches
+// don't need it since they don't carry a resource version and can't conflict.
+func UpdateStatus[T client.Object](ctx context.Context, c client.Client, obj T, mutate func(T)) error {
+	key := client.ObjectKeyFromObject(obj)
+	refetch := false
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refetch {
+			if err := c.Get(ctx, key, obj); err != nil {
+				return err
+			}
+		}
+		refetch = true
+		mutate(obj)
+		return c.Status().Update(ctx, obj)
+	})
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUpdateStatusConflict(t *testing.T) {
+	ctx := context.Background()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod1"}}
+	fakeClient := fake.NewClientBuilder().WithObjects(pod).Build()
+
+	stale := &corev1.Pod{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), stale))
+
+	// A concurrent write makes the resource version of stale outdated.
+	latest := stale.DeepCopy()
+	latest.Status.Reason = "Concurrent"
+	require.NoError(t, fakeClient.Status().Update(ctx, latest))
+
+	attempts := 0
+	err := UpdateStatus(ctx, fakeClient, stale, func(p *corev1.Pod) {
+		attempts++
+		p.Status.Message = "updated"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts, "the update should be retried once after the conflict")
+
+	actual := &corev1.Pod{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), actual))
+	assert.Equal(t, "updated", actual.Status.Message)
+	assert.Equal(t, "Concurrent", actual.Status.Reason, "the concurrent write should not be lost")
+}
@@ -24,4 +24,8 @@ cp build/helm/clusterrole.yaml charts/k8ssandra-operator/templates/clusterrole.y
 # Generate the leader election role from the RBAC generated manifests
 cat charts/templates/leader-role.tmpl.yaml | tee build/helm/leader-role.yaml
 cat build/helm/k8ssandra-operator-rbac.yaml | yq 'select(di == 2).rules' | tee -a build/helm/leader-role.yaml
-cp build/helm/leader-role.yaml charts/k8ssandra-operator/templates/leader-role.yaml
\ No newline at end of file
+cp build/helm/leader-role.yaml charts/k8ssandra-operator/templates/leader-role.yaml
+# Generate the role of the cluster-scoped resources, which is bound whatever the scope of the operator
+cat charts/templates/cluster-resources-role.tmpl.yaml | tee build/helm/cluster-resources-role.yaml
+cat build/helm/k8ssandra-operator-rbac.yaml | yq 'select(.kind == "ClusterRole" and .metadata.name == "k8ssandra-operator-cluster-resources").rules' | tee -a build/helm/cluster-resources-role.yaml
+cp build/helm/cluster-resources-role.yaml charts/k8ssandra-operator/templates/cluster-resources-role.yaml
\ No newline at end of file